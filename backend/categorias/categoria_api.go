@@ -24,7 +24,9 @@ import (
 	"net/http"
 	"strconv" // Para convertir ID de URL
 
-	// "time" // No se usa directamente aquí si los mapeadores se simplifican
+	"time" // Para formatear eliminada_en
+
+	"backend/shared/i18n" // Idioma de la petición para traducir el contenido
 
 	"github.com/gin-gonic/gin"
 	// "backend/shared/apitypes" // Ya no es necesario aquí, el middleware usa esto
@@ -46,13 +48,22 @@ func NewCategoriaHandler(s CategoriaService) *CategoriaHandler {
 // Usan los tipos Categoria (dominio) y CategoriaResponseDTO (API DTO) definidos en este paquete.
 
 func mapDomainToResponseDTO(cat Categoria) CategoriaResponseDTO {
-	return CategoriaResponseDTO{
+	dto := CategoriaResponseDTO{
 		ID:     cat.ID,
 		Nombre: cat.Nombre,
 		Slug:   cat.Slug,
+		Idioma: string(cat.Idioma),
 		// CreatedAt: cat.CreatedAt.Format(time.RFC3339), // Quitado para simplicidad o si el DTO no lo tiene
 		// UpdatedAt: cat.UpdatedAt.Format(time.RFC3339),
 	}
+	if cat.Idioma == "" {
+		dto.Idioma = string(i18n.IdiomaPorDefecto)
+	}
+	if cat.EliminadaEn != nil {
+		eliminadaEn := cat.EliminadaEn.Format(time.RFC3339)
+		dto.EliminadaEn = &eliminadaEn
+	}
+	return dto
 }
 
 func mapDomainsToResponseDTOs(cats []Categoria) []CategoriaResponseDTO {
@@ -69,11 +80,18 @@ func mapDomainsToResponseDTOs(cats []Categoria) []CategoriaResponseDTO {
 // Anotaciones Swagger se mantienen igual, pero el cuerpo del error será ErrorResponseDTO
 // @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
 func (h *CategoriaHandler) GetAll(c *gin.Context) {
-	domainCategorias, err := h.service.GetAll(c.Request.Context())
+	ctx := c.Request.Context()
+	domainCategorias, err := h.service.GetAll(ctx)
 	if err != nil {
 		_ = c.Error(err) // Pasar error al middleware
 		return
 	}
+	// Contenido en el idioma de la petición (con respaldo al idioma por defecto)
+	domainCategorias, err = h.service.Traducir(ctx, i18n.IdiomaDe(ctx), domainCategorias)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	c.JSON(http.StatusOK, mapDomainsToResponseDTOs(domainCategorias))
 }
 
@@ -94,12 +112,18 @@ func (h *CategoriaHandler) GetByID(c *gin.Context) {
 	}
 	id := uint(idUint64)
 
-	domainCategoria, err := h.service.GetByID(c.Request.Context(), id)
+	ctx := c.Request.Context()
+	domainCategoria, err := h.service.GetByID(ctx, id)
 	if err != nil {
 		_ = c.Error(err) // Pasar error al middleware (ej: ErrCategoriaNotFound)
 		return
 	}
-	c.JSON(http.StatusOK, mapDomainToResponseDTO(*domainCategoria))
+	traducidas, err := h.service.Traducir(ctx, i18n.IdiomaDe(ctx), []Categoria{*domainCategoria})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainToResponseDTO(traducidas[0]))
 }
 
 // Create maneja POST /categorias
@@ -172,3 +196,172 @@ func (h *CategoriaHandler) Delete(c *gin.Context) {
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// --- Papelera ---
+
+// GetPapelera maneja GET /admin/categorias/papelera
+// @Summary Lista las categorías de la papelera
+// @Tags Categorias
+// @Produce json
+// @Success 200 {array} CategoriaResponseDTO "Categorías eliminadas (con eliminada_en)"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /admin/categorias/papelera [get]
+func (h *CategoriaHandler) GetPapelera(c *gin.Context) {
+	domainCategorias, err := h.service.ListarPapelera(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainsToResponseDTOs(domainCategorias))
+}
+
+// RestaurarDePapelera maneja POST /admin/categorias/papelera/:id/restaurar
+// @Summary Restaura una categoría de la papelera
+// @Tags Categorias
+// @Produce json
+// @Param id path uint true "ID de la categoría"
+// @Success 200 {object} CategoriaResponseDTO "Categoría restaurada (el slug puede llevar sufijo)"
+// @Failure 404 {object} apitypes.ErrorResponse "La categoría no está en la papelera"
+// @Failure 409 {object} apitypes.ErrorResponse "Otra categoría activa tiene el mismo nombre"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /admin/categorias/papelera/{id}/restaurar [post]
+func (h *CategoriaHandler) RestaurarDePapelera(c *gin.Context) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("parámetro ID inválido para restaurar: %s - %w", idStr, err))
+		return
+	}
+
+	categoria, err := h.service.RestaurarDePapelera(c.Request.Context(), uint(idUint64))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainToResponseDTO(*categoria))
+}
+
+// PurgarDePapelera maneja DELETE /admin/categorias/papelera/:id
+// @Summary Borra definitivamente una categoría de la papelera
+// @Tags Categorias
+// @Param id path uint true "ID de la categoría"
+// @Success 204 "Categoría purgada"
+// @Failure 404 {object} apitypes.ErrorResponse "La categoría no está en la papelera"
+// @Failure 409 {object} apitypes.ErrorResponse "La categoría aún tiene recetas"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /admin/categorias/papelera/{id} [delete]
+func (h *CategoriaHandler) PurgarDePapelera(c *gin.Context) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("parámetro ID inválido para purgar: %s - %w", idStr, err))
+		return
+	}
+
+	if err := h.service.PurgarDePapelera(c.Request.Context(), uint(idUint64)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// --- Traducciones ---
+
+func mapTraduccionToResponseDTO(t CategoriaTraduccion) CategoriaTraduccionResponseDTO {
+	return CategoriaTraduccionResponseDTO{
+		Idioma:    string(t.Idioma),
+		Nombre:    t.Nombre,
+		Slug:      t.Slug,
+		UpdatedAt: t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetTraducciones maneja GET /admin/categorias/:id/traducciones
+// @Summary Lista las traducciones de una categoría
+// @Tags Categorias
+// @Produce json
+// @Param id path uint true "ID de la categoría"
+// @Success 200 {array} CategoriaTraduccionResponseDTO "Traducciones, ordenadas por idioma"
+// @Failure 404 {object} apitypes.ErrorResponse "Categoría no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /admin/categorias/{id}/traducciones [get]
+func (h *CategoriaHandler) GetTraducciones(c *gin.Context) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("parámetro ID inválido: %s - %w", idStr, err))
+		return
+	}
+
+	traducciones, err := h.service.ListarTraducciones(c.Request.Context(), uint(idUint64))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]CategoriaTraduccionResponseDTO, 0, len(traducciones))
+	for _, t := range traducciones {
+		responseDTOs = append(responseDTOs, mapTraduccionToResponseDTO(t))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// GuardarTraduccion maneja PUT /admin/categorias/:id/traducciones/:idioma
+// @Summary Crea o reemplaza la traducción de una categoría
+// @Description El slug se genera a partir del nombre y es único dentro del idioma.
+// @Tags Categorias
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la categoría"
+// @Param idioma path string true "Idioma soportado distinto del idioma por defecto" example:"en"
+// @Param traduccion body CategoriaTraduccionRequestDTO true "Contenido traducido"
+// @Success 200 {object} CategoriaTraduccionResponseDTO "Traducción guardada"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos inválidos o idioma no traducible"
+// @Failure 404 {object} apitypes.ErrorResponse "Categoría no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /admin/categorias/{id}/traducciones/{idioma} [put]
+func (h *CategoriaHandler) GuardarTraduccion(c *gin.Context) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("parámetro ID inválido: %s - %w", idStr, err))
+		return
+	}
+	var requestBody CategoriaTraduccionRequestDTO
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	traduccion, err := h.service.GuardarTraduccion(c.Request.Context(), uint(idUint64), i18n.Idioma(c.Param("idioma")),
+		CategoriaTraduccionInputDTO{Nombre: requestBody.Nombre})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapTraduccionToResponseDTO(*traduccion))
+}
+
+// EliminarTraduccion maneja DELETE /admin/categorias/:id/traducciones/:idioma
+// @Summary Elimina la traducción de una categoría
+// @Tags Categorias
+// @Param id path uint true "ID de la categoría"
+// @Param idioma path string true "Idioma de la traducción" example:"en"
+// @Success 204 "Traducción eliminada"
+// @Failure 400 {object} apitypes.ErrorResponse "Idioma no traducible"
+// @Failure 404 {object} apitypes.ErrorResponse "La categoría no tiene traducción a ese idioma"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /admin/categorias/{id}/traducciones/{idioma} [delete]
+func (h *CategoriaHandler) EliminarTraduccion(c *gin.Context) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("parámetro ID inválido: %s - %w", idStr, err))
+		return
+	}
+
+	if err := h.service.EliminarTraduccion(c.Request.Context(), uint(idUint64), i18n.Idioma(c.Param("idioma"))); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -27,6 +27,24 @@ type CategoriaResponseDTO struct {
 	ID     uint   `json:"id" example:"1"` // @description ID de la categoría
 	Nombre string `json:"nombre" example:"Postres"`
 	Slug   string `json:"slug" example:"postres"`
+	Idioma string `json:"idioma" example:"es"` // Idioma de nombre y slug (el pedido o, sin traducción, el por defecto)
+	EliminadaEn *string `json:"eliminada_en,omitempty" example:"2025-05-21T09:00:00Z"` // Solo en la papelera
 }
+
+// --- Traducciones ---
+
+// CategoriaTraduccionRequestDTO es el contenido de una categoría en otro idioma (el idioma va en la URL).
+type CategoriaTraduccionRequestDTO struct {
+	Nombre string `json:"nombre" binding:"required,min=3,max=100" example:"Desserts"`
+}
+
+// CategoriaTraduccionResponseDTO es una traducción guardada.
+type CategoriaTraduccionResponseDTO struct {
+	Idioma    string `json:"idioma" example:"en"`
+	Nombre    string `json:"nombre" example:"Desserts"`
+	Slug      string `json:"slug" example:"desserts"`
+	UpdatedAt string `json:"updated_at" example:"2025-05-17T10:00:00Z"`
+}
+
 // Para listas de recetas en la respuesta
 // type RecetaResponses []RecetaResponseDTO // Si prefieres un alias
@@ -0,0 +1,18 @@
+// backend/categorias/categoria_api_errors.go
+
+// Traducción de los errores de dominio de categorías a respuestas HTTP (ver shared/apperrors).
+
+package categorias
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrCategoriaNotFound, http.StatusNotFound, "categoria_no_encontrada")
+	apperrors.Registrar(ErrCategoriaNombreYaExiste, http.StatusConflict, "categoria_nombre_duplicado")
+	apperrors.Registrar(ErrCategoriaEnUso, http.StatusConflict, "categoria_en_uso")
+	apperrors.Registrar(ErrCategoriaTraduccionNotFound, http.StatusNotFound, "categoria_traduccion_no_encontrada")
+}
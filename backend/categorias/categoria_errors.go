@@ -8,8 +8,10 @@ import "errors"
 
 // Errores específicos del dominio de negocio
 var (
-	ErrCategoriaNotFound       = errors.New("categoría no encontrada")
-	ErrCategoriaNombreYaExiste = errors.New("ya existe una categoría con ese nombre")
+	ErrCategoriaNotFound           = errors.New("categoría no encontrada")
+	ErrCategoriaNombreYaExiste     = errors.New("ya existe una categoría con ese nombre")
+	ErrCategoriaEnUso              = errors.New("la categoría tiene recetas (también en la papelera); no se puede borrar definitivamente")
+	ErrCategoriaTraduccionNotFound = errors.New("la categoría no tiene traducción a ese idioma")
 	// Puedes añadir otros errores de validación de negocio aquí si son necesarios
 	// ErrCategoriaNombreInvalido = errors.New("el nombre de la categoría no es válido")
 )
@@ -32,7 +32,11 @@
 
 package categorias
 
-import "time" // Solo si necesitas CreatedAt/UpdatedAt aquí
+import (
+	"time" // Solo si necesitas CreatedAt/UpdatedAt aquí
+
+	"backend/shared/i18n"
+)
 
 // Categoria representa la entidad de negocio pura para una categoría.
 // [✅ BUENA PRÁCTICA] Sin tags de Gorm. Representa el negocio, no la base de datos.
@@ -42,6 +46,8 @@ type Categoria struct {
 	Slug   string // Slug URL-amigable único
 	CreatedAt time.Time // Fecha de creación
 	UpdatedAt time.Time // Última fecha de modificación
+	EliminadaEn *time.Time // Solo en la papelera: cuándo se eliminó
+	Idioma    i18n.Idioma // Idioma de Nombre y Slug; vacío = idioma por defecto (ver categoria_traduccion.go)
 }
 
 // Type alias para slices, si lo prefieres (opcional)
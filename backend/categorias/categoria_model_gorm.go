@@ -8,6 +8,7 @@ package categorias // Asegúrate que el paquete sea 'categorias'
 
 import (
 	// YA NO necesitas: "backend/internal/domain" si Categoria (dominio) está en este paquete
+	"backend/shared/database" // Para migrar los índices únicos
 	"time"
 	"gorm.io/gorm"
 )
@@ -20,12 +21,24 @@ type CategoriaModel struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// Activo es 1 si la fila no está eliminada y NULL en la papelera (columna generada, solo lectura).
+	// Forma parte de los índices únicos para que nombre y slug solo sean únicos entre las activas.
+	Activo    *bool          `gorm:"->;type:tinyint(1) GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL;uniqueIndex:uk_categorias_nombre,priority:2;uniqueIndex:uk_categorias_slug,priority:2"`
 }
 
 func (CategoriaModel) TableName() string {
 	return "categorias"
 }
 
+// MigrarIndicesUnicos convierte los índices únicos anteriores a la papelera, UNIQUE(nombre)
+// y UNIQUE(slug), en UNIQUE(nombre, activo) y UNIQUE(slug, activo). Ejecutar después de AutoMigrate.
+func MigrarIndicesUnicos(db *gorm.DB) error {
+	if err := database.AsegurarIndiceUnico(db, "categorias", "uk_categorias_nombre", "nombre", "activo"); err != nil {
+		return err
+	}
+	return database.AsegurarIndiceUnico(db, "categorias", "uk_categorias_slug", "slug", "activo")
+}
+
 // --- Funciones de Mapeo ---
 
 // ToDomain convierte el modelo de persistencia (GORM) al modelo de dominio (puro).
@@ -34,13 +47,18 @@ func (m *CategoriaModel) ToDomain() *Categoria { // <-- SIN 'domain.'
 	if m == nil {
 		return nil
 	}
-	return &Categoria{ // <-- SIN 'domain.'
+	categoria := &Categoria{ // <-- SIN 'domain.'
 		ID:        m.ID,
 		Nombre:    m.Nombre,
 		Slug:      m.Slug,
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 	}
+	if m.DeletedAt.Valid { // Solo al consultar la papelera (Unscoped)
+		eliminadaEn := m.DeletedAt.Time
+		categoria.EliminadaEn = &eliminadaEn
+	}
+	return categoria
 }
 
 // FromDomain convierte un modelo de dominio (puro) al modelo de persistencia (GORM).
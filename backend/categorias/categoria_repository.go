@@ -8,6 +8,8 @@ package categorias
 import (
 	//"backend/internal/domain" // Depende SOLO del dominio
 	"context"
+	"time"
+	"backend/shared/i18n"
 )
 
 // CategoriaRepository define los métodos para interactuar con el almacenamiento de categorías.
@@ -19,4 +21,19 @@ type CategoriaRepository interface {
 	Create(ctx context.Context, categoria *Categoria) error // Recibe y potencialmente modifica el puntero (ej: asignando ID)
 	Update(ctx context.Context, categoria *Categoria) error
 	Delete(ctx context.Context, id uint) error
+
+	// --- Papelera (registros con soft delete) ---
+	FindEliminadas(ctx context.Context) ([]Categoria, error)            // Eliminadas más recientemente primero
+	GetEliminadaByID(ctx context.Context, id uint) (*Categoria, error)   // ErrRecordNotFound si no está eliminada
+	Restaurar(ctx context.Context, id uint, slug string) error          // Quita el soft delete y fija el slug
+	Purgar(ctx context.Context, id uint) error                          // Borrado definitivo; ErrForeignKeyViolation si tiene recetas
+	PurgarEliminadasAntesDe(ctx context.Context, limite time.Time) (int64, error) // Omite las que aún tienen recetas
+
+	// --- Traducciones ---
+	FindTraducciones(ctx context.Context, categoriaID uint) ([]CategoriaTraduccion, error) // Ordenadas por idioma
+	FindTraduccionesEnIdioma(ctx context.Context, idioma i18n.Idioma, categoriaIDs []uint) ([]CategoriaTraduccion, error)
+	GuardarTraduccion(ctx context.Context, traduccion *CategoriaTraduccion) error // Crea o reemplaza la del idioma
+	DeleteTraduccion(ctx context.Context, categoriaID uint, idioma i18n.Idioma) error // ErrRecordNotFound si no existe
+	// SlugTraducidoEnUso indica si otra categoría (distinta de 'categoriaID') usa el slug en ese idioma.
+	SlugTraducidoEnUso(ctx context.Context, idioma i18n.Idioma, slug string, categoriaID uint) (bool, error)
 }
\ No newline at end of file
@@ -8,6 +8,8 @@ package categorias
 
 import (
 	"context"
+	"time"
+	"backend/shared/i18n"
 	"github.com/stretchr/testify/mock" // Importar el paquete de mock
 )
 
@@ -69,4 +71,68 @@ func (m *CategoriaRepositoryMock) Update(ctx context.Context, categoria *Categor
 func (m *CategoriaRepositoryMock) Delete(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
-}
\ No newline at end of file
+}
+
+// --- Papelera ---
+
+func (m *CategoriaRepositoryMock) FindEliminadas(ctx context.Context) ([]Categoria, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]Categoria), args.Error(1)
+}
+
+func (m *CategoriaRepositoryMock) GetEliminadaByID(ctx context.Context, id uint) (*Categoria, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Categoria), args.Error(1)
+}
+
+func (m *CategoriaRepositoryMock) Restaurar(ctx context.Context, id uint, slug string) error {
+	args := m.Called(ctx, id, slug)
+	return args.Error(0)
+}
+
+func (m *CategoriaRepositoryMock) Purgar(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *CategoriaRepositoryMock) PurgarEliminadasAntesDe(ctx context.Context, limite time.Time) (int64, error) {
+	args := m.Called(ctx, limite)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *CategoriaRepositoryMock) FindTraducciones(ctx context.Context, categoriaID uint) ([]CategoriaTraduccion, error) {
+	args := m.Called(ctx, categoriaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]CategoriaTraduccion), args.Error(1)
+}
+
+func (m *CategoriaRepositoryMock) FindTraduccionesEnIdioma(ctx context.Context, idioma i18n.Idioma, categoriaIDs []uint) ([]CategoriaTraduccion, error) {
+	args := m.Called(ctx, idioma, categoriaIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]CategoriaTraduccion), args.Error(1)
+}
+
+func (m *CategoriaRepositoryMock) GuardarTraduccion(ctx context.Context, traduccion *CategoriaTraduccion) error {
+	args := m.Called(ctx, traduccion)
+	return args.Error(0)
+}
+
+func (m *CategoriaRepositoryMock) DeleteTraduccion(ctx context.Context, categoriaID uint, idioma i18n.Idioma) error {
+	args := m.Called(ctx, categoriaID, idioma)
+	return args.Error(0)
+}
+
+func (m *CategoriaRepositoryMock) SlugTraducidoEnUso(ctx context.Context, idioma i18n.Idioma, slug string, categoriaID uint) (bool, error) {
+	args := m.Called(ctx, idioma, slug, categoriaID)
+	return args.Bool(0), args.Error(1)
+}
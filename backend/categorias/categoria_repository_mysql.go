@@ -10,7 +10,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause" // Para el upsert de traducciones
+	"backend/shared/i18n"
 	"backend/shared/repository"
 )
 
@@ -66,8 +69,8 @@ func (r *categoriaRepository) Create(ctx context.Context, categoria *Categoria)
 	model := FromDomain(categoria) // Mapear Dominio -> Modelo GORM
 	// Crear usando el Modelo GORM
 	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
-		// Podríamos añadir chequeo de error UNIQUE aquí basado en el string del error MySQL
-		return fmt.Errorf("repositorio mysql: error al crear categoria: %w", err)
+		// Un nombre o slug duplicado llega como repository.ErrDuplicateRecord (ver TraducirError)
+		return fmt.Errorf("repositorio mysql: error al crear categoria: %w", repository.TraducirError(err))
 	}
 	// Actualizar el ID en el objeto de dominio original que nos pasaron
 	categoria.ID = model.ID
@@ -80,8 +83,7 @@ func (r *categoriaRepository) Update(ctx context.Context, categoria *Categoria)
 	// Asegúrate que el modelo tenga el ID correcto
 	result := r.db.WithContext(ctx).Model(&CategoriaModel{}).Where("id = ?", model.ID).Updates(model)
 	if result.Error != nil {
-		// Podríamos chequear error UNIQUE aquí también
-		return fmt.Errorf("repositorio mysql: error al actualizar categoria id %d: %w", model.ID, result.Error)
+		return fmt.Errorf("repositorio mysql: error al actualizar categoria id %d: %w", model.ID, repository.TraducirError(result.Error))
 	}
 	if result.RowsAffected == 0 {
 		return repository.ErrRecordNotFound // No encontró el ID para actualizar
@@ -94,10 +96,131 @@ func (r *categoriaRepository) Delete(ctx context.Context, id uint) error {
 	// Borrar usando el Modelo GORM como referencia y el ID
 	result := r.db.WithContext(ctx).Delete(&CategoriaModel{}, id)
 	if result.Error != nil {
-		return fmt.Errorf("repositorio mysql: error al eliminar categoria id %d: %w", id, result.Error)
+		return fmt.Errorf("repositorio mysql: error al eliminar categoria id %d: %w", id, repository.TraducirError(result.Error))
 	}
 	if result.RowsAffected == 0 {
 		return repository.ErrRecordNotFound // No encontró el ID para borrar
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// --- Papelera ---
+
+// sinRecetas excluye las categorías que aún referencian recetas (activas o en la papelera):
+// borrarlas dejaría esas recetas sin categoría (la FK es ON DELETE SET NULL).
+const sinRecetas = "NOT EXISTS (SELECT 1 FROM recetas WHERE recetas.categoria_id = categorias.id)"
+
+func (r *categoriaRepository) FindEliminadas(ctx context.Context) ([]Categoria, error) {
+	var models []CategoriaModel
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repositorio mysql: error al obtener categorias eliminadas: %w", err)
+	}
+	return ModelsToDomains(models), nil
+}
+
+func (r *categoriaRepository) GetEliminadaByID(ctx context.Context, id uint) (*Categoria, error) {
+	var model CategoriaModel
+	if err := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repositorio mysql: error al obtener categoria eliminada id %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *categoriaRepository) Restaurar(ctx context.Context, id uint, slug string) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&CategoriaModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "slug": slug})
+	if result.Error != nil {
+		return fmt.Errorf("repositorio mysql: error al restaurar categoria id %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *categoriaRepository) Purgar(ctx context.Context, id uint) error {
+	var enUso int64
+	if err := r.db.WithContext(ctx).Table("recetas").Where("categoria_id = ?", id).Count(&enUso).Error; err != nil {
+		return fmt.Errorf("repositorio mysql: error al contar recetas de categoria id %d: %w", id, err)
+	}
+	if enUso > 0 {
+		return repository.ErrForeignKeyViolation
+	}
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(&CategoriaModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repositorio mysql: error al purgar categoria id %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *categoriaRepository) PurgarEliminadasAntesDe(ctx context.Context, limite time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND "+sinRecetas, limite).
+		Delete(&CategoriaModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("repositorio mysql: error al purgar categorias vencidas: %w", repository.TraducirError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
+
+// --- Traducciones ---
+
+func (r *categoriaRepository) FindTraducciones(ctx context.Context, categoriaID uint) ([]CategoriaTraduccion, error) {
+	var models []CategoriaTraduccionModel
+	if err := r.db.WithContext(ctx).Where("categoria_id = ?", categoriaID).Order("idioma").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repositorio mysql: error al obtener traducciones de categoria id %d: %w", categoriaID, err)
+	}
+	return CategoriaTraduccionModelsToDomains(models), nil
+}
+
+func (r *categoriaRepository) FindTraduccionesEnIdioma(ctx context.Context, idioma i18n.Idioma, categoriaIDs []uint) ([]CategoriaTraduccion, error) {
+	if len(categoriaIDs) == 0 {
+		return []CategoriaTraduccion{}, nil
+	}
+	var models []CategoriaTraduccionModel
+	if err := r.db.WithContext(ctx).Where("idioma = ? AND categoria_id IN ?", string(idioma), categoriaIDs).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repositorio mysql: error al obtener traducciones '%s' de categorias: %w", idioma, err)
+	}
+	return CategoriaTraduccionModelsToDomains(models), nil
+}
+
+func (r *categoriaRepository) GuardarTraduccion(ctx context.Context, traduccion *CategoriaTraduccion) error {
+	model := FromCategoriaTraduccionDomain(traduccion)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "categoria_id"}, {Name: "idioma"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nombre", "slug", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("repositorio mysql: error al guardar traduccion '%s' de categoria id %d: %w", traduccion.Idioma, traduccion.CategoriaID, repository.TraducirError(err))
+	}
+	traduccion.CreatedAt, traduccion.UpdatedAt = model.CreatedAt, model.UpdatedAt
+	return nil
+}
+
+func (r *categoriaRepository) DeleteTraduccion(ctx context.Context, categoriaID uint, idioma i18n.Idioma) error {
+	result := r.db.WithContext(ctx).Where("categoria_id = ? AND idioma = ?", categoriaID, string(idioma)).Delete(&CategoriaTraduccionModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repositorio mysql: error al eliminar traduccion '%s' de categoria id %d: %w", idioma, categoriaID, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *categoriaRepository) SlugTraducidoEnUso(ctx context.Context, idioma i18n.Idioma, slug string, categoriaID uint) (bool, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&CategoriaTraduccionModel{}).
+		Where("idioma = ? AND slug = ? AND categoria_id <> ?", string(idioma), slug, categoriaID).
+		Count(&total).Error; err != nil {
+		return false, fmt.Errorf("repositorio mysql: error al verificar slug '%s' (%s): %w", slug, idioma, err)
+	}
+	return total > 0, nil
+}
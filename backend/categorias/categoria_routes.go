@@ -13,8 +13,8 @@ import (
 
 // RegisterCategoriaRoutes registra las rutas específicas para la entidad Categoria.
 // Recibe el grupo de router BASE de la API (ej: el que se crea con router.Group("/api/v1"))
-// y el handler específico para categorías.
-func RegisterCategoriaRoutes(apiBaseGroup *gin.RouterGroup, h *CategoriaHandler) {
+// y el handler específico para categorías. requireAdmin protege las rutas de /admin/categorias.
+func RegisterCategoriaRoutes(apiBaseGroup *gin.RouterGroup, h *CategoriaHandler, requireAdmin gin.HandlerFunc) {
 	// Crear un subgrupo específico para categorías a partir del grupo base
 	// Esto resultará en rutas como /api/v1/categorias
 	categoriaRoutes := apiBaseGroup.Group("/categorias")
@@ -25,6 +25,22 @@ func RegisterCategoriaRoutes(apiBaseGroup *gin.RouterGroup, h *CategoriaHandler)
 		categoriaRoutes.PUT("/:id", h.Update)
 		categoriaRoutes.DELETE("/:id", h.Delete)
 	}
+
+	// Papelera (solo admins)
+	categoriasAdminRoutes := apiBaseGroup.Group("/admin/categorias/papelera", requireAdmin)
+	{
+		categoriasAdminRoutes.GET("", h.GetPapelera)
+		categoriasAdminRoutes.POST("/:id/restaurar", h.RestaurarDePapelera)
+		categoriasAdminRoutes.DELETE("/:id", h.PurgarDePapelera)
+	}
+
+	// Traducciones (solo admins)
+	traduccionesAdminRoutes := apiBaseGroup.Group("/admin/categorias/:id/traducciones", requireAdmin)
+	{
+		traduccionesAdminRoutes.GET("", h.GetTraducciones)
+		traduccionesAdminRoutes.PUT("/:idioma", h.GuardarTraduccion)
+		traduccionesAdminRoutes.DELETE("/:idioma", h.EliminarTraduccion)
+	}
 	log.Println("🛣️  Rutas de Categorías configuradas bajo el grupo API base.")
 }
 
@@ -12,8 +12,11 @@ import (
 	"fmt"
 	"log" // Temporal, reemplazar con logger estructurado
 	"strings"
+	"time"
 	"github.com/gosimple/slug" // Para generar slugs
+	"backend/shared/i18n"
 	"backend/shared/repository"
+	utils "backend/shared/utilis" // Para buscar un slug libre al restaurar
 )
 // --- Interfaz del Servicio ---
 // Define el contrato que este servicio ofrece a las capas externas (Handlers).
@@ -25,6 +28,24 @@ type CategoriaService interface {
 	Create(ctx context.Context, input CategoriaInputDTO) (*Categoria, error)          // Devuelve la categoría creada
 	Update(ctx context.Context, id uint, input CategoriaInputDTO) (*Categoria, error) // Devuelve la categoría actualizada
 	Delete(ctx context.Context, id uint) error
+
+	// --- Papelera ---
+	ListarPapelera(ctx context.Context) ([]Categoria, error)
+	// RestaurarDePapelera devuelve la categoría a su sitio. Falla si otra activa tiene su nombre;
+	// si solo coincide el slug, se le asigna el primer 'slug-N' libre.
+	RestaurarDePapelera(ctx context.Context, id uint) (*Categoria, error)
+	PurgarDePapelera(ctx context.Context, id uint) error // Borrado definitivo (no si tiene recetas)
+	// PurgarVencidas borra definitivamente las eliminadas antes de 'antesDe' (ver papelera.Purgador).
+	PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error)
+
+	// --- Traducciones (ver categoria_traduccion.go) ---
+	// Traducir devuelve las categorías en 'idioma' cuando tienen traducción; las demás
+	// (y todas si 'idioma' es el idioma por defecto) se devuelven sin cambios.
+	Traducir(ctx context.Context, idioma i18n.Idioma, categorias []Categoria) ([]Categoria, error)
+	ListarTraducciones(ctx context.Context, id uint) ([]CategoriaTraduccion, error)
+	// GuardarTraduccion crea o reemplaza la traducción; el slug se genera del nombre y es único por idioma.
+	GuardarTraduccion(ctx context.Context, id uint, idioma i18n.Idioma, input CategoriaTraduccionInputDTO) (*CategoriaTraduccion, error)
+	EliminarTraduccion(ctx context.Context, id uint, idioma i18n.Idioma) error
 }
 // --- Implementación Concreta del Servicio ---
 // Proporciona la lógica real para la interfaz CategoriaService.
@@ -86,6 +107,9 @@ func (s *categoriaService) Create(ctx context.Context, input CategoriaInputDTO)
 
 	err = s.repo.Create(ctx, nuevaCategoria) // Llamar al repo
 	if err != nil {
+		if errors.Is(err, repository.ErrDuplicateRecord) { // Otra petición creó el mismo nombre/slug entre la verificación y el insert
+			return nil, repository.ConCausa(ErrCategoriaNombreYaExiste, err)
+		}
 		return nil, fmt.Errorf("servicio: error al crear categoria en repositorio: %w", err)
 	}
 
@@ -126,6 +150,9 @@ func (s *categoriaService) Update(ctx context.Context, id uint, input CategoriaI
 			log.Printf("Advertencia: La categoría %d desapareció justo antes de actualizarla.\n", id)
 			return nil, ErrCategoriaNotFound
 		}
+		if errors.Is(err, repository.ErrDuplicateRecord) {
+			return nil, repository.ConCausa(ErrCategoriaNombreYaExiste, err)
+		}
 		return nil, fmt.Errorf("servicio: error al actualizar categoría en repositorio: %w", err)
 	}
 
@@ -154,3 +181,164 @@ func (s *categoriaService) Delete(ctx context.Context, id uint) error {
 	log.Printf("Servicio: Categoría ID %d eliminada.\n", id)
 	return nil
 }
+
+// --- Papelera ---
+
+func (s *categoriaService) ListarPapelera(ctx context.Context) ([]Categoria, error) {
+	categorias, err := s.repo.FindEliminadas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("servicio: error al listar papelera de categorias: %w", err)
+	}
+	return categorias, nil
+}
+
+func (s *categoriaService) RestaurarDePapelera(ctx context.Context, id uint) (*Categoria, error) {
+	categoria, err := s.repo.GetEliminadaByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrCategoriaNotFound
+		}
+		return nil, fmt.Errorf("servicio: error al buscar categoría %d en papelera: %w", id, err)
+	}
+
+	_, err = s.repo.GetByNombre(ctx, categoria.Nombre) // El nombre no se puede cambiar automáticamente
+	if err == nil {
+		return nil, fmt.Errorf("%w: renombre la categoría activa '%s' antes de restaurar", ErrCategoriaNombreYaExiste, categoria.Nombre)
+	}
+	if !errors.Is(err, repository.ErrRecordNotFound) {
+		return nil, fmt.Errorf("servicio: error inesperado al verificar nombre '%s': %w", categoria.Nombre, err)
+	}
+
+	slugLibre, err := utils.SlugDisponible(ctx, categoria.Slug, func(ctx context.Context, candidato string) (bool, error) {
+		existente, err := s.repo.GetBySlug(ctx, candidato)
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return false, nil
+		}
+		return err == nil && existente.ID != id, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("servicio: error buscando slug libre para categoría %d: %w", id, err)
+	}
+
+	if err := s.repo.Restaurar(ctx, id, slugLibre); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrCategoriaNotFound
+		}
+		if errors.Is(err, repository.ErrDuplicateRecord) {
+			return nil, repository.ConCausa(ErrCategoriaNombreYaExiste, err)
+		}
+		return nil, fmt.Errorf("servicio: error al restaurar categoría %d: %w", id, err)
+	}
+	categoria.Slug = slugLibre
+	categoria.EliminadaEn = nil
+
+	log.Printf("Servicio: Categoría ID %d restaurada de la papelera (slug '%s').\n", id, slugLibre)
+	return categoria, nil
+}
+
+func (s *categoriaService) PurgarDePapelera(ctx context.Context, id uint) error {
+	if err := s.repo.Purgar(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrCategoriaNotFound
+		}
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			return ErrCategoriaEnUso
+		}
+		return fmt.Errorf("servicio: error al purgar categoría %d: %w", id, err)
+	}
+	log.Printf("Servicio: Categoría ID %d purgada definitivamente.\n", id)
+	return nil
+}
+
+func (s *categoriaService) PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error) {
+	n, err := s.repo.PurgarEliminadasAntesDe(ctx, antesDe)
+	if err != nil {
+		return 0, fmt.Errorf("servicio: error al purgar categorias vencidas: %w", err)
+	}
+	return n, nil
+}
+
+// --- Traducciones ---
+
+func (s *categoriaService) Traducir(ctx context.Context, idioma i18n.Idioma, categorias []Categoria) ([]Categoria, error) {
+	if !i18n.EsTraducible(idioma) || len(categorias) == 0 {
+		return categorias, nil
+	}
+	ids := make([]uint, 0, len(categorias))
+	for _, categoria := range categorias {
+		ids = append(ids, categoria.ID)
+	}
+	traducciones, err := s.repo.FindTraduccionesEnIdioma(ctx, idioma, ids)
+	if err != nil {
+		return nil, fmt.Errorf("servicio: error al obtener traducciones '%s' de categorias: %w", idioma, err)
+	}
+	porCategoria := make(map[uint]CategoriaTraduccion, len(traducciones))
+	for _, t := range traducciones {
+		porCategoria[t.CategoriaID] = t
+	}
+
+	traducidas := make([]Categoria, len(categorias)) // No modificar el slice recibido
+	for i, categoria := range categorias {
+		if t, ok := porCategoria[categoria.ID]; ok {
+			categoria = categoria.Traducida(t)
+		}
+		traducidas[i] = categoria
+	}
+	return traducidas, nil
+}
+
+func (s *categoriaService) ListarTraducciones(ctx context.Context, id uint) ([]CategoriaTraduccion, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	traducciones, err := s.repo.FindTraducciones(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("servicio: error al listar traducciones de categoría %d: %w", id, err)
+	}
+	return traducciones, nil
+}
+
+func (s *categoriaService) GuardarTraduccion(ctx context.Context, id uint, idioma i18n.Idioma, input CategoriaTraduccionInputDTO) (*CategoriaTraduccion, error) {
+	if !i18n.EsTraducible(idioma) {
+		return nil, fmt.Errorf("%w: '%s'", i18n.ErrIdiomaNoTraducible, idioma)
+	}
+	nombreLimpio := strings.TrimSpace(input.Nombre)
+	if nombreLimpio == "" {
+		return nil, errors.New("servicio: el nombre de la categoría no puede estar vacío")
+	}
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	slugLibre, err := utils.SlugDisponible(ctx, slug.Make(nombreLimpio), func(ctx context.Context, candidato string) (bool, error) {
+		return s.repo.SlugTraducidoEnUso(ctx, idioma, candidato, id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("servicio: error buscando slug libre para la traducción '%s' de categoría %d: %w", idioma, id, err)
+	}
+
+	traduccion := &CategoriaTraduccion{CategoriaID: id, Idioma: idioma, Nombre: nombreLimpio, Slug: slugLibre}
+	if err := s.repo.GuardarTraduccion(ctx, traduccion); err != nil {
+		if errors.Is(err, repository.ErrForeignKeyViolation) { // La categoría se purgó entre la verificación y el guardado
+			return nil, repository.ConCausa(ErrCategoriaNotFound, err)
+		}
+		return nil, fmt.Errorf("servicio: error al guardar traducción '%s' de categoría %d: %w", idioma, id, err)
+	}
+
+	log.Printf("Servicio: Traducción '%s' de la categoría ID %d guardada (slug '%s').\n", idioma, id, slugLibre)
+	return traduccion, nil
+}
+
+func (s *categoriaService) EliminarTraduccion(ctx context.Context, id uint, idioma i18n.Idioma) error {
+	if !i18n.EsTraducible(idioma) {
+		return fmt.Errorf("%w: '%s'", i18n.ErrIdiomaNoTraducible, idioma)
+	}
+	if err := s.repo.DeleteTraduccion(ctx, id, idioma); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrCategoriaTraduccionNotFound
+		}
+		return fmt.Errorf("servicio: error al eliminar traducción '%s' de categoría %d: %w", idioma, id, err)
+	}
+	log.Printf("Servicio: Traducción '%s' de la categoría ID %d eliminada.\n", idioma, id)
+	return nil
+}
@@ -12,3 +12,9 @@ package categorias
 type CategoriaInputDTO struct {
 	Nombre string
 }
+
+
+// CategoriaTraduccionInputDTO es el contenido traducido de una categoría (el idioma va aparte).
+type CategoriaTraduccionInputDTO struct {
+	Nombre string
+}
@@ -9,10 +9,12 @@ package categorias
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing" // Paquete estándar de testing
 	//"github.com/stretchr/testify/assert" // Para aserciones
 	"github.com/stretchr/testify/mock"   // Para configurar el mock
 	"github.com/stretchr/testify/suite"  // Para organizar tests
+	"backend/shared/i18n"
 	"backend/shared/repository"
 )
 
@@ -301,4 +303,130 @@ func (s *CategoriaServiceTestSuite) TestDelete_Success() {
     s.mockRepo.AssertExpectations(s.T())
 }
 
-// TODO: Añadir más tests para Delete (NotFound, RepoGetError, RepoDeleteError)
\ No newline at end of file
+// TODO: Añadir más tests para Delete (NotFound, RepoGetError, RepoDeleteError)
+
+// --- Tests para la Papelera ---
+
+func (s *CategoriaServiceTestSuite) TestRestaurarDePapelera_SlugOcupado() {
+	ctx := context.Background()
+	eliminada := &Categoria{ID: 3, Nombre: "Postres", Slug: "postres"}
+	s.mockRepo.On("GetEliminadaByID", ctx, uint(3)).Return(eliminada, nil).Once()
+	s.mockRepo.On("GetByNombre", ctx, "Postres").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRepo.On("GetBySlug", ctx, "postres").Return(&Categoria{ID: 8, Nombre: "Postrés", Slug: "postres"}, nil).Once()
+	s.mockRepo.On("GetBySlug", ctx, "postres-2").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRepo.On("Restaurar", ctx, uint(3), "postres-2").Return(nil).Once()
+
+	categoria, err := s.service.RestaurarDePapelera(ctx, 3)
+
+	s.NoError(err)
+	s.Equal("postres-2", categoria.Slug)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *CategoriaServiceTestSuite) TestRestaurarDePapelera_Fail_NombreOcupado() {
+	ctx := context.Background()
+	s.mockRepo.On("GetEliminadaByID", ctx, uint(3)).Return(&Categoria{ID: 3, Nombre: "Postres", Slug: "postres"}, nil).Once()
+	s.mockRepo.On("GetByNombre", ctx, "Postres").Return(&Categoria{ID: 8, Nombre: "Postres"}, nil).Once()
+
+	_, err := s.service.RestaurarDePapelera(ctx, 3)
+
+	s.ErrorIs(err, ErrCategoriaNombreYaExiste)
+	s.mockRepo.AssertNotCalled(s.T(), "Restaurar", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CategoriaServiceTestSuite) TestPurgarDePapelera_Fail_EnUso() {
+	ctx := context.Background()
+	s.mockRepo.On("Purgar", ctx, uint(3)).Return(repository.ErrForeignKeyViolation).Once()
+
+	err := s.service.PurgarDePapelera(ctx, 3)
+
+	s.ErrorIs(err, ErrCategoriaEnUso)
+}
+
+func (s *CategoriaServiceTestSuite) TestPurgarDePapelera_Fail_NoEstaEnPapelera() {
+	ctx := context.Background()
+	s.mockRepo.On("Purgar", ctx, uint(3)).Return(repository.ErrRecordNotFound).Once()
+
+	err := s.service.PurgarDePapelera(ctx, 3)
+
+	s.ErrorIs(err, ErrCategoriaNotFound)
+}
+
+func (s *CategoriaServiceTestSuite) TestCreate_Fail_DuplicadoEnRepo() {
+	ctx := context.Background()
+	duplicado := &repository.ConstraintError{Base: repository.ErrDuplicateRecord, Campo: "slug", Causa: errors.New("Error 1062")}
+	s.mockRepo.On("GetByNombre", ctx, "Postres").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRepo.On("Create", ctx, mock.AnythingOfType("*categorias.Categoria")).Return(fmt.Errorf("repositorio mysql: %w", duplicado)).Once()
+
+	categoria, err := s.service.Create(ctx, CategoriaInputDTO{Nombre: "Postres"})
+
+	s.Nil(categoria)
+	s.ErrorIs(err, ErrCategoriaNombreYaExiste)
+	s.EqualError(err, ErrCategoriaNombreYaExiste.Error()) // Sin detalles del driver
+	campo, _ := repository.CampoEnConflicto(err)
+	s.Equal("slug", campo)
+}
+
+// --- Traducciones ---
+
+func (s *CategoriaServiceTestSuite) TestTraducir_IdiomaPorDefecto_NoConsultaRepo() {
+	ctx := context.Background()
+	cats := []Categoria{{ID: 1, Nombre: "Postres", Slug: "postres"}}
+
+	traducidas, err := s.service.Traducir(ctx, i18n.IdiomaPorDefecto, cats)
+
+	s.NoError(err)
+	s.Equal(cats, traducidas)
+	s.mockRepo.AssertNotCalled(s.T(), "FindTraduccionesEnIdioma", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CategoriaServiceTestSuite) TestTraducir_AplicaTraduccionYRecurreAlOriginal() {
+	ctx := context.Background()
+	cats := []Categoria{{ID: 1, Nombre: "Postres", Slug: "postres"}, {ID: 2, Nombre: "Sopas", Slug: "sopas"}}
+	s.mockRepo.On("FindTraduccionesEnIdioma", ctx, i18n.EN, []uint{1, 2}).
+		Return([]CategoriaTraduccion{{CategoriaID: 1, Idioma: i18n.EN, Nombre: "Desserts", Slug: "desserts"}}, nil).Once()
+
+	traducidas, err := s.service.Traducir(ctx, i18n.EN, cats)
+
+	s.NoError(err)
+	s.Equal("Desserts", traducidas[0].Nombre)
+	s.Equal(i18n.EN, traducidas[0].Idioma)
+	s.Equal("Sopas", traducidas[1].Nombre) // Sin traducción: idioma por defecto
+	s.Empty(traducidas[1].Idioma)
+	s.Equal("Postres", cats[0].Nombre) // El slice recibido no se modifica
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *CategoriaServiceTestSuite) TestGuardarTraduccion_SlugOcupadoEnElIdioma() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(1)).Return(&Categoria{ID: 1, Nombre: "Postres"}, nil).Once()
+	s.mockRepo.On("SlugTraducidoEnUso", ctx, i18n.EN, "desserts", uint(1)).Return(true, nil).Once()
+	s.mockRepo.On("SlugTraducidoEnUso", ctx, i18n.EN, "desserts-2", uint(1)).Return(false, nil).Once()
+	s.mockRepo.On("GuardarTraduccion", ctx, mock.MatchedBy(func(t *CategoriaTraduccion) bool {
+		return t.CategoriaID == 1 && t.Idioma == i18n.EN && t.Nombre == "Desserts" && t.Slug == "desserts-2"
+	})).Return(nil).Once()
+
+	traduccion, err := s.service.GuardarTraduccion(ctx, 1, i18n.EN, CategoriaTraduccionInputDTO{Nombre: " Desserts "})
+
+	s.NoError(err)
+	s.Equal("desserts-2", traduccion.Slug)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *CategoriaServiceTestSuite) TestGuardarTraduccion_Fail_IdiomaNoTraducible() {
+	ctx := context.Background()
+	for _, idioma := range []i18n.Idioma{i18n.IdiomaPorDefecto, "pt"} {
+		_, err := s.service.GuardarTraduccion(ctx, 1, idioma, CategoriaTraduccionInputDTO{Nombre: "Postres"})
+		s.ErrorIs(err, i18n.ErrIdiomaNoTraducible)
+	}
+	s.mockRepo.AssertNotCalled(s.T(), "GuardarTraduccion", mock.Anything, mock.Anything)
+}
+
+func (s *CategoriaServiceTestSuite) TestEliminarTraduccion_NoExiste() {
+	ctx := context.Background()
+	s.mockRepo.On("DeleteTraduccion", ctx, uint(1), i18n.EN).Return(repository.ErrRecordNotFound).Once()
+
+	err := s.service.EliminarTraduccion(ctx, 1, i18n.EN)
+
+	s.ErrorIs(err, ErrCategoriaTraduccionNotFound)
+}
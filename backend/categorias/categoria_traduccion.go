@@ -0,0 +1,34 @@
+// backend/categorias/categoria_traduccion.go
+
+// Este archivo define las traducciones del contenido de una categoría.
+//
+// El contenido en el idioma por defecto (i18n.IdiomaPorDefecto) vive en la propia
+// categoría; cada traducción guarda el nombre y su slug en otro idioma soportado.
+// Las lecturas públicas devuelven la categoría en el idioma de la petición si tiene
+// traducción y, si no, en el idioma por defecto (ver CategoriaService.Traducir).
+
+package categorias
+
+import (
+	"time"
+
+	"backend/shared/i18n"
+)
+
+// CategoriaTraduccion es el contenido de una categoría en un idioma distinto del idioma por defecto.
+type CategoriaTraduccion struct {
+	CategoriaID uint
+	Idioma      i18n.Idioma
+	Nombre      string
+	Slug        string // Único por idioma
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Traducida devuelve una copia de la categoría con el contenido de la traducción.
+func (c Categoria) Traducida(t CategoriaTraduccion) Categoria {
+	c.Nombre = t.Nombre
+	c.Slug = t.Slug
+	c.Idioma = t.Idioma
+	return c
+}
@@ -0,0 +1,66 @@
+// backend/categorias/categoria_traduccion_model_gorm.go
+
+// Este archivo define el modelo de persistencia de las traducciones de categorías.
+
+package categorias
+
+import (
+	"time"
+
+	"backend/shared/i18n"
+)
+
+// CategoriaTraduccionModel representa la tabla 'categoria_traducciones'.
+// Una fila por categoría e idioma; el slug es único dentro de cada idioma.
+type CategoriaTraduccionModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	CategoriaID uint   `gorm:"not null;uniqueIndex:uk_categoria_traducciones_idioma,priority:1"`
+	Idioma      string `gorm:"type:varchar(5);not null;uniqueIndex:uk_categoria_traducciones_idioma,priority:2;uniqueIndex:uk_categoria_traducciones_slug,priority:1"`
+	Nombre      string `gorm:"type:varchar(100);not null"`
+	Slug        string `gorm:"type:varchar(120);not null;uniqueIndex:uk_categoria_traducciones_slug,priority:2"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Categoria CategoriaModel `gorm:"foreignKey:CategoriaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (CategoriaTraduccionModel) TableName() string {
+	return "categoria_traducciones"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *CategoriaTraduccionModel) ToDomain() *CategoriaTraduccion {
+	if m == nil {
+		return nil
+	}
+	return &CategoriaTraduccion{
+		CategoriaID: m.CategoriaID,
+		Idioma:      i18n.Idioma(m.Idioma),
+		Nombre:      m.Nombre,
+		Slug:        m.Slug,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func FromCategoriaTraduccionDomain(d *CategoriaTraduccion) *CategoriaTraduccionModel {
+	if d == nil {
+		return nil
+	}
+	return &CategoriaTraduccionModel{
+		CategoriaID: d.CategoriaID,
+		Idioma:      string(d.Idioma),
+		Nombre:      d.Nombre,
+		Slug:        d.Slug,
+	}
+}
+
+// CategoriaTraduccionModelsToDomains convierte un slice de modelos GORM a dominio.
+func CategoriaTraduccionModelsToDomains(models []CategoriaTraduccionModel) []CategoriaTraduccion {
+	traducciones := make([]CategoriaTraduccion, 0, len(models))
+	for _, model := range models {
+		traducciones = append(traducciones, *model.ToDomain())
+	}
+	return traducciones
+}
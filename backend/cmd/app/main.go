@@ -11,9 +11,11 @@ package main
 
 import (
 	// --- Paquetes Estándar de Go ---
+	"context" // Para las tareas en segundo plano
 	"fmt"
 	"log"      // Para logging inicial y errores fatales
 	"net/http" // Para http.StatusNotFound y http.StatusOK
+	"time"     // Para los intervalos de las tareas en segundo plano
 
 	// --- Paquetes de Terceros ---
 	"github.com/gin-gonic/gin"         // El framework web Gin
@@ -22,15 +24,27 @@ import (
 	// --- Paquetes Internos del Proyecto (Nueva Estructura "Paquete por Característica" y "Shared") ---
 	_ "backend/docs" // Paquete generado por Swagger para la documentación de la API (importado por efectos secundarios)
 
-	"backend/categorias" // Paquete para la característica/dominio de Categorías
+	"backend/categorias"  // Paquete para la característica/dominio de Categorías
+	"backend/comentarios" // Paquete para la característica/dominio de Comentarios
+	"backend/compras"     // Paquete para las Listas de la compra
 	"backend/contactos"  // Paquete para la característica/dominio de Contactos
+	"backend/correos"    // Bandeja de salida de correos (envío en segundo plano con reintentos)
+	"backend/favoritos"  // Paquete para Favoritos y Colecciones personales
+	"backend/ingredientes" // Paquete para Ingredientes y los ingredientes de cada receta
+	"backend/media"        // Paquete para el registro de archivos subidos
+	"backend/planificador" // Paquete para el Planificador semanal de comidas
 	"backend/recetas"    // Paquete para la característica/dominio de Recetas
 	// "backend/auth"       // Paquete para Autenticación (cuando se implemente)
 
 	"backend/shared/config"       // Paquete compartido para la configuración de la aplicación
 	"backend/shared/database"     // Paquete compartido para la conexión a la base de datos
+	"backend/shared/i18n"         // Idiomas (de los correos al admin)
 	"backend/shared/middleware"   // Paquete compartido para middlewares (ej: ErrorHandler)
 	"backend/shared/notifications" // Paquete compartido para notificaciones (ej: EmailNotifier)
+	"backend/shared/papelera"      // Tarea que vacía la papelera (soft deletes vencidos)
+	"backend/shared/security"      // Paquete compartido para JWT y hashing
+	"backend/shared/storage"       // Almacenamiento de archivos subidos (disco o S3)
+	utils "backend/shared/utilis"  // Límites de las imágenes subidas
 
 	// Paquetes de Swagger (si no los has importado en otro lado y los necesitas aquí)
 	swaggerFiles "github.com/swaggo/files"
@@ -78,11 +92,33 @@ func main() {
 	log.Printf("✅ Conexión a base de datos '%s' establecida.\n", cfg.Database.Name)
 
 	// --- 3. Ejecutar AutoMigrate (para Desarrollo y Tests) ---
+	// Los comentarios de recetas ya purgadas impedirían crear su FK
+	if err := comentarios.BorrarHuerfanos(dbInstance); err != nil {
+		log.Fatalf("❌ ERROR CRÍTICO preparando la migración de comentarios: %v", err)
+	}
 	log.Println("🔧 Ejecutando AutoMigrate para todas las entidades GORM...")
 	err = dbInstance.AutoMigrate(
 		&categorias.CategoriaModel{},
+		&categorias.CategoriaTraduccionModel{}, // Contenido de categorías en otros idiomas
+		&media.MediaModel{},    // Archivos subidos (antes que las recetas, que los referencian)
+		&media.MediaUsoModel{}, // Qué entidades usan cada archivo
 		&recetas.RecetaModel{},
+		&recetas.RecetaFotoModel{},     // Galería de fotos de recetas
+		&recetas.RecetaRevisionModel{}, // Historial inmutable de recetas
+		&recetas.RecetaTraduccionModel{}, // Contenido de recetas en otros idiomas
 		&contactos.ContactoModel{}, // Añadido modelo de Contactos
+		&correos.CorreoModel{},     // Bandeja de salida de correos
+		&comentarios.ComentarioModel{},
+		&comentarios.ReporteComentarioModel{},
+		&favoritos.FavoritoModel{},
+		&favoritos.ColeccionModel{},
+		&favoritos.ColeccionRecetaModel{},
+		&planificador.PlanSemanalModel{},
+		&planificador.ComidaPlanModel{},
+		&ingredientes.IngredienteModel{},
+		&ingredientes.RecetaIngredienteModel{},
+		&compras.ListaCompraModel{},
+		&compras.ItemListaModel{},
 		// &auth.UserModel{},
 		// ...otros *Model GORM aquí...
 	)
@@ -91,6 +127,15 @@ func main() {
 	}
 	log.Println("✅ AutoMigrate completado.")
 
+	// Índices únicos compatibles con soft delete (AutoMigrate no modifica índices existentes)
+	if err := categorias.MigrarIndicesUnicos(dbInstance); err != nil {
+		log.Fatalf("❌ ERROR CRÍTICO migrando índices de categorías: %v", err)
+	}
+	if err := recetas.MigrarIndicesUnicos(dbInstance); err != nil {
+		log.Fatalf("❌ ERROR CRÍTICO migrando índices de recetas: %v", err)
+	}
+	log.Println("✅ Índices únicos compatibles con la papelera asegurados.")
+
 	// --- 4. Inyección de Dependencias ---
 	log.Println("🏗️  Inicializando dependencias de la aplicación...")
 
@@ -100,6 +145,30 @@ func main() {
 		log.Fatalf("❌ ERROR CRÍTICO al crear el notificador de email: %v", err)
 	}
 	log.Println("   - Notificador de Email (SMTP) inicializado.")
+	plantillasCorreo := notifications.NewPlantillas(cfg.SMTP.PlantillasDir) // Embebidas, o las de la carpeta configurada
+
+	almacen, err := storage.Nuevo(cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ ERROR CRÍTICO al crear el almacenamiento de archivos: %v", err)
+	}
+	storage.EstablecerPredeterminado(almacen) // Para las URLs de las fotos en los DTO
+	storage.EstablecerCaducidadFirmas(time.Duration(cfg.Storage.FirmaMinutos) * time.Minute)
+	if local, ok := almacen.(*storage.Local); ok && local.Firma == nil {
+		log.Println("⚠️  storage.clave_firma vacía: las fotos de recetas no publicadas solo las verá su autor autenticado.")
+	}
+	log.Printf("   - Almacenamiento de archivos '%s' inicializado.\n", cfg.Storage.Driver)
+
+	// El generador de tokens se usará en el login; aquí solo se necesita el verificador.
+	_, tokenVerifier, err := security.NewJWTManager(cfg.JWT)
+	if err != nil {
+		log.Fatalf("❌ ERROR CRÍTICO al crear el gestor de JWT: %v", err)
+	}
+	log.Println("   - Gestor de JWT inicializado.")
+
+	// Dependencias de Media (archivos subidos)
+	mediaRepo := media.NewMediaRepository(dbInstance)
+	mediaService := media.NewMediaService(mediaRepo, almacen)
+	log.Println("   - Dependencias de 'Media' inicializadas.")
 
 	// Dependencias de Categorías
 	categoriaRepo := categorias.NewCategoriaRepository(dbInstance)
@@ -109,19 +178,93 @@ func main() {
 
 	// Dependencias de Recetas
 	recetaRepo := recetas.NewRecetaRepository(dbInstance)
-	recetaService := recetas.NewRecetaService(recetaRepo, categoriaService) // RecetaService depende de CategoriaService
-	recetaHandler := recetas.NewRecetaHandler(recetaService)
+	planRepo := planificador.NewPlanRepository(dbInstance) // Se crea antes: RecetaService le notifica las recetas eliminadas
+	recetaService := recetas.NewRecetaService(recetaRepo, categoriaService, mediaService, planificador.NewRecetaEliminadaListener(planRepo)) // RecetaService depende de CategoriaService
 	log.Println("   - Dependencias de 'Recetas' inicializadas.")
 
+	// Dependencias de Favoritos (antes del handler de recetas, que marca 'es_favorito')
+	favoritoRepo := favoritos.NewFavoritoRepository(dbInstance)
+	favoritoService := favoritos.NewFavoritoService(favoritoRepo, recetaService)
+	favoritoHandler := favoritos.NewFavoritoHandler(favoritoService)
+	limitesFoto := utils.LimitesImagen{
+		MaxBytes:   cfg.Uploads.MaxBytes,
+		MaxAncho:   cfg.Uploads.MaxAncho,
+		MaxAlto:    cfg.Uploads.MaxAlto,
+		MaxPixeles: cfg.Uploads.MaxPixeles,
+	}
+	recetaHandler := recetas.NewRecetaHandler(recetaService, favoritoService, mediaService, almacen, limitesFoto)
+	log.Println("   - Dependencias de 'Favoritos' inicializadas.")
+
+	// Dependencias del Planificador (el repositorio se creó junto con Recetas)
+	planService := planificador.NewPlanService(planRepo, recetaService)
+	planHandler := planificador.NewPlanHandler(planService)
+	log.Println("   - Dependencias del 'Planificador' inicializadas.")
+
+	// Dependencias de Ingredientes
+	ingredienteRepo := ingredientes.NewIngredienteRepository(dbInstance)
+	ingredienteService := ingredientes.NewIngredienteService(ingredienteRepo, recetaService)
+	ingredienteHandler := ingredientes.NewIngredienteHandler(ingredienteService)
+	log.Println("   - Dependencias de 'Ingredientes' inicializadas.")
+
+	// Dependencias de Listas de la compra (suman ingredientes de recetas o de un plan)
+	listaCompraRepo := compras.NewListaCompraRepository(dbInstance)
+	listaCompraService := compras.NewListaCompraService(listaCompraRepo, ingredienteService, recetaService, planService)
+	listaCompraHandler := compras.NewListaCompraHandler(listaCompraService)
+	log.Println("   - Dependencias de 'Listas de la compra' inicializadas.")
+
+	// Dependencias de la bandeja de salida de correos (el notificador lo usa su despachador)
+	correoRepo := correos.NewCorreoRepository(dbInstance)
+	correoService := correos.NewCorreoService(correoRepo)
+	correoHandler := correos.NewCorreoHandler(correoService)
+	log.Println("   - Dependencias de 'Correos' inicializadas.")
+
 	// Dependencias de Contactos
 	contactoRepo := contactos.NewContactoRepository(dbInstance)
-	// ContactoService necesita el notificador y los emails de admin/from de la config
-	contactoService := contactos.NewContactoService(contactoRepo, emailNotifier, cfg.SMTP.AdminTo, cfg.SMTP.From)
+	// ContactoService encola los avisos (generados con las plantillas de correo) con los emails de admin/from de la config
+	contactoService := contactos.NewContactoService(contactoRepo, plantillasCorreo, i18n.Idioma(cfg.SMTP.IdiomaAdmin), cfg.SMTP.AdminTo, cfg.SMTP.From)
 	contactoHandler := contactos.NewContactoHandler(contactoService)
 	log.Println("   - Dependencias de 'Contactos' inicializadas.")
 
+	// Dependencias de Comentarios
+	comentarioRepo := comentarios.NewComentarioRepository(dbInstance)
+	comentarioService := comentarios.NewComentarioService(comentarioRepo, recetaService) // Valida recetas vía RecetaService
+	comentarioHandler := comentarios.NewComentarioHandler(comentarioService)
+	log.Println("   - Dependencias de 'Comentarios' inicializadas.")
+
 	log.Println("✅ Todas las dependencias necesarias inicializadas.")
 
+	// --- Tareas en segundo plano ---
+	if cfg.Jobs.PublicacionIntervaloSegundos > 0 {
+		publicador := recetas.NewPublicadorProgramado(recetaService, time.Duration(cfg.Jobs.PublicacionIntervaloSegundos)*time.Second)
+		go publicador.Iniciar(context.Background())
+	}
+	if cfg.Jobs.PapeleraRetencionDias > 0 && cfg.Jobs.PapeleraIntervaloMinutos > 0 {
+		limpiador := papelera.NewLimpiador(
+			time.Duration(cfg.Jobs.PapeleraRetencionDias)*24*time.Hour,
+			time.Duration(cfg.Jobs.PapeleraIntervaloMinutos)*time.Minute,
+		).
+			Registrar("recetas", recetaService).
+			Registrar("categorias", categoriaService). // Después de recetas: no se purgan si aún tienen recetas
+			Registrar("contactos", contactoService)
+		go limpiador.Iniciar(context.Background())
+	}
+	if cfg.Jobs.HuerfanosIntervaloHoras > 0 {
+		recolector := media.NewRecolector(mediaRepo, almacen, time.Duration(cfg.Jobs.HuerfanosGraciaHoras)*time.Hour).
+			Registrar(recetas.NewReferenciasFotos(recetaRepo))
+		go recolector.Iniciar(context.Background(), time.Duration(cfg.Jobs.HuerfanosIntervaloHoras)*time.Hour, cfg.Jobs.HuerfanosSimular)
+	}
+
+	if cfg.Jobs.CorreosIntervaloSegundos > 0 {
+		despachador := correos.NewDespachador(correoRepo, emailNotifier, correos.Reintentos{
+			Max:  cfg.Jobs.CorreosMaxIntentos,
+			Base: time.Duration(cfg.Jobs.CorreosEsperaBaseSegundos) * time.Second,
+			Tope: time.Duration(cfg.Jobs.CorreosEsperaMaxMinutos) * time.Minute,
+		})
+		go despachador.Iniciar(context.Background(), time.Duration(cfg.Jobs.CorreosIntervaloSegundos)*time.Second)
+	} else {
+		log.Println("⚠️  jobs.correos_intervalo_segundos = 0: los correos quedan en cola sin enviarse.")
+	}
+
 	// --- 5. Inicialización del Router Gin ---
 	if cfg.AppEnv != "production" {
 		gin.SetMode(gin.DebugMode)
@@ -131,21 +274,46 @@ func main() {
 	router := gin.New()
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())    // X-Request-ID para logs y respuestas de error
+	router.Use(middleware.Localizacion()) // Idioma de la respuesta (?lang= o Accept-Language)
 	router.Use(middleware.ErrorHandler()) // Nuestro middleware de errores global
-	log.Println("✅ Middlewares globales (Logger, Recovery, ErrorHandler) registrados.")
+	router.Use(middleware.OptionalAuth(tokenVerifier)) // Identifica al usuario si envía un Bearer token
+	router.Use(middleware.Admins(cfg.Auth.Admins))     // Marca a los admins (auth.admins)
+	log.Println("✅ Middlewares globales (Logger, Recovery, RequestID, Localizacion, ErrorHandler, OptionalAuth, Admins) registrados.")
+	if len(cfg.Auth.Admins) == 0 {
+		log.Println("⚠️  auth.admins está vacío: las rutas /admin no serán accesibles.")
+	}
 	log.Printf("✅ Router Gin inicializado en modo: %s.\n", gin.Mode())
 
 	// --- 6. Configuración de Rutas ---
 	apiV1 := router.Group("/api/v1") // Grupo base para la API versionada
 
 	if categoriaHandler != nil {
-		categorias.RegisterCategoriaRoutes(apiV1, categoriaHandler)
+		categorias.RegisterCategoriaRoutes(apiV1, categoriaHandler, middleware.RequireAdmin())
 	}
 	if recetaHandler != nil {
-		recetas.RegisterRecetaRoutes(apiV1, recetaHandler)
+		recetas.RegisterRecetaRoutes(apiV1, recetaHandler, middleware.RequireAuth(), middleware.RequireAdmin())
 	}
 	if contactoHandler != nil {
-		contactos.RegisterContactoRoutes(apiV1, contactoHandler) // Registrar rutas de contactos
+		contactos.RegisterContactoRoutes(apiV1, contactoHandler, middleware.RequireAdmin()) // Registrar rutas de contactos
+	}
+	if correoHandler != nil {
+		correos.RegisterCorreoRoutes(apiV1, correoHandler, middleware.RequireAdmin())
+	}
+	if comentarioHandler != nil {
+		comentarios.RegisterComentarioRoutes(apiV1, comentarioHandler, middleware.RequireAdmin())
+	}
+	if favoritoHandler != nil {
+		favoritos.RegisterFavoritoRoutes(apiV1, favoritoHandler, middleware.RequireAuth())
+	}
+	if planHandler != nil {
+		planificador.RegisterPlanRoutes(apiV1, planHandler, middleware.RequireAuth())
+	}
+	if ingredienteHandler != nil {
+		ingredientes.RegisterIngredienteRoutes(apiV1, ingredienteHandler)
+	}
+	if listaCompraHandler != nil {
+		compras.RegisterListaCompraRoutes(apiV1, listaCompraHandler, middleware.RequireAuth())
 	}
 	log.Println("✅ Rutas de API de características registradas.")
 
@@ -156,7 +324,15 @@ func main() {
 
 	// Rutas Base / Estáticas / No API
 	router.Static("/public", "./public")
-	router.Static("/uploads", "./uploads")
+	if local, ok := almacen.(*storage.Local); ok { // Con S3 los archivos se sirven desde el bucket o el CDN
+		// Sin router.Static: los archivos de recetas no publicadas no son públicos (ver media.ControlAcceso).
+		accesoMedia := media.NewControlAcceso(mediaRepo).
+			Registrar(recetas.EntidadMedia, recetas.NewPoliticaFotos(recetaRepo)).
+			Registrar(recetas.EntidadMediaGaleria, recetas.NewPoliticaGaleria(recetaRepo)).
+			RestringirSinRegistro(recetas.CarpetaFotos) // Fotos antiguas: denegadas hasta ejecutar cmd/fotosmedia
+		mediaHandler := media.NewMediaHandler(accesoMedia, local, time.Duration(cfg.Storage.CachePublicoSegundos)*time.Second)
+		media.RegisterMediaRoutes(router, local.URLBase, mediaHandler)
+	}
 	router.NoRoute(func(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Ruta no encontrada"})
 	})
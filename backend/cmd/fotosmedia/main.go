@@ -0,0 +1,74 @@
+// backend/cmd/fotosmedia/main.go
+// Este comando registra en 'media' las fotos de recetas subidas antes de que existiera la
+// tabla (ver recetas.RegistrarFotosSinMedia): crea el registro con su hash, tipo y tamaño,
+// el uso de la receta y lo enlaza en foto_id. Se ejecuta una vez tras desplegar; mientras
+// tanto el control de acceso deniega esos archivos. Repetirlo no duplica nada.
+//
+// Uso (desde backend/):
+//
+//	go run ./cmd/fotosmedia -simular   # Solo informa
+//	go run ./cmd/fotosmedia            # Registra
+//	go run ./cmd/marcadores            # Después: BlurHash y color de las recién registradas
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"backend/media"
+	"backend/recetas"
+	"backend/shared/config"
+	"backend/shared/database"
+	"backend/shared/storage"
+)
+
+func main() {
+	simular := flag.Bool("simular", false, "solo informar, sin registrar nada")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig("config")
+	if err != nil {
+		log.Fatalf("❌ Error cargando config: %v", err)
+	}
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ Error conectando a la BD: %v", err)
+	}
+	almacen, err := storage.Nuevo(cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ Error creando el almacenamiento de archivos: %v", err)
+	}
+	mediaSvc := media.NewMediaService(media.NewMediaRepository(db), almacen)
+
+	ctx, cancelar := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancelar()
+	informe, err := recetas.RegistrarFotosSinMedia(ctx, recetas.NewRecetaRepository(db), mediaSvc, *simular)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	imprimir(informe, *simular)
+	if informe.Errores > 0 {
+		os.Exit(1)
+	}
+}
+
+// imprimir muestra el informe en la salida estándar.
+func imprimir(informe *recetas.InformeFotosSinMedia, simular bool) {
+	fmt.Printf("Fotos sin registro en media: %d\n", informe.Revisadas)
+	if simular {
+		fmt.Println("Simulacro: no se ha registrado nada")
+		return
+	}
+	fmt.Printf("Registradas: %d\n", informe.Registradas)
+	fmt.Printf("Archivos que no están: %d\n", len(informe.Faltantes))
+	for _, clave := range informe.Faltantes {
+		fmt.Printf("  %s\n", clave)
+	}
+	if informe.Errores > 0 {
+		fmt.Printf("Errores: %d (ver el log)\n", informe.Errores)
+	}
+}
@@ -0,0 +1,84 @@
+// backend/cmd/huerfanos/main.go
+// Este comando busca los archivos subidos que nadie usa y las recetas que apuntan a
+// archivos que no están (ver media.Recolector). Es la misma pasada que la tarea
+// periódica de la API (jobs.huerfanos_*), pero a demanda y con simulacro.
+//
+// Uso (desde backend/):
+//
+//	go run ./cmd/huerfanos -simular      # Solo informa
+//	go run ./cmd/huerfanos -gracia 72h   # Borra los huérfanos con más de 72 horas
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"backend/media"
+	"backend/recetas"
+	"backend/shared/config"
+	"backend/shared/database"
+	"backend/shared/storage"
+)
+
+func main() {
+	simular := flag.Bool("simular", false, "solo informar, sin borrar nada")
+	gracia := flag.Duration("gracia", 0, "antigüedad mínima de un archivo sin uso para borrarlo (por defecto, jobs.huerfanos_gracia_horas)")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig("config")
+	if err != nil {
+		log.Fatalf("❌ Error cargando config: %v", err)
+	}
+	if *gracia <= 0 {
+		*gracia = time.Duration(cfg.Jobs.HuerfanosGraciaHoras) * time.Hour
+	}
+
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ Error conectando a la BD: %v", err)
+	}
+	almacen, err := storage.Nuevo(cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ Error creando el almacenamiento de archivos: %v", err)
+	}
+
+	recolector := media.NewRecolector(media.NewMediaRepository(db), almacen, *gracia).
+		Registrar(recetas.NewReferenciasFotos(recetas.NewRecetaRepository(db)))
+
+	ctx, cancelar := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancelar()
+	informe, err := recolector.Ejecutar(ctx, *simular)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	imprimir(informe, *gracia)
+	if informe.Errores > 0 {
+		os.Exit(1)
+	}
+}
+
+// imprimir muestra el informe en la salida estándar.
+func imprimir(informe *media.Informe, gracia time.Duration) {
+	accion := "borrados"
+	if informe.Simulacro {
+		accion = "a borrar (simulacro)"
+	}
+	fmt.Printf("Archivos revisados: %d\n", informe.ArchivosRevisados)
+	fmt.Printf("Huérfanos con más de %s %s: %d (%d bytes)\n", gracia, accion, len(informe.Huerfanos), informe.BytesHuerfanos)
+	for _, o := range informe.Huerfanos {
+		fmt.Printf("  %s\t%d bytes\t%s\n", o.Clave, o.Tamano, o.Modificado.Format(time.RFC3339))
+	}
+	fmt.Printf("Registros de media sin uso %s: %d %v\n", accion, len(informe.RegistrosHuerfanos), informe.RegistrosHuerfanos)
+	fmt.Printf("Referencias a archivos que no están: %d\n", len(informe.Faltantes))
+	for _, f := range informe.Faltantes {
+		fmt.Printf("  %s ID %d -> %s\n", f.Entidad, f.EntidadID, f.Clave)
+	}
+	if informe.Errores > 0 {
+		fmt.Printf("Errores al borrar: %d (ver el log)\n", informe.Errores)
+	}
+}
@@ -0,0 +1,184 @@
+// backend/cmd/marcadores/main.go
+// Este comando calcula el BlurHash y el color dominante (ver imagenes.Marcador) de las fotos
+// subidas antes de que se calcularan al procesarlas. Recorre los archivos de la carpeta y
+// guarda el marcador en su registro de 'media'. A los archivos sin registro (anteriores a la
+// tabla) primero se les crea (hash, tipo y tamaño, ver MediaService.RegistrarArchivo); en la
+// carpeta de recetas, antes se registran las fotos de las recetas con su uso.
+//
+// Uso (desde backend/):
+//
+//	go run ./cmd/marcadores -simular   # Solo informa de cuántos faltan
+//	go run ./cmd/marcadores            # Calcula los que faltan
+//	go run ./cmd/marcadores -todos     # Recalcula todos
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path"
+	"sort"
+	"strings"
+
+	"backend/media"
+	"backend/recetas"
+	"backend/shared/config"
+	"backend/shared/database"
+	"backend/shared/imagenes"
+	"backend/shared/repository"
+	"backend/shared/storage"
+)
+
+// informe resume la pasada.
+type informe struct {
+	Imagenes     int // Imágenes distintas en la carpeta (sin contar variantes)
+	Calculados   int
+	YaTenian     int
+	Registrados  int // Imágenes sin registro en 'media' a las que se les ha creado
+	Errores      int
+	SinPrincipal []string // Sin registro y sin archivo principal (solo variantes): se omiten
+}
+
+func main() {
+	carpeta := flag.String("carpeta", recetas.CarpetaFotos, "carpeta del almacenamiento a recorrer")
+	todos := flag.Bool("todos", false, "recalcular también los que ya tienen marcador")
+	simular := flag.Bool("simular", false, "solo informar, sin calcular ni guardar nada")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig("config")
+	if err != nil {
+		log.Fatalf("❌ Error cargando config: %v", err)
+	}
+	db, err := database.ConnectDB(cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ Error conectando a la BD: %v", err)
+	}
+	almacen, err := storage.Nuevo(cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ Error creando el almacenamiento de archivos: %v", err)
+	}
+
+	ctx, cancelar := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancelar()
+	repo := media.NewMediaRepository(db)
+	mediaSvc := media.NewMediaService(repo, almacen)
+	erroresFotos := 0
+	if *carpeta == recetas.CarpetaFotos && !*simular {
+		// Primero las fotos que usa alguna receta, para registrarlas con su uso (ver cmd/fotosmedia):
+		// un registro sin usos lo borraría el recolector de huérfanos.
+		fotos, err := recetas.RegistrarFotosSinMedia(ctx, recetas.NewRecetaRepository(db), mediaSvc, false)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fmt.Printf("Fotos de recetas registradas con su uso: %d\n", fotos.Registradas)
+		erroresFotos = fotos.Errores
+	}
+	inf, err := rellenar(ctx, repo, mediaSvc, almacen, *carpeta, *todos, *simular)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	imprimir(inf, *simular)
+	if inf.Errores+erroresFotos > 0 {
+		os.Exit(1)
+	}
+}
+
+// rellenar calcula el marcador de cada imagen de la carpeta, registrando antes en 'media'
+// las que no lo estaban.
+func rellenar(ctx context.Context, repo media.MediaRepository, mediaSvc media.MediaService, almacen storage.Storage, carpeta string, todos, simular bool) (*informe, error) {
+	objetos, err := almacen.Listar(ctx, carpeta+"/")
+	if err != nil {
+		return nil, fmt.Errorf("marcadores: error al listar %s: %w", carpeta, err)
+	}
+	// Cada imagen una sola vez, aunque tenga varias variantes, con su archivo principal
+	// (el que no lleva sufijo de variante; entre JPEG/PNG y WebP, el primero).
+	principales := make(map[string]string)
+	for _, o := range objetos {
+		nombre := path.Base(o.Clave)
+		original := imagenes.Original(nombre, imagenes.TamanosPorDefecto)
+		imagen := path.Join(path.Dir(o.Clave), original)
+		actual, vista := principales[imagen]
+		if !vista {
+			principales[imagen] = ""
+		}
+		esPrincipal := strings.TrimSuffix(nombre, path.Ext(nombre)) == original
+		if esPrincipal && (actual == "" || path.Ext(actual) == ".webp") {
+			principales[imagen] = o.Clave
+		}
+	}
+	imagenesCarpeta := make([]string, 0, len(principales))
+	for imagen := range principales {
+		imagenesCarpeta = append(imagenesCarpeta, imagen)
+	}
+	sort.Strings(imagenesCarpeta)
+
+	inf := &informe{Imagenes: len(imagenesCarpeta)}
+	for _, imagen := range imagenesCarpeta {
+		if err := ctx.Err(); err != nil {
+			return inf, err
+		}
+		m, err := repo.GetByImagen(ctx, imagen)
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			clave := principales[imagen]
+			if clave == "" {
+				inf.SinPrincipal = append(inf.SinPrincipal, imagen)
+				continue
+			}
+			if simular {
+				inf.Registrados++
+				inf.Calculados++
+				continue
+			}
+			if m, err = mediaSvc.RegistrarArchivo(ctx, clave, nil); err != nil {
+				log.Printf("Marcadores: No se pudo registrar %s: %v\n", clave, err)
+				inf.Errores++
+				continue
+			}
+			inf.Registrados++
+		} else if err != nil {
+			return inf, fmt.Errorf("marcadores: error al buscar %s: %w", imagen, err)
+		}
+		if m.TieneMarcador() && !todos {
+			inf.YaTenian++
+			continue
+		}
+		if simular {
+			inf.Calculados++
+			continue
+		}
+		marcador, err := imagenes.MarcadorDe(ctx, almacen, m.Clave, m.Variantes)
+		if err == nil {
+			err = repo.ActualizarMarcador(ctx, m.ID, marcador)
+		}
+		if err != nil {
+			log.Printf("Marcadores: Media ID %d (%s): %v\n", m.ID, m.Clave, err)
+			inf.Errores++
+			continue
+		}
+		inf.Calculados++
+	}
+	return inf, nil
+}
+
+// imprimir muestra el informe en la salida estándar.
+func imprimir(inf *informe, simular bool) {
+	accion := "calculados"
+	if simular {
+		accion = "a calcular (simulacro)"
+	}
+	fmt.Printf("Imágenes revisadas: %d\n", inf.Imagenes)
+	fmt.Printf("Marcadores %s: %d\n", accion, inf.Calculados)
+	fmt.Printf("Ya lo tenían: %d\n", inf.YaTenian)
+	fmt.Printf("Registradas en media (no lo estaban): %d\n", inf.Registrados)
+	fmt.Printf("Sin registro ni archivo principal (omitidas): %d\n", len(inf.SinPrincipal))
+	for _, imagen := range inf.SinPrincipal {
+		fmt.Printf("  %s\n", imagen)
+	}
+	if inf.Errores > 0 {
+		fmt.Printf("Errores: %d (ver el log)\n", inf.Errores)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	//"time" // Para CreatedAt/UpdatedAt si los seteamos manualmente
 	// Importar paquetes necesarios
 	"backend/categorias"         // Para CategoriaModel y sus constructores/tipos si es necesario
+	"backend/media"              // Para MediaModel (las recetas lo referencian)
 	"backend/recetas"            // Para RecetaModel y sus constructores/tipos
 	"backend/shared/config"    // Para cargar configuración
 	"backend/shared/database"  // Para conectar a la BD
@@ -45,13 +46,22 @@ func main() {
 	log.Println("   - Ejecutando AutoMigrate para asegurar tablas...")
 	err = db.AutoMigrate(
 		&categorias.CategoriaModel{},
+		&media.MediaModel{}, // Las recetas referencian sus fotos
+		&media.MediaUsoModel{},
 		&recetas.RecetaModel{},
+		&recetas.RecetaFotoModel{},
 		// ... añadir TODOS tus otros *Model GORM aquí ...
 	)
 	if err != nil {
 		log.Fatalf("❌ Error durante AutoMigrate en seeder: %v", err)
 	}
 	log.Println("   - AutoMigrate completado.")
+	if err := categorias.MigrarIndicesUnicos(db); err != nil {
+		log.Fatalf("❌ Error migrando índices de categorías en seeder: %v", err)
+	}
+	if err := recetas.MigrarIndicesUnicos(db); err != nil {
+		log.Fatalf("❌ Error migrando índices de recetas en seeder: %v", err)
+	}
 
 	// --- 4. Seeding de Datos ---
 
@@ -0,0 +1,282 @@
+// backend/comentarios/comentario_api.go
+// Implementación con Gin de ComentarioHandler.
+package comentarios
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/recetas"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ComentarioHandler maneja las peticiones HTTP para Comentarios.
+type ComentarioHandler struct {
+	service ComentarioService
+}
+
+// NewComentarioHandler crea una nueva instancia de ComentarioHandler.
+func NewComentarioHandler(s ComentarioService) *ComentarioHandler {
+	return &ComentarioHandler{service: s}
+}
+
+// --- Mapeadores Helper ---
+
+func mapDomainToResponseDTO(c Comentario) ComentarioResponseDTO {
+	respuestas := make([]ComentarioResponseDTO, 0, len(c.Respuestas))
+	for _, r := range c.Respuestas {
+		respuestas = append(respuestas, mapDomainToResponseDTO(r))
+	}
+	return ComentarioResponseDTO{
+		ID:          c.ID,
+		ParentID:    c.ParentID,
+		AutorNombre: c.AutorNombre,
+		Contenido:   c.Contenido,
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+		Respuestas:  respuestas,
+	}
+}
+
+func mapDomainToModeracionDTO(c Comentario) ComentarioModeracionDTO {
+	return ComentarioModeracionDTO{
+		ID:          c.ID,
+		RecetaID:    c.RecetaID,
+		ParentID:    c.ParentID,
+		AutorNombre: c.AutorNombre,
+		Contenido:   c.Contenido,
+		Estado:      string(c.Estado),
+		Revisado:    c.Revisado,
+		Reportes:    c.Reportes,
+		CreatedAt:   c.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// usuarioIDDesdeContexto obtiene el ID del usuario autenticado, si algún middleware de Auth lo dejó en el contexto.
+func usuarioIDDesdeContexto(c *gin.Context) *uint {
+	if v, exists := c.Get("userID"); exists {
+		if uid, ok := v.(uint); ok {
+			return &uid
+		}
+	}
+	return nil
+}
+
+func parseIDParam(c *gin.Context, nombre string) (uint, error) {
+	idStr := c.Param(nombre)
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parámetro %s inválido: %s - %w", nombre, idStr, err)
+	}
+	return uint(idUint64), nil
+}
+
+// --- Handlers públicos ---
+
+// GetByReceta godoc
+// @Summary Obtiene los comentarios aprobados de una receta
+// @Description Devuelve el hilo de comentarios aprobados, con las respuestas anidadas.
+// @Tags Comentarios
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Success 200 {array} ComentarioResponseDTO "Hilo de comentarios"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/comentarios [get]
+func (h *ComentarioHandler) GetByReceta(c *gin.Context) {
+	recetaID, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	lector := recetas.Lector{UsuarioID: usuarioIDDesdeContexto(c), Editor: c.GetBool("esAdmin")}
+	hilo, err := h.service.ObtenerHiloPorReceta(c.Request.Context(), recetaID, lector)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	responseDTOs := make([]ComentarioResponseDTO, 0, len(hilo))
+	for _, com := range hilo {
+		responseDTOs = append(responseDTOs, mapDomainToResponseDTO(com))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// Create godoc
+// @Summary Publica un comentario en una receta
+// @Description Crea un comentario (o respuesta) que queda pendiente de moderación.
+// @Tags Comentarios
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param comentario body ComentarioRequestDTO true "Datos del comentario"
+// @Success 201 {object} ComentarioResponseDTO "Comentario recibido, pendiente de moderación"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/comentarios [post]
+func (h *ComentarioHandler) Create(c *gin.Context) {
+	recetaID, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req ComentarioRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	input := CrearComentarioInput{
+		RecetaID:    recetaID,
+		ParentID:    req.ParentID,
+		AutorNombre: req.Nombre,
+		AutorEmail:  req.Email,
+		Contenido:   req.Contenido,
+		UserID:      usuarioIDDesdeContexto(c),
+		Editor:      c.GetBool("esAdmin"),
+		IPOrigen:    c.ClientIP(),
+	}
+
+	comentario, err := h.service.Crear(c.Request.Context(), input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapDomainToResponseDTO(*comentario))
+}
+
+// Reportar godoc
+// @Summary Reporta un comentario
+// @Description Registra un reporte y devuelve el comentario a la cola de moderación.
+// @Tags Comentarios
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID del Comentario"
+// @Param reporte body ReporteRequestDTO true "Motivo del reporte"
+// @Success 202 {object} gin.H "Reporte recibido"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 404 {object} apitypes.ErrorResponse "Comentario no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /comentarios/{id}/reportar [post]
+func (h *ComentarioHandler) Reportar(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req ReporteRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	input := ReportarComentarioInput{
+		ComentarioID: id,
+		Motivo:       req.Motivo,
+		UserID:       usuarioIDDesdeContexto(c),
+		IPOrigen:     c.ClientIP(),
+	}
+	if err := h.service.Reportar(c.Request.Context(), input); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"mensaje": "Reporte recibido. Un moderador revisará el comentario."})
+}
+
+// --- Handlers para administración (requerirían autenticación de admin) ---
+
+// GetColaModeracion godoc
+// @Summary (Admin) Lista comentarios para moderación
+// @Description (Admin) Devuelve comentarios filtrados por estado y/o solo los que requieren atención.
+// @Tags Comentarios_Admin
+// @Produce json
+// @Param estado query string false "Estado (pendiente, aprobado, rechazado)"
+// @Param sin_revisar query bool false "Solo comentarios nuevos o reportados sin revisar"
+// @Success 200 {array} ComentarioModeracionDTO "Cola de moderación"
+// @Failure 400 {object} apitypes.ErrorResponse "Filtro inválido"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/comentarios [get]
+// @Security ApiKeyAuth
+func (h *ComentarioHandler) GetColaModeracion(c *gin.Context) {
+	var filtro FiltroModeracion
+	if estadoStr := c.Query("estado"); estadoStr != "" {
+		estado := EstadoComentario(estadoStr)
+		filtro.Estado = &estado
+	}
+	if sinRevisarStr := c.Query("sin_revisar"); sinRevisarStr != "" {
+		sinRevisar, err := strconv.ParseBool(sinRevisarStr)
+		if err != nil {
+			_ = c.Error(fmt.Errorf("parámetro sin_revisar inválido: %s - %w", sinRevisarStr, err))
+			return
+		}
+		filtro.SoloSinRevisar = sinRevisar
+	}
+
+	comentarios, err := h.service.ListarParaModeracion(c.Request.Context(), filtro)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	responseDTOs := make([]ComentarioModeracionDTO, 0, len(comentarios))
+	for _, com := range comentarios {
+		responseDTOs = append(responseDTOs, mapDomainToModeracionDTO(com))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// Aprobar godoc
+// @Summary (Admin) Aprueba un comentario
+// @Tags Comentarios_Admin
+// @Produce json
+// @Param id path uint true "ID del Comentario"
+// @Success 200 {object} gin.H "Comentario aprobado"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Comentario no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/comentarios/{id}/aprobar [patch]
+// @Security ApiKeyAuth
+func (h *ComentarioHandler) Aprobar(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.Aprobar(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"mensaje": fmt.Sprintf("Comentario ID %d aprobado.", id)})
+}
+
+// Rechazar godoc
+// @Summary (Admin) Rechaza un comentario
+// @Tags Comentarios_Admin
+// @Produce json
+// @Param id path uint true "ID del Comentario"
+// @Success 200 {object} gin.H "Comentario rechazado"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Comentario no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/comentarios/{id}/rechazar [patch]
+// @Security ApiKeyAuth
+func (h *ComentarioHandler) Rechazar(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.Rechazar(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"mensaje": fmt.Sprintf("Comentario ID %d rechazado.", id)})
+}
@@ -0,0 +1,40 @@
+// backend/comentarios/comentario_api_dto.go
+// DTOs de la API para Comentarios.
+// Ningún DTO de salida incluye el email del autor: se guarda solo para uso interno.
+package comentarios
+
+// ComentarioRequestDTO es el DTO de entrada para publicar un comentario o una respuesta.
+type ComentarioRequestDTO struct {
+	Nombre    string `json:"nombre" binding:"required,min=2,max=150" example:"Ana"`
+	Email     string `json:"email" binding:"required,email" example:"ana@example.com"` // No se publica
+	Contenido string `json:"contenido" binding:"required,min=2,max=5000" example:"¡Me salió perfecta!"`
+	ParentID  *uint  `json:"parent_id,omitempty" binding:"omitempty,gt=0" example:"12"` // Opcional: comentario al que se responde
+}
+
+// ReporteRequestDTO es el DTO de entrada para reportar un comentario.
+type ReporteRequestDTO struct {
+	Motivo string `json:"motivo" binding:"required,min=3,max=500" example:"Contenido ofensivo"`
+}
+
+// ComentarioResponseDTO es el DTO público de un comentario con sus respuestas anidadas.
+type ComentarioResponseDTO struct {
+	ID          uint                    `json:"id" example:"12"`
+	ParentID    *uint                   `json:"parent_id,omitempty" example:"3"`
+	AutorNombre string                  `json:"autor_nombre" example:"Ana"`
+	Contenido   string                  `json:"contenido" example:"¡Me salió perfecta!"`
+	CreatedAt   string                  `json:"created_at" example:"2025-05-17T10:00:00Z"`
+	Respuestas  []ComentarioResponseDTO `json:"respuestas"`
+}
+
+// ComentarioModeracionDTO es el DTO para la cola de moderación del panel de admin.
+type ComentarioModeracionDTO struct {
+	ID          uint   `json:"id"`
+	RecetaID    uint   `json:"receta_id"`
+	ParentID    *uint  `json:"parent_id,omitempty"`
+	AutorNombre string `json:"autor_nombre"`
+	Contenido   string `json:"contenido"`
+	Estado      string `json:"estado" example:"pendiente"`
+	Revisado    bool   `json:"revisado"`
+	Reportes    uint   `json:"reportes"`
+	CreatedAt   string `json:"created_at"`
+}
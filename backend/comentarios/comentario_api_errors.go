@@ -0,0 +1,21 @@
+// backend/comentarios/comentario_api_errors.go
+
+// Traducción de los errores de dominio de comentarios a respuestas HTTP (ver shared/apperrors).
+
+package comentarios
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrComentarioNotFound, http.StatusNotFound, "comentario_no_encontrado")
+
+	apperrors.Registrar(ErrComentarioContenidoVacio, http.StatusBadRequest, "comentario_contenido_vacio")
+	apperrors.Registrar(ErrComentarioAutorInvalido, http.StatusBadRequest, "comentario_autor_invalido")
+	apperrors.Registrar(ErrComentarioPadreInvalido, http.StatusBadRequest, "comentario_padre_invalido")
+	apperrors.Registrar(ErrComentarioEstadoInvalido, http.StatusBadRequest, "comentario_estado_invalido")
+	apperrors.Registrar(ErrReporteMotivoVacio, http.StatusBadRequest, "reporte_motivo_vacio")
+}
@@ -0,0 +1,79 @@
+// Archivo: backend/comentarios/comentario_model.go
+// Funcionalidad: Modelo de dominio para Comentarios de recetas.
+// Capa: Dominio / Lógica de negocio.
+
+// Descripción:
+// Define un comentario sobre una receta. Los comentarios pueden anidarse
+// (respuestas) mediante ParentID y pasan por una cola de moderación antes
+// de ser públicos.
+//
+// Reglas de Negocio:
+// - Todo comentario nuevo nace 'pendiente' y sin revisar.
+// - Solo los comentarios 'aprobado' son visibles públicamente.
+// - Una respuesta solo puede colgar de un comentario aprobado de la misma receta.
+// - El email del autor se guarda para contacto interno, pero NUNCA se expone en la API.
+// - Revisado funciona como ContactoForm.Leido: false significa que un admin
+//   todavía debe atenderlo (comentario nuevo o reportado).
+
+package comentarios
+
+import (
+	"errors"
+	"time"
+)
+
+// EstadoComentario representa el estado de moderación de un comentario.
+type EstadoComentario string
+
+const (
+	EstadoPendiente EstadoComentario = "pendiente"
+	EstadoAprobado  EstadoComentario = "aprobado"
+	EstadoRechazado EstadoComentario = "rechazado"
+)
+
+// EsValido indica si el estado es uno de los reconocidos.
+func (e EstadoComentario) EsValido() bool {
+	switch e {
+	case EstadoPendiente, EstadoAprobado, EstadoRechazado:
+		return true
+	}
+	return false
+}
+
+// Comentario representa la entidad de negocio pura para un comentario.
+type Comentario struct {
+	ID          uint             // Identificador único
+	RecetaID    uint             // Receta comentada
+	ParentID    *uint            // Comentario al que responde (nil si es raíz)
+	UserID      *uint            // Usuario autenticado que comenta (opcional)
+	AutorNombre string           // Nombre visible del autor
+	AutorEmail  string           // Email del autor (uso interno, nunca expuesto)
+	Contenido   string           // Texto del comentario
+	Estado      EstadoComentario // Estado de moderación
+	Revisado    bool             // false = requiere atención de un admin
+	Reportes    uint             // Cantidad de reportes recibidos
+	IPOrigen    string           // IP del autor (auditoría)
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Respuestas  []Comentario // Respuestas anidadas (solo al construir el hilo)
+}
+
+// ReporteComentario representa el reporte de un comentario por parte de un usuario.
+type ReporteComentario struct {
+	ID           uint
+	ComentarioID uint
+	UserID       *uint
+	Motivo       string
+	IPOrigen     string
+	CreatedAt    time.Time
+}
+
+// Errores específicos del dominio de Comentarios.
+var (
+	ErrComentarioNotFound       = errors.New("comentario no encontrado")
+	ErrComentarioContenidoVacio = errors.New("el contenido del comentario es requerido")
+	ErrComentarioAutorInvalido  = errors.New("el nombre y email del autor son requeridos")
+	ErrComentarioPadreInvalido  = errors.New("el comentario al que se responde no existe o no pertenece a la receta")
+	ErrComentarioEstadoInvalido = errors.New("estado de moderación inválido")
+	ErrReporteMotivoVacio       = errors.New("el motivo del reporte es requerido")
+)
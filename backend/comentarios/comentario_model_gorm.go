@@ -0,0 +1,139 @@
+// backend/comentarios/comentario_model_gorm.go
+
+// Este archivo define los modelos de persistencia para comentarios y sus reportes.
+// Utiliza GORM para la definición de las tablas y el mapeo de campos.
+
+package comentarios
+
+import (
+	"fmt"
+	"time"
+
+	"backend/recetas"
+
+	"gorm.io/gorm"
+)
+
+// ComentarioModel representa la tabla 'comentarios' en la BD y usa GORM.
+type ComentarioModel struct {
+	ID          uint      `gorm:"primaryKey"`
+	RecetaID    uint      `gorm:"not null;index:idx_comentarios_receta_estado"`
+	ParentID    *uint     `gorm:"index;default:null"`
+	UserID      *uint     `gorm:"index;default:null"`
+	AutorNombre string    `gorm:"type:varchar(150);not null"`
+	AutorEmail  string    `gorm:"type:varchar(255);not null"`
+	Contenido   string    `gorm:"type:text;not null"`
+	Estado      string    `gorm:"type:varchar(20);not null;default:'pendiente';index:idx_comentarios_receta_estado"`
+	Revisado    bool      `gorm:"not null;default:false;index:idx_comentarios_revisado"`
+	Reportes    uint      `gorm:"not null;default:0"`
+	IPOrigen    string    `gorm:"type:varchar(45);default:null"`
+	CreatedAt   time.Time `gorm:"index:idx_comentarios_revisado"`
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+
+	// Parent es la relación consigo misma para las respuestas (solo se usa para la FK).
+	Parent *ComentarioModel `gorm:"foreignKey:ParentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	// Receta solo se usa para la FK: al purgar una receta de la papelera se borran sus comentarios.
+	Receta *recetas.RecetaModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (ComentarioModel) TableName() string {
+	return "comentarios"
+}
+
+// BorrarHuerfanos borra los comentarios de recetas que ya no existen (purgadas antes de
+// que hubiera FK), que impedirían crearla. Ejecutar antes de AutoMigrate.
+func BorrarHuerfanos(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&ComentarioModel{}) || !db.Migrator().HasTable(&recetas.RecetaModel{}) {
+		return nil // Base de datos nueva
+	}
+	result := db.Exec("DELETE FROM comentarios WHERE NOT EXISTS (SELECT 1 FROM recetas WHERE recetas.id = comentarios.receta_id)")
+	if result.Error != nil {
+		return fmt.Errorf("migración comentarios: error borrando huérfanos: %w", result.Error)
+	}
+	return nil
+}
+
+// ReporteComentarioModel representa la tabla 'comentario_reportes'.
+type ReporteComentarioModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	ComentarioID uint   `gorm:"not null;index"`
+	UserID       *uint  `gorm:"index;default:null"`
+	Motivo       string `gorm:"type:varchar(500);not null"`
+	IPOrigen     string `gorm:"type:varchar(45);default:null"`
+	CreatedAt    time.Time
+
+	Comentario ComentarioModel `gorm:"foreignKey:ComentarioID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (ReporteComentarioModel) TableName() string {
+	return "comentario_reportes"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *ComentarioModel) ToDomain() *Comentario {
+	if m == nil {
+		return nil
+	}
+	return &Comentario{
+		ID:          m.ID,
+		RecetaID:    m.RecetaID,
+		ParentID:    m.ParentID,
+		UserID:      m.UserID,
+		AutorNombre: m.AutorNombre,
+		AutorEmail:  m.AutorEmail,
+		Contenido:   m.Contenido,
+		Estado:      EstadoComentario(m.Estado),
+		Revisado:    m.Revisado,
+		Reportes:    m.Reportes,
+		IPOrigen:    m.IPOrigen,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func FromComentarioDomain(d *Comentario) *ComentarioModel {
+	if d == nil {
+		return nil
+	}
+	return &ComentarioModel{
+		ID:          d.ID,
+		RecetaID:    d.RecetaID,
+		ParentID:    d.ParentID,
+		UserID:      d.UserID,
+		AutorNombre: d.AutorNombre,
+		AutorEmail:  d.AutorEmail,
+		Contenido:   d.Contenido,
+		Estado:      string(d.Estado),
+		Revisado:    d.Revisado,
+		Reportes:    d.Reportes,
+		IPOrigen:    d.IPOrigen,
+	}
+}
+
+func ComentarioModelsToDomains(models []ComentarioModel) []Comentario {
+	if models == nil {
+		return []Comentario{}
+	}
+	domainComentarios := make([]Comentario, 0, len(models))
+	for _, model := range models {
+		if dm := model.ToDomain(); dm != nil {
+			domainComentarios = append(domainComentarios, *dm)
+		}
+	}
+	return domainComentarios
+}
+
+func FromReporteDomain(d *ReporteComentario) *ReporteComentarioModel {
+	if d == nil {
+		return nil
+	}
+	return &ReporteComentarioModel{
+		ID:           d.ID,
+		ComentarioID: d.ComentarioID,
+		UserID:       d.UserID,
+		Motivo:       d.Motivo,
+		IPOrigen:     d.IPOrigen,
+	}
+}
@@ -0,0 +1,36 @@
+// backend/comentarios/comentario_repository.go
+// Funcionalidad: Interfaz para la persistencia de Comentarios.
+// Capa: Repositorio (Abstracción).
+package comentarios
+
+import (
+	"context"
+)
+
+// FiltroModeracion define los criterios para listar comentarios en la cola de moderación.
+type FiltroModeracion struct {
+	Estado         *EstadoComentario // nil = cualquier estado
+	SoloSinRevisar bool              // true = solo los que requieren atención (Revisado=false)
+}
+
+// ComentarioRepository define el contrato para las operaciones de datos de Comentarios.
+type ComentarioRepository interface {
+	// Create guarda un nuevo comentario. Modifica el puntero para incluir el ID generado.
+	Create(ctx context.Context, comentario *Comentario) error
+
+	// GetByID recupera un comentario por su ID (cualquier estado).
+	GetByID(ctx context.Context, id uint) (*Comentario, error)
+
+	// FindAprobadosByRecetaID recupera los comentarios aprobados de una receta, más antiguos primero.
+	FindAprobadosByRecetaID(ctx context.Context, recetaID uint) ([]Comentario, error)
+
+	// FindForModeracion recupera comentarios según el filtro de moderación.
+	FindForModeracion(ctx context.Context, filtro FiltroModeracion) ([]Comentario, error)
+
+	// UpdateEstado cambia el estado de moderación y marca el comentario como revisado.
+	UpdateEstado(ctx context.Context, id uint, estado EstadoComentario) error
+
+	// AddReporte guarda un reporte, incrementa el contador del comentario
+	// y lo devuelve a la cola de moderación (Revisado=false).
+	AddReporte(ctx context.Context, reporte *ReporteComentario) error
+}
@@ -0,0 +1,113 @@
+// backend/comentarios/comentario_repository_gorm.go
+// Funcionalidad: Implementación GORM de ComentarioRepository.
+// Capa: Repositorio (Implementación de Persistencia).
+package comentarios
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/shared/repository"
+)
+
+type gormComentarioRepository struct {
+	db *gorm.DB
+}
+
+// NewComentarioRepository crea una instancia de la implementación GORM de ComentarioRepository.
+func NewComentarioRepository(db *gorm.DB) ComentarioRepository {
+	return &gormComentarioRepository{db: db}
+}
+
+func (r *gormComentarioRepository) Create(ctx context.Context, comentario *Comentario) error {
+	model := FromComentarioDomain(comentario)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm comentarios: create: %w", repository.TraducirError(err))
+	}
+	comentario.ID = model.ID
+	comentario.CreatedAt = model.CreatedAt
+	comentario.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *gormComentarioRepository) GetByID(ctx context.Context, id uint) (*Comentario, error) {
+	var model ComentarioModel
+	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm comentarios: getbyid %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormComentarioRepository) FindAprobadosByRecetaID(ctx context.Context, recetaID uint) ([]Comentario, error) {
+	var models []ComentarioModel
+	err := r.db.WithContext(ctx).
+		Where("receta_id = ? AND estado = ?", recetaID, string(EstadoAprobado)).
+		Order("created_at asc, id asc").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm comentarios: findaprobadosbyrecetaid %d: %w", recetaID, err)
+	}
+	return ComentarioModelsToDomains(models), nil
+}
+
+func (r *gormComentarioRepository) FindForModeracion(ctx context.Context, filtro FiltroModeracion) ([]Comentario, error) {
+	var models []ComentarioModel
+	query := r.db.WithContext(ctx).Model(&ComentarioModel{})
+	if filtro.Estado != nil {
+		query = query.Where("estado = ?", string(*filtro.Estado))
+	}
+	if filtro.SoloSinRevisar {
+		query = query.Where("revisado = ?", false)
+	}
+	// Los más reportados primero y, a igualdad, los más antiguos (llevan más tiempo esperando).
+	if err := query.Order("reportes desc, created_at asc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm comentarios: findformoderacion: %w", err)
+	}
+	return ComentarioModelsToDomains(models), nil
+}
+
+func (r *gormComentarioRepository) UpdateEstado(ctx context.Context, id uint, estado EstadoComentario) error {
+	result := r.db.WithContext(ctx).Model(&ComentarioModel{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"estado":     string(estado),
+		"revisado":   true,
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm comentarios: updateestado %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormComentarioRepository) AddReporte(ctx context.Context, reporte *ReporteComentario) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&ComentarioModel{}).Where("id = ?", reporte.ComentarioID).Updates(map[string]interface{}{
+			"reportes":   gorm.Expr("reportes + 1"),
+			"revisado":   false, // Vuelve a la cola de moderación
+			"updated_at": time.Now(),
+		})
+		if result.Error != nil {
+			return fmt.Errorf("repo gorm comentarios: addreporte %d: %w", reporte.ComentarioID, repository.TraducirError(result.Error))
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrRecordNotFound
+		}
+
+		model := FromReporteDomain(reporte)
+		if err := tx.Create(model).Error; err != nil {
+			return fmt.Errorf("repo gorm comentarios: addreporte create: %w", repository.TraducirError(err))
+		}
+		reporte.ID = model.ID
+		reporte.CreatedAt = model.CreatedAt
+		return nil
+	})
+}
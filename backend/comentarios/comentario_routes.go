@@ -0,0 +1,34 @@
+// backend/comentarios/comentario_routes.go
+package comentarios
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterComentarioRoutes registra las rutas para la funcionalidad de Comentarios.
+// requireAdmin protege las rutas de moderación.
+func RegisterComentarioRoutes(apiBaseGroup *gin.RouterGroup, h *ComentarioHandler, requireAdmin gin.HandlerFunc) {
+	// Rutas públicas anidadas bajo la receta: /api/v1/recetas/:id/comentarios
+	recetaComentarios := apiBaseGroup.Group("/recetas/:id/comentarios")
+	{
+		recetaComentarios.GET("", h.GetByReceta)
+		recetaComentarios.POST("", h.Create)
+	}
+
+	comentarioRoutes := apiBaseGroup.Group("/comentarios")
+	{
+		comentarioRoutes.POST("/:id/reportar", h.Reportar)
+	}
+
+	// Rutas de moderación (solo admins)
+	comentariosAdminRoutes := apiBaseGroup.Group("/admin/comentarios", requireAdmin)
+	{
+		comentariosAdminRoutes.GET("", h.GetColaModeracion)
+		comentariosAdminRoutes.PATCH("/:id/aprobar", h.Aprobar)
+		comentariosAdminRoutes.PATCH("/:id/rechazar", h.Rechazar)
+	}
+
+	log.Println("🛣️  Rutas de Comentarios configuradas.")
+}
@@ -0,0 +1,182 @@
+// backend/comentarios/comentario_service.go
+// Funcionalidad: Lógica de negocio para Comentarios y su moderación.
+// Capa: Servicio / Casos de Uso.
+package comentarios
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log" // Temporal, reemplazar con logger estructurado
+	"strings"
+
+	"backend/recetas" // Para validar que la receta comentada exista
+	"backend/shared/repository"
+)
+
+// ComentarioService define el contrato para la lógica de negocio de Comentarios.
+type ComentarioService interface {
+	Crear(ctx context.Context, input CrearComentarioInput) (*Comentario, error)
+	ObtenerHiloPorReceta(ctx context.Context, recetaID uint, lector recetas.Lector) ([]Comentario, error) // Comentarios raíz con sus respuestas anidadas
+	Reportar(ctx context.Context, input ReportarComentarioInput) error
+	ListarParaModeracion(ctx context.Context, filtro FiltroModeracion) ([]Comentario, error)
+	Aprobar(ctx context.Context, id uint) error
+	Rechazar(ctx context.Context, id uint) error
+}
+
+type comentarioService struct {
+	repo      ComentarioRepository
+	recetaSvc recetas.RecetaService // Dependencia del servicio de recetas
+}
+
+// NewComentarioService crea una nueva instancia de ComentarioService.
+func NewComentarioService(repo ComentarioRepository, recetaSvc recetas.RecetaService) ComentarioService {
+	return &comentarioService{repo: repo, recetaSvc: recetaSvc}
+}
+
+// Crear valida y guarda un comentario nuevo en estado 'pendiente'.
+func (s *comentarioService) Crear(ctx context.Context, input CrearComentarioInput) (*Comentario, error) {
+	nombre := strings.TrimSpace(input.AutorNombre)
+	email := strings.TrimSpace(input.AutorEmail)
+	if nombre == "" || email == "" || !strings.Contains(email, "@") {
+		return nil, ErrComentarioAutorInvalido
+	}
+	contenido := strings.TrimSpace(input.Contenido)
+	if contenido == "" {
+		return nil, ErrComentarioContenidoVacio
+	}
+
+	// La receta debe existir y el usuario poder verla (si no, ErrRecetaNotFound)
+	lector := recetas.Lector{UsuarioID: input.UserID, Editor: input.Editor}
+	if _, err := s.recetaSvc.ObtenerVisible(ctx, input.RecetaID, lector); err != nil {
+		return nil, fmt.Errorf("servicio comentarios: error validando receta %d: %w", input.RecetaID, err)
+	}
+
+	// Si es una respuesta, el padre debe estar aprobado y ser de la misma receta
+	if input.ParentID != nil {
+		padre, err := s.repo.GetByID(ctx, *input.ParentID)
+		if err != nil {
+			if errors.Is(err, repository.ErrRecordNotFound) {
+				return nil, ErrComentarioPadreInvalido
+			}
+			return nil, fmt.Errorf("servicio comentarios: error buscando comentario padre %d: %w", *input.ParentID, err)
+		}
+		if padre.RecetaID != input.RecetaID || padre.Estado != EstadoAprobado {
+			return nil, ErrComentarioPadreInvalido
+		}
+	}
+
+	comentario := &Comentario{
+		RecetaID:    input.RecetaID,
+		ParentID:    input.ParentID,
+		UserID:      input.UserID,
+		AutorNombre: nombre,
+		AutorEmail:  email,
+		Contenido:   contenido,
+		Estado:      EstadoPendiente,
+		Revisado:    false, // Entra en la cola de moderación
+		IPOrigen:    input.IPOrigen,
+	}
+	if err := s.repo.Create(ctx, comentario); err != nil {
+		return nil, fmt.Errorf("servicio comentarios: error al crear: %w", err)
+	}
+
+	log.Printf("Servicio: Comentario ID %d creado (pendiente de moderación) para RecetaID: %d\n", comentario.ID, comentario.RecetaID)
+	return comentario, nil
+}
+
+// ObtenerHiloPorReceta devuelve los comentarios aprobados de una receta organizados en árbol.
+// Las respuestas cuyo padre no está aprobado (ej: rechazado después) se ocultan junto con el padre.
+func (s *comentarioService) ObtenerHiloPorReceta(ctx context.Context, recetaID uint, lector recetas.Lector) ([]Comentario, error) {
+	if _, err := s.recetaSvc.ObtenerVisible(ctx, recetaID, lector); err != nil {
+		return nil, fmt.Errorf("servicio comentarios: error validando receta %d: %w", recetaID, err)
+	}
+
+	aprobados, err := s.repo.FindAprobadosByRecetaID(ctx, recetaID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio comentarios: error obteniendo comentarios de receta %d: %w", recetaID, err)
+	}
+	return construirHilo(aprobados), nil
+}
+
+// construirHilo arma el árbol de comentarios a partir de una lista plana ordenada por fecha.
+func construirHilo(planos []Comentario) []Comentario {
+	hijos := make(map[uint][]Comentario)
+	raices := make([]Comentario, 0)
+	for _, c := range planos {
+		if c.ParentID == nil {
+			raices = append(raices, c)
+			continue
+		}
+		hijos[*c.ParentID] = append(hijos[*c.ParentID], c)
+	}
+
+	var adjuntar func(c *Comentario)
+	adjuntar = func(c *Comentario) {
+		c.Respuestas = hijos[c.ID]
+		for i := range c.Respuestas {
+			adjuntar(&c.Respuestas[i])
+		}
+	}
+	for i := range raices {
+		adjuntar(&raices[i])
+	}
+	return raices
+}
+
+// Reportar registra un reporte y devuelve el comentario a la cola de moderación.
+func (s *comentarioService) Reportar(ctx context.Context, input ReportarComentarioInput) error {
+	motivo := strings.TrimSpace(input.Motivo)
+	if motivo == "" {
+		return ErrReporteMotivoVacio
+	}
+
+	reporte := &ReporteComentario{
+		ComentarioID: input.ComentarioID,
+		UserID:       input.UserID,
+		Motivo:       motivo,
+		IPOrigen:     input.IPOrigen,
+	}
+	if err := s.repo.AddReporte(ctx, reporte); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrComentarioNotFound
+		}
+		return fmt.Errorf("servicio comentarios: error reportando comentario %d: %w", input.ComentarioID, err)
+	}
+
+	log.Printf("Servicio: Comentario ID %d reportado (reporte ID %d).\n", input.ComentarioID, reporte.ID)
+	return nil
+}
+
+// ListarParaModeracion devuelve los comentarios que coinciden con el filtro de moderación.
+func (s *comentarioService) ListarParaModeracion(ctx context.Context, filtro FiltroModeracion) ([]Comentario, error) {
+	if filtro.Estado != nil && !filtro.Estado.EsValido() {
+		return nil, ErrComentarioEstadoInvalido
+	}
+	comentarios, err := s.repo.FindForModeracion(ctx, filtro)
+	if err != nil {
+		return nil, fmt.Errorf("servicio comentarios: error listando para moderación: %w", err)
+	}
+	return comentarios, nil
+}
+
+// Aprobar publica un comentario.
+func (s *comentarioService) Aprobar(ctx context.Context, id uint) error {
+	return s.moderar(ctx, id, EstadoAprobado)
+}
+
+// Rechazar oculta un comentario (y, en consecuencia, sus respuestas).
+func (s *comentarioService) Rechazar(ctx context.Context, id uint) error {
+	return s.moderar(ctx, id, EstadoRechazado)
+}
+
+func (s *comentarioService) moderar(ctx context.Context, id uint, estado EstadoComentario) error {
+	if err := s.repo.UpdateEstado(ctx, id, estado); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrComentarioNotFound
+		}
+		return fmt.Errorf("servicio comentarios: error cambiando estado de %d a %s: %w", id, estado, err)
+	}
+	log.Printf("Servicio: Comentario ID %d marcado como '%s'.\n", id, estado)
+	return nil
+}
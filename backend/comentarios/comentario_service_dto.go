@@ -0,0 +1,23 @@
+// backend/comentarios/comentario_service_dto.go
+package comentarios
+
+// CrearComentarioInput es el DTO para la entrada del servicio al crear un comentario.
+type CrearComentarioInput struct {
+	RecetaID    uint
+	ParentID    *uint // Opcional: comentario al que se responde
+	AutorNombre string
+	AutorEmail  string
+	Contenido   string
+	// Campos que el handler deriva de la petición:
+	UserID   *uint // ID del usuario logueado, si aplica
+	Editor   bool  // Los editores pueden comentar recetas no publicadas
+	IPOrigen string
+}
+
+// ReportarComentarioInput es el DTO para la entrada del servicio al reportar un comentario.
+type ReportarComentarioInput struct {
+	ComentarioID uint
+	Motivo       string
+	UserID       *uint
+	IPOrigen     string
+}
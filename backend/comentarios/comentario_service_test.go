@@ -0,0 +1,188 @@
+// backend/comentarios/comentario_service_test.go
+package comentarios_test // Usar paquete _test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/comentarios"                        // El paquete bajo test
+	comentariosMocks "backend/comentarios/mocks" // Mocks del paquete comentarios
+	"backend/recetas"                            // Para el tipo recetas.Receta y sus errores
+	recetasMocks "backend/recetas/mocks"         // Mock de RecetaService
+	"backend/shared/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ComentarioServiceTestSuite struct {
+	suite.Suite
+	mockRepo      *comentariosMocks.ComentarioRepositoryMock
+	mockRecetaSvc *recetasMocks.RecetaServiceMock
+	service       comentarios.ComentarioService
+}
+
+func (s *ComentarioServiceTestSuite) SetupTest() {
+	s.mockRepo = new(comentariosMocks.ComentarioRepositoryMock)
+	s.mockRecetaSvc = new(recetasMocks.RecetaServiceMock)
+	s.service = comentarios.NewComentarioService(s.mockRepo, s.mockRecetaSvc)
+}
+
+func TestComentarioServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ComentarioServiceTestSuite))
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func (s *ComentarioServiceTestSuite) TestCrear_Success_QuedaPendiente() {
+	ctx := context.Background()
+	input := comentarios.CrearComentarioInput{
+		RecetaID:    5,
+		AutorNombre: "  Ana  ",
+		AutorEmail:  "ana@example.com",
+		Contenido:   " ¡Muy rica! ",
+	}
+
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(5), recetas.Lector{}).Return(&recetas.Receta{ID: 5}, nil).Once()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(c *comentarios.Comentario) bool {
+		return c.RecetaID == 5 &&
+			c.AutorNombre == "Ana" &&
+			c.Contenido == "¡Muy rica!" &&
+			c.Estado == comentarios.EstadoPendiente &&
+			!c.Revisado
+	})).Return(nil).Once()
+
+	comentario, err := s.service.Crear(ctx, input)
+
+	s.NoError(err)
+	s.Require().NotNil(comentario)
+	s.Equal(uint(1), comentario.ID)
+	s.mockRecetaSvc.AssertExpectations(s.T())
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ComentarioServiceTestSuite) TestCrear_Fail_RecetaNoExiste() {
+	ctx := context.Background()
+	input := comentarios.CrearComentarioInput{RecetaID: 99, AutorNombre: "Ana", AutorEmail: "ana@example.com", Contenido: "Hola"}
+
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(99), mock.Anything).Return(nil, recetas.ErrRecetaNotFound).Once()
+
+	comentario, err := s.service.Crear(ctx, input)
+
+	s.Nil(comentario)
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "Create")
+}
+
+func (s *ComentarioServiceTestSuite) TestCrear_ValidaLaRecetaComoElUsuario() {
+	ctx := context.Background()
+	input := comentarios.CrearComentarioInput{RecetaID: 5, AutorNombre: "Ana", AutorEmail: "ana@example.com", Contenido: "Hola", UserID: uintPtr(3)}
+
+	// Para quien no puede ver el borrador, la receta no existe
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(5), recetas.LectorUsuario(3)).Return(nil, recetas.ErrRecetaNotFound).Once()
+
+	comentario, err := s.service.Crear(ctx, input)
+
+	s.Nil(comentario)
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRecetaSvc.AssertExpectations(s.T())
+	s.mockRepo.AssertNotCalled(s.T(), "Create")
+}
+
+func (s *ComentarioServiceTestSuite) TestCrear_Fail_PadreDeOtraReceta() {
+	ctx := context.Background()
+	input := comentarios.CrearComentarioInput{
+		RecetaID: 5, ParentID: uintPtr(7), AutorNombre: "Ana", AutorEmail: "ana@example.com", Contenido: "Respuesta",
+	}
+
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(5), mock.Anything).Return(&recetas.Receta{ID: 5}, nil).Once()
+	s.mockRepo.On("GetByID", ctx, uint(7)).Return(&comentarios.Comentario{ID: 7, RecetaID: 6, Estado: comentarios.EstadoAprobado}, nil).Once()
+
+	comentario, err := s.service.Crear(ctx, input)
+
+	s.Nil(comentario)
+	s.ErrorIs(err, comentarios.ErrComentarioPadreInvalido)
+	s.mockRepo.AssertNotCalled(s.T(), "Create")
+}
+
+func (s *ComentarioServiceTestSuite) TestCrear_Fail_AutorSinEmail() {
+	ctx := context.Background()
+	input := comentarios.CrearComentarioInput{RecetaID: 5, AutorNombre: "Ana", AutorEmail: "", Contenido: "Hola"}
+
+	comentario, err := s.service.Crear(ctx, input)
+
+	s.Nil(comentario)
+	s.ErrorIs(err, comentarios.ErrComentarioAutorInvalido)
+	s.mockRecetaSvc.AssertNotCalled(s.T(), "ObtenerVisible")
+}
+
+func (s *ComentarioServiceTestSuite) TestObtenerHiloPorReceta_ConstruyeArbol() {
+	ctx := context.Background()
+	planos := []comentarios.Comentario{
+		{ID: 1, RecetaID: 5},
+		{ID: 2, RecetaID: 5, ParentID: uintPtr(1)},
+		{ID: 3, RecetaID: 5},
+		{ID: 4, RecetaID: 5, ParentID: uintPtr(2)},
+		{ID: 5, RecetaID: 5, ParentID: uintPtr(42)}, // Padre no aprobado: se oculta
+	}
+
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(5), mock.Anything).Return(&recetas.Receta{ID: 5}, nil).Once()
+	s.mockRepo.On("FindAprobadosByRecetaID", ctx, uint(5)).Return(planos, nil).Once()
+
+	hilo, err := s.service.ObtenerHiloPorReceta(ctx, 5, recetas.Lector{})
+
+	s.NoError(err)
+	s.Require().Len(hilo, 2)
+	s.Equal(uint(1), hilo[0].ID)
+	s.Require().Len(hilo[0].Respuestas, 1)
+	s.Equal(uint(2), hilo[0].Respuestas[0].ID)
+	s.Require().Len(hilo[0].Respuestas[0].Respuestas, 1)
+	s.Equal(uint(4), hilo[0].Respuestas[0].Respuestas[0].ID)
+	s.Equal(uint(3), hilo[1].ID)
+	s.Empty(hilo[1].Respuestas)
+}
+
+func (s *ComentarioServiceTestSuite) TestReportar_ComentarioNoExiste() {
+	ctx := context.Background()
+	s.mockRepo.On("AddReporte", ctx, mock.AnythingOfType("*comentarios.ReporteComentario")).Return(repository.ErrRecordNotFound).Once()
+
+	err := s.service.Reportar(ctx, comentarios.ReportarComentarioInput{ComentarioID: 8, Motivo: "spam"})
+
+	s.ErrorIs(err, comentarios.ErrComentarioNotFound)
+}
+
+func (s *ComentarioServiceTestSuite) TestReportar_Fail_MotivoVacio() {
+	err := s.service.Reportar(context.Background(), comentarios.ReportarComentarioInput{ComentarioID: 8, Motivo: "  "})
+
+	s.ErrorIs(err, comentarios.ErrReporteMotivoVacio)
+	s.mockRepo.AssertNotCalled(s.T(), "AddReporte")
+}
+
+func (s *ComentarioServiceTestSuite) TestAprobar_Success() {
+	ctx := context.Background()
+	s.mockRepo.On("UpdateEstado", ctx, uint(3), comentarios.EstadoAprobado).Return(nil).Once()
+
+	s.NoError(s.service.Aprobar(ctx, 3))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ComentarioServiceTestSuite) TestRechazar_RepoError() {
+	ctx := context.Background()
+	repoErr := errors.New("fallo de base de datos")
+	s.mockRepo.On("UpdateEstado", ctx, uint(3), comentarios.EstadoRechazado).Return(repoErr).Once()
+
+	err := s.service.Rechazar(ctx, 3)
+
+	s.ErrorIs(err, repoErr)
+}
+
+func (s *ComentarioServiceTestSuite) TestListarParaModeracion_EstadoInvalido() {
+	estado := comentarios.EstadoComentario("borrado")
+
+	comentariosList, err := s.service.ListarParaModeracion(context.Background(), comentarios.FiltroModeracion{Estado: &estado})
+
+	s.Nil(comentariosList)
+	s.ErrorIs(err, comentarios.ErrComentarioEstadoInvalido)
+	s.mockRepo.AssertNotCalled(s.T(), "FindForModeracion")
+}
@@ -0,0 +1,59 @@
+// backend/comentarios/mocks/comentario_repository_mock.go
+package mocks
+
+import (
+	"backend/comentarios" // Para los tipos de dominio y la interfaz
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ComentarioRepositoryMock struct {
+	mock.Mock
+}
+
+// Asegurar que implementa la interfaz
+var _ comentarios.ComentarioRepository = (*ComentarioRepositoryMock)(nil)
+
+func (m *ComentarioRepositoryMock) Create(ctx context.Context, comentario *comentarios.Comentario) error {
+	args := m.Called(ctx, comentario)
+	// Simular que el repo asigna ID si el Create es exitoso
+	if args.Error(0) == nil && comentario != nil {
+		comentario.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *ComentarioRepositoryMock) GetByID(ctx context.Context, id uint) (*comentarios.Comentario, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*comentarios.Comentario), args.Error(1)
+}
+
+func (m *ComentarioRepositoryMock) FindAprobadosByRecetaID(ctx context.Context, recetaID uint) ([]comentarios.Comentario, error) {
+	args := m.Called(ctx, recetaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]comentarios.Comentario), args.Error(1)
+}
+
+func (m *ComentarioRepositoryMock) FindForModeracion(ctx context.Context, filtro comentarios.FiltroModeracion) ([]comentarios.Comentario, error) {
+	args := m.Called(ctx, filtro)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]comentarios.Comentario), args.Error(1)
+}
+
+func (m *ComentarioRepositoryMock) UpdateEstado(ctx context.Context, id uint, estado comentarios.EstadoComentario) error {
+	args := m.Called(ctx, id, estado)
+	return args.Error(0)
+}
+
+func (m *ComentarioRepositoryMock) AddReporte(ctx context.Context, reporte *comentarios.ReporteComentario) error {
+	args := m.Called(ctx, reporte)
+	return args.Error(0)
+}
@@ -0,0 +1,103 @@
+// Archivo: backend/compras/agregador.go
+// Funcionalidad: Suma de ingredientes de varias recetas en ítems de la lista.
+//
+// Cada línea se escala por su factor de porciones, se lleva a la unidad base
+// de su magnitud (g, ml, unidades) y se acumula por ingrediente. Si el
+// ingrediente tiene densidad, el volumen se convierte a masa para que
+// "200 g + 1 taza" termine en una sola cantidad en gramos.
+
+package compras
+
+import (
+	"sort"
+
+	"backend/ingredientes"
+)
+
+// LineaEscalada es una línea de ingrediente de receta con el factor de porciones a aplicar.
+type LineaEscalada struct {
+	Linea  ingredientes.RecetaIngrediente
+	Factor float64 // porciones pedidas / porciones base de la receta
+}
+
+// parcial acumula una cantidad de un ingrediente en una magnitud (o unidad desconocida).
+type parcial struct {
+	magnitud ingredientes.Magnitud
+	base     float64
+	unidad   string // Unidad original (solo relevante para magnitudes desconocidas)
+}
+
+type acumulado struct {
+	ingredienteID uint
+	nombre        string
+	pasillo       string
+	parciales     map[string]*parcial
+	claves        []string // Orden de aparición de las magnitudes
+}
+
+// Agregar suma las líneas por ingrediente y devuelve los ítems ordenados por
+// pasillo y nombre, con Orden consecutivo.
+func Agregar(lineas []LineaEscalada) []ItemLista {
+	porIngrediente := make(map[uint]*acumulado)
+	for _, le := range lineas {
+		l := le.Linea
+		acc, ok := porIngrediente[l.IngredienteID]
+		if !ok {
+			acc = &acumulado{ingredienteID: l.IngredienteID, pasillo: ingredientes.PasilloPorDefecto, parciales: make(map[string]*parcial)}
+			if l.Ingrediente != nil {
+				acc.nombre = l.Ingrediente.Nombre
+				if l.Ingrediente.Pasillo != "" {
+					acc.pasillo = l.Ingrediente.Pasillo
+				}
+			}
+			porIngrediente[l.IngredienteID] = acc
+		}
+
+		magnitud, base := ingredientes.ACantidadBase(l.Cantidad*le.Factor, l.Unidad)
+		if magnitud == ingredientes.MagnitudVolumen && l.Ingrediente != nil && l.Ingrediente.DensidadGML != nil {
+			magnitud, base = ingredientes.MagnitudMasa, base**l.Ingrediente.DensidadGML
+		}
+		clave := string(magnitud)
+		if magnitud == ingredientes.MagnitudDesconocida {
+			clave = "?" + ingredientes.NormalizarUnidad(l.Unidad)
+		}
+		p, ok := acc.parciales[clave]
+		if !ok {
+			p = &parcial{magnitud: magnitud, unidad: l.Unidad}
+			acc.parciales[clave] = p
+			acc.claves = append(acc.claves, clave)
+		}
+		p.base += base
+	}
+
+	items := make([]ItemLista, 0, len(porIngrediente))
+	for _, acc := range porIngrediente {
+		id := acc.ingredienteID
+		for _, clave := range acc.claves {
+			p := acc.parciales[clave]
+			cantidad, unidad := ingredientes.FormatearCantidad(p.magnitud, p.base, p.unidad)
+			items = append(items, ItemLista{
+				IngredienteID: &id,
+				Nombre:        acc.nombre,
+				Pasillo:       acc.pasillo,
+				Cantidad:      cantidad,
+				Unidad:        unidad,
+			})
+		}
+	}
+
+	// Orden estable: pasillo, nombre, unidad (el mapa no garantiza orden).
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Pasillo != items[j].Pasillo {
+			return items[i].Pasillo < items[j].Pasillo
+		}
+		if items[i].Nombre != items[j].Nombre {
+			return items[i].Nombre < items[j].Nombre
+		}
+		return items[i].Unidad < items[j].Unidad
+	})
+	for i := range items {
+		items[i].Orden = i
+	}
+	return items
+}
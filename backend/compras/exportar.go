@@ -0,0 +1,66 @@
+// Archivo: backend/compras/exportar.go
+// Funcionalidad: Exportación de una lista de la compra a texto plano o Markdown.
+
+package compras
+
+import (
+	"fmt"
+	"strings"
+
+	"backend/ingredientes"
+)
+
+// Exportar devuelve la lista en el formato pedido, agrupada por pasillo.
+func Exportar(lista ListaCompra, formato FormatoExportacion) (string, error) {
+	switch formato {
+	case FormatoTexto:
+		return exportarTexto(lista), nil
+	case FormatoMarkdown:
+		return exportarMarkdown(lista), nil
+	default:
+		return "", ErrFormatoExportacionInvalido
+	}
+}
+
+func exportarTexto(lista ListaCompra) string {
+	var b strings.Builder
+	b.WriteString(lista.Nombre)
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("=", len([]rune(lista.Nombre))))
+	b.WriteString("\n")
+	for _, grupo := range lista.PorPasillo() {
+		fmt.Fprintf(&b, "\n%s\n", strings.ToUpper(grupo.Pasillo))
+		for _, item := range grupo.Items {
+			marca := "[ ]"
+			if item.Comprado {
+				marca = "[x]"
+			}
+			fmt.Fprintf(&b, "  %s %s\n", marca, lineaItem(item))
+		}
+	}
+	return b.String()
+}
+
+func exportarMarkdown(lista ListaCompra) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", lista.Nombre)
+	for _, grupo := range lista.PorPasillo() {
+		fmt.Fprintf(&b, "\n## %s\n\n", grupo.Pasillo)
+		for _, item := range grupo.Items {
+			marca := "[ ]"
+			if item.Comprado {
+				marca = "[x]"
+			}
+			fmt.Fprintf(&b, "- %s %s\n", marca, lineaItem(item))
+		}
+	}
+	return b.String()
+}
+
+// lineaItem devuelve "Harina de trigo: 320 g" (o solo el nombre si es "al gusto").
+func lineaItem(item ItemLista) string {
+	if item.Cantidad == 0 {
+		return item.Nombre
+	}
+	return fmt.Sprintf("%s: %s", item.Nombre, ingredientes.TextoCantidad(item.Cantidad, item.Unidad))
+}
@@ -0,0 +1,303 @@
+// backend/compras/lista_compra_api.go
+// Implementación con Gin de ListaCompraHandler.
+package compras
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/ingredientes" // Para el texto de las cantidades
+	"backend/planificador" // Para el error de semana inválida
+)
+
+// formatoSemana es el formato de fecha de las semanas en los DTOs.
+const formatoSemana = "2006-01-02"
+
+// errUsuarioNoIdentificado se produce si un handler protegido se ejecuta sin userID en el contexto.
+var errUsuarioNoIdentificado = errors.New("compras: userID ausente en el contexto")
+
+// ListaCompraHandler maneja las peticiones HTTP de Listas de la compra.
+type ListaCompraHandler struct {
+	service ListaCompraService
+}
+
+// NewListaCompraHandler crea una nueva instancia de ListaCompraHandler.
+func NewListaCompraHandler(s ListaCompraService) *ListaCompraHandler {
+	return &ListaCompraHandler{service: s}
+}
+
+// --- Mapeadores Helper ---
+
+func mapItemToResponseDTO(item ItemLista) ItemListaResponseDTO {
+	texto := ""
+	if item.Cantidad != 0 {
+		texto = ingredientes.TextoCantidad(item.Cantidad, item.Unidad)
+	}
+	return ItemListaResponseDTO{
+		ID:            item.ID,
+		IngredienteID: item.IngredienteID,
+		Nombre:        item.Nombre,
+		Cantidad:      item.Cantidad,
+		Unidad:        item.Unidad,
+		Texto:         texto,
+		Comprado:      item.Comprado,
+	}
+}
+
+func mapListaToResponseDTO(lista ListaCompra) ListaCompraResponseDTO {
+	dto := ListaCompraResponseDTO{
+		ID:        lista.ID,
+		Nombre:    lista.Nombre,
+		CreatedAt: lista.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: lista.UpdatedAt.Format(time.RFC3339),
+	}
+	if lista.SemanaPlan != nil {
+		semana := lista.SemanaPlan.Format(formatoSemana)
+		dto.SemanaPlan = &semana
+	}
+	for _, grupo := range lista.PorPasillo() {
+		pasillo := PasilloResponseDTO{Pasillo: grupo.Pasillo, Items: make([]ItemListaResponseDTO, 0, len(grupo.Items))}
+		for _, item := range grupo.Items {
+			pasillo.Items = append(pasillo.Items, mapItemToResponseDTO(item))
+		}
+		dto.Pasillos = append(dto.Pasillos, pasillo)
+	}
+	return dto
+}
+
+// usuarioIDDesdeContexto obtiene el ID del usuario autenticado (lo deja el middleware de Auth).
+func usuarioIDDesdeContexto(c *gin.Context) (uint, error) {
+	if v, exists := c.Get("userID"); exists {
+		if uid, ok := v.(uint); ok {
+			return uid, nil
+		}
+	}
+	return 0, errUsuarioNoIdentificado
+}
+
+func parseIDParam(c *gin.Context, nombre string) (uint, error) {
+	idStr := c.Param(nombre)
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parámetro %s inválido: %s - %w", nombre, idStr, err)
+	}
+	return uint(idUint64), nil
+}
+
+// usuarioYLista obtiene el usuario autenticado y el ID de la lista de la URL.
+func usuarioYLista(c *gin.Context) (uint, uint, error) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	listaID, err := parseIDParam(c, "id")
+	if err != nil {
+		return 0, 0, err
+	}
+	return userID, listaID, nil
+}
+
+// --- Handlers ---
+
+// GetListas godoc
+// @Summary Lista mis listas de la compra
+// @Tags Listas de la compra
+// @Produce json
+// @Success 200 {array} ListaCompraResponseDTO "Listas (sin ítems), las más recientes primero"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/listas-compra [get]
+// @Security ApiKeyAuth
+func (h *ListaCompraHandler) GetListas(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	listas, err := h.service.Listar(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]ListaCompraResponseDTO, 0, len(listas))
+	for _, l := range listas {
+		responseDTOs = append(responseDTOs, mapListaToResponseDTO(l))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// GenerarLista godoc
+// @Summary Genera una lista de la compra
+// @Description Suma los ingredientes de un plan semanal ("semana") o de varias recetas con sus porciones ("recetas"), convirtiendo unidades y agrupando por pasillo.
+// @Tags Listas de la compra
+// @Accept json
+// @Produce json
+// @Param lista body GenerarListaRequestDTO true "Origen de la lista"
+// @Success 201 {object} ListaCompraResponseDTO "Lista generada"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos o recetas sin ingredientes"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Plan o receta no encontrados"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/listas-compra [post]
+// @Security ApiKeyAuth
+func (h *ListaCompraHandler) GenerarLista(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req GenerarListaRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	input := GenerarListaInput{Nombre: req.Nombre}
+	if req.Semana != "" {
+		semana, err := time.Parse(formatoSemana, req.Semana)
+		if err != nil {
+			_ = c.Error(fmt.Errorf("%w: %q", planificador.ErrSemanaInvalida, req.Semana))
+			return
+		}
+		input.Semana = &semana
+	}
+	for _, r := range req.Recetas {
+		input.Recetas = append(input.Recetas, RecetaPorcionesInput{RecetaID: r.RecetaID, Porciones: r.Porciones})
+	}
+
+	lista, err := h.service.Generar(c.Request.Context(), userID, input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapListaToResponseDTO(*lista))
+}
+
+// GetLista godoc
+// @Summary Obtiene una lista de la compra
+// @Tags Listas de la compra
+// @Produce json
+// @Param id path uint true "ID de la Lista"
+// @Success 200 {object} ListaCompraResponseDTO "Lista con sus ítems agrupados por pasillo"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Lista no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/listas-compra/{id} [get]
+// @Security ApiKeyAuth
+func (h *ListaCompraHandler) GetLista(c *gin.Context) {
+	userID, listaID, err := usuarioYLista(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	lista, err := h.service.Obtener(c.Request.Context(), userID, listaID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapListaToResponseDTO(*lista))
+}
+
+// DeleteLista godoc
+// @Summary Elimina una lista de la compra
+// @Tags Listas de la compra
+// @Param id path uint true "ID de la Lista"
+// @Success 204 "Lista eliminada"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Lista no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/listas-compra/{id} [delete]
+// @Security ApiKeyAuth
+func (h *ListaCompraHandler) DeleteLista(c *gin.Context) {
+	userID, listaID, err := usuarioYLista(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.Eliminar(c.Request.Context(), userID, listaID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MarcarItem godoc
+// @Summary Marca o desmarca un ítem como comprado
+// @Tags Listas de la compra
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Lista"
+// @Param item_id path uint true "ID del Ítem"
+// @Param estado body MarcarItemRequestDTO true "Nuevo estado"
+// @Success 200 {object} ItemListaResponseDTO "Ítem actualizado"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Lista o ítem no encontrados"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/listas-compra/{id}/items/{item_id} [patch]
+// @Security ApiKeyAuth
+func (h *ListaCompraHandler) MarcarItem(c *gin.Context) {
+	userID, listaID, err := usuarioYLista(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	itemID, err := parseIDParam(c, "item_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req MarcarItemRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	item, err := h.service.MarcarItem(c.Request.Context(), userID, listaID, itemID, *req.Comprado)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapItemToResponseDTO(*item))
+}
+
+// ExportarLista godoc
+// @Summary Exporta una lista de la compra
+// @Description Devuelve la lista agrupada por pasillo como texto plano o Markdown (con casillas [ ] / [x]).
+// @Tags Listas de la compra
+// @Produce plain
+// @Produce text/markdown
+// @Param id path uint true "ID de la Lista"
+// @Param formato query string false "texto (por defecto) o markdown"
+// @Success 200 {string} string "Lista exportada"
+// @Failure 400 {object} apitypes.ErrorResponse "Formato no soportado"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Lista no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/listas-compra/{id}/exportar [get]
+// @Security ApiKeyAuth
+func (h *ListaCompraHandler) ExportarLista(c *gin.Context) {
+	userID, listaID, err := usuarioYLista(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	formato := FormatoExportacion(c.DefaultQuery("formato", string(FormatoTexto)))
+	contenido, err := h.service.Exportar(c.Request.Context(), userID, listaID, formato)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	contentType := "text/plain; charset=utf-8"
+	if formato == FormatoMarkdown {
+		contentType = "text/markdown; charset=utf-8"
+	}
+	c.Data(http.StatusOK, contentType, []byte(contenido))
+}
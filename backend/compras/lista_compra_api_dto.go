@@ -0,0 +1,49 @@
+// backend/compras/lista_compra_api_dto.go
+// DTOs de la API de Listas de la compra.
+package compras
+
+// RecetaPorcionesDTO es una receta con las porciones a cocinar.
+type RecetaPorcionesDTO struct {
+	RecetaID  uint `json:"receta_id" binding:"required,gt=0" example:"7"`
+	Porciones int  `json:"porciones" binding:"omitempty,min=1,max=100" example:"6"` // Opcional: por defecto las de la receta
+}
+
+// GenerarListaRequestDTO es el DTO de entrada para generar una lista.
+// Indique "semana" (plan del planificador) o "recetas", no ambas.
+type GenerarListaRequestDTO struct {
+	Nombre  string               `json:"nombre" binding:"max=120" example:"Compra del sábado"`
+	Semana  string               `json:"semana,omitempty" example:"2025-05-19"` // Cualquier fecha de la semana (AAAA-MM-DD)
+	Recetas []RecetaPorcionesDTO `json:"recetas,omitempty" binding:"dive"`
+}
+
+// MarcarItemRequestDTO es el DTO de entrada para marcar un ítem como comprado.
+type MarcarItemRequestDTO struct {
+	Comprado *bool `json:"comprado" binding:"required" example:"true"`
+}
+
+// ItemListaResponseDTO es un ítem de la lista.
+type ItemListaResponseDTO struct {
+	ID            uint    `json:"id" example:"12"`
+	IngredienteID *uint   `json:"ingrediente_id,omitempty" example:"3"`
+	Nombre        string  `json:"nombre" example:"Harina de trigo"`
+	Cantidad      float64 `json:"cantidad" example:"320"`
+	Unidad        string  `json:"unidad" example:"g"`
+	Texto         string  `json:"texto" example:"320 g"` // Cantidad legible ("" si es al gusto)
+	Comprado      bool    `json:"comprado" example:"false"`
+}
+
+// PasilloResponseDTO agrupa los ítems de un pasillo.
+type PasilloResponseDTO struct {
+	Pasillo string                 `json:"pasillo" example:"Panadería y harinas"`
+	Items   []ItemListaResponseDTO `json:"items"`
+}
+
+// ListaCompraResponseDTO es el DTO de salida de una lista de la compra.
+type ListaCompraResponseDTO struct {
+	ID         uint                 `json:"id" example:"5"`
+	Nombre     string               `json:"nombre" example:"Semana del 2025-05-19"`
+	SemanaPlan *string              `json:"semana_plan,omitempty" example:"2025-05-19"`
+	Pasillos   []PasilloResponseDTO `json:"pasillos,omitempty"` // Solo en el detalle
+	CreatedAt  string               `json:"created_at" example:"2025-05-17T10:00:00Z"`
+	UpdatedAt  string               `json:"updated_at" example:"2025-05-17T10:00:00Z"`
+}
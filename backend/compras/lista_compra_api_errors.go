@@ -0,0 +1,21 @@
+// backend/compras/lista_compra_api_errors.go
+
+// Traducción de los errores de dominio de listas de la compra a respuestas HTTP (ver shared/apperrors).
+
+package compras
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrListaNotFound, http.StatusNotFound, "lista_no_encontrada")
+	apperrors.Registrar(ErrItemNotFound, http.StatusNotFound, "lista_item_no_encontrado")
+
+	apperrors.Registrar(ErrOrigenListaInvalido, http.StatusBadRequest, "lista_origen_invalido")
+	apperrors.Registrar(ErrPorcionesInvalidas, http.StatusBadRequest, "lista_porciones_invalidas")
+	apperrors.Registrar(ErrListaSinIngredientes, http.StatusBadRequest, "lista_sin_ingredientes")
+	apperrors.Registrar(ErrFormatoExportacionInvalido, http.StatusBadRequest, "lista_formato_invalido")
+}
@@ -0,0 +1,89 @@
+// Archivo: backend/compras/lista_compra_model.go
+// Funcionalidad: Modelo de dominio para las Listas de la compra.
+// Capa: Dominio / Lógica de negocio.
+
+// Descripción:
+// Una lista de la compra se genera a partir de un plan semanal o de un conjunto
+// de recetas con sus porciones. Los ingredientes de todas las recetas se suman
+// (convirtiendo unidades: "200 g + 1 taza de harina" da una sola línea en gramos)
+// y se agrupan por pasillo del supermercado.
+//
+// Reglas de Negocio:
+// - Las cantidades se escalan según las porciones pedidas / porciones base de la receta.
+// - Las unidades de la misma magnitud se suman; volumen y masa solo se combinan
+//   si el ingrediente tiene densidad conocida. Las unidades desconocidas se suman
+//   únicamente con la misma unidad.
+// - La lista es una foto: editar recetas después no la modifica. Solo cambia el
+//   estado "comprado" de cada ítem.
+// - Las listas solo son visibles para su dueño.
+
+package compras
+
+import (
+	"errors"
+	"time"
+)
+
+// ListaCompra representa una lista de la compra generada.
+type ListaCompra struct {
+	ID         uint
+	UserID     uint       // Dueño de la lista
+	Nombre     string     // Nombre visible (ej: "Semana del 2025-05-19")
+	SemanaPlan *time.Time // Lunes del plan de origen (nil si se generó desde recetas)
+	Items      []ItemLista
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// ItemLista es una línea de la lista: un ingrediente con su cantidad total.
+type ItemLista struct {
+	ID            uint
+	ListaID       uint
+	IngredienteID *uint   // Ingrediente de origen (informativo)
+	Nombre        string  // Copia del nombre del ingrediente al generar la lista
+	Pasillo       string  // Copia del pasillo al generar la lista
+	Cantidad      float64 // 0 = "al gusto"
+	Unidad        string  // Unidad legible ("g", "kg", "ml", "l", "" para unidades, o la unidad original)
+	Comprado      bool
+	Orden         int // Orden dentro de la lista (agrupado por pasillo)
+}
+
+// GrupoPasillo agrupa los ítems de un mismo pasillo.
+type GrupoPasillo struct {
+	Pasillo string
+	Items   []ItemLista
+}
+
+// PorPasillo devuelve los ítems agrupados por pasillo, respetando el orden de la lista.
+func (l ListaCompra) PorPasillo() []GrupoPasillo {
+	grupos := make([]GrupoPasillo, 0)
+	indice := make(map[string]int)
+	for _, item := range l.Items {
+		i, ok := indice[item.Pasillo]
+		if !ok {
+			i = len(grupos)
+			indice[item.Pasillo] = i
+			grupos = append(grupos, GrupoPasillo{Pasillo: item.Pasillo})
+		}
+		grupos[i].Items = append(grupos[i].Items, item)
+	}
+	return grupos
+}
+
+// FormatoExportacion identifica el formato de exportación de una lista.
+type FormatoExportacion string
+
+const (
+	FormatoTexto    FormatoExportacion = "texto"
+	FormatoMarkdown FormatoExportacion = "markdown"
+)
+
+// Errores específicos del dominio de Listas de la compra.
+var (
+	ErrListaNotFound              = errors.New("lista de la compra no encontrada")
+	ErrItemNotFound               = errors.New("el ítem no pertenece a la lista")
+	ErrOrigenListaInvalido        = errors.New("indique una semana del planificador o una lista de recetas (no ambas)")
+	ErrPorcionesInvalidas         = errors.New("las porciones deben ser al menos 1")
+	ErrListaSinIngredientes       = errors.New("las recetas seleccionadas no tienen ingredientes")
+	ErrFormatoExportacionInvalido = errors.New("formato de exportación no soportado (use 'texto' o 'markdown')")
+)
@@ -0,0 +1,104 @@
+// backend/compras/lista_compra_model_gorm.go
+
+// Este archivo define los modelos de persistencia para las listas de la compra.
+// Utiliza GORM para la definición de las tablas y el mapeo de campos.
+
+package compras
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ListaCompraModel representa la tabla 'listas_compra'.
+type ListaCompraModel struct {
+	ID         uint       `gorm:"primaryKey"`
+	UserID     uint       `gorm:"not null;index"`
+	Nombre     string     `gorm:"type:varchar(120);not null"`
+	SemanaPlan *time.Time `gorm:"type:date;default:null"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	DeletedAt  gorm.DeletedAt `gorm:"index"`
+
+	Items []ItemListaModel `gorm:"foreignKey:ListaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (ListaCompraModel) TableName() string {
+	return "listas_compra"
+}
+
+// ItemListaModel representa la tabla 'lista_compra_items'.
+// Nombre y pasillo se copian del ingrediente: la lista no cambia si el catálogo cambia.
+type ItemListaModel struct {
+	ID            uint    `gorm:"primaryKey"`
+	ListaID       uint    `gorm:"not null;index"`
+	IngredienteID *uint   `gorm:"default:null"`
+	Nombre        string  `gorm:"type:varchar(100);not null"`
+	Pasillo       string  `gorm:"type:varchar(60);not null"`
+	Cantidad      float64 `gorm:"type:decimal(10,2);not null;default:0"`
+	Unidad        string  `gorm:"type:varchar(30);not null;default:''"`
+	Comprado      bool    `gorm:"not null;default:false"`
+	Orden         int     `gorm:"not null;default:0"`
+}
+
+func (ItemListaModel) TableName() string {
+	return "lista_compra_items"
+}
+
+// --- Funciones de Mapeo ---
+
+// ToDomain convierte el modelo de lista al dominio (los ítems solo si fueron precargados).
+func (m *ListaCompraModel) ToDomain() *ListaCompra {
+	if m == nil {
+		return nil
+	}
+	lista := &ListaCompra{
+		ID:         m.ID,
+		UserID:     m.UserID,
+		Nombre:     m.Nombre,
+		SemanaPlan: m.SemanaPlan,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+	for _, im := range m.Items {
+		lista.Items = append(lista.Items, ItemLista{
+			ID:            im.ID,
+			ListaID:       im.ListaID,
+			IngredienteID: im.IngredienteID,
+			Nombre:        im.Nombre,
+			Pasillo:       im.Pasillo,
+			Cantidad:      im.Cantidad,
+			Unidad:        im.Unidad,
+			Comprado:      im.Comprado,
+			Orden:         im.Orden,
+		})
+	}
+	return lista
+}
+
+func FromListaCompraDomain(d *ListaCompra) *ListaCompraModel {
+	if d == nil {
+		return nil
+	}
+	model := &ListaCompraModel{
+		ID:         d.ID,
+		UserID:     d.UserID,
+		Nombre:     d.Nombre,
+		SemanaPlan: d.SemanaPlan,
+	}
+	for _, item := range d.Items {
+		model.Items = append(model.Items, ItemListaModel{
+			ID:            item.ID,
+			ListaID:       item.ListaID,
+			IngredienteID: item.IngredienteID,
+			Nombre:        item.Nombre,
+			Pasillo:       item.Pasillo,
+			Cantidad:      item.Cantidad,
+			Unidad:        item.Unidad,
+			Comprado:      item.Comprado,
+			Orden:         item.Orden,
+		})
+	}
+	return model
+}
@@ -0,0 +1,19 @@
+// backend/compras/lista_compra_repository.go
+// Funcionalidad: Interfaz para la persistencia de Listas de la compra.
+// Capa: Repositorio (Abstracción).
+package compras
+
+import "context"
+
+// ListaCompraRepository define el contrato para las operaciones de datos de Listas de la compra.
+type ListaCompraRepository interface {
+	// Create guarda la lista con todos sus ítems.
+	Create(ctx context.Context, lista *ListaCompra) error
+	// GetByID devuelve la lista con sus ítems ordenados.
+	GetByID(ctx context.Context, id uint) (*ListaCompra, error)
+	// FindByUserID devuelve las listas del usuario (sin ítems), las más recientes primero.
+	FindByUserID(ctx context.Context, userID uint) ([]ListaCompra, error)
+	Delete(ctx context.Context, id uint) error
+	// UpdateItemComprado cambia el estado "comprado" de un ítem de la lista.
+	UpdateItemComprado(ctx context.Context, listaID, itemID uint, comprado bool) error
+}
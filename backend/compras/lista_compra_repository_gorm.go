@@ -0,0 +1,82 @@
+// backend/compras/lista_compra_repository_gorm.go
+// Funcionalidad: Implementación GORM de ListaCompraRepository.
+// Capa: Repositorio (Implementación de Persistencia).
+package compras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"backend/shared/repository"
+)
+
+type gormListaCompraRepository struct {
+	db *gorm.DB
+}
+
+// NewListaCompraRepository crea una instancia de la implementación GORM de ListaCompraRepository.
+func NewListaCompraRepository(db *gorm.DB) ListaCompraRepository {
+	return &gormListaCompraRepository{db: db}
+}
+
+func (r *gormListaCompraRepository) Create(ctx context.Context, lista *ListaCompra) error {
+	model := FromListaCompraDomain(lista)
+	// GORM inserta los ítems (asociación has-many) en la misma transacción.
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm compras: create: %w", repository.TraducirError(err))
+	}
+	*lista = *model.ToDomain()
+	return nil
+}
+
+func (r *gormListaCompraRepository) GetByID(ctx context.Context, id uint) (*ListaCompra, error) {
+	var model ListaCompraModel
+	err := r.db.WithContext(ctx).
+		Preload("Items", func(tx *gorm.DB) *gorm.DB { return tx.Order("orden asc") }).
+		First(&model, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm compras: getbyid %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormListaCompraRepository) FindByUserID(ctx context.Context, userID uint) ([]ListaCompra, error) {
+	var models []ListaCompraModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at desc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm compras: findbyuserid %d: %w", userID, err)
+	}
+	listas := make([]ListaCompra, 0, len(models))
+	for i := range models {
+		listas = append(listas, *models[i].ToDomain())
+	}
+	return listas, nil
+}
+
+func (r *gormListaCompraRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&ListaCompraModel{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm compras: delete %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormListaCompraRepository) UpdateItemComprado(ctx context.Context, listaID, itemID uint, comprado bool) error {
+	// Sin comprobar RowsAffected: MySQL devuelve 0 si el valor no cambia.
+	// La existencia del ítem la verifica el servicio.
+	err := r.db.WithContext(ctx).Model(&ItemListaModel{}).
+		Where("id = ? AND lista_id = ?", itemID, listaID).
+		Update("comprado", comprado).Error
+	if err != nil {
+		return fmt.Errorf("repo gorm compras: updateitemcomprado lista %d item %d: %w", listaID, itemID, repository.TraducirError(err))
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+// backend/compras/lista_compra_routes.go
+package compras
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterListaCompraRoutes registra las rutas de Listas de la compra.
+// requireAuth es el middleware que exige un usuario autenticado (se inyecta desde main).
+func RegisterListaCompraRoutes(apiBaseGroup *gin.RouterGroup, h *ListaCompraHandler, requireAuth gin.HandlerFunc) {
+	// Rutas del usuario autenticado: /api/v1/me/listas-compra
+	listaRoutes := apiBaseGroup.Group("/me/listas-compra", requireAuth)
+	{
+		listaRoutes.GET("", h.GetListas)
+		listaRoutes.POST("", h.GenerarLista)
+		listaRoutes.GET("/:id", h.GetLista)
+		listaRoutes.DELETE("/:id", h.DeleteLista)
+		listaRoutes.PATCH("/:id/items/:item_id", h.MarcarItem)
+		listaRoutes.GET("/:id/exportar", h.ExportarLista)
+	}
+
+	log.Println("🛣️  Rutas de Listas de la compra configuradas.")
+}
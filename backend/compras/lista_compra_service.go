@@ -0,0 +1,222 @@
+// backend/compras/lista_compra_service.go
+// Funcionalidad: Lógica de negocio de las Listas de la compra.
+// Capa: Servicio / Casos de Uso.
+package compras
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log" // Temporal, reemplazar con logger estructurado
+	"strings"
+	"time"
+
+	"backend/ingredientes" // Ingredientes de cada receta
+	"backend/planificador" // Plan semanal de origen
+	"backend/recetas"      // Porciones base de cada receta
+	"backend/shared/repository"
+)
+
+// ListaCompraService define el contrato para la lógica de negocio de Listas de la compra.
+// Todas las operaciones verifican que la lista pertenezca al usuario.
+type ListaCompraService interface {
+	Listar(ctx context.Context, userID uint) ([]ListaCompra, error)
+	Obtener(ctx context.Context, userID, listaID uint) (*ListaCompra, error)
+	Generar(ctx context.Context, userID uint, input GenerarListaInput) (*ListaCompra, error)
+	MarcarItem(ctx context.Context, userID, listaID, itemID uint, comprado bool) (*ItemLista, error)
+	Eliminar(ctx context.Context, userID, listaID uint) error
+	Exportar(ctx context.Context, userID, listaID uint, formato FormatoExportacion) (string, error)
+}
+
+type listaCompraService struct {
+	repo           ListaCompraRepository
+	ingredienteSvc ingredientes.IngredienteService
+	recetaSvc      recetas.RecetaService
+	planSvc        planificador.PlanService
+}
+
+// NewListaCompraService crea una nueva instancia de ListaCompraService.
+func NewListaCompraService(repo ListaCompraRepository, ingredienteSvc ingredientes.IngredienteService, recetaSvc recetas.RecetaService, planSvc planificador.PlanService) ListaCompraService {
+	return &listaCompraService{repo: repo, ingredienteSvc: ingredienteSvc, recetaSvc: recetaSvc, planSvc: planSvc}
+}
+
+func (s *listaCompraService) Listar(ctx context.Context, userID uint) ([]ListaCompra, error) {
+	listas, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio compras: error al listar de user %d: %w", userID, err)
+	}
+	return listas, nil
+}
+
+// Obtener devuelve la lista si existe y pertenece al usuario (si no, ErrListaNotFound).
+func (s *listaCompraService) Obtener(ctx context.Context, userID, listaID uint) (*ListaCompra, error) {
+	lista, err := s.repo.GetByID(ctx, listaID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrListaNotFound
+		}
+		return nil, fmt.Errorf("servicio compras: error al obtener lista %d: %w", listaID, err)
+	}
+	if lista.UserID != userID {
+		return nil, ErrListaNotFound // No revelar listas ajenas
+	}
+	return lista, nil
+}
+
+// Generar crea una lista sumando los ingredientes de un plan semanal o de varias recetas.
+func (s *listaCompraService) Generar(ctx context.Context, userID uint, input GenerarListaInput) (*ListaCompra, error) {
+	if (input.Semana == nil) == (len(input.Recetas) == 0) {
+		return nil, ErrOrigenListaInvalido
+	}
+
+	lista := &ListaCompra{UserID: userID, Nombre: strings.TrimSpace(input.Nombre)}
+	var (
+		factores []recetaFactor
+		err      error
+	)
+	if input.Semana != nil {
+		factores, err = s.factoresDesdePlan(ctx, userID, *input.Semana)
+		if err != nil {
+			return nil, err
+		}
+		semana := planificador.InicioDeSemana(*input.Semana)
+		lista.SemanaPlan = &semana
+		if lista.Nombre == "" {
+			lista.Nombre = fmt.Sprintf("Semana del %s", semana.Format("2006-01-02"))
+		}
+	} else {
+		factores, err = s.factoresDesdeRecetas(ctx, userID, input.Recetas)
+		if err != nil {
+			return nil, err
+		}
+		if lista.Nombre == "" {
+			lista.Nombre = fmt.Sprintf("Lista del %s", time.Now().Format("2006-01-02"))
+		}
+	}
+
+	ids := make([]uint, 0, len(factores))
+	vistos := make(map[uint]bool, len(factores))
+	for _, f := range factores {
+		if !vistos[f.recetaID] {
+			vistos[f.recetaID] = true
+			ids = append(ids, f.recetaID)
+		}
+	}
+	porReceta, err := s.ingredienteSvc.ObtenerDeRecetas(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("servicio compras: error obteniendo ingredientes: %w", err)
+	}
+
+	lineas := make([]LineaEscalada, 0)
+	for _, f := range factores {
+		for _, l := range porReceta[f.recetaID] {
+			lineas = append(lineas, LineaEscalada{Linea: l, Factor: f.factor})
+		}
+	}
+	if len(lineas) == 0 {
+		return nil, ErrListaSinIngredientes
+	}
+	lista.Items = Agregar(lineas)
+
+	if err := s.repo.Create(ctx, lista); err != nil {
+		return nil, fmt.Errorf("servicio compras: error al crear lista: %w", err)
+	}
+	log.Printf("Servicio: Lista de la compra '%s' creada con ID %d (%d ítems) para UserID %d\n", lista.Nombre, lista.ID, len(lista.Items), userID)
+	return lista, nil
+}
+
+// MarcarItem marca o desmarca un ítem como comprado.
+func (s *listaCompraService) MarcarItem(ctx context.Context, userID, listaID, itemID uint, comprado bool) (*ItemLista, error) {
+	lista, err := s.Obtener(ctx, userID, listaID)
+	if err != nil {
+		return nil, err
+	}
+	var item *ItemLista
+	for i := range lista.Items {
+		if lista.Items[i].ID == itemID {
+			item = &lista.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, ErrItemNotFound
+	}
+	if err := s.repo.UpdateItemComprado(ctx, listaID, itemID, comprado); err != nil {
+		return nil, fmt.Errorf("servicio compras: error marcando ítem %d: %w", itemID, err)
+	}
+	item.Comprado = comprado
+	return item, nil
+}
+
+func (s *listaCompraService) Eliminar(ctx context.Context, userID, listaID uint) error {
+	if _, err := s.Obtener(ctx, userID, listaID); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, listaID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrListaNotFound
+		}
+		return fmt.Errorf("servicio compras: error al eliminar lista %d: %w", listaID, err)
+	}
+	log.Printf("Servicio: Lista de la compra ID %d eliminada.\n", listaID)
+	return nil
+}
+
+func (s *listaCompraService) Exportar(ctx context.Context, userID, listaID uint, formato FormatoExportacion) (string, error) {
+	lista, err := s.Obtener(ctx, userID, listaID)
+	if err != nil {
+		return "", err
+	}
+	return Exportar(*lista, formato)
+}
+
+// --- Helpers ---
+
+// recetaFactor es una receta a cocinar con su factor de escala de porciones.
+type recetaFactor struct {
+	recetaID uint
+	factor   float64
+}
+
+func factorPorciones(pedidas, base int) float64 {
+	if base <= 0 {
+		base = recetas.PorcionesPorDefecto
+	}
+	return float64(pedidas) / float64(base)
+}
+
+// factoresDesdePlan toma las comidas del plan que aún tienen receta.
+func (s *listaCompraService) factoresDesdePlan(ctx context.Context, userID uint, semana time.Time) ([]recetaFactor, error) {
+	plan, err := s.planSvc.ObtenerPlan(ctx, userID, semana)
+	if err != nil {
+		return nil, err
+	}
+	factores := make([]recetaFactor, 0, len(plan.Comidas))
+	for _, c := range plan.Comidas {
+		if c.RecetaEliminada || c.RecetaID == nil || c.Receta == nil {
+			continue
+		}
+		factores = append(factores, recetaFactor{recetaID: *c.RecetaID, factor: factorPorciones(c.Porciones, c.Receta.Porciones)})
+	}
+	return factores, nil
+}
+
+// factoresDesdeRecetas valida las recetas pedidas (el usuario tiene que poder verlas) y su escala.
+func (s *listaCompraService) factoresDesdeRecetas(ctx context.Context, userID uint, recetasInput []RecetaPorcionesInput) ([]recetaFactor, error) {
+	factores := make([]recetaFactor, 0, len(recetasInput))
+	for _, ri := range recetasInput {
+		if ri.Porciones < 0 {
+			return nil, ErrPorcionesInvalidas
+		}
+		receta, err := s.recetaSvc.ObtenerVisible(ctx, ri.RecetaID, recetas.LectorUsuario(userID))
+		if err != nil {
+			return nil, fmt.Errorf("servicio compras: error validando receta %d: %w", ri.RecetaID, err)
+		}
+		factor := 1.0 // Sin porciones: las de la receta
+		if ri.Porciones > 0 {
+			factor = factorPorciones(ri.Porciones, receta.Porciones)
+		}
+		factores = append(factores, recetaFactor{recetaID: receta.ID, factor: factor})
+	}
+	return factores, nil
+}
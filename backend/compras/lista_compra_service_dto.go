@@ -0,0 +1,18 @@
+// backend/compras/lista_compra_service_dto.go
+package compras
+
+import "time"
+
+// GenerarListaInput son los datos para generar una lista de la compra.
+// Debe indicarse Semana (plan del planificador) o Recetas, pero no ambas.
+type GenerarListaInput struct {
+	Nombre  string     // Opcional: se genera uno si está vacío
+	Semana  *time.Time // Cualquier fecha de la semana del plan
+	Recetas []RecetaPorcionesInput
+}
+
+// RecetaPorcionesInput es una receta con las porciones que se quieren cocinar.
+type RecetaPorcionesInput struct {
+	RecetaID  uint
+	Porciones int // 0 = porciones base de la receta
+}
@@ -0,0 +1,250 @@
+// backend/compras/lista_compra_service_test.go
+package compras_test // Usar paquete _test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/compras"                    // El paquete bajo test
+	comprasMocks "backend/compras/mocks" // Mocks del paquete compras
+	"backend/ingredientes"
+	"backend/planificador"
+	"backend/recetas"
+	recetasMocks "backend/recetas/mocks" // Mock de RecetaService
+	"backend/shared/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ListaCompraServiceTestSuite struct {
+	suite.Suite
+	mockRepo           *comprasMocks.ListaCompraRepositoryMock
+	mockIngredienteSvc *comprasMocks.IngredienteServiceMock
+	mockRecetaSvc      *recetasMocks.RecetaServiceMock
+	mockPlanSvc        *comprasMocks.PlanServiceMock
+	service            compras.ListaCompraService
+
+	harina *ingredientes.Ingrediente
+	leche  *ingredientes.Ingrediente
+}
+
+func (s *ListaCompraServiceTestSuite) SetupTest() {
+	s.mockRepo = new(comprasMocks.ListaCompraRepositoryMock)
+	s.mockIngredienteSvc = new(comprasMocks.IngredienteServiceMock)
+	s.mockRecetaSvc = new(recetasMocks.RecetaServiceMock)
+	s.mockPlanSvc = new(comprasMocks.PlanServiceMock)
+	s.service = compras.NewListaCompraService(s.mockRepo, s.mockIngredienteSvc, s.mockRecetaSvc, s.mockPlanSvc)
+
+	densidadHarina := 0.5
+	s.harina = &ingredientes.Ingrediente{ID: 3, Nombre: "Harina de trigo", Pasillo: "Panadería", DensidadGML: &densidadHarina}
+	s.leche = &ingredientes.Ingrediente{ID: 5, Nombre: "Leche", Pasillo: "Lácteos"}
+}
+
+func TestListaCompraServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ListaCompraServiceTestSuite))
+}
+
+func (s *ListaCompraServiceTestSuite) linea(recetaID uint, ing *ingredientes.Ingrediente, cantidad float64, unidad string) ingredientes.RecetaIngrediente {
+	return ingredientes.RecetaIngrediente{RecetaID: recetaID, IngredienteID: ing.ID, Ingrediente: ing, Cantidad: cantidad, Unidad: unidad}
+}
+
+// --- Agregación ---
+
+func (s *ListaCompraServiceTestSuite) TestAgregar_GramosYTazaConDensidad_SeFusionan() {
+	items := compras.Agregar([]compras.LineaEscalada{
+		{Linea: s.linea(1, s.harina, 200, "g"), Factor: 1},
+		{Linea: s.linea(2, s.harina, 1, "taza"), Factor: 1}, // 240 ml * 0.5 g/ml = 120 g
+	})
+
+	s.Require().Len(items, 1)
+	s.Equal("Harina de trigo", items[0].Nombre)
+	s.Equal(320.0, items[0].Cantidad)
+	s.Equal("g", items[0].Unidad)
+}
+
+func (s *ListaCompraServiceTestSuite) TestAgregar_SinDensidad_VolumenYMasaSeparados() {
+	items := compras.Agregar([]compras.LineaEscalada{
+		{Linea: s.linea(1, s.leche, 1, "l"), Factor: 1},
+		{Linea: s.linea(2, s.leche, 2, "tazas"), Factor: 1},
+		{Linea: s.linea(2, s.leche, 100, "g"), Factor: 1},
+	})
+
+	s.Require().Len(items, 2)
+	s.Equal(100.0, items[0].Cantidad) // Ordenados por unidad: "g" < "l"
+	s.Equal("g", items[0].Unidad)
+	s.Equal(1.48, items[1].Cantidad) // 1000 ml + 480 ml
+	s.Equal("l", items[1].Unidad)
+}
+
+func (s *ListaCompraServiceTestSuite) TestAgregar_EscalaYOrdenaPorPasillo() {
+	items := compras.Agregar([]compras.LineaEscalada{
+		{Linea: s.linea(1, s.leche, 250, "ml"), Factor: 1.5},
+		{Linea: s.linea(1, s.harina, 500, "g"), Factor: 3},
+	})
+
+	s.Require().Len(items, 2)
+	s.Equal("Lácteos", items[0].Pasillo) // "Lácteos" < "Panadería"
+	s.Equal(375.0, items[0].Cantidad)
+	s.Equal("Panadería", items[1].Pasillo)
+	s.Equal(1.5, items[1].Cantidad)
+	s.Equal("kg", items[1].Unidad)
+	s.Equal(1, items[1].Orden)
+}
+
+// --- Generación ---
+
+func (s *ListaCompraServiceTestSuite) TestGenerar_DesdeRecetas_Success() {
+	ctx := context.Background()
+	input := compras.GenerarListaInput{Recetas: []compras.RecetaPorcionesInput{
+		{RecetaID: 7, Porciones: 8}, // Receta de 4 porciones: se duplica
+		{RecetaID: 8},               // Porciones de la receta
+	}}
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(9)).Return(&recetas.Receta{ID: 7, Porciones: 4}, nil).Once()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(8), recetas.LectorUsuario(9)).Return(&recetas.Receta{ID: 8, Porciones: 2}, nil).Once()
+	s.mockIngredienteSvc.On("ObtenerDeRecetas", ctx, []uint{7, 8}).Return(map[uint][]ingredientes.RecetaIngrediente{
+		7: {s.linea(7, s.harina, 100, "g")},
+		8: {s.linea(8, s.harina, 1, "taza")},
+	}, nil).Once()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(l *compras.ListaCompra) bool {
+		return l.UserID == 9 && l.SemanaPlan == nil && len(l.Items) == 1 && l.Items[0].Cantidad == 320
+	})).Return(nil).Once()
+
+	lista, err := s.service.Generar(ctx, 9, input)
+
+	s.NoError(err)
+	s.Equal(uint(1), lista.ID)
+	s.NotEmpty(lista.Nombre)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockIngredienteSvc.AssertExpectations(s.T())
+}
+
+func (s *ListaCompraServiceTestSuite) TestGenerar_DesdePlan_OmiteRecetasEliminadas() {
+	ctx := context.Background()
+	miercoles := time.Date(2025, 5, 21, 0, 0, 0, 0, time.UTC)
+	lunes := time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC)
+	recetaID := uint(7)
+	plan := &planificador.PlanSemanal{ID: 2, UserID: 9, SemanaInicio: lunes, Comidas: []planificador.ComidaPlan{
+		{Dia: 1, Momento: planificador.MomentoCena, RecetaID: &recetaID, Receta: &recetas.Receta{ID: 7, Porciones: 4}, Porciones: 2},
+		{Dia: 2, Momento: planificador.MomentoCena, RecetaID: &recetaID, Receta: &recetas.Receta{ID: 7, Porciones: 4}, Porciones: 4},
+		{Dia: 3, Momento: planificador.MomentoCena, Porciones: 4, RecetaEliminada: true},
+	}}
+	s.mockPlanSvc.On("ObtenerPlan", ctx, uint(9), miercoles).Return(plan, nil).Once()
+	s.mockIngredienteSvc.On("ObtenerDeRecetas", ctx, []uint{7}).Return(map[uint][]ingredientes.RecetaIngrediente{
+		7: {s.linea(7, s.leche, 200, "ml")},
+	}, nil).Once()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(l *compras.ListaCompra) bool {
+		return l.SemanaPlan != nil && l.SemanaPlan.Equal(lunes) && l.Nombre == "Semana del 2025-05-19" &&
+			len(l.Items) == 1 && l.Items[0].Cantidad == 300 // 200 * 0.5 + 200 * 1
+	})).Return(nil).Once()
+
+	_, err := s.service.Generar(ctx, 9, compras.GenerarListaInput{Semana: &miercoles})
+
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockPlanSvc.AssertExpectations(s.T())
+}
+
+func (s *ListaCompraServiceTestSuite) TestGenerar_Fail_OrigenInvalido() {
+	semana := time.Now()
+	_, err := s.service.Generar(context.Background(), 9, compras.GenerarListaInput{})
+	s.ErrorIs(err, compras.ErrOrigenListaInvalido)
+
+	_, err = s.service.Generar(context.Background(), 9, compras.GenerarListaInput{
+		Semana:  &semana,
+		Recetas: []compras.RecetaPorcionesInput{{RecetaID: 7}},
+	})
+	s.ErrorIs(err, compras.ErrOrigenListaInvalido)
+}
+
+func (s *ListaCompraServiceTestSuite) TestGenerar_Fail_SinIngredientes() {
+	ctx := context.Background()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(9)).Return(&recetas.Receta{ID: 7, Porciones: 4}, nil).Once()
+	s.mockIngredienteSvc.On("ObtenerDeRecetas", ctx, []uint{7}).Return(map[uint][]ingredientes.RecetaIngrediente{}, nil).Once()
+
+	_, err := s.service.Generar(ctx, 9, compras.GenerarListaInput{Recetas: []compras.RecetaPorcionesInput{{RecetaID: 7}}})
+
+	s.ErrorIs(err, compras.ErrListaSinIngredientes)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *ListaCompraServiceTestSuite) TestGenerar_Fail_RecetaNoVisible() {
+	ctx := context.Background()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(9)).Return(nil, recetas.ErrRecetaNotFound).Once()
+
+	_, err := s.service.Generar(ctx, 9, compras.GenerarListaInput{Recetas: []compras.RecetaPorcionesInput{{RecetaID: 7}}})
+
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockIngredienteSvc.AssertNotCalled(s.T(), "ObtenerDeRecetas", mock.Anything, mock.Anything)
+}
+
+// --- Consulta y check-off ---
+
+func (s *ListaCompraServiceTestSuite) TestObtener_Fail_ListaAjena() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(5)).Return(&compras.ListaCompra{ID: 5, UserID: 2}, nil).Once()
+
+	lista, err := s.service.Obtener(ctx, 9, 5)
+
+	s.Nil(lista)
+	s.ErrorIs(err, compras.ErrListaNotFound)
+}
+
+func (s *ListaCompraServiceTestSuite) TestObtener_Fail_NotFound() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(5)).Return(nil, repository.ErrRecordNotFound).Once()
+
+	_, err := s.service.Obtener(ctx, 9, 5)
+
+	s.ErrorIs(err, compras.ErrListaNotFound)
+}
+
+func (s *ListaCompraServiceTestSuite) TestMarcarItem_Success() {
+	ctx := context.Background()
+	lista := &compras.ListaCompra{ID: 5, UserID: 9, Items: []compras.ItemLista{{ID: 12, ListaID: 5, Nombre: "Leche"}}}
+	s.mockRepo.On("GetByID", ctx, uint(5)).Return(lista, nil).Once()
+	s.mockRepo.On("UpdateItemComprado", ctx, uint(5), uint(12), true).Return(nil).Once()
+
+	item, err := s.service.MarcarItem(ctx, 9, 5, 12, true)
+
+	s.NoError(err)
+	s.True(item.Comprado)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *ListaCompraServiceTestSuite) TestMarcarItem_Fail_ItemDeOtraLista() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(5)).Return(&compras.ListaCompra{ID: 5, UserID: 9}, nil).Once()
+
+	_, err := s.service.MarcarItem(ctx, 9, 5, 99, true)
+
+	s.ErrorIs(err, compras.ErrItemNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "UpdateItemComprado", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// --- Exportación ---
+
+func (s *ListaCompraServiceTestSuite) TestExportar_MarkdownAgrupaPorPasillo() {
+	ctx := context.Background()
+	lista := &compras.ListaCompra{ID: 5, UserID: 9, Nombre: "Compra", Items: []compras.ItemLista{
+		{ID: 1, Nombre: "Leche", Pasillo: "Lácteos", Cantidad: 1.5, Unidad: "l", Comprado: true},
+		{ID: 2, Nombre: "Harina de trigo", Pasillo: "Panadería", Cantidad: 320, Unidad: "g"},
+		{ID: 3, Nombre: "Sal", Pasillo: "Panadería"},
+	}}
+	s.mockRepo.On("GetByID", ctx, uint(5)).Return(lista, nil).Once()
+
+	contenido, err := s.service.Exportar(ctx, 9, 5, compras.FormatoMarkdown)
+
+	s.NoError(err)
+	s.Equal("# Compra\n\n## Lácteos\n\n- [x] Leche: 1.5 l\n\n## Panadería\n\n- [ ] Harina de trigo: 320 g\n- [ ] Sal\n", contenido)
+}
+
+func (s *ListaCompraServiceTestSuite) TestExportar_Fail_FormatoInvalido() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(5)).Return(&compras.ListaCompra{ID: 5, UserID: 9}, nil).Once()
+
+	_, err := s.service.Exportar(ctx, 9, 5, compras.FormatoExportacion("pdf"))
+
+	s.ErrorIs(err, compras.ErrFormatoExportacionInvalido)
+}
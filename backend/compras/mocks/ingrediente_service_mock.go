@@ -0,0 +1,79 @@
+// backend/compras/mocks/ingrediente_service_mock.go
+package mocks
+
+import (
+	"backend/ingredientes" // Para la interfaz y tipos de dominio de Ingrediente
+	"backend/recetas"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// IngredienteServiceMock es una implementación mock de IngredienteService.
+type IngredienteServiceMock struct {
+	mock.Mock
+}
+
+// Verifica que IngredienteServiceMock implementa la interfaz IngredienteService.
+var _ ingredientes.IngredienteService = (*IngredienteServiceMock)(nil)
+
+func (m *IngredienteServiceMock) GetAll(ctx context.Context) ([]ingredientes.Ingrediente, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ingredientes.Ingrediente), args.Error(1)
+}
+
+func (m *IngredienteServiceMock) GetByID(ctx context.Context, id uint) (*ingredientes.Ingrediente, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ingredientes.Ingrediente), args.Error(1)
+}
+
+func (m *IngredienteServiceMock) Create(ctx context.Context, input ingredientes.IngredienteInputDTO) (*ingredientes.Ingrediente, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ingredientes.Ingrediente), args.Error(1)
+}
+
+func (m *IngredienteServiceMock) Update(ctx context.Context, id uint, input ingredientes.IngredienteInputDTO) (*ingredientes.Ingrediente, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ingredientes.Ingrediente), args.Error(1)
+}
+
+func (m *IngredienteServiceMock) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *IngredienteServiceMock) ObtenerDeReceta(ctx context.Context, recetaID uint, lector recetas.Lector) ([]ingredientes.RecetaIngrediente, error) {
+	args := m.Called(ctx, recetaID, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ingredientes.RecetaIngrediente), args.Error(1)
+}
+
+func (m *IngredienteServiceMock) ReemplazarDeReceta(ctx context.Context, recetaID uint, lector recetas.Lector, lineas []ingredientes.LineaIngredienteInput) ([]ingredientes.RecetaIngrediente, error) {
+	args := m.Called(ctx, recetaID, lector, lineas)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ingredientes.RecetaIngrediente), args.Error(1)
+}
+
+func (m *IngredienteServiceMock) ObtenerDeRecetas(ctx context.Context, recetaIDs []uint) (map[uint][]ingredientes.RecetaIngrediente, error) {
+	args := m.Called(ctx, recetaIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uint][]ingredientes.RecetaIngrediente), args.Error(1)
+}
@@ -0,0 +1,51 @@
+// backend/compras/mocks/lista_compra_repository_mock.go
+package mocks
+
+import (
+	"backend/compras" // Para los tipos de dominio y la interfaz
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ListaCompraRepositoryMock struct {
+	mock.Mock
+}
+
+// Asegurar que implementa la interfaz
+var _ compras.ListaCompraRepository = (*ListaCompraRepositoryMock)(nil)
+
+func (m *ListaCompraRepositoryMock) Create(ctx context.Context, lista *compras.ListaCompra) error {
+	args := m.Called(ctx, lista)
+	// Simular que el repo asigna ID si el Create es exitoso
+	if args.Error(0) == nil && lista != nil {
+		lista.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *ListaCompraRepositoryMock) GetByID(ctx context.Context, id uint) (*compras.ListaCompra, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*compras.ListaCompra), args.Error(1)
+}
+
+func (m *ListaCompraRepositoryMock) FindByUserID(ctx context.Context, userID uint) ([]compras.ListaCompra, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]compras.ListaCompra), args.Error(1)
+}
+
+func (m *ListaCompraRepositoryMock) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ListaCompraRepositoryMock) UpdateItemComprado(ctx context.Context, listaID, itemID uint, comprado bool) error {
+	args := m.Called(ctx, listaID, itemID, comprado)
+	return args.Error(0)
+}
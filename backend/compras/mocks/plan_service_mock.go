@@ -0,0 +1,63 @@
+// backend/compras/mocks/plan_service_mock.go
+package mocks
+
+import (
+	"backend/planificador" // Para la interfaz y tipos de dominio del Planificador
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// PlanServiceMock es una implementación mock de PlanService.
+type PlanServiceMock struct {
+	mock.Mock
+}
+
+// Verifica que PlanServiceMock implementa la interfaz PlanService.
+var _ planificador.PlanService = (*PlanServiceMock)(nil)
+
+func (m *PlanServiceMock) planOError(args mock.Arguments) (*planificador.PlanSemanal, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*planificador.PlanSemanal), args.Error(1)
+}
+
+func (m *PlanServiceMock) ListarPlanes(ctx context.Context, userID uint) ([]planificador.PlanSemanal, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]planificador.PlanSemanal), args.Error(1)
+}
+
+func (m *PlanServiceMock) ObtenerPlan(ctx context.Context, userID uint, semana time.Time) (*planificador.PlanSemanal, error) {
+	return m.planOError(m.Called(ctx, userID, semana))
+}
+
+func (m *PlanServiceMock) CrearPlan(ctx context.Context, userID uint, input planificador.CrearPlanInput) (*planificador.PlanSemanal, error) {
+	return m.planOError(m.Called(ctx, userID, input))
+}
+
+func (m *PlanServiceMock) CopiarSemanaAnterior(ctx context.Context, userID uint, semana time.Time) (*planificador.PlanSemanal, error) {
+	return m.planOError(m.Called(ctx, userID, semana))
+}
+
+func (m *PlanServiceMock) AsignarComida(ctx context.Context, userID uint, semana time.Time, input planificador.ComidaInput) (*planificador.PlanSemanal, error) {
+	return m.planOError(m.Called(ctx, userID, semana, input))
+}
+
+func (m *PlanServiceMock) QuitarComida(ctx context.Context, userID uint, semana time.Time, dia int, momento planificador.MomentoComida) error {
+	args := m.Called(ctx, userID, semana, dia, momento)
+	return args.Error(0)
+}
+
+func (m *PlanServiceMock) IntercambiarComidas(ctx context.Context, userID uint, semana time.Time, input planificador.IntercambioInput) (*planificador.PlanSemanal, error) {
+	return m.planOError(m.Called(ctx, userID, semana, input))
+}
+
+func (m *PlanServiceMock) EliminarPlan(ctx context.Context, userID uint, semana time.Time) error {
+	args := m.Called(ctx, userID, semana)
+	return args.Error(0)
+}
@@ -26,4 +26,5 @@ type ContactoListItemDTO struct {
 	Asunto        string `json:"asunto"`
 	FechaContacto string `json:"fecha_contacto"` // Formateada
 	Leido         bool   `json:"leido"`
+	EliminadoEn   *string `json:"eliminado_en,omitempty"` // Solo en la papelera
 }
\ No newline at end of file
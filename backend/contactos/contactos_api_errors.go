@@ -0,0 +1,21 @@
+// backend/contactos/contactos_api_errors.go
+
+// Traducción de los errores de dominio de contactos a respuestas HTTP (ver shared/apperrors).
+
+package contactos
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrContactoNotFound, http.StatusNotFound, "contacto_no_encontrado")
+
+	apperrors.Registrar(ErrContactoInvalido, http.StatusBadRequest, "contacto_invalido")
+	apperrors.Registrar(ErrNombreRemitenteVacio, http.StatusBadRequest, "contacto_nombre_vacio")
+	apperrors.Registrar(ErrEmailRemitenteInvalido, http.StatusBadRequest, "contacto_email_invalido")
+	apperrors.Registrar(ErrMensajeVacio, http.StatusBadRequest, "contacto_mensaje_vacio")
+	apperrors.Registrar(ErrAsuntoDemasiadoLargo, http.StatusBadRequest, "contacto_asunto_demasiado_largo")
+}
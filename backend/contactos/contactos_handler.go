@@ -12,6 +12,27 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// mapContactosToListItemDTOs convierte los mensajes al DTO del listado de admin.
+func mapContactosToListItemDTOs(domainForms []ContactoForm) []ContactoListItemDTO {
+	responseDTOs := make([]ContactoListItemDTO, 0, len(domainForms))
+	for _, df := range domainForms {
+		dto := ContactoListItemDTO{
+			ID:            df.ID,
+			NombreRemitente: df.NombreRemitente,
+			EmailRemitente: df.EmailRemitente,
+			Asunto:        df.Asunto,
+			FechaContacto: df.FechaContacto.Format("2006-01-02 15:04"),
+			Leido:         df.Leido,
+		}
+		if df.EliminadoEn != nil {
+			eliminadoEn := df.EliminadoEn.Format("2006-01-02 15:04")
+			dto.EliminadoEn = &eliminadoEn
+		}
+		responseDTOs = append(responseDTOs, dto)
+	}
+	return responseDTOs
+}
+
 // ContactoHandler maneja las peticiones HTTP para Contactos.
 type ContactoHandler struct {
 	service ContactoService
@@ -96,18 +117,7 @@ func (h *ContactoHandler) GetAllContactos(c *gin.Context) {
 		return
 	}
 
-	responseDTOs := make([]ContactoListItemDTO, 0, len(domainForms))
-	for _, df := range domainForms {
-		responseDTOs = append(responseDTOs, ContactoListItemDTO{
-			ID:            df.ID,
-			NombreRemitente: df.NombreRemitente,
-			EmailRemitente: df.EmailRemitente,
-			Asunto:        df.Asunto,
-			FechaContacto: df.FechaContacto.Format("2006-01-02 15:04"),
-			Leido:         df.Leido,
-		})
-	}
-	c.JSON(http.StatusOK, responseDTOs)
+	c.JSON(http.StatusOK, mapContactosToListItemDTOs(domainForms))
 }
 
 // MarcarComoLeido godoc
@@ -132,8 +142,103 @@ func (h *ContactoHandler) MarcarComoLeido(c *gin.Context) {
 	id := uint(idUint64)
 
 	if err := h.service.MarcarContactoComoLeido(c.Request.Context(), id); err != nil {
-		_ = c.Error(err) // El middleware manejará ErrContactoInvalido (como 404)
+		_ = c.Error(err) // El middleware manejará ErrContactoNotFound (404)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"mensaje": fmt.Sprintf("Mensaje ID %d marcado como leído.", id)})
-}
\ No newline at end of file
+}
+
+// parseContactoID obtiene el :id de la URL.
+func parseContactoID(c *gin.Context) (uint, error) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parámetro ID de contacto inválido: %s - %w", idStr, err)
+	}
+	return uint(idUint64), nil
+}
+
+// EliminarContacto godoc
+// @Summary (Admin) Elimina un mensaje
+// @Description (Admin) Envía el mensaje a la papelera; se puede restaurar hasta que venza la retención.
+// @Tags Contactos_Admin
+// @Param id path uint true "ID del Mensaje de Contacto"
+// @Success 204 "Mensaje en la papelera"
+// @Failure 404 {object} apitypes.ErrorResponse "Mensaje no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/contactos/{id} [delete]
+// @Security ApiKeyAuth
+func (h *ContactoHandler) EliminarContacto(c *gin.Context) {
+	id, err := parseContactoID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.EliminarContacto(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetPapelera godoc
+// @Summary (Admin) Lista los mensajes de la papelera
+// @Tags Contactos_Admin
+// @Produce json
+// @Success 200 {array} ContactoListItemDTO "Mensajes eliminados (con eliminado_en)"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/contactos/papelera [get]
+// @Security ApiKeyAuth
+func (h *ContactoHandler) GetPapelera(c *gin.Context) {
+	domainForms, err := h.service.ListarPapelera(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapContactosToListItemDTOs(domainForms))
+}
+
+// RestaurarDePapelera godoc
+// @Summary (Admin) Restaura un mensaje de la papelera
+// @Tags Contactos_Admin
+// @Produce json
+// @Param id path uint true "ID del Mensaje de Contacto"
+// @Success 200 {object} gin.H "Mensaje restaurado"
+// @Failure 404 {object} apitypes.ErrorResponse "El mensaje no está en la papelera"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/contactos/papelera/{id}/restaurar [post]
+// @Security ApiKeyAuth
+func (h *ContactoHandler) RestaurarDePapelera(c *gin.Context) {
+	id, err := parseContactoID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.RestaurarDePapelera(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"mensaje": fmt.Sprintf("Mensaje ID %d restaurado.", id)})
+}
+
+// PurgarDePapelera godoc
+// @Summary (Admin) Borra definitivamente un mensaje de la papelera
+// @Tags Contactos_Admin
+// @Param id path uint true "ID del Mensaje de Contacto"
+// @Success 204 "Mensaje purgado"
+// @Failure 404 {object} apitypes.ErrorResponse "El mensaje no está en la papelera"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/contactos/papelera/{id} [delete]
+// @Security ApiKeyAuth
+func (h *ContactoHandler) PurgarDePapelera(c *gin.Context) {
+	id, err := parseContactoID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.PurgarDePapelera(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -37,25 +37,27 @@ import (
 // ContactoForm representa la información enviada a través del formulario de contacto.
 // Es la entidad pura de negocio.
 type ContactoForm struct {
-	ID                uint      // Identificador único del registro de contacto
-	UserID            *uint     // ID del usuario registrado que envía (opcional, puede ser nil)
-	NombreRemitente   string    // Nombre proporcionado por el remitente
-	EmailRemitente    string    // Email proporcionado por el remitente
-	TelefonoRemitente string    // Teléfono proporcionado por el remitente (opcional)
-	Asunto            string    // Asunto del mensaje (opcional)
-	Mensaje           string    // Cuerpo del mensaje
-	Leido             bool      // Indica si el mensaje ha sido leído por un admin
-	FechaContacto     time.Time // Fecha y hora en que el usuario envió el formulario
-	IPOrigen          string    // IP del remitente (para auditoría, considerar privacidad)
-	UserAgent         string    // User-Agent del navegador del remitente (para auditoría)
-	CreatedAt         time.Time // Timestamp de creación del registro en BD
-	UpdatedAt         time.Time // Timestamp de última actualización del registro en BD
+	ID                uint       // Identificador único del registro de contacto
+	UserID            *uint      // ID del usuario registrado que envía (opcional, puede ser nil)
+	NombreRemitente   string     // Nombre proporcionado por el remitente
+	EmailRemitente    string     // Email proporcionado por el remitente
+	TelefonoRemitente string     // Teléfono proporcionado por el remitente (opcional)
+	Asunto            string     // Asunto del mensaje (opcional)
+	Mensaje           string     // Cuerpo del mensaje
+	Leido             bool       // Indica si el mensaje ha sido leído por un admin
+	FechaContacto     time.Time  // Fecha y hora en que el usuario envió el formulario
+	IPOrigen          string     // IP del remitente (para auditoría, considerar privacidad)
+	UserAgent         string     // User-Agent del navegador del remitente (para auditoría)
+	CreatedAt         time.Time  // Timestamp de creación del registro en BD
+	UpdatedAt         time.Time  // Timestamp de última actualización del registro en BD
+	EliminadoEn       *time.Time // Solo en la papelera: cuándo se eliminó
 	// User           *users.User // Para cargar el objeto User completo (cuando exista el paquete 'users')
 }
 
 // Errores específicos del dominio de Contacto.
 var (
 	ErrContactoInvalido       = errors.New("los datos del formulario de contacto son inválidos")
+	ErrContactoNotFound       = errors.New("mensaje de contacto no encontrado")
 	ErrNombreRemitenteVacio   = errors.New("el nombre del remitente es requerido")
 	ErrEmailRemitenteInvalido = errors.New("el email del remitente es inválido o requerido")
 	ErrMensajeVacio           = errors.New("el mensaje es requerido")
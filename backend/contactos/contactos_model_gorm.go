@@ -34,12 +34,17 @@ func (ContactoModel) TableName() string {
 
 func (m *ContactoModel) ToDomain() *ContactoForm {
 	if m == nil { return nil }
-	return &ContactoForm{
+	contacto := &ContactoForm{
 		ID: m.ID, UserID: m.UserID, NombreRemitente: m.NombreRemitente, EmailRemitente: m.EmailRemitente,
 		TelefonoRemitente: m.TelefonoRemitente, Asunto: m.Asunto, Mensaje: m.Mensaje, Leido: m.Leido,
 		FechaContacto: m.FechaContacto, IPOrigen: m.IPOrigen, UserAgent: m.UserAgent,
 		CreatedAt: m.CreatedAt, UpdatedAt: m.UpdatedAt,
 	}
+	if m.DeletedAt.Valid { // Solo al consultar la papelera (Unscoped)
+		eliminadoEn := m.DeletedAt.Time
+		contacto.EliminadoEn = &eliminadoEn
+	}
+	return contacto
 }
 
 func FromContactoFormDomain(d *ContactoForm) *ContactoModel {
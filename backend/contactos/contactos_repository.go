@@ -6,6 +6,9 @@ package contactos
 import (
 	"context"
 	"errors" // Para errores comunes de repositorio
+	"time"
+
+	"backend/correos" // Los avisos se encolan en la misma transacción
 )
 
 // Errores específicos o comunes para el repositorio de contactos.
@@ -18,7 +21,9 @@ var (
 type ContactoRepository interface {
 	// Create guarda un nuevo mensaje de contacto en la base de datos.
 	// Modifica el puntero 'contacto' para incluir el ID generado.
-	Create(ctx context.Context, contacto *ContactoForm) error
+	// Si 'aviso' no es nil, encola en la misma transacción el correo que devuelve para el
+	// contacto ya guardado (con ID): se guardan los dos o ninguno (también si 'aviso' falla).
+	Create(ctx context.Context, contacto *ContactoForm, aviso func(*ContactoForm) (*correos.Correo, error)) error
 
 	// GetByID recupera un mensaje de contacto por su ID.
 	GetByID(ctx context.Context, id uint) (*ContactoForm, error)
@@ -29,6 +34,12 @@ type ContactoRepository interface {
 	// MarkAsRead marca un mensaje como leído.
 	MarkAsRead(ctx context.Context, id uint) error
 
-	// (Opcional) Delete para eliminar mensajes de contacto (considerar soft delete).
-	// Delete(ctx context.Context, id uint) error
+	// Delete elimina un mensaje (soft delete: pasa a la papelera).
+	Delete(ctx context.Context, id uint) error
+
+	// --- Papelera (registros con soft delete) ---
+	FindEliminados(ctx context.Context) ([]ContactoForm, error) // Eliminados más recientemente primero
+	Restaurar(ctx context.Context, id uint) error               // ErrContactoRepoNotFound si no está en la papelera
+	Purgar(ctx context.Context, id uint) error                  // Borrado definitivo de un mensaje de la papelera
+	PurgarEliminadosAntesDe(ctx context.Context, limite time.Time) (int64, error)
 }
\ No newline at end of file
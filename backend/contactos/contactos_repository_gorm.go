@@ -8,6 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"time"
+
+	"backend/correos"
+	"backend/shared/repository"
+
 	"gorm.io/gorm"
 )
 
@@ -20,10 +24,25 @@ func NewContactoRepository(db *gorm.DB) ContactoRepository {
 	return &gormContactoRepository{db: db}
 }
 
-func (r *gormContactoRepository) Create(ctx context.Context, contacto *ContactoForm) error {
+func (r *gormContactoRepository) Create(ctx context.Context, contacto *ContactoForm, aviso func(*ContactoForm) (*correos.Correo, error)) error {
 	model := FromContactoFormDomain(contacto)
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
-		return fmt.Errorf("repo gorm contactos: error creando contacto: %w", err)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return fmt.Errorf("repo gorm contactos: error creando contacto: %w", repository.TraducirError(err))
+		}
+		if aviso == nil {
+			return nil
+		}
+		guardado := *contacto
+		guardado.ID, guardado.CreatedAt, guardado.UpdatedAt = model.ID, model.CreatedAt, model.UpdatedAt
+		correo, err := aviso(&guardado)
+		if err != nil {
+			return err
+		}
+		return correos.Encolar(tx, correo)
+	})
+	if err != nil {
+		return err
 	}
 	// Actualizar el objeto de dominio con el ID generado y timestamps
 	contacto.ID = model.ID
@@ -59,10 +78,62 @@ func (r *gormContactoRepository) MarkAsRead(ctx context.Context, id uint) error
 		"updated_at": time.Now(), // Forzar actualización de UpdatedAt
 	})
 	if result.Error != nil {
-		return fmt.Errorf("repo gorm contactos: error marcando como leído id %d: %w", id, result.Error)
+		return fmt.Errorf("repo gorm contactos: error marcando como leído id %d: %w", id, repository.TraducirError(result.Error))
 	}
 	if result.RowsAffected == 0 {
 		return ErrContactoRepoNotFound // No se encontró para actualizar
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+func (r *gormContactoRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&ContactoModel{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm contactos: error eliminando id %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return ErrContactoRepoNotFound
+	}
+	return nil
+}
+
+// --- Papelera ---
+
+func (r *gormContactoRepository) FindEliminados(ctx context.Context) ([]ContactoForm, error) {
+	var models []ContactoModel
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm contactos: error obteniendo eliminados: %w", err)
+	}
+	return ContactoModelsToDomains(models), nil
+}
+
+func (r *gormContactoRepository) Restaurar(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&ContactoModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm contactos: error restaurando id %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return ErrContactoRepoNotFound
+	}
+	return nil
+}
+
+func (r *gormContactoRepository) Purgar(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(&ContactoModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm contactos: error purgando id %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return ErrContactoRepoNotFound
+	}
+	return nil
+}
+
+func (r *gormContactoRepository) PurgarEliminadosAntesDe(ctx context.Context, limite time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", limite).Delete(&ContactoModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("repo gorm contactos: error purgando vencidos: %w", repository.TraducirError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"backend/contactos" // El paquete bajo test
+	"backend/correos"   // Bandeja de salida (los avisos se guardan con el contacto)
 	"backend/shared/config"
 	"backend/shared/database"
+	"backend/shared/notifications"
 	// "backend/users" // Si tuvieras que crear un UserModel para la FK en ContactoModel
 
 //"github.com/stretchr/testify/require"
@@ -50,7 +52,7 @@ func (s *ContactoRepositoryIntegrationTestSuite) SetupSuite() {
 	s.T().Log("SetupSuite: Ejecutando AutoMigrate para ContactoModel...")
 	// Si ContactoModel tuviera FK a UserModel, también necesitarías migrar UserModel:
 	// err = s.db.AutoMigrate(&users.UserModel{}, &contactos.ContactoModel{})
-	err = s.db.AutoMigrate(&contactos.ContactoModel{}) // Usa el Modelo GORM del paquete 'contactos'
+	err = s.db.AutoMigrate(&contactos.ContactoModel{}, &correos.CorreoModel{}) // Usa el Modelo GORM del paquete 'contactos' (y la bandeja de salida)
 	s.Require().NoError(err, "SetupSuite: Falló AutoMigrate para ContactoModel")
 	s.T().Log("SetupSuite: Tabla 'contactos' asegurada/creada vía AutoMigrate.")
 
@@ -72,11 +74,12 @@ func (s *ContactoRepositoryIntegrationTestSuite) SetupTest() {
 	s.T().Logf("--- Iniciando SetupTest para [%s] ---", s.T().Name())
 	s.Require().NotNil(s.db, "SetupTest: s.db no debe ser nil")
 
-	tableName := contactos.ContactoModel{}.TableName()
-	s.T().Logf("SetupTest: Limpiando tabla '%s'...", tableName)
-	err := s.db.Exec(fmt.Sprintf("TRUNCATE TABLE `%s`", tableName)).Error
-	s.Require().NoError(err, "SetupTest: Falló TRUNCATE TABLE para %s", tableName)
-	s.T().Logf("SetupTest: Tabla '%s' truncada.", tableName)
+	for _, tableName := range []string{contactos.ContactoModel{}.TableName(), correos.CorreoModel{}.TableName()} {
+		s.T().Logf("SetupTest: Limpiando tabla '%s'...", tableName)
+		err := s.db.Exec(fmt.Sprintf("TRUNCATE TABLE `%s`", tableName)).Error
+		s.Require().NoError(err, "SetupTest: Falló TRUNCATE TABLE para %s", tableName)
+		s.T().Logf("SetupTest: Tabla '%s' truncada.", tableName)
+	}
 }
 
 func TestContactoRepositoryIntegrationTestSuite(t *testing.T) {
@@ -107,7 +110,7 @@ func (s *ContactoRepositoryIntegrationTestSuite) TestCreateAndGetByID_Success()
 	}
 
 	// ACT: Create
-	err := s.repo.Create(ctx, contactoACrear)
+	err := s.repo.Create(ctx, contactoACrear, nil)
 	// ASSERT: Create
 	s.Require().NoError(err, "Create contacto debe ser exitoso")
 	s.Require().NotZero(contactoACrear.ID, "Create debe asignar ID al objeto dominio original")
@@ -142,6 +145,33 @@ func (s *ContactoRepositoryIntegrationTestSuite) TestCreateAndGetByID_Success()
 	// }
 }
 
+func (s *ContactoRepositoryIntegrationTestSuite) TestCreate_EncolaElAvisoEnLaMismaTransaccion() {
+	ctx := context.Background()
+	form := &contactos.ContactoForm{NombreRemitente: "Eva", EmailRemitente: "eva@test.com", Mensaje: "Con aviso", FechaContacto: time.Now()}
+	aviso := func(c *contactos.ContactoForm) (*correos.Correo, error) {
+		return correos.Nuevo(contactos.EntidadCorreos, c.ID, notifications.EmailData{To: []string{"admin@test.com"}, Subject: "Nuevo", Body: "..."}), nil
+	}
+
+	s.Require().NoError(s.repo.Create(ctx, form, aviso))
+
+	var encolados []correos.CorreoModel
+	s.Require().NoError(s.db.Find(&encolados).Error)
+	s.Require().Len(encolados, 1)
+	s.Equal(form.ID, encolados[0].OrigenID, "El aviso se genera con el ID ya asignado")
+	s.Equal(correos.EstadoPendiente, encolados[0].Estado)
+
+	// Si el aviso no se puede guardar, el contacto tampoco
+	avisoDuplicado := func(c *contactos.ContactoForm) (*correos.Correo, error) {
+		correo, err := aviso(c)
+		correo.ID = encolados[0].ID // Clave primaria repetida
+		return correo, err
+	}
+	s.Error(s.repo.Create(ctx, &contactos.ContactoForm{NombreRemitente: "Ana", EmailRemitente: "ana@test.com", Mensaje: "Sin aviso", FechaContacto: time.Now()}, avisoDuplicado))
+	todos, err := s.repo.GetAll(ctx)
+	s.Require().NoError(err)
+	s.Len(todos, 1)
+}
+
 func (s *ContactoRepositoryIntegrationTestSuite) TestGetByID_NotFound() {
 	ctx := context.Background()
 	idInexistente := uint(77777)
@@ -165,8 +195,8 @@ func (s *ContactoRepositoryIntegrationTestSuite) TestGetAll_EmptyAndWithData() {
 	// --- Insertar datos ---
 	form1 := &contactos.ContactoForm{NombreRemitente: "Ana", EmailRemitente: "ana@test.com", Mensaje: "Msg1", FechaContacto: time.Now().Add(-1 * time.Hour)}
 	form2 := &contactos.ContactoForm{NombreRemitente: "Luis", EmailRemitente: "luis@test.com", Mensaje: "Msg2", FechaContacto: time.Now()}
-	s.Require().NoError(s.repo.Create(ctx, form1))
-	s.Require().NoError(s.repo.Create(ctx, form2))
+	s.Require().NoError(s.repo.Create(ctx, form1, nil))
+	s.Require().NoError(s.repo.Create(ctx, form2, nil))
 
 	// --- Test GetAll con datos ---
 	contactosConDatos, err := s.repo.GetAll(ctx)
@@ -182,7 +212,7 @@ func (s *ContactoRepositoryIntegrationTestSuite) TestGetAll_EmptyAndWithData() {
 func (s *ContactoRepositoryIntegrationTestSuite) TestMarkAsRead_SuccessAndNotFound() {
 	ctx := context.Background()
 	form := &contactos.ContactoForm{NombreRemitente: "Pedro", EmailRemitente: "pedro@test.com", Mensaje: "Para leer", FechaContacto: time.Now(), Leido: false}
-	s.Require().NoError(s.repo.Create(ctx, form))
+	s.Require().NoError(s.repo.Create(ctx, form, nil))
 	idCreado := form.ID
 
 	// --- Test MarkAsRead Success ---
@@ -7,24 +7,25 @@ import (
 )
 
 // RegisterRoutes registra las rutas para la funcionalidad de Contactos.
-func RegisterContactoRoutes(apiBaseGroup *gin.RouterGroup, h *ContactoHandler /*, authMiddleware gin.HandlerFunc */) {
+// requireAdmin protege las rutas de administración (/admin/contactos).
+func RegisterContactoRoutes(apiBaseGroup *gin.RouterGroup, h *ContactoHandler, requireAdmin gin.HandlerFunc) {
 	// Rutas públicas para enviar mensajes de contacto
 	contactosPublicRoutes := apiBaseGroup.Group("/contactos")
 	{
 		contactosPublicRoutes.POST("", h.EnviarMensaje)
 	}
 
-	// Rutas para administración de contactos (requerirían autenticación de admin)
-	// Ejemplo: Asumiendo que el middleware de autenticación de admin se aplica a 'adminApiGroup' en main.go
-	// o se pasa aquí como 'authMiddleware'.
-	contactosAdminRoutes := apiBaseGroup.Group("/admin/contactos")
-	// Ejemplo de cómo aplicar un middleware específico aquí si se pasara:
-	// if authMiddleware != nil {
-	//  contactosAdminRoutes.Use(authMiddleware)
-	// }
+	// Rutas para administración de contactos (solo admins)
+	contactosAdminRoutes := apiBaseGroup.Group("/admin/contactos", requireAdmin)
 	{
-		contactosAdminRoutes.GET("", h.GetAllContactos)          // Necesita Auth Admin
-		contactosAdminRoutes.PATCH("/:id/leido", h.MarcarComoLeido) // Necesita Auth Admin
+		contactosAdminRoutes.GET("", h.GetAllContactos)
+		contactosAdminRoutes.PATCH("/:id/leido", h.MarcarComoLeido)
+		contactosAdminRoutes.DELETE("/:id", h.EliminarContacto)
+
+		// Papelera
+		contactosAdminRoutes.GET("/papelera", h.GetPapelera)
+		contactosAdminRoutes.POST("/papelera/:id/restaurar", h.RestaurarDePapelera)
+		contactosAdminRoutes.DELETE("/papelera/:id", h.PurgarDePapelera)
 	}
 
 	log.Println("🛣️  Rutas de Contactos configuradas.")
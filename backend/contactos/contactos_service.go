@@ -7,36 +7,53 @@ import (
 	"context"
 	"fmt"
 	"log" // Temporal
+	"net/mail" // Reply-To del aviso
 	"strings"
 	"time"
 	"errors"
-	"backend/shared/notifications" // Para la interfaz EmailNotifier
+	"backend/correos"              // Bandeja de salida de los avisos por email
+	"backend/shared/i18n"          // Idioma de los avisos
+	"backend/shared/notifications" // Plantillas de los avisos
 )
 
+// EntidadCorreos es el origen de los correos de aviso en la bandeja de salida.
+const EntidadCorreos = "contactos"
+
 // ContactoService define el contrato para la lógica de negocio de Contactos.
 type ContactoService interface {
 	ProcesarNuevoContacto(ctx context.Context, input EnviarContactoInput) (*ContactoForm, error)
 	ObtenerTodosLosContactos(ctx context.Context) ([]ContactoForm, error)
 	ObtenerContactoPorID(ctx context.Context, id uint) (*ContactoForm, error)
 	MarcarContactoComoLeido(ctx context.Context, id uint) error
+	EliminarContacto(ctx context.Context, id uint) error // Lo manda a la papelera
+
+	// --- Papelera ---
+	ListarPapelera(ctx context.Context) ([]ContactoForm, error)
+	RestaurarDePapelera(ctx context.Context, id uint) error
+	PurgarDePapelera(ctx context.Context, id uint) error // Borrado definitivo
+	// PurgarVencidas borra definitivamente los eliminados antes de 'antesDe' (ver papelera.Purgador).
+	PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error)
 }
 
 type contactoService struct {
-	repo     ContactoRepository          // Repositorio de contactos de este paquete
-	notifier notifications.EmailNotifier // Notificador de email compartido
-	adminEmail string                    // Email del admin a notificar (de config)
-    fromEmail  string                    // Email "De" para notificaciones (de config)
+	repo        ContactoRepository       // Repositorio de contactos de este paquete
+	plantillas  notifications.Plantillas // Plantillas de los correos de aviso
+	idiomaAdmin i18n.Idioma              // Idioma de los avisos al admin (de config)
+	adminEmail  string                   // Email del admin a notificar (de config)
+    fromEmail   string                   // Email "De" para notificaciones (de config)
 }
 
 // NewContactoService crea una nueva instancia de ContactoService.
-// Recibe el repositorio, el notificador y los emails relevantes de la configuración.
+// Recibe el repositorio, las plantillas de correo y el idioma y los emails relevantes de la
+// configuración. Los avisos no se envían aquí: se encolan en la bandeja de salida (ver correos.Despachador).
 func NewContactoService(
 	r ContactoRepository,
-	n notifications.EmailNotifier,
-	adminEmail string, // Email del admin para recibir notificaciones
-    fromEmail string,  // Email 'From' para los correos de notificación
+	p notifications.Plantillas,
+	idiomaAdmin i18n.Idioma, // Idioma de los correos al admin
+	adminEmail string,       // Email del admin para recibir notificaciones
+    fromEmail string,        // Email 'From' para los correos de notificación
 ) ContactoService {
-	return &contactoService{repo: r, notifier: n, adminEmail: adminEmail, fromEmail: fromEmail}
+	return &contactoService{repo: r, plantillas: p, idiomaAdmin: idiomaAdmin, adminEmail: adminEmail, fromEmail: fromEmail}
 }
 
 func (s *contactoService) ProcesarNuevoContacto(ctx context.Context, input EnviarContactoInput) (*ContactoForm, error) {
@@ -68,53 +85,35 @@ func (s *contactoService) ProcesarNuevoContacto(ctx context.Context, input Envia
 		UserAgent:         input.UserAgent,
 	}
 
-	// Guardar en la base de datos
-	if err := s.repo.Create(ctx, contacto); err != nil {
+	// Guardar en la base de datos, junto con el aviso al admin (en la misma transacción):
+	// el correo se envía en segundo plano (ver correos.Despachador) y se reintenta si falla.
+	if err := s.repo.Create(ctx, contacto, s.avisoAdmin); err != nil {
 		return nil, fmt.Errorf("servicio contactos: error guardando contacto: %w", err)
 	}
-	log.Printf("Servicio: Contacto de '%s' guardado con ID: %d\n", contacto.EmailRemitente, contacto.ID)
-
-	// Enviar notificación por email
-	emailSubject := fmt.Sprintf("Nuevo Mensaje de Contacto: %s", contacto.Asunto)
-	if contacto.Asunto == "" {
-		emailSubject = "Nuevo Mensaje de Contacto Recibido"
-	}
-	emailBody := fmt.Sprintf(
-		"Has recibido un nuevo mensaje de contacto:\n\n"+
-			"Nombre: %s\n"+
-			"Email: %s\n"+
-			"Teléfono: %s\n"+
-			"Asunto: %s\n"+
-			"Fecha: %s\n"+
-			"IP: %s\n"+
-			"User Agent: %s\n\n"+
-			"Mensaje:\n%s\n\n"+
-			"ID del Mensaje: %d",
-		contacto.NombreRemitente, contacto.EmailRemitente, contacto.TelefonoRemitente,
-		contacto.Asunto, contacto.FechaContacto.Format("2006-01-02 15:04:05"),
-		contacto.IPOrigen, contacto.UserAgent, contacto.Mensaje, contacto.ID,
-	)
-
-	emailData := notifications.EmailData{
-		To:      []string{s.adminEmail}, // Email del admin desde config
-		From:    s.fromEmail,          // Email "De" desde config
-		Subject: emailSubject,
-		Body:    emailBody,
-		IsHTML:  false, // Por ahora, texto plano
-	}
-
-	if err := s.notifier.SendEmail(ctx, emailData); err != nil {
-		// Loguear el error de email pero no hacer fallar la operación principal de guardar contacto.
-		// El mensaje ya se guardó en la BD.
-		log.Printf("ALERTA: Mensaje de contacto (ID: %d) guardado, PERO falló el envío de email de notificación: %v\n", contacto.ID, err)
-		// Podrías tener un sistema de reintentos para emails o una cola aquí.
-	} else {
-		log.Printf("Servicio: Email de notificación para contacto ID %d enviado a %s\n", contacto.ID, s.adminEmail)
-	}
+	log.Printf("Servicio: Contacto de '%s' guardado con ID: %d (aviso a %s en cola)\n", contacto.EmailRemitente, contacto.ID, s.adminEmail)
 
 	return contacto, nil // Devolver el contacto guardado (con ID y timestamps)
 }
 
+// PlantillaAviso es la plantilla del correo que avisa al admin de un mensaje nuevo
+// (ver shared/notifications/plantillas); recibe el ContactoForm guardado.
+const PlantillaAviso = "contacto_nuevo"
+
+// avisoAdmin es el correo que avisa al admin de un mensaje nuevo (ya guardado, con ID).
+func (s *contactoService) avisoAdmin(contacto *ContactoForm) (*correos.Correo, error) {
+	aviso, err := s.plantillas.Renderizar(PlantillaAviso, s.idiomaAdmin, contacto)
+	if err != nil {
+		return nil, fmt.Errorf("servicio contactos: error generando el aviso: %w", err)
+	}
+	// Email del admin y "De" desde config
+	datos := aviso.EmailData([]string{s.adminEmail}, s.fromEmail)
+	// Responder al aviso es responder a quien escribió (si su email es una dirección válida)
+	if _, err := mail.ParseAddress(contacto.EmailRemitente); err == nil {
+		datos.ReplyTo = (&mail.Address{Name: contacto.NombreRemitente, Address: contacto.EmailRemitente}).String()
+	}
+	return correos.Nuevo(EntidadCorreos, contacto.ID, datos), nil
+}
+
 func (s *contactoService) ObtenerTodosLosContactos(ctx context.Context) ([]ContactoForm, error) {
 	contactos, err := s.repo.GetAll(ctx)
 	if err != nil {
@@ -127,7 +126,7 @@ func (s *contactoService) ObtenerContactoPorID(ctx context.Context, id uint) (*C
 	contacto, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, ErrContactoRepoNotFound) {
-			return nil, ErrContactoInvalido // O un ErrContactoNoEncontrado específico
+			return nil, ErrContactoNotFound
 		}
 		return nil, fmt.Errorf("servicio contactos: error obteniendo por id %d: %w", id, err)
 	}
@@ -138,10 +137,61 @@ func (s *contactoService) MarcarContactoComoLeido(ctx context.Context, id uint)
 	err := s.repo.MarkAsRead(ctx, id)
 	if err != nil {
 		if errors.Is(err, ErrContactoRepoNotFound) {
-			return ErrContactoInvalido // O un ErrContactoNoEncontrado específico
+			return ErrContactoNotFound
 		}
 		return fmt.Errorf("servicio contactos: error marcando como leído id %d: %w", id, err)
 	}
 	log.Printf("Servicio: Contacto ID %d marcado como leído.\n", id)
 	return nil
-}
\ No newline at end of file
+}
+
+func (s *contactoService) EliminarContacto(ctx context.Context, id uint) error {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, ErrContactoRepoNotFound) {
+			return ErrContactoNotFound
+		}
+		return fmt.Errorf("servicio contactos: error eliminando id %d: %w", id, err)
+	}
+	log.Printf("Servicio: Contacto ID %d enviado a la papelera.\n", id)
+	return nil
+}
+
+// --- Papelera ---
+
+func (s *contactoService) ListarPapelera(ctx context.Context) ([]ContactoForm, error) {
+	contactos, err := s.repo.FindEliminados(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("servicio contactos: error obteniendo papelera: %w", err)
+	}
+	return contactos, nil
+}
+
+func (s *contactoService) RestaurarDePapelera(ctx context.Context, id uint) error {
+	if err := s.repo.Restaurar(ctx, id); err != nil {
+		if errors.Is(err, ErrContactoRepoNotFound) {
+			return ErrContactoNotFound
+		}
+		return fmt.Errorf("servicio contactos: error restaurando id %d: %w", id, err)
+	}
+	log.Printf("Servicio: Contacto ID %d restaurado de la papelera.\n", id)
+	return nil
+}
+
+func (s *contactoService) PurgarDePapelera(ctx context.Context, id uint) error {
+	if err := s.repo.Purgar(ctx, id); err != nil {
+		if errors.Is(err, ErrContactoRepoNotFound) {
+			return ErrContactoNotFound
+		}
+		return fmt.Errorf("servicio contactos: error purgando id %d: %w", id, err)
+	}
+	log.Printf("Servicio: Contacto ID %d purgado definitivamente.\n", id)
+	return nil
+}
+
+func (s *contactoService) PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error) {
+	n, err := s.repo.PurgarEliminadosAntesDe(ctx, antesDe)
+	if err != nil {
+		return 0, fmt.Errorf("servicio contactos: error purgando vencidos: %w", err)
+	}
+	return n, nil
+}
@@ -4,13 +4,16 @@ package contactos_test // Usar paquete _test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"backend/contactos" // El paquete bajo test
 	contactosMocks "backend/contactos/mocks" // Mocks del paquete contactos
-	notificationsMocks "backend/shared/notifications/mocks" // Mocks del paquete shared/notifications
-	"backend/shared/notifications" // Para el tipo notifications.EmailData
+	"backend/correos"                        // Para el aviso encolado
+	"backend/shared/i18n"                    // Idioma de los avisos
+	"backend/shared/notifications"           // Plantillas reales de los avisos
 
 	//"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -20,7 +23,6 @@ import (
 type ContactoServiceTestSuite struct {
 	suite.Suite
 	mockContactoRepo *contactosMocks.ContactoRepositoryMock
-	mockEmailNotifier *notificationsMocks.EmailNotifierMock
 	service           contactos.ContactoService
 	adminEmail        string
 	fromEmail         string
@@ -28,10 +30,9 @@ type ContactoServiceTestSuite struct {
 
 func (s *ContactoServiceTestSuite) SetupTest() {
 	s.mockContactoRepo = new(contactosMocks.ContactoRepositoryMock)
-	s.mockEmailNotifier = new(notificationsMocks.EmailNotifierMock)
 	s.adminEmail = "admin@test.com"
 	s.fromEmail = "noreply@test.com"
-	s.service = contactos.NewContactoService(s.mockContactoRepo, s.mockEmailNotifier, s.adminEmail, s.fromEmail)
+	s.service = contactos.NewContactoService(s.mockContactoRepo, notifications.NewPlantillas(""), i18n.ES, s.adminEmail, s.fromEmail)
 }
 
 func TestContactoServiceTestSuite(t *testing.T) {
@@ -49,26 +50,19 @@ func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_Success() {
 		UserAgent: "Go Test",
 	}
 
-	// Arrange: Mockear ContactoRepository.Create
-	s.mockContactoRepo.On("Create", ctx, mock.AnythingOfType("*contactos.ContactoForm")).Run(func(args mock.Arguments) {
+	// Arrange: Mockear ContactoRepository.Create, que encola el aviso en la misma transacción
+	var aviso *correos.Correo
+	s.mockContactoRepo.On("Create", ctx, mock.AnythingOfType("*contactos.ContactoForm"), mock.Anything).Run(func(args mock.Arguments) {
 		arg := args.Get(1).(*contactos.ContactoForm)
 		arg.ID = 1 // Simular que el repo asigna ID
 		arg.CreatedAt = time.Now()
 		arg.UpdatedAt = time.Now()
 		arg.FechaContacto = arg.CreatedAt // Asumir que se setea igual que CreatedAt en el servicio
+		var err error
+		aviso, err = args.Get(2).(func(*contactos.ContactoForm) (*correos.Correo, error))(arg)
+		s.Require().NoError(err)
 	}).Return(nil).Once()
 
-	// Arrange: Mockear EmailNotifier.SendEmail
-	s.mockEmailNotifier.On("SendEmail", ctx, mock.MatchedBy(func(data notifications.EmailData) bool {
-		s.Contains(data.To, s.adminEmail)
-		s.Equal(s.fromEmail, data.From)
-		s.Contains(data.Subject, input.Asunto)
-		s.Contains(data.Body, input.Nombre)
-		s.Contains(data.Body, input.Email)
-		s.Contains(data.Body, input.Mensaje)
-		return true
-	})).Return(nil).Once()
-
 	// Act
 	contactoGuardado, err := s.service.ProcesarNuevoContacto(ctx, input)
 
@@ -80,8 +74,47 @@ func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_Success() {
 	s.Equal(input.Email, contactoGuardado.EmailRemitente)
 	s.WithinDuration(time.Now(), contactoGuardado.FechaContacto, 5*time.Second) // Verificar que FechaContacto se seteó
 
+	// El aviso al admin queda pendiente en la bandeja de salida, no se envía en la petición
+	s.Require().NotNil(aviso)
+	s.Equal(correos.EstadoPendiente, aviso.Estado)
+	s.Equal(contactos.EntidadCorreos, aviso.Origen)
+	s.Equal(uint(1), aviso.OrigenID)
+	s.Contains(aviso.Datos.To, s.adminEmail)
+	s.Equal(s.fromEmail, aviso.Datos.From)
+	s.Contains(aviso.Datos.Subject, input.Asunto)
+	s.Contains(aviso.Datos.Body, input.Nombre)
+	s.Contains(aviso.Datos.Body, input.Email)
+	s.Contains(aviso.Datos.Body, input.Mensaje)
+	s.True(aviso.Datos.IsHTML)
+	s.Contains(aviso.Datos.ReplyTo, input.Email, "El admin responde directamente a quien escribió")
+	s.Contains(aviso.Datos.TextBody, input.Mensaje, "Con alternativa en texto plano")
+
 	s.mockContactoRepo.AssertExpectations(s.T())
-	s.mockEmailNotifier.AssertExpectations(s.T())
+}
+
+func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_AsuntoLargoCabeEnLaColumna() {
+	ctx := context.Background()
+	input := contactos.EnviarContactoInput{
+		Nombre:  "Usuario de Prueba",
+		Email:   "prueba@example.com",
+		Mensaje: "Mensaje",
+		Asunto:  strings.Repeat("a", 255), // El máximo que admite el formulario
+	}
+	var aviso *correos.Correo
+	s.mockContactoRepo.On("Create", ctx, mock.AnythingOfType("*contactos.ContactoForm"), mock.Anything).Run(func(args mock.Arguments) {
+		arg := args.Get(1).(*contactos.ContactoForm)
+		arg.ID = 1
+		var err error
+		aviso, err = args.Get(2).(func(*contactos.ContactoForm) (*correos.Correo, error))(arg)
+		s.Require().NoError(err)
+	}).Return(nil).Once()
+
+	_, err := s.service.ProcesarNuevoContacto(ctx, input)
+
+	s.NoError(err)
+	s.Require().NotNil(aviso)
+	s.Equal(correos.MaxAsunto, utf8.RuneCountInString(aviso.Datos.Subject))
+	s.True(strings.HasPrefix(aviso.Datos.Subject, "Nuevo Mensaje de Contacto: aaa"))
 }
 
 func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_Fail_NombreVacio() {
@@ -93,7 +126,6 @@ func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_Fail_NombreVacio()
 	s.Error(err)
 	s.ErrorIs(err, contactos.ErrNombreRemitenteVacio)
 	s.mockContactoRepo.AssertNotCalled(s.T(), "Create")
-	s.mockEmailNotifier.AssertNotCalled(s.T(), "SendEmail")
 }
 
 func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_Fail_EmailInvalido() {
@@ -121,41 +153,44 @@ func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_Fail_RepoCreateErro
 	input := contactos.EnviarContactoInput{Nombre: "Test", Email: "test@example.com", Mensaje: "Msg"}
 	repoError := errors.New("error de BD al crear")
 
-	s.mockContactoRepo.On("Create", ctx, mock.AnythingOfType("*contactos.ContactoForm")).Return(repoError).Once()
+	s.mockContactoRepo.On("Create", ctx, mock.AnythingOfType("*contactos.ContactoForm"), mock.Anything).Return(repoError).Once()
 
 	_, err := s.service.ProcesarNuevoContacto(ctx, input)
 
 	s.Error(err)
 	s.ErrorIs(err, repoError) // Esperamos el error del repo envuelto
 	s.Contains(err.Error(), "error guardando contacto")
-	s.mockEmailNotifier.AssertNotCalled(s.T(), "SendEmail") // No se debe enviar email si falla el guardado
 }
 
-func (s *ContactoServiceTestSuite) TestProcesarNuevoContacto_Success_EmailSendFails() {
+// TODO: Añadir tests para ObtenerTodosLosContactos, ObtenerContactoPorID, MarcarContactoComoLeido
+// cubriendo casos de éxito, "no encontrado" (repo devuelve error), y error genérico del repo.
+
+// --- Papelera ---
+
+func (s *ContactoServiceTestSuite) TestEliminarContacto_NotFound() {
 	ctx := context.Background()
-	input := contactos.EnviarContactoInput{Nombre: "Test", Email: "test@example.com", Mensaje: "Msg"}
-	emailError := errors.New("smtp falló")
+	s.mockContactoRepo.On("Delete", ctx, uint(5)).Return(contactos.ErrContactoRepoNotFound).Once()
 
-	s.mockContactoRepo.On("Create", ctx, mock.AnythingOfType("*contactos.ContactoForm")).Run(func(args mock.Arguments) {
-		arg := args.Get(1).(*contactos.ContactoForm); arg.ID = 1
-	}).Return(nil).Once()
-	s.mockEmailNotifier.On("SendEmail", ctx, mock.AnythingOfType("notifications.EmailData")).Return(emailError).Once()
+	err := s.service.EliminarContacto(ctx, 5)
 
-	// Act: El servicio DEBERÍA guardar el contacto aunque el email falle, y loguear el error del email.
-	// El error devuelto por ProcesarNuevoContacto debería ser nil en este caso,
-	// ya que el guardado en BD fue exitoso.
-	contactoGuardado, err := s.service.ProcesarNuevoContacto(ctx, input)
+	s.ErrorIs(err, contactos.ErrContactoNotFound)
+}
 
-	// Assert
-	s.NoError(err, "ProcesarNuevoContacto no debería fallar si solo el envío de email falla (pero sí loguear)")
-	s.NotNil(contactoGuardado)
-	s.Equal(uint(1), contactoGuardado.ID)
-	// Aquí podrías verificar que se logueó una advertencia (requiere mockear el logger global o inyectar logger).
-	// Por ahora, confiamos en el log.Printf dentro del servicio.
+func (s *ContactoServiceTestSuite) TestRestaurarDePapelera_Success() {
+	ctx := context.Background()
+	s.mockContactoRepo.On("Restaurar", ctx, uint(5)).Return(nil).Once()
 
+	s.NoError(s.service.RestaurarDePapelera(ctx, 5))
 	s.mockContactoRepo.AssertExpectations(s.T())
-	s.mockEmailNotifier.AssertExpectations(s.T())
 }
 
-// TODO: Añadir tests para ObtenerTodosLosContactos, ObtenerContactoPorID, MarcarContactoComoLeido
-// cubriendo casos de éxito, "no encontrado" (repo devuelve error), y error genérico del repo.
\ No newline at end of file
+func (s *ContactoServiceTestSuite) TestPurgarVencidas() {
+	ctx := context.Background()
+	limite := time.Now().Add(-30 * 24 * time.Hour)
+	s.mockContactoRepo.On("PurgarEliminadosAntesDe", ctx, limite).Return(int64(4), nil).Once()
+
+	n, err := s.service.PurgarVencidas(ctx, limite)
+
+	s.NoError(err)
+	s.Equal(int64(4), n)
+}
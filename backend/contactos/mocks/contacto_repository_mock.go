@@ -3,7 +3,9 @@ package mocks
 
 import (
 	"backend/contactos" // Para los tipos de dominio y la interfaz
+	"backend/correos"
 	"context"
+	"time"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -14,8 +16,8 @@ type ContactoRepositoryMock struct {
 // Asegurar que implementa la interfaz
 var _ contactos.ContactoRepository = (*ContactoRepositoryMock)(nil)
 
-func (m *ContactoRepositoryMock) Create(ctx context.Context, contacto *contactos.ContactoForm) error {
-	args := m.Called(ctx, contacto)
+func (m *ContactoRepositoryMock) Create(ctx context.Context, contacto *contactos.ContactoForm, aviso func(*contactos.ContactoForm) (*correos.Correo, error)) error {
+	args := m.Called(ctx, contacto, aviso)
 	// Simular que el repo asigna ID y timestamps si el Create es exitoso
 	if args.Error(0) == nil && contacto != nil {
 		contacto.ID = 1 // ID de ejemplo
@@ -44,4 +46,32 @@ func (m *ContactoRepositoryMock) GetAll(ctx context.Context) ([]contactos.Contac
 func (m *ContactoRepositoryMock) MarkAsRead(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
-}
\ No newline at end of file
+}
+
+func (m *ContactoRepositoryMock) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ContactoRepositoryMock) FindEliminados(ctx context.Context) ([]contactos.ContactoForm, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]contactos.ContactoForm), args.Error(1)
+}
+
+func (m *ContactoRepositoryMock) Restaurar(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ContactoRepositoryMock) Purgar(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *ContactoRepositoryMock) PurgarEliminadosAntesDe(ctx context.Context, limite time.Time) (int64, error) {
+	args := m.Called(ctx, limite)
+	return args.Get(0).(int64), args.Error(1)
+}
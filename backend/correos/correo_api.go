@@ -0,0 +1,131 @@
+// backend/correos/correo_api.go
+package correos
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CorreoHandler maneja las peticiones HTTP de la bandeja de salida (admin).
+type CorreoHandler struct {
+	service CorreoService
+}
+
+// NewCorreoHandler crea una nueva instancia de CorreoHandler.
+func NewCorreoHandler(s CorreoService) *CorreoHandler {
+	return &CorreoHandler{service: s}
+}
+
+// ListarCorreos godoc
+// @Summary (Admin) Lista la bandeja de salida de correos
+// @Description (Admin) Los últimos 200 correos, los más recientes primero. Con estado=fallido,
+// @Description los que agotaron los reintentos y no se enviarán si no se reintentan a mano.
+// @Tags Correos_Admin
+// @Produce json
+// @Param estado query string false "pendiente, enviado o fallido"
+// @Success 200 {array} CorreoResponseDTO "Correos"
+// @Failure 400 {object} apitypes.ErrorResponse "Estado inválido"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/correos [get]
+// @Security ApiKeyAuth
+func (h *CorreoHandler) ListarCorreos(c *gin.Context) {
+	correos, err := h.service.Listar(c.Request.Context(), Estado(c.Query("estado")))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	dtos := make([]CorreoResponseDTO, 0, len(correos))
+	for i := range correos {
+		dtos = append(dtos, mapCorreoToResponseDTO(&correos[i]))
+	}
+	c.JSON(http.StatusOK, dtos)
+}
+
+// GetCorreo godoc
+// @Summary (Admin) Obtiene un correo de la bandeja de salida, con su cuerpo
+// @Tags Correos_Admin
+// @Produce json
+// @Param id path uint true "ID del Correo"
+// @Success 200 {object} CorreoResponseDTO "Correo"
+// @Failure 404 {object} apitypes.ErrorResponse "Correo no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/correos/{id} [get]
+// @Security ApiKeyAuth
+func (h *CorreoHandler) GetCorreo(c *gin.Context) {
+	id, err := parseCorreoID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	correo, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	dto := mapCorreoToResponseDTO(correo)
+	dto.Cuerpo = correo.Datos.Body
+	c.JSON(http.StatusOK, dto)
+}
+
+// ReintentarCorreo godoc
+// @Summary (Admin) Reintenta un correo fallido
+// @Description (Admin) Lo devuelve a la cola con los intentos a cero; se envía en la próxima pasada del despachador.
+// @Tags Correos_Admin
+// @Produce json
+// @Param id path uint true "ID del Correo"
+// @Success 202 {object} CorreoResponseDTO "Correo en cola"
+// @Failure 404 {object} apitypes.ErrorResponse "Correo no encontrado"
+// @Failure 409 {object} apitypes.ErrorResponse "El correo no está fallido"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/correos/{id}/reintentar [post]
+// @Security ApiKeyAuth
+func (h *CorreoHandler) ReintentarCorreo(c *gin.Context) {
+	id, err := parseCorreoID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	correo, err := h.service.Reintentar(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, mapCorreoToResponseDTO(correo))
+}
+
+// parseCorreoID obtiene el :id de la URL.
+func parseCorreoID(c *gin.Context) (uint, error) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parámetro ID de correo inválido: %s - %w", idStr, err)
+	}
+	return uint(idUint64), nil
+}
+
+func mapCorreoToResponseDTO(c *Correo) CorreoResponseDTO {
+	dto := CorreoResponseDTO{
+		ID:          c.ID,
+		Origen:      c.Origen,
+		OrigenID:    c.OrigenID,
+		Para:        c.Datos.To,
+		Asunto:      c.Datos.Subject,
+		Estado:      c.Estado,
+		Intentos:    c.Intentos,
+		UltimoError: c.UltimoError,
+		CreadoEn:    c.CreatedAt.Format(time.RFC3339),
+	}
+	if c.Estado == EstadoPendiente {
+		proximo := c.ProximoIntento.Format(time.RFC3339)
+		dto.ProximoIntento = &proximo
+	}
+	if c.EnviadoEn != nil {
+		enviadoEn := c.EnviadoEn.Format(time.RFC3339)
+		dto.EnviadoEn = &enviadoEn
+	}
+	return dto
+}
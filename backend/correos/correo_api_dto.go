@@ -0,0 +1,18 @@
+// backend/correos/correo_api_dto.go
+package correos
+
+// CorreoResponseDTO es un correo de la bandeja de salida, para el panel de admin.
+type CorreoResponseDTO struct {
+	ID             uint     `json:"id"`
+	Origen         string   `json:"origen" example:"contactos"`
+	OrigenID       uint     `json:"origen_id" example:"12"`
+	Para           []string `json:"para" example:"admin@example.com"`
+	Asunto         string   `json:"asunto" example:"Nuevo Mensaje de Contacto: Hola"`
+	Estado         Estado   `json:"estado" example:"fallido"` // pendiente, enviado o fallido
+	Intentos       int      `json:"intentos" example:"8"`
+	ProximoIntento *string  `json:"proximo_intento,omitempty" example:"2025-05-18T10:20:30Z"` // Solo si está pendiente
+	UltimoError    string   `json:"ultimo_error,omitempty" example:"smtpNotifier: error al enviar email: dial tcp: i/o timeout"`
+	EnviadoEn      *string  `json:"enviado_en,omitempty"`
+	CreadoEn       string   `json:"creado_en" example:"2025-05-18T10:20:30Z"`
+	Cuerpo         string   `json:"cuerpo,omitempty"` // Solo en el detalle
+}
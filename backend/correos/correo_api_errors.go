@@ -0,0 +1,17 @@
+// backend/correos/correo_api_errors.go
+
+// Traducción de los errores de la bandeja de salida a respuestas HTTP (ver shared/apperrors).
+
+package correos
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrCorreoNotFound, http.StatusNotFound, "correo_no_encontrado")
+	apperrors.Registrar(ErrCorreoNoReintentable, http.StatusConflict, "correo_no_reintentable")
+	apperrors.Registrar(ErrEstadoInvalido, http.StatusBadRequest, "correo_estado_invalido")
+}
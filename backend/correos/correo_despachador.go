@@ -0,0 +1,124 @@
+// backend/correos/correo_despachador.go
+
+// El Despachador envía en segundo plano los correos de la bandeja de salida. Cada pasada
+// reserva un lote de pendientes (ver CorreoRepository.Reservar) y los envía uno a uno.
+// Un envío fallido se reintenta con espera exponencial (Reintentos.Espera); al agotar los
+// intentos el correo queda fallido (dead letter) y solo se reenvía a mano desde la API de admin.
+
+package correos
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"backend/shared/notifications"
+)
+
+// Tamaño del lote de cada pasada y duración de la reserva de un correo: si el despachador
+// se cae a medio envío, el correo se retoma al vencer (puede llegar a enviarse dos veces,
+// con el mismo Message-ID).
+const (
+	loteDespacho    = 20
+	reservaDespacho = 5 * time.Minute
+)
+
+// Reintentos define cuántas veces y con qué espera se reintenta un envío.
+type Reintentos struct {
+	Max  int           // Intentos antes de dar el correo por fallido
+	Base time.Duration // Espera tras el primer fallo; se duplica en cada uno
+	Tope time.Duration // Espera máxima entre intentos
+}
+
+// Espera es el tiempo hasta el siguiente intento tras 'intentos' fallos: Base, 2*Base, 4*Base...
+// sin pasar de Tope.
+func (r Reintentos) Espera(intentos int) time.Duration {
+	espera := r.Base
+	for i := 1; i < intentos && espera < r.Tope; i++ {
+		espera *= 2
+	}
+	if r.Tope > 0 && espera > r.Tope {
+		return r.Tope
+	}
+	return espera
+}
+
+// Despachador envía los correos pendientes.
+type Despachador struct {
+	repo       CorreoRepository
+	notifier   notifications.EmailNotifier
+	reintentos Reintentos
+	ahora      func() time.Time
+}
+
+// NewDespachador crea el despachador.
+func NewDespachador(repo CorreoRepository, notifier notifications.EmailNotifier, reintentos Reintentos) *Despachador {
+	return &Despachador{repo: repo, notifier: notifier, reintentos: reintentos, ahora: time.Now}
+}
+
+// Iniciar envía los pendientes al arrancar y luego cada 'intervalo', hasta que se cancele
+// ctx. Bloquea: ejecútelo en una goroutine.
+func (d *Despachador) Iniciar(ctx context.Context, intervalo time.Duration) {
+	log.Printf("📨 Envío de correos iniciado (cada %s, %d intentos).\n", intervalo, d.reintentos.Max)
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		d.Ejecutar(ctx)
+		select {
+		case <-ctx.Done():
+			log.Println("📨 Envío de correos detenido.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ejecutar envía los correos pendientes hasta vaciar la cola (por lotes) y devuelve cuántos
+// envió. Los fallos se registran en cada correo y se reintentan en pasadas posteriores.
+func (d *Despachador) Ejecutar(ctx context.Context) int {
+	enviados := 0
+	for ctx.Err() == nil {
+		ahora := d.ahora()
+		lote, err := d.repo.Reservar(ctx, ahora, ahora.Add(reservaDespacho), loteDespacho)
+		if err != nil {
+			log.Printf("Correos: error reservando pendientes: %v\n", err)
+		}
+		for i := range lote {
+			if d.enviar(ctx, &lote[i]) {
+				enviados++
+			}
+		}
+		if err != nil || len(lote) < loteDespacho {
+			break
+		}
+	}
+	return enviados
+}
+
+// enviar intenta enviar el correo y registra el resultado. Devuelve si se envió.
+func (d *Despachador) enviar(ctx context.Context, c *Correo) bool {
+	errEnvio := d.notifier.SendEmail(ctx, c.DatosEnvio())
+	// Registrar el resultado aunque se cancele ctx (ej: al apagar): si no, el correo
+	// enviado se volvería a enviar al vencer la reserva.
+	ctxRegistro := context.WithoutCancel(ctx)
+	if errEnvio == nil {
+		if err := d.repo.MarcarEnviado(ctxRegistro, c.ID, d.ahora()); err != nil {
+			log.Printf("Correos: Correo ID %d enviado, pero no se pudo marcar como enviado: %v\n", c.ID, err)
+		}
+		return true
+	}
+
+	intentos := c.Intentos + 1
+	estado, proximo := EstadoPendiente, d.ahora().Add(d.reintentos.Espera(intentos))
+	if intentos >= d.reintentos.Max {
+		estado = EstadoFallido
+		log.Printf("ALERTA: Correo ID %d (%s %d) descartado tras %d intentos: %v\n", c.ID, c.Origen, c.OrigenID, intentos, errEnvio)
+	} else {
+		log.Printf("Correos: Correo ID %d falló (intento %d/%d), siguiente a las %s: %v\n", c.ID, intentos, d.reintentos.Max, proximo.Format(time.RFC3339), errEnvio)
+	}
+	if err := d.repo.MarcarFallo(ctxRegistro, c.ID, intentos, estado, proximo, errEnvio.Error()); err != nil {
+		log.Printf("Correos: no se pudo registrar el fallo del correo ID %d: %v\n", c.ID, err)
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// backend/correos/correo_despachador_test.go
+package correos_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend/correos"
+	correosMocks "backend/correos/mocks"
+	"backend/shared/notifications"
+	notificationsMocks "backend/shared/notifications/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReintentos_EsperaExponencialConTope(t *testing.T) {
+	r := correos.Reintentos{Max: 8, Base: time.Minute, Tope: time.Hour}
+	for intentos, want := range map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		3:  4 * time.Minute,
+		6:  32 * time.Minute,
+		7:  time.Hour, // 64 minutos, con tope
+		40: time.Hour,
+	} {
+		assert.Equal(t, want, r.Espera(intentos), "intentos %d", intentos)
+	}
+}
+
+func TestDespachador_Ejecutar(t *testing.T) {
+	ctx := context.Background()
+	repo := new(correosMocks.CorreoRepositoryMock)
+	notifier := new(notificationsMocks.EmailNotifierMock)
+	reintentos := correos.Reintentos{Max: 3, Base: time.Minute, Tope: time.Hour}
+	datos := func(asunto string) notifications.EmailData {
+		return notifications.EmailData{To: []string{"admin@test.com"}, Subject: asunto, Body: "..."}
+	}
+	creado := time.Unix(1700000000, 0)
+	// Se envían con un Message-ID fijo: el mismo en cada reintento
+	enviado := func(asunto string, id uint) notifications.EmailData {
+		d := datos(asunto)
+		d.MessageID = fmt.Sprintf("correo-%d.1700000000", id)
+		return d
+	}
+	lote := []correos.Correo{
+		{ID: 1, Datos: datos("ok"), Estado: correos.EstadoPendiente, CreatedAt: creado},
+		{ID: 2, Datos: datos("falla"), Estado: correos.EstadoPendiente, CreatedAt: creado},
+		{ID: 3, Datos: datos("falla"), Estado: correos.EstadoPendiente, Intentos: 2, CreatedAt: creado}, // Último intento
+	}
+	errSMTP := errors.New("smtp caído")
+	repo.On("Reservar", ctx, mock.Anything, mock.Anything, mock.Anything).Return(lote, nil).Once()
+	notifier.On("SendEmail", ctx, enviado("ok", 1)).Return(nil).Once()
+	notifier.On("SendEmail", ctx, enviado("falla", 2)).Return(errSMTP).Once()
+	notifier.On("SendEmail", ctx, enviado("falla", 3)).Return(errSMTP).Once()
+	repo.On("MarcarEnviado", mock.Anything, uint(1), mock.Anything).Return(nil).Once()
+	antes := time.Now()
+	repo.On("MarcarFallo", mock.Anything, uint(2), 1, correos.EstadoPendiente, mock.MatchedBy(func(proximo time.Time) bool {
+		return !proximo.Before(antes.Add(time.Minute)) // Espera tras el primer fallo
+	}), "smtp caído").Return(nil).Once()
+	repo.On("MarcarFallo", mock.Anything, uint(3), 3, correos.EstadoFallido, mock.Anything, "smtp caído").Return(nil).Once()
+
+	enviados := correos.NewDespachador(repo, notifier, reintentos).Ejecutar(ctx)
+
+	assert.Equal(t, 1, enviados)
+	repo.AssertExpectations(t)
+	repo.AssertNumberOfCalls(t, "Reservar", 1) // El lote no estaba lleno: no hay más pendientes
+	notifier.AssertExpectations(t)
+}
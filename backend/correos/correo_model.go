@@ -0,0 +1,83 @@
+// Archivo: backend/correos/correo_model.go
+// Funcionalidad: Modelo de dominio de la bandeja de salida de correos.
+// Capa: Dominio / Lógica de negocio.
+
+// Descripción:
+// Los correos no se envían dentro de la petición HTTP: se guardan en la tabla 'correos'
+// (en la misma transacción que el registro que los origina, ej: un mensaje de contacto)
+// y el Despachador los envía en segundo plano. Si el servidor SMTP falla, el correo no
+// se pierde: se reintenta con esperas cada vez más largas y, agotados los intentos, queda
+// como fallido para que un admin lo revise y lo reintente.
+//
+// Reglas de Negocio:
+// - pendiente -> enviado, o pendiente -> (reintentos) -> fallido.
+// - Solo los fallidos se reintentan a mano; vuelven a pendiente con los intentos a cero.
+// - El asunto se recorta a MaxAsunto caracteres (incluye texto del usuario, ej: el de un contacto).
+
+package correos
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"backend/shared/notifications" // Para EmailData
+)
+
+// Estado de un correo en la bandeja de salida.
+type Estado string
+
+const (
+	EstadoPendiente Estado = "pendiente" // Por enviar (o esperando el siguiente intento)
+	EstadoEnviado   Estado = "enviado"
+	EstadoFallido   Estado = "fallido" // Agotó los intentos: solo se reenvía a mano
+)
+
+// Valido indica si es uno de los estados conocidos.
+func (e Estado) Valido() bool {
+	return e == EstadoPendiente || e == EstadoEnviado || e == EstadoFallido
+}
+
+// Correo es un email de la bandeja de salida.
+type Correo struct {
+	ID             uint
+	Origen         string // Característica que lo generó, ej: "contactos"
+	OrigenID       uint   // Registro que lo generó, ej: el ID del mensaje de contacto
+	Datos          notifications.EmailData
+	Estado         Estado
+	Intentos       int       // Envíos fallidos hasta ahora
+	ProximoIntento time.Time // No se envía antes de esta fecha
+	UltimoError    string    // Error del último intento fallido
+	EnviadoEn      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// MaxAsunto es el tamaño de la columna asunto (en caracteres).
+const MaxAsunto = 255
+
+// Nuevo crea un correo pendiente de enviar cuanto antes, con el asunto recortado a MaxAsunto.
+func Nuevo(origen string, origenID uint, datos notifications.EmailData) *Correo {
+	if utf8.RuneCountInString(datos.Subject) > MaxAsunto {
+		datos.Subject = string([]rune(datos.Subject)[:MaxAsunto])
+	}
+	return &Correo{Origen: origen, OrigenID: origenID, Datos: datos, Estado: EstadoPendiente, ProximoIntento: time.Now()}
+}
+
+// DatosEnvio son los datos del correo con un Message-ID fijo, derivado de su ID y su fecha de
+// creación (por si la tabla se vacía y los IDs se repiten): todos los reintentos lo comparten.
+func (c *Correo) DatosEnvio() notifications.EmailData {
+	datos := c.Datos
+	datos.MessageID = fmt.Sprintf("correo-%d.%d", c.ID, c.CreatedAt.Unix())
+	return datos
+}
+
+var (
+	// ErrCorreoNotFound se produce si el correo no existe.
+	ErrCorreoNotFound = errors.New("correo no encontrado")
+	// ErrCorreoNoReintentable se produce al reintentar un correo que no ha fallado.
+	ErrCorreoNoReintentable = errors.New("solo se pueden reintentar los correos fallidos")
+	// ErrEstadoInvalido se produce al filtrar por un estado desconocido.
+	ErrEstadoInvalido = errors.New("estado de correo inválido (pendiente, enviado o fallido)")
+)
@@ -0,0 +1,106 @@
+// backend/correos/correo_model_gorm.go
+
+// Este archivo define el modelo de persistencia de la bandeja de salida de correos.
+// Utiliza GORM para la definición de la tabla y el mapeo de campos.
+
+package correos
+
+import (
+	"time"
+
+	"backend/shared/notifications"
+)
+
+// CorreoModel representa la tabla 'correos'.
+type CorreoModel struct {
+	ID             uint                       `gorm:"primaryKey"`
+	Origen         string                     `gorm:"type:varchar(50);not null;index:idx_correos_origen,priority:1"`
+	OrigenID       uint                       `gorm:"not null;index:idx_correos_origen,priority:2"`
+	Para           []string                   `gorm:"type:json;serializer:json;not null"`
+	Cc             []string                   `gorm:"type:json;serializer:json"`
+	Cco            []string                   `gorm:"type:json;serializer:json"` // Copia oculta (Bcc)
+	ResponderA     string                     `gorm:"type:varchar(255)"`         // Reply-To
+	De             string                     `gorm:"type:varchar(255)"`
+	Asunto         string                     `gorm:"type:varchar(255);not null"`
+	Cuerpo         string                     `gorm:"type:mediumtext;not null"`
+	Texto          string                     `gorm:"type:mediumtext"` // Alternativa en texto plano de un cuerpo HTML
+	EsHTML         bool                       `gorm:"not null;default:false"`
+	Adjuntos       []notifications.Attachment `gorm:"type:json;serializer:json"` // Contenido en base64 dentro del JSON
+	Estado         Estado                     `gorm:"type:varchar(20);not null;index:idx_correos_cola,priority:1"`
+	Intentos       int                        `gorm:"not null;default:0"`
+	ProximoIntento time.Time                  `gorm:"not null;index:idx_correos_cola,priority:2"` // El despachador busca por (estado, proximo_intento)
+	UltimoError    string                     `gorm:"type:varchar(500)"`
+	EnviadoEn      *time.Time                 `gorm:"default:null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (CorreoModel) TableName() string {
+	return "correos"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *CorreoModel) ToDomain() *Correo {
+	if m == nil {
+		return nil
+	}
+	return &Correo{
+		ID:       m.ID,
+		Origen:   m.Origen,
+		OrigenID: m.OrigenID,
+		Datos: notifications.EmailData{
+			To:          m.Para,
+			From:        m.De,
+			Subject:     m.Asunto,
+			Body:        m.Cuerpo,
+			IsHTML:      m.EsHTML,
+			TextBody:    m.Texto,
+			Cc:          m.Cc,
+			Bcc:         m.Cco,
+			ReplyTo:     m.ResponderA,
+			Attachments: m.Adjuntos,
+		},
+		Estado:         m.Estado,
+		Intentos:       m.Intentos,
+		ProximoIntento: m.ProximoIntento,
+		UltimoError:    m.UltimoError,
+		EnviadoEn:      m.EnviadoEn,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func FromCorreoDomain(d *Correo) *CorreoModel {
+	if d == nil {
+		return nil
+	}
+	return &CorreoModel{
+		ID:             d.ID,
+		Origen:         d.Origen,
+		OrigenID:       d.OrigenID,
+		Para:           d.Datos.To,
+		De:             d.Datos.From,
+		Asunto:         d.Datos.Subject,
+		Cuerpo:         d.Datos.Body,
+		Texto:          d.Datos.TextBody,
+		Cc:             d.Datos.Cc,
+		Cco:            d.Datos.Bcc,
+		ResponderA:     d.Datos.ReplyTo,
+		Adjuntos:       d.Datos.Attachments,
+		EsHTML:         d.Datos.IsHTML,
+		Estado:         d.Estado,
+		Intentos:       d.Intentos,
+		ProximoIntento: d.ProximoIntento,
+		UltimoError:    d.UltimoError,
+		EnviadoEn:      d.EnviadoEn,
+	}
+}
+
+func CorreoModelsToDomains(models []CorreoModel) []Correo {
+	correos := make([]Correo, 0, len(models))
+	for i := range models {
+		correos = append(correos, *models[i].ToDomain())
+	}
+	return correos
+}
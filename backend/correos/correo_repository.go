@@ -0,0 +1,33 @@
+// backend/correos/correo_repository.go
+// Funcionalidad: Interfaz para la persistencia de la bandeja de salida de correos.
+// Capa: Repositorio (Abstracción).
+package correos
+
+import (
+	"context"
+	"time"
+)
+
+// CorreoRepository define el contrato para las operaciones de datos de Correo.
+// Para escribir un correo en la transacción de otra característica, ver Encolar.
+type CorreoRepository interface {
+	// Create guarda un correo nuevo y le asigna el ID.
+	Create(ctx context.Context, c *Correo) error
+	// GetByID devuelve repository.ErrRecordNotFound si no existe.
+	GetByID(ctx context.Context, id uint) (*Correo, error)
+	// Listar devuelve los correos en ese estado ("" = todos), los más recientes primero.
+	Listar(ctx context.Context, estado Estado, limite int) ([]Correo, error)
+
+	// Reservar elige hasta 'limite' correos pendientes cuyo intento toca antes de 'ahora' y les
+	// aplaza el siguiente intento hasta 'hasta'. Un correo reservado no lo toma otro despachador
+	// (ej: otra instancia de la API) y, si este se cae a medio envío, se retoma al vencer la reserva.
+	Reservar(ctx context.Context, ahora, hasta time.Time, limite int) ([]Correo, error)
+	// MarcarEnviado pasa el correo a enviado.
+	MarcarEnviado(ctx context.Context, id uint, enviadoEn time.Time) error
+	// MarcarFallo guarda un intento fallido: el número de intentos, el error, el nuevo estado
+	// (pendiente o fallido) y cuándo toca el siguiente intento.
+	MarcarFallo(ctx context.Context, id uint, intentos int, estado Estado, proximo time.Time, causa string) error
+	// Reintentar devuelve un correo fallido a pendiente, con los intentos a cero, para enviarlo
+	// a partir de 'ahora'. Devuelve repository.ErrRecordNotFound si no existe o no está fallido.
+	Reintentar(ctx context.Context, id uint, ahora time.Time) error
+}
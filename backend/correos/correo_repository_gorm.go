@@ -0,0 +1,139 @@
+// backend/correos/correo_repository_gorm.go
+// Funcionalidad: Implementación GORM de CorreoRepository.
+// Capa: Repositorio (Implementación de Persistencia).
+package correos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"backend/shared/repository"
+
+	"gorm.io/gorm"
+)
+
+// maxUltimoError es el tamaño de la columna ultimo_error (en caracteres).
+const maxUltimoError = 500
+
+type gormCorreoRepository struct {
+	db *gorm.DB
+}
+
+// NewCorreoRepository crea una instancia de la implementación GORM de CorreoRepository.
+func NewCorreoRepository(db *gorm.DB) CorreoRepository {
+	return &gormCorreoRepository{db: db}
+}
+
+// Encolar guarda el correo con la conexión 'tx', normalmente la transacción que guarda el
+// registro que lo origina: si esta se deshace, el correo tampoco queda encolado, y si se
+// confirma, el correo se enviará aunque el servidor SMTP esté caído en ese momento.
+func Encolar(tx *gorm.DB, c *Correo) error {
+	model := FromCorreoDomain(c)
+	if err := tx.Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm correos: error encolando correo de %s %d: %w", c.Origen, c.OrigenID, repository.TraducirError(err))
+	}
+	c.ID, c.CreatedAt, c.UpdatedAt = model.ID, model.CreatedAt, model.UpdatedAt
+	return nil
+}
+
+func (r *gormCorreoRepository) Create(ctx context.Context, c *Correo) error {
+	return Encolar(r.db.WithContext(ctx), c)
+}
+
+func (r *gormCorreoRepository) GetByID(ctx context.Context, id uint) (*Correo, error) {
+	var model CorreoModel
+	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm correos: error obteniendo por id %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormCorreoRepository) Listar(ctx context.Context, estado Estado, limite int) ([]Correo, error) {
+	var models []CorreoModel
+	q := r.db.WithContext(ctx).Order("id desc").Limit(limite)
+	if estado != "" {
+		q = q.Where("estado = ?", estado)
+	}
+	if err := q.Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm correos: error listando (estado %q): %w", estado, err)
+	}
+	return CorreoModelsToDomains(models), nil
+}
+
+// Reservar elige los candidatos y los reserva uno a uno con un UPDATE condicionado: si otro
+// despachador reservó alguno entre medias, su UPDATE no afecta filas y se descarta.
+func (r *gormCorreoRepository) Reservar(ctx context.Context, ahora, hasta time.Time, limite int) ([]Correo, error) {
+	var candidatos []CorreoModel
+	err := r.db.WithContext(ctx).
+		Where("estado = ? AND proximo_intento <= ?", EstadoPendiente, ahora).
+		Order("proximo_intento asc").Limit(limite).Find(&candidatos).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm correos: error buscando pendientes: %w", err)
+	}
+	reservados := make([]Correo, 0, len(candidatos))
+	for i := range candidatos {
+		result := r.db.WithContext(ctx).Model(&CorreoModel{}).
+			Where("id = ? AND estado = ? AND proximo_intento = ?", candidatos[i].ID, EstadoPendiente, candidatos[i].ProximoIntento).
+			Update("proximo_intento", hasta)
+		if result.Error != nil {
+			return reservados, fmt.Errorf("repo gorm correos: error reservando %d: %w", candidatos[i].ID, repository.TraducirError(result.Error))
+		}
+		if result.RowsAffected == 1 {
+			candidatos[i].ProximoIntento = hasta
+			reservados = append(reservados, *candidatos[i].ToDomain())
+		}
+	}
+	return reservados, nil
+}
+
+func (r *gormCorreoRepository) MarcarEnviado(ctx context.Context, id uint, enviadoEn time.Time) error {
+	return r.actualizar(ctx, "marcarenviado", id, map[string]interface{}{
+		"estado":     EstadoEnviado,
+		"enviado_en": enviadoEn,
+	})
+}
+
+func (r *gormCorreoRepository) MarcarFallo(ctx context.Context, id uint, intentos int, estado Estado, proximo time.Time, causa string) error {
+	if utf8.RuneCountInString(causa) > maxUltimoError {
+		causa = string([]rune(causa)[:maxUltimoError])
+	}
+	return r.actualizar(ctx, "marcarfallo", id, map[string]interface{}{
+		"estado":          estado,
+		"intentos":        intentos,
+		"proximo_intento": proximo,
+		"ultimo_error":    causa,
+	})
+}
+
+func (r *gormCorreoRepository) Reintentar(ctx context.Context, id uint, ahora time.Time) error {
+	result := r.db.WithContext(ctx).Model(&CorreoModel{}).Where("id = ? AND estado = ?", id, EstadoFallido).Updates(map[string]interface{}{
+		"estado":          EstadoPendiente,
+		"intentos":        0,
+		"proximo_intento": ahora,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm correos: reintentar %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+// actualizar aplica los cambios al correo 'id' (repository.ErrRecordNotFound si no existe).
+func (r *gormCorreoRepository) actualizar(ctx context.Context, operacion string, id uint, cambios map[string]interface{}) error {
+	result := r.db.WithContext(ctx).Model(&CorreoModel{}).Where("id = ?", id).Updates(cambios)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm correos: %s %d: %w", operacion, id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// backend/correos/correo_routes.go
+package correos
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterCorreoRoutes registra las rutas de administración de la bandeja de salida.
+// requireAdmin protege todas (/admin/correos).
+func RegisterCorreoRoutes(apiBaseGroup *gin.RouterGroup, h *CorreoHandler, requireAdmin gin.HandlerFunc) {
+	correosAdminRoutes := apiBaseGroup.Group("/admin/correos", requireAdmin)
+	{
+		correosAdminRoutes.GET("", h.ListarCorreos) // ?estado=fallido
+		correosAdminRoutes.GET("/:id", h.GetCorreo)
+		correosAdminRoutes.POST("/:id/reintentar", h.ReintentarCorreo)
+	}
+
+	log.Println("🛣️  Rutas de Correos configuradas.")
+}
@@ -0,0 +1,79 @@
+// backend/correos/correo_service.go
+// Funcionalidad: Consulta y reintento de la bandeja de salida (para el admin).
+// Capa: Servicio / Casos de Uso.
+package correos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log" // Temporal, reemplazar con logger estructurado
+	"time"
+
+	"backend/shared/repository"
+)
+
+// maxListado es el número máximo de correos que devuelve Listar.
+const maxListado = 200
+
+// CorreoService define el contrato para la lógica de negocio de la bandeja de salida.
+type CorreoService interface {
+	// Listar devuelve los últimos correos en ese estado ("" = todos).
+	Listar(ctx context.Context, estado Estado) ([]Correo, error)
+	GetByID(ctx context.Context, id uint) (*Correo, error)
+	// Reintentar vuelve a poner en cola un correo fallido; el despachador lo envía en su próxima pasada.
+	Reintentar(ctx context.Context, id uint) (*Correo, error)
+}
+
+type correoService struct {
+	repo  CorreoRepository
+	ahora func() time.Time
+}
+
+// NewCorreoService crea una nueva instancia de CorreoService.
+func NewCorreoService(repo CorreoRepository) CorreoService {
+	return &correoService{repo: repo, ahora: time.Now}
+}
+
+func (s *correoService) Listar(ctx context.Context, estado Estado) ([]Correo, error) {
+	if estado != "" && !estado.Valido() {
+		return nil, fmt.Errorf("%w: %q", ErrEstadoInvalido, estado)
+	}
+	correos, err := s.repo.Listar(ctx, estado, maxListado)
+	if err != nil {
+		return nil, fmt.Errorf("servicio correos: error listando: %w", err)
+	}
+	return correos, nil
+}
+
+func (s *correoService) GetByID(ctx context.Context, id uint) (*Correo, error) {
+	c, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrCorreoNotFound
+		}
+		return nil, fmt.Errorf("servicio correos: error obteniendo %d: %w", id, err)
+	}
+	return c, nil
+}
+
+func (s *correoService) Reintentar(ctx context.Context, id uint) (*Correo, error) {
+	c, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if c.Estado != EstadoFallido {
+		return nil, fmt.Errorf("%w: el correo %d está %s", ErrCorreoNoReintentable, id, c.Estado)
+	}
+	ahora := s.ahora()
+	if err := s.repo.Reintentar(ctx, id, ahora); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			// Otro admin lo reintentó a la vez
+			return nil, fmt.Errorf("%w: el correo %d ya no está fallido", ErrCorreoNoReintentable, id)
+		}
+		return nil, fmt.Errorf("servicio correos: error reintentando %d: %w", id, err)
+	}
+	c.Estado, c.Intentos, c.ProximoIntento = EstadoPendiente, 0, ahora
+	log.Printf("Servicio: Correo ID %d (%s %d) en cola de nuevo\n", c.ID, c.Origen, c.OrigenID)
+	return c, nil
+}
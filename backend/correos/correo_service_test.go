@@ -0,0 +1,78 @@
+// backend/correos/correo_service_test.go
+package correos_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"backend/correos"
+	correosMocks "backend/correos/mocks"
+	"backend/shared/notifications"
+	"backend/shared/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CorreoServiceTestSuite struct {
+	suite.Suite
+	mockRepo *correosMocks.CorreoRepositoryMock
+	service  correos.CorreoService
+}
+
+func (s *CorreoServiceTestSuite) SetupTest() {
+	s.mockRepo = new(correosMocks.CorreoRepositoryMock)
+	s.service = correos.NewCorreoService(s.mockRepo)
+}
+
+func TestCorreoServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CorreoServiceTestSuite))
+}
+
+func (s *CorreoServiceTestSuite) TestNuevo_RecortaElAsuntoSinPartirCaracteres() {
+	c := correos.Nuevo("contactos", 1, notifications.EmailData{Subject: "Asunto: " + strings.Repeat("ñ", 255)})
+
+	s.Equal(correos.MaxAsunto, utf8.RuneCountInString(c.Datos.Subject))
+	s.True(utf8.ValidString(c.Datos.Subject))
+}
+
+func (s *CorreoServiceTestSuite) TestListar_Fail_EstadoInvalido() {
+	_, err := s.service.Listar(context.Background(), "perdido")
+
+	s.ErrorIs(err, correos.ErrEstadoInvalido)
+	s.mockRepo.AssertNotCalled(s.T(), "Listar", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CorreoServiceTestSuite) TestReintentar_Success() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(4)).Return(&correos.Correo{ID: 4, Estado: correos.EstadoFallido, Intentos: 8}, nil).Once()
+	s.mockRepo.On("Reintentar", ctx, uint(4), mock.Anything).Return(nil).Once()
+
+	c, err := s.service.Reintentar(ctx, 4)
+
+	s.NoError(err)
+	s.Equal(correos.EstadoPendiente, c.Estado)
+	s.Zero(c.Intentos)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *CorreoServiceTestSuite) TestReintentar_Fail_NoFallido() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(4)).Return(&correos.Correo{ID: 4, Estado: correos.EstadoEnviado}, nil).Once()
+
+	_, err := s.service.Reintentar(ctx, 4)
+
+	s.ErrorIs(err, correos.ErrCorreoNoReintentable)
+	s.mockRepo.AssertNotCalled(s.T(), "Reintentar", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CorreoServiceTestSuite) TestReintentar_Fail_NotFound() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByID", ctx, uint(9)).Return(nil, repository.ErrRecordNotFound).Once()
+
+	_, err := s.service.Reintentar(ctx, 9)
+
+	s.ErrorIs(err, correos.ErrCorreoNotFound)
+}
@@ -0,0 +1,62 @@
+// backend/correos/mocks/correo_repository_mock.go
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"backend/correos"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CorreoRepositoryMock struct {
+	mock.Mock
+}
+
+// Asegurar que implementa la interfaz
+var _ correos.CorreoRepository = (*CorreoRepositoryMock)(nil)
+
+func (m *CorreoRepositoryMock) Create(ctx context.Context, c *correos.Correo) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *CorreoRepositoryMock) GetByID(ctx context.Context, id uint) (*correos.Correo, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*correos.Correo), args.Error(1)
+}
+
+func (m *CorreoRepositoryMock) Listar(ctx context.Context, estado correos.Estado, limite int) ([]correos.Correo, error) {
+	args := m.Called(ctx, estado, limite)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]correos.Correo), args.Error(1)
+}
+
+func (m *CorreoRepositoryMock) Reservar(ctx context.Context, ahora, hasta time.Time, limite int) ([]correos.Correo, error) {
+	args := m.Called(ctx, ahora, hasta, limite)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]correos.Correo), args.Error(1)
+}
+
+func (m *CorreoRepositoryMock) MarcarEnviado(ctx context.Context, id uint, enviadoEn time.Time) error {
+	args := m.Called(ctx, id, enviadoEn)
+	return args.Error(0)
+}
+
+func (m *CorreoRepositoryMock) MarcarFallo(ctx context.Context, id uint, intentos int, estado correos.Estado, proximo time.Time, causa string) error {
+	args := m.Called(ctx, id, intentos, estado, proximo, causa)
+	return args.Error(0)
+}
+
+func (m *CorreoRepositoryMock) Reintentar(ctx context.Context, id uint, ahora time.Time) error {
+	args := m.Called(ctx, id, ahora)
+	return args.Error(0)
+}
@@ -0,0 +1,488 @@
+// backend/favoritos/favorito_api.go
+// Implementación con Gin de FavoritoHandler.
+package favoritos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/recetas" // Para mapear recetas a su DTO
+)
+
+// errUsuarioNoIdentificado se produce si un handler protegido se ejecuta sin userID en el contexto
+// (ej: ruta registrada sin el middleware de autenticación).
+var errUsuarioNoIdentificado = errors.New("favoritos: userID ausente en el contexto")
+
+// FavoritoHandler maneja las peticiones HTTP para Favoritos y Colecciones.
+type FavoritoHandler struct {
+	service FavoritoService
+}
+
+// NewFavoritoHandler crea una nueva instancia de FavoritoHandler.
+func NewFavoritoHandler(s FavoritoService) *FavoritoHandler {
+	return &FavoritoHandler{service: s}
+}
+
+// --- Mapeadores Helper ---
+
+// mapColeccionToResponseDTO convierte una colección a su DTO. El token solo se
+// incluye para el dueño (no en la vista pública, donde las URLs de las fotos nunca van firmadas).
+func mapColeccionToResponseDTO(col Coleccion, incluirToken bool) ColeccionResponseDTO {
+	lector := recetas.Lector{} // Vista pública: anónimo
+	if incluirToken {
+		lector = recetas.LectorUsuario(col.UserID)
+	}
+	dto := ColeccionResponseDTO{
+		ID:           col.ID,
+		Nombre:       col.Nombre,
+		Descripcion:  col.Descripcion,
+		Compartida:   col.Compartida(),
+		TotalRecetas: col.TotalRecetas,
+		CreatedAt:    col.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    col.UpdatedAt.Format(time.RFC3339),
+	}
+	if incluirToken && col.Compartida() {
+		dto.TokenPublico = *col.TokenPublico
+	}
+	if len(col.Recetas) > 0 {
+		dto.Recetas = make([]ColeccionRecetaDTO, 0, len(col.Recetas))
+		for _, r := range col.Recetas {
+			dto.Recetas = append(dto.Recetas, ColeccionRecetaDTO{
+				Posicion:   r.Posicion,
+				AgregadaEn: r.AgregadaEn.Format(time.RFC3339),
+				Receta:     recetas.ToRecetaResponseDTO(r.Receta, lector),
+			})
+		}
+	}
+	return dto
+}
+
+// usuarioIDDesdeContexto obtiene el ID del usuario autenticado (lo deja el middleware de Auth).
+func usuarioIDDesdeContexto(c *gin.Context) (uint, error) {
+	if v, exists := c.Get("userID"); exists {
+		if uid, ok := v.(uint); ok {
+			return uid, nil
+		}
+	}
+	return 0, errUsuarioNoIdentificado
+}
+
+func parseIDParam(c *gin.Context, nombre string) (uint, error) {
+	idStr := c.Param(nombre)
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parámetro %s inválido: %s - %w", nombre, idStr, err)
+	}
+	return uint(idUint64), nil
+}
+
+// usuarioYParam combina la obtención del usuario y de un ID de la URL.
+func usuarioYParam(c *gin.Context, nombre string) (uint, uint, error) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	id, err := parseIDParam(c, nombre)
+	if err != nil {
+		return 0, 0, err
+	}
+	return userID, id, nil
+}
+
+// --- Favoritos ---
+
+// GetFavoritos godoc
+// @Summary Lista mis recetas favoritas
+// @Tags Favoritos
+// @Produce json
+// @Success 200 {array} recetas.RecetaResponseDTO "Recetas favoritas"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/favoritos [get]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) GetFavoritos(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	favoritas, err := h.service.ListarFavoritos(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]recetas.RecetaResponseDTO, 0, len(favoritas))
+	esFavorito := true
+	for _, r := range favoritas {
+		dto := recetas.ToRecetaResponseDTO(r, recetas.LectorUsuario(userID))
+		dto.EsFavorito = &esFavorito
+		responseDTOs = append(responseDTOs, dto)
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// AddFavorito godoc
+// @Summary Marca una receta como favorita
+// @Description Idempotente: marcar una receta que ya es favorita no es un error.
+// @Tags Favoritos
+// @Produce json
+// @Param receta_id path uint true "ID de la Receta"
+// @Success 204 "Receta marcada como favorita"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/favoritos/{receta_id} [put]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) AddFavorito(c *gin.Context) {
+	userID, recetaID, err := usuarioYParam(c, "receta_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.AgregarFavorito(c.Request.Context(), userID, recetaID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveFavorito godoc
+// @Summary Quita una receta de favoritos
+// @Tags Favoritos
+// @Produce json
+// @Param receta_id path uint true "ID de la Receta"
+// @Success 204 "Receta quitada de favoritos"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "La receta no estaba en favoritos"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/favoritos/{receta_id} [delete]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) RemoveFavorito(c *gin.Context) {
+	userID, recetaID, err := usuarioYParam(c, "receta_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.QuitarFavorito(c.Request.Context(), userID, recetaID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// --- Colecciones ---
+
+// GetColecciones godoc
+// @Summary Lista mis colecciones
+// @Tags Colecciones
+// @Produce json
+// @Success 200 {array} ColeccionResponseDTO "Colecciones del usuario (sin detalle de recetas)"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones [get]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) GetColecciones(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	colecciones, err := h.service.ListarColecciones(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]ColeccionResponseDTO, 0, len(colecciones))
+	for _, col := range colecciones {
+		responseDTOs = append(responseDTOs, mapColeccionToResponseDTO(col, true))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// CreateColeccion godoc
+// @Summary Crea una colección
+// @Tags Colecciones
+// @Accept json
+// @Produce json
+// @Param coleccion body ColeccionRequestDTO true "Datos de la colección"
+// @Success 201 {object} ColeccionResponseDTO "Colección creada"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones [post]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) CreateColeccion(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req ColeccionRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	coleccion, err := h.service.CrearColeccion(c.Request.Context(), userID, ColeccionInput{Nombre: req.Nombre, Descripcion: req.Descripcion})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapColeccionToResponseDTO(*coleccion, true))
+}
+
+// GetColeccion godoc
+// @Summary Obtiene una colección con sus recetas
+// @Tags Colecciones
+// @Produce json
+// @Param id path uint true "ID de la Colección"
+// @Success 200 {object} ColeccionResponseDTO "Colección con recetas ordenadas"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id} [get]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) GetColeccion(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	coleccion, err := h.service.ObtenerColeccion(c.Request.Context(), userID, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapColeccionToResponseDTO(*coleccion, true))
+}
+
+// UpdateColeccion godoc
+// @Summary Actualiza nombre y descripción de una colección
+// @Tags Colecciones
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Colección"
+// @Param coleccion body ColeccionRequestDTO true "Datos de la colección"
+// @Success 200 {object} ColeccionResponseDTO "Colección actualizada"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id} [put]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) UpdateColeccion(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req ColeccionRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	coleccion, err := h.service.ActualizarColeccion(c.Request.Context(), userID, id, ColeccionInput{Nombre: req.Nombre, Descripcion: req.Descripcion})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapColeccionToResponseDTO(*coleccion, true))
+}
+
+// DeleteColeccion godoc
+// @Summary Elimina una colección
+// @Description Elimina la colección; las recetas no se modifican.
+// @Tags Colecciones
+// @Param id path uint true "ID de la Colección"
+// @Success 204 "Colección eliminada"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id} [delete]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) DeleteColeccion(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.EliminarColeccion(c.Request.Context(), userID, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddReceta godoc
+// @Summary Añade una receta a una colección
+// @Tags Colecciones
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Colección"
+// @Param receta body AgregarRecetaColeccionDTO true "Receta y posición opcional"
+// @Success 200 {object} ColeccionResponseDTO "Colección actualizada"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección o receta no encontrada"
+// @Failure 409 {object} apitypes.ErrorResponse "La receta ya está en la colección"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id}/recetas [post]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) AddReceta(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req AgregarRecetaColeccionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	coleccion, err := h.service.AgregarRecetaAColeccion(c.Request.Context(), userID, id, AgregarRecetaInput{RecetaID: req.RecetaID, Posicion: req.Posicion})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapColeccionToResponseDTO(*coleccion, true))
+}
+
+// RemoveReceta godoc
+// @Summary Quita una receta de una colección
+// @Tags Colecciones
+// @Param id path uint true "ID de la Colección"
+// @Param receta_id path uint true "ID de la Receta"
+// @Success 204 "Receta quitada"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección no encontrada o receta no incluida"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id}/recetas/{receta_id} [delete]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) RemoveReceta(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	recetaID, err := parseIDParam(c, "receta_id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.QuitarRecetaDeColeccion(c.Request.Context(), userID, id, recetaID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ReorderRecetas godoc
+// @Summary Reordena las recetas de una colección
+// @Tags Colecciones
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Colección"
+// @Param orden body ReordenarColeccionDTO true "IDs de todas las recetas en el nuevo orden"
+// @Success 200 {object} ColeccionResponseDTO "Colección reordenada"
+// @Failure 400 {object} apitypes.ErrorResponse "Orden inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id}/recetas/orden [put]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) ReorderRecetas(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req ReordenarColeccionDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	coleccion, err := h.service.ReordenarColeccion(c.Request.Context(), userID, id, req.RecetaIDs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapColeccionToResponseDTO(*coleccion, true))
+}
+
+// Compartir godoc
+// @Summary Genera un enlace público para la colección
+// @Description Crea un token no adivinable. Si ya estaba compartida, el enlace anterior deja de funcionar.
+// @Tags Colecciones
+// @Produce json
+// @Param id path uint true "ID de la Colección"
+// @Success 200 {object} ColeccionResponseDTO "Colección compartida (incluye token_publico)"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id}/compartir [post]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) Compartir(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	coleccion, err := h.service.Compartir(c.Request.Context(), userID, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapColeccionToResponseDTO(*coleccion, true))
+}
+
+// DejarDeCompartir godoc
+// @Summary Revoca el enlace público de la colección
+// @Tags Colecciones
+// @Param id path uint true "ID de la Colección"
+// @Success 204 "Enlace revocado"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Colección no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/colecciones/{id}/compartir [delete]
+// @Security ApiKeyAuth
+func (h *FavoritoHandler) DejarDeCompartir(c *gin.Context) {
+	userID, id, err := usuarioYParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.DejarDeCompartir(c.Request.Context(), userID, id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetColeccionCompartida godoc
+// @Summary Obtiene una colección compartida por su enlace público
+// @Tags Colecciones
+// @Produce json
+// @Param token path string true "Token público de la colección"
+// @Success 200 {object} ColeccionResponseDTO "Colección compartida"
+// @Failure 404 {object} apitypes.ErrorResponse "Enlace inválido o revocado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /colecciones/compartidas/{token} [get]
+func (h *FavoritoHandler) GetColeccionCompartida(c *gin.Context) {
+	coleccion, err := h.service.ObtenerColeccionCompartida(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapColeccionToResponseDTO(*coleccion, false))
+}
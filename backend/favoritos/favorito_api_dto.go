@@ -0,0 +1,42 @@
+// backend/favoritos/favorito_api_dto.go
+// DTOs de la API para Favoritos y Colecciones.
+package favoritos
+
+import "backend/recetas" // Las colecciones anidan el DTO de receta
+
+// ColeccionRequestDTO es el DTO de entrada para crear o actualizar una colección.
+type ColeccionRequestDTO struct {
+	Nombre      string `json:"nombre" binding:"required,min=1,max=100" example:"Cenas rápidas"`
+	Descripcion string `json:"descripcion" binding:"max=500" example:"Recetas de menos de 30 minutos"`
+}
+
+// AgregarRecetaColeccionDTO es el DTO de entrada para añadir una receta a una colección.
+type AgregarRecetaColeccionDTO struct {
+	RecetaID uint `json:"receta_id" binding:"required,gt=0" example:"7"`
+	Posicion *int `json:"posicion,omitempty" binding:"omitempty,gte=0" example:"0"` // Opcional: por defecto al final
+}
+
+// ReordenarColeccionDTO es el DTO de entrada para reordenar las recetas de una colección.
+type ReordenarColeccionDTO struct {
+	RecetaIDs []uint `json:"receta_ids" binding:"required" example:"7,3,12"` // Todas las recetas, en el nuevo orden
+}
+
+// ColeccionRecetaDTO es una receta dentro de una colección.
+type ColeccionRecetaDTO struct {
+	Posicion   int                       `json:"posicion" example:"0"`
+	AgregadaEn string                    `json:"agregada_en" example:"2025-05-17T10:00:00Z"`
+	Receta     recetas.RecetaResponseDTO `json:"receta"`
+}
+
+// ColeccionResponseDTO es el DTO de salida de una colección.
+type ColeccionResponseDTO struct {
+	ID           uint                 `json:"id" example:"4"`
+	Nombre       string               `json:"nombre" example:"Cenas rápidas"`
+	Descripcion  string               `json:"descripcion,omitempty" example:"Recetas de menos de 30 minutos"`
+	Compartida   bool                 `json:"compartida" example:"true"`
+	TokenPublico string               `json:"token_publico,omitempty" example:"9f86d081884c7d65..."` // Solo para el dueño
+	TotalRecetas int                  `json:"total_recetas" example:"3"`
+	Recetas      []ColeccionRecetaDTO `json:"recetas,omitempty"` // Solo en el detalle
+	CreatedAt    string               `json:"created_at" example:"2025-05-17T10:00:00Z"`
+	UpdatedAt    string               `json:"updated_at" example:"2025-05-17T10:00:00Z"`
+}
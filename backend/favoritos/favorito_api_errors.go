@@ -0,0 +1,22 @@
+// backend/favoritos/favorito_api_errors.go
+
+// Traducción de los errores de dominio de favoritos y colecciones a respuestas HTTP (ver shared/apperrors).
+
+package favoritos
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrFavoritoNotFound, http.StatusNotFound, "favorito_no_encontrado")
+	apperrors.Registrar(ErrColeccionNotFound, http.StatusNotFound, "coleccion_no_encontrada")
+	apperrors.Registrar(ErrRecetaNoEnColeccion, http.StatusNotFound, "receta_no_en_coleccion")
+
+	apperrors.Registrar(ErrRecetaYaEnColeccion, http.StatusConflict, "receta_ya_en_coleccion")
+
+	apperrors.Registrar(ErrColeccionNombreInvalido, http.StatusBadRequest, "coleccion_nombre_invalido")
+	apperrors.Registrar(ErrOrdenColeccionInvalido, http.StatusBadRequest, "coleccion_orden_invalido")
+}
@@ -0,0 +1,59 @@
+// Archivo: backend/favoritos/favorito_model.go
+// Funcionalidad: Modelo de dominio para Favoritos y Colecciones personales de recetas.
+// Capa: Dominio / Lógica de negocio.
+
+// Descripción:
+// Un usuario puede marcar recetas como favoritas y organizarlas en colecciones
+// con nombre ("Navidad", "Cenas rápidas"). Cada colección mantiene el orden de
+// sus recetas y puede compartirse opcionalmente mediante un enlace público con
+// un token no adivinable.
+//
+// Reglas de Negocio:
+// - Marcar como favorita una receta ya favorita no es un error (idempotente).
+// - Una receta aparece como máximo una vez por colección.
+// - Las colecciones solo son visibles para su dueño, salvo por el enlace público.
+// - Revocar el enlace público invalida el token anterior de forma definitiva.
+
+package favoritos
+
+import (
+	"errors"
+	"time"
+
+	"backend/recetas" // Las colecciones contienen recetas de dominio
+)
+
+// Coleccion representa una colección personal de recetas.
+type Coleccion struct {
+	ID           uint
+	UserID       uint                // Dueño de la colección
+	Nombre       string              // Nombre visible (ej: "Navidad")
+	Descripcion  string              // Descripción opcional
+	TokenPublico *string             // Token del enlace público (nil = no compartida)
+	TotalRecetas int                 // Cantidad de recetas (se llena en los listados)
+	Recetas      []RecetaEnColeccion // Recetas ordenadas por Posicion (solo en el detalle)
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Compartida indica si la colección tiene un enlace público activo.
+func (c Coleccion) Compartida() bool {
+	return c.TokenPublico != nil && *c.TokenPublico != ""
+}
+
+// RecetaEnColeccion es una receta dentro de una colección, con su posición.
+type RecetaEnColeccion struct {
+	Receta     recetas.Receta
+	Posicion   int
+	AgregadaEn time.Time
+}
+
+// Errores específicos del dominio de Favoritos/Colecciones.
+var (
+	ErrFavoritoNotFound        = errors.New("la receta no está en favoritos")
+	ErrColeccionNotFound       = errors.New("colección no encontrada")
+	ErrColeccionNombreInvalido = errors.New("el nombre de la colección no es válido o está vacío")
+	ErrRecetaYaEnColeccion     = errors.New("la receta ya está en la colección")
+	ErrRecetaNoEnColeccion     = errors.New("la receta no está en la colección")
+	ErrOrdenColeccionInvalido  = errors.New("el nuevo orden debe incluir exactamente las recetas de la colección")
+)
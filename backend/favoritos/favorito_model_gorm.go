@@ -0,0 +1,104 @@
+// backend/favoritos/favorito_model_gorm.go
+
+// Este archivo define los modelos de persistencia para favoritos y colecciones.
+// Utiliza GORM para la definición de las tablas y el mapeo de campos.
+
+package favoritos
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/recetas" // Para la relación con recetas.RecetaModel
+)
+
+// FavoritoModel representa la tabla 'favoritos' (relación usuario-receta).
+type FavoritoModel struct {
+	UserID    uint `gorm:"primaryKey;autoIncrement:false"`
+	RecetaID  uint `gorm:"primaryKey;autoIncrement:false;index"`
+	CreatedAt time.Time
+
+	Receta recetas.RecetaModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (FavoritoModel) TableName() string {
+	return "favoritos"
+}
+
+// ColeccionModel representa la tabla 'colecciones'.
+type ColeccionModel struct {
+	ID           uint    `gorm:"primaryKey"`
+	UserID       uint    `gorm:"not null;index"`
+	Nombre       string  `gorm:"type:varchar(100);not null"`
+	Descripcion  string  `gorm:"type:varchar(500);default:null"`
+	TokenPublico *string `gorm:"type:varchar(64);uniqueIndex:uk_colecciones_token_publico;default:null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+
+	Recetas []ColeccionRecetaModel `gorm:"foreignKey:ColeccionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (ColeccionModel) TableName() string {
+	return "colecciones"
+}
+
+// ColeccionRecetaModel representa la tabla 'coleccion_recetas' (recetas ordenadas de una colección).
+type ColeccionRecetaModel struct {
+	ColeccionID uint `gorm:"primaryKey;autoIncrement:false"`
+	RecetaID    uint `gorm:"primaryKey;autoIncrement:false;index"`
+	Posicion    int  `gorm:"not null;default:0"`
+	CreatedAt   time.Time
+
+	Receta recetas.RecetaModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (ColeccionRecetaModel) TableName() string {
+	return "coleccion_recetas"
+}
+
+// --- Funciones de Mapeo ---
+
+// ToDomain convierte el modelo de colección al dominio.
+// Las recetas solo se mapean si fueron precargadas; las que están soft-deleted
+// (Receta.ID == 0 tras el Preload) se omiten.
+func (m *ColeccionModel) ToDomain() *Coleccion {
+	if m == nil {
+		return nil
+	}
+	coleccion := &Coleccion{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		Nombre:       m.Nombre,
+		Descripcion:  m.Descripcion,
+		TokenPublico: m.TokenPublico,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+	for _, cr := range m.Recetas {
+		if cr.Receta.ID == 0 {
+			continue
+		}
+		coleccion.Recetas = append(coleccion.Recetas, RecetaEnColeccion{
+			Receta:     *cr.Receta.ToDomain(),
+			Posicion:   cr.Posicion,
+			AgregadaEn: cr.CreatedAt,
+		})
+	}
+	coleccion.TotalRecetas = len(coleccion.Recetas)
+	return coleccion
+}
+
+func FromColeccionDomain(d *Coleccion) *ColeccionModel {
+	if d == nil {
+		return nil
+	}
+	return &ColeccionModel{
+		ID:           d.ID,
+		UserID:       d.UserID,
+		Nombre:       d.Nombre,
+		Descripcion:  d.Descripcion,
+		TokenPublico: d.TokenPublico,
+	}
+}
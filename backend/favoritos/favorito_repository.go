@@ -0,0 +1,45 @@
+// backend/favoritos/favorito_repository.go
+// Funcionalidad: Interfaz para la persistencia de Favoritos y Colecciones.
+// Capa: Repositorio (Abstracción).
+package favoritos
+
+import (
+	"context"
+
+	"backend/recetas"
+)
+
+// FavoritoRepository define el contrato para las operaciones de datos de Favoritos y Colecciones.
+type FavoritoRepository interface {
+	// --- Favoritos ---
+
+	// AddFavorito marca una receta como favorita. Si ya lo era, no hace nada.
+	AddFavorito(ctx context.Context, userID, recetaID uint) error
+	// RemoveFavorito quita una receta de favoritos (repository.ErrRecordNotFound si no lo era).
+	RemoveFavorito(ctx context.Context, userID, recetaID uint) error
+	// FindFavoritos devuelve las recetas favoritas del usuario, las más recientes primero.
+	FindFavoritos(ctx context.Context, userID uint) ([]recetas.Receta, error)
+	// FindFavoritoIDs devuelve cuáles de los recetaIDs dados son favoritos del usuario.
+	FindFavoritoIDs(ctx context.Context, userID uint, recetaIDs []uint) ([]uint, error)
+
+	// --- Colecciones ---
+
+	CreateColeccion(ctx context.Context, coleccion *Coleccion) error
+	// GetColeccionByID devuelve la colección con sus recetas ordenadas por posición.
+	GetColeccionByID(ctx context.Context, id uint) (*Coleccion, error)
+	// GetColeccionByToken devuelve la colección compartida con ese token público, solo con
+	// sus recetas publicadas (el enlace lo ve cualquiera).
+	GetColeccionByToken(ctx context.Context, token string) (*Coleccion, error)
+	// FindColeccionesByUserID devuelve las colecciones del usuario (con TotalRecetas, sin el detalle).
+	FindColeccionesByUserID(ctx context.Context, userID uint) ([]Coleccion, error)
+	// UpdateColeccion actualiza nombre, descripción y token público.
+	UpdateColeccion(ctx context.Context, coleccion *Coleccion) error
+	DeleteColeccion(ctx context.Context, id uint) error
+
+	// AddRecetaToColeccion inserta la receta en la posición dada (nil = al final),
+	// desplazando las siguientes. Devuelve repository.ErrDuplicateRecord si ya estaba.
+	AddRecetaToColeccion(ctx context.Context, coleccionID, recetaID uint, posicion *int) error
+	RemoveRecetaFromColeccion(ctx context.Context, coleccionID, recetaID uint) error
+	// ReorderColeccion asigna a cada receta la posición de su índice en recetaIDs.
+	ReorderColeccion(ctx context.Context, coleccionID uint, recetaIDs []uint) error
+}
@@ -0,0 +1,278 @@
+// backend/favoritos/favorito_repository_gorm.go
+// Funcionalidad: Implementación GORM de FavoritoRepository.
+// Capa: Repositorio (Implementación de Persistencia).
+package favoritos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"backend/recetas"
+	"backend/shared/repository"
+)
+
+type gormFavoritoRepository struct {
+	db *gorm.DB
+}
+
+// NewFavoritoRepository crea una instancia de la implementación GORM de FavoritoRepository.
+func NewFavoritoRepository(db *gorm.DB) FavoritoRepository {
+	return &gormFavoritoRepository{db: db}
+}
+
+// --- Favoritos ---
+
+func (r *gormFavoritoRepository) AddFavorito(ctx context.Context, userID, recetaID uint) error {
+	model := &FavoritoModel{UserID: userID, RecetaID: recetaID}
+	// ON DUPLICATE KEY: si ya era favorita, no hacer nada (idempotente).
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm favoritos: addfavorito user %d receta %d: %w", userID, recetaID, repository.TraducirError(err))
+	}
+	return nil
+}
+
+func (r *gormFavoritoRepository) RemoveFavorito(ctx context.Context, userID, recetaID uint) error {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND receta_id = ?", userID, recetaID).Delete(&FavoritoModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm favoritos: removefavorito user %d receta %d: %w", userID, recetaID, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormFavoritoRepository) FindFavoritos(ctx context.Context, userID uint) ([]recetas.Receta, error) {
+	var models []FavoritoModel
+	err := r.db.WithContext(ctx).
+		Preload("Receta").Preload("Receta.Categoria").Preload("Receta.FotoMedia").
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm favoritos: findfavoritos user %d: %w", userID, err)
+	}
+
+	favoritas := make([]recetas.Receta, 0, len(models))
+	for _, m := range models {
+		if m.Receta.ID == 0 { // Receta eliminada (soft delete)
+			continue
+		}
+		favoritas = append(favoritas, *m.Receta.ToDomain())
+	}
+	return favoritas, nil
+}
+
+func (r *gormFavoritoRepository) FindFavoritoIDs(ctx context.Context, userID uint, recetaIDs []uint) ([]uint, error) {
+	ids := make([]uint, 0)
+	if len(recetaIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.WithContext(ctx).Model(&FavoritoModel{}).
+		Where("user_id = ? AND receta_id IN ?", userID, recetaIDs).
+		Pluck("receta_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm favoritos: findfavoritoids user %d: %w", userID, err)
+	}
+	return ids, nil
+}
+
+// --- Colecciones ---
+
+func (r *gormFavoritoRepository) CreateColeccion(ctx context.Context, coleccion *Coleccion) error {
+	model := FromColeccionDomain(coleccion)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm favoritos: createcoleccion: %w", repository.TraducirError(err))
+	}
+	coleccion.ID = model.ID
+	coleccion.CreatedAt = model.CreatedAt
+	coleccion.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// preloadRecetasOrdenadas precarga las recetas de la colección (y su categoría) en orden.
+// Con 'soloPublicadas' (enlace compartido, lo ve cualquiera) omite las que no están publicadas.
+func preloadRecetasOrdenadas(db *gorm.DB, soloPublicadas bool) *gorm.DB {
+	receta := func(tx *gorm.DB) *gorm.DB {
+		if soloPublicadas {
+			return tx.Where("estado = ?", recetas.EstadoPublicada)
+		}
+		return tx
+	}
+	return db.
+		Preload("Recetas", func(tx *gorm.DB) *gorm.DB { return tx.Order("posicion asc") }).
+		Preload("Recetas.Receta", receta).
+		Preload("Recetas.Receta.Categoria").
+		Preload("Recetas.Receta.FotoMedia")
+}
+
+func (r *gormFavoritoRepository) GetColeccionByID(ctx context.Context, id uint) (*Coleccion, error) {
+	var model ColeccionModel
+	if err := preloadRecetasOrdenadas(r.db.WithContext(ctx), false).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm favoritos: getcoleccionbyid %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormFavoritoRepository) GetColeccionByToken(ctx context.Context, token string) (*Coleccion, error) {
+	var model ColeccionModel
+	err := preloadRecetasOrdenadas(r.db.WithContext(ctx), true).
+		Where("token_publico = ?", token).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm favoritos: getcoleccionbytoken: %w", err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormFavoritoRepository) FindColeccionesByUserID(ctx context.Context, userID uint) ([]Coleccion, error) {
+	var models []ColeccionModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("nombre asc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm favoritos: findcoleccionesbyuserid %d: %w", userID, err)
+	}
+
+	// Contar recetas (no eliminadas) por colección en una sola consulta.
+	type conteo struct {
+		ColeccionID uint
+		Total       int
+	}
+	var conteos []conteo
+	if len(models) > 0 {
+		ids := make([]uint, 0, len(models))
+		for _, m := range models {
+			ids = append(ids, m.ID)
+		}
+		err := r.db.WithContext(ctx).Model(&ColeccionRecetaModel{}).
+			Select("coleccion_recetas.coleccion_id AS coleccion_id, COUNT(*) AS total").
+			Joins("JOIN recetas ON recetas.id = coleccion_recetas.receta_id AND recetas.deleted_at IS NULL").
+			Where("coleccion_recetas.coleccion_id IN ?", ids).
+			Group("coleccion_recetas.coleccion_id").
+			Scan(&conteos).Error
+		if err != nil {
+			return nil, fmt.Errorf("repo gorm favoritos: contar recetas de colecciones: %w", err)
+		}
+	}
+	totales := make(map[uint]int, len(conteos))
+	for _, c := range conteos {
+		totales[c.ColeccionID] = c.Total
+	}
+
+	colecciones := make([]Coleccion, 0, len(models))
+	for i := range models {
+		c := models[i].ToDomain()
+		c.TotalRecetas = totales[c.ID]
+		colecciones = append(colecciones, *c)
+	}
+	return colecciones, nil
+}
+
+func (r *gormFavoritoRepository) UpdateColeccion(ctx context.Context, coleccion *Coleccion) error {
+	// Usar un map para poder poner token_publico a NULL al dejar de compartir.
+	result := r.db.WithContext(ctx).Model(&ColeccionModel{}).Where("id = ?", coleccion.ID).Updates(map[string]interface{}{
+		"nombre":        coleccion.Nombre,
+		"descripcion":   coleccion.Descripcion,
+		"token_publico": coleccion.TokenPublico,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm favoritos: updatecoleccion %d: %w", coleccion.ID, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormFavoritoRepository) DeleteColeccion(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&ColeccionModel{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm favoritos: deletecoleccion %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormFavoritoRepository) AddRecetaToColeccion(ctx context.Context, coleccionID, recetaID uint, posicion *int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existentes int64
+		if err := tx.Model(&ColeccionRecetaModel{}).
+			Where("coleccion_id = ? AND receta_id = ?", coleccionID, recetaID).
+			Count(&existentes).Error; err != nil {
+			return fmt.Errorf("repo gorm favoritos: addrecetatocoleccion verificar: %w", err)
+		}
+		if existentes > 0 {
+			return repository.ErrDuplicateRecord
+		}
+
+		var total int64
+		if err := tx.Model(&ColeccionRecetaModel{}).Where("coleccion_id = ?", coleccionID).Count(&total).Error; err != nil {
+			return fmt.Errorf("repo gorm favoritos: addrecetatocoleccion contar: %w", err)
+		}
+
+		destino := int(total) // Por defecto, al final
+		if posicion != nil && *posicion < destino {
+			destino = *posicion
+			// Abrir hueco desplazando las recetas siguientes
+			if err := tx.Model(&ColeccionRecetaModel{}).
+				Where("coleccion_id = ? AND posicion >= ?", coleccionID, destino).
+				Update("posicion", gorm.Expr("posicion + 1")).Error; err != nil {
+				return fmt.Errorf("repo gorm favoritos: addrecetatocoleccion desplazar: %w", repository.TraducirError(err))
+			}
+		}
+
+		model := &ColeccionRecetaModel{ColeccionID: coleccionID, RecetaID: recetaID, Posicion: destino}
+		if err := tx.Create(model).Error; err != nil {
+			return fmt.Errorf("repo gorm favoritos: addrecetatocoleccion crear: %w", repository.TraducirError(err))
+		}
+		return nil
+	})
+}
+
+func (r *gormFavoritoRepository) RemoveRecetaFromColeccion(ctx context.Context, coleccionID, recetaID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model ColeccionRecetaModel
+		err := tx.Where("coleccion_id = ? AND receta_id = ?", coleccionID, recetaID).First(&model).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repository.ErrRecordNotFound
+			}
+			return fmt.Errorf("repo gorm favoritos: removerecetafromcoleccion buscar: %w", err)
+		}
+
+		if err := tx.Where("coleccion_id = ? AND receta_id = ?", coleccionID, recetaID).Delete(&ColeccionRecetaModel{}).Error; err != nil {
+			return fmt.Errorf("repo gorm favoritos: removerecetafromcoleccion borrar: %w", repository.TraducirError(err))
+		}
+		// Cerrar el hueco para mantener posiciones consecutivas
+		if err := tx.Model(&ColeccionRecetaModel{}).
+			Where("coleccion_id = ? AND posicion > ?", coleccionID, model.Posicion).
+			Update("posicion", gorm.Expr("posicion - 1")).Error; err != nil {
+			return fmt.Errorf("repo gorm favoritos: removerecetafromcoleccion compactar: %w", repository.TraducirError(err))
+		}
+		return nil
+	})
+}
+
+func (r *gormFavoritoRepository) ReorderColeccion(ctx context.Context, coleccionID uint, recetaIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, recetaID := range recetaIDs {
+			result := tx.Model(&ColeccionRecetaModel{}).
+				Where("coleccion_id = ? AND receta_id = ?", coleccionID, recetaID).
+				Update("posicion", i)
+			if result.Error != nil {
+				return fmt.Errorf("repo gorm favoritos: reordercoleccion %d: %w", coleccionID, repository.TraducirError(result.Error))
+			}
+		}
+		return nil
+	})
+}
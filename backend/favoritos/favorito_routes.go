@@ -0,0 +1,37 @@
+// backend/favoritos/favorito_routes.go
+package favoritos
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterFavoritoRoutes registra las rutas de Favoritos y Colecciones.
+// requireAuth es el middleware que exige un usuario autenticado (se inyecta
+// desde main para no depender del paquete de middleware).
+func RegisterFavoritoRoutes(apiBaseGroup *gin.RouterGroup, h *FavoritoHandler, requireAuth gin.HandlerFunc) {
+	// Rutas del usuario autenticado: /api/v1/me/...
+	meRoutes := apiBaseGroup.Group("/me", requireAuth)
+	{
+		meRoutes.GET("/favoritos", h.GetFavoritos)
+		meRoutes.PUT("/favoritos/:receta_id", h.AddFavorito)
+		meRoutes.DELETE("/favoritos/:receta_id", h.RemoveFavorito)
+
+		meRoutes.GET("/colecciones", h.GetColecciones)
+		meRoutes.POST("/colecciones", h.CreateColeccion)
+		meRoutes.GET("/colecciones/:id", h.GetColeccion)
+		meRoutes.PUT("/colecciones/:id", h.UpdateColeccion)
+		meRoutes.DELETE("/colecciones/:id", h.DeleteColeccion)
+		meRoutes.POST("/colecciones/:id/recetas", h.AddReceta)
+		meRoutes.PUT("/colecciones/:id/recetas/orden", h.ReorderRecetas)
+		meRoutes.DELETE("/colecciones/:id/recetas/:receta_id", h.RemoveReceta)
+		meRoutes.POST("/colecciones/:id/compartir", h.Compartir)
+		meRoutes.DELETE("/colecciones/:id/compartir", h.DejarDeCompartir)
+	}
+
+	// Ruta pública del enlace compartido: /api/v1/colecciones/compartidas/:token
+	apiBaseGroup.GET("/colecciones/compartidas/:token", h.GetColeccionCompartida)
+
+	log.Println("🛣️  Rutas de Favoritos y Colecciones configuradas.")
+}
@@ -0,0 +1,320 @@
+// backend/favoritos/favorito_service.go
+// Funcionalidad: Lógica de negocio para Favoritos y Colecciones personales.
+// Capa: Servicio / Casos de Uso.
+package favoritos
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log" // Temporal, reemplazar con logger estructurado
+	"strings"
+
+	"backend/recetas" // Para validar que las recetas existan
+	"backend/shared/repository"
+)
+
+// FavoritoService define el contrato para la lógica de negocio de Favoritos y Colecciones.
+// Todas las operaciones sobre colecciones reciben el userID del dueño: una colección
+// de otro usuario se trata como inexistente (ErrColeccionNotFound).
+type FavoritoService interface {
+	AgregarFavorito(ctx context.Context, userID, recetaID uint) error
+	QuitarFavorito(ctx context.Context, userID, recetaID uint) error
+	ListarFavoritos(ctx context.Context, userID uint) ([]recetas.Receta, error)
+	RecetasFavoritas(ctx context.Context, userID uint, recetaIDs []uint) (map[uint]bool, error) // Cumple recetas.FavoritosResolver
+
+	CrearColeccion(ctx context.Context, userID uint, input ColeccionInput) (*Coleccion, error)
+	ListarColecciones(ctx context.Context, userID uint) ([]Coleccion, error)
+	ObtenerColeccion(ctx context.Context, userID, coleccionID uint) (*Coleccion, error)
+	ActualizarColeccion(ctx context.Context, userID, coleccionID uint, input ColeccionInput) (*Coleccion, error)
+	EliminarColeccion(ctx context.Context, userID, coleccionID uint) error
+
+	AgregarRecetaAColeccion(ctx context.Context, userID, coleccionID uint, input AgregarRecetaInput) (*Coleccion, error)
+	QuitarRecetaDeColeccion(ctx context.Context, userID, coleccionID, recetaID uint) error
+	ReordenarColeccion(ctx context.Context, userID, coleccionID uint, recetaIDs []uint) (*Coleccion, error)
+
+	Compartir(ctx context.Context, userID, coleccionID uint) (*Coleccion, error) // Genera (o regenera) el token público
+	DejarDeCompartir(ctx context.Context, userID, coleccionID uint) error
+	ObtenerColeccionCompartida(ctx context.Context, token string) (*Coleccion, error)
+}
+
+type favoritoService struct {
+	repo      FavoritoRepository
+	recetaSvc recetas.RecetaService // Dependencia del servicio de recetas
+}
+
+// NewFavoritoService crea una nueva instancia de FavoritoService.
+func NewFavoritoService(repo FavoritoRepository, recetaSvc recetas.RecetaService) FavoritoService {
+	return &favoritoService{repo: repo, recetaSvc: recetaSvc}
+}
+
+// --- Favoritos ---
+
+// AgregarFavorito marca una receta como favorita del usuario (idempotente).
+func (s *favoritoService) AgregarFavorito(ctx context.Context, userID, recetaID uint) error {
+	if _, err := s.recetaSvc.ObtenerVisible(ctx, recetaID, recetas.LectorUsuario(userID)); err != nil {
+		return fmt.Errorf("servicio favoritos: error validando receta %d: %w", recetaID, err)
+	}
+	if err := s.repo.AddFavorito(ctx, userID, recetaID); err != nil {
+		return fmt.Errorf("servicio favoritos: error agregando favorito: %w", err)
+	}
+	log.Printf("Servicio: Receta ID %d marcada como favorita por UserID %d\n", recetaID, userID)
+	return nil
+}
+
+// QuitarFavorito quita una receta de los favoritos del usuario.
+func (s *favoritoService) QuitarFavorito(ctx context.Context, userID, recetaID uint) error {
+	if err := s.repo.RemoveFavorito(ctx, userID, recetaID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrFavoritoNotFound
+		}
+		return fmt.Errorf("servicio favoritos: error quitando favorito: %w", err)
+	}
+	return nil
+}
+
+// ListarFavoritos devuelve las recetas favoritas del usuario. Omite las que ya no
+// puede ver (p. ej. despublicadas por su autor tras marcarlas).
+func (s *favoritoService) ListarFavoritos(ctx context.Context, userID uint) ([]recetas.Receta, error) {
+	favoritas, err := s.repo.FindFavoritos(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio favoritos: error listando favoritos de user %d: %w", userID, err)
+	}
+	lector := recetas.LectorUsuario(userID)
+	visibles := make([]recetas.Receta, 0, len(favoritas))
+	for _, r := range favoritas {
+		if r.VisiblePara(lector) {
+			visibles = append(visibles, r)
+		}
+	}
+	return visibles, nil
+}
+
+// RecetasFavoritas indica, para cada receta dada, si es favorita del usuario.
+// Las recetas que no son favoritas no aparecen en el mapa.
+func (s *favoritoService) RecetasFavoritas(ctx context.Context, userID uint, recetaIDs []uint) (map[uint]bool, error) {
+	ids, err := s.repo.FindFavoritoIDs(ctx, userID, recetaIDs)
+	if err != nil {
+		return nil, fmt.Errorf("servicio favoritos: error consultando favoritos de user %d: %w", userID, err)
+	}
+	favoritas := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		favoritas[id] = true
+	}
+	return favoritas, nil
+}
+
+// --- Colecciones ---
+
+// CrearColeccion crea una colección vacía para el usuario.
+func (s *favoritoService) CrearColeccion(ctx context.Context, userID uint, input ColeccionInput) (*Coleccion, error) {
+	nombre := strings.TrimSpace(input.Nombre)
+	if nombre == "" {
+		return nil, ErrColeccionNombreInvalido
+	}
+	coleccion := &Coleccion{
+		UserID:      userID,
+		Nombre:      nombre,
+		Descripcion: strings.TrimSpace(input.Descripcion),
+	}
+	if err := s.repo.CreateColeccion(ctx, coleccion); err != nil {
+		return nil, fmt.Errorf("servicio favoritos: error creando colección: %w", err)
+	}
+	log.Printf("Servicio: Colección ID %d creada para UserID %d\n", coleccion.ID, userID)
+	return coleccion, nil
+}
+
+// ListarColecciones devuelve las colecciones del usuario.
+func (s *favoritoService) ListarColecciones(ctx context.Context, userID uint) ([]Coleccion, error) {
+	colecciones, err := s.repo.FindColeccionesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio favoritos: error listando colecciones de user %d: %w", userID, err)
+	}
+	return colecciones, nil
+}
+
+// ObtenerColeccion devuelve una colección del usuario con sus recetas.
+func (s *favoritoService) ObtenerColeccion(ctx context.Context, userID, coleccionID uint) (*Coleccion, error) {
+	coleccion, err := s.repo.GetColeccionByID(ctx, coleccionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrColeccionNotFound
+		}
+		return nil, fmt.Errorf("servicio favoritos: error obteniendo colección %d: %w", coleccionID, err)
+	}
+	if coleccion.UserID != userID {
+		return nil, ErrColeccionNotFound // No revelar que existe
+	}
+	return coleccion, nil
+}
+
+// ActualizarColeccion cambia nombre y descripción de una colección.
+func (s *favoritoService) ActualizarColeccion(ctx context.Context, userID, coleccionID uint, input ColeccionInput) (*Coleccion, error) {
+	nombre := strings.TrimSpace(input.Nombre)
+	if nombre == "" {
+		return nil, ErrColeccionNombreInvalido
+	}
+	coleccion, err := s.ObtenerColeccion(ctx, userID, coleccionID)
+	if err != nil {
+		return nil, err
+	}
+	coleccion.Nombre = nombre
+	coleccion.Descripcion = strings.TrimSpace(input.Descripcion)
+	if err := s.actualizar(ctx, coleccion); err != nil {
+		return nil, err
+	}
+	return coleccion, nil
+}
+
+// EliminarColeccion elimina una colección del usuario (las recetas no se tocan).
+func (s *favoritoService) EliminarColeccion(ctx context.Context, userID, coleccionID uint) error {
+	if _, err := s.ObtenerColeccion(ctx, userID, coleccionID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteColeccion(ctx, coleccionID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrColeccionNotFound
+		}
+		return fmt.Errorf("servicio favoritos: error eliminando colección %d: %w", coleccionID, err)
+	}
+	log.Printf("Servicio: Colección ID %d eliminada.\n", coleccionID)
+	return nil
+}
+
+// AgregarRecetaAColeccion añade una receta a la colección en la posición indicada (o al final).
+func (s *favoritoService) AgregarRecetaAColeccion(ctx context.Context, userID, coleccionID uint, input AgregarRecetaInput) (*Coleccion, error) {
+	if input.Posicion != nil && *input.Posicion < 0 {
+		return nil, ErrOrdenColeccionInvalido
+	}
+	if _, err := s.ObtenerColeccion(ctx, userID, coleccionID); err != nil {
+		return nil, err
+	}
+	if _, err := s.recetaSvc.ObtenerVisible(ctx, input.RecetaID, recetas.LectorUsuario(userID)); err != nil {
+		return nil, fmt.Errorf("servicio favoritos: error validando receta %d: %w", input.RecetaID, err)
+	}
+
+	if err := s.repo.AddRecetaToColeccion(ctx, coleccionID, input.RecetaID, input.Posicion); err != nil {
+		if errors.Is(err, repository.ErrDuplicateRecord) {
+			return nil, ErrRecetaYaEnColeccion
+		}
+		return nil, fmt.Errorf("servicio favoritos: error agregando receta %d a colección %d: %w", input.RecetaID, coleccionID, err)
+	}
+	return s.ObtenerColeccion(ctx, userID, coleccionID)
+}
+
+// QuitarRecetaDeColeccion quita una receta de la colección.
+func (s *favoritoService) QuitarRecetaDeColeccion(ctx context.Context, userID, coleccionID, recetaID uint) error {
+	if _, err := s.ObtenerColeccion(ctx, userID, coleccionID); err != nil {
+		return err
+	}
+	if err := s.repo.RemoveRecetaFromColeccion(ctx, coleccionID, recetaID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrRecetaNoEnColeccion
+		}
+		return fmt.Errorf("servicio favoritos: error quitando receta %d de colección %d: %w", recetaID, coleccionID, err)
+	}
+	return nil
+}
+
+// ReordenarColeccion establece un nuevo orden. recetaIDs debe contener exactamente
+// las recetas actuales de la colección, sin repetir.
+func (s *favoritoService) ReordenarColeccion(ctx context.Context, userID, coleccionID uint, recetaIDs []uint) (*Coleccion, error) {
+	coleccion, err := s.ObtenerColeccion(ctx, userID, coleccionID)
+	if err != nil {
+		return nil, err
+	}
+
+	actuales := make(map[uint]bool, len(coleccion.Recetas))
+	for _, r := range coleccion.Recetas {
+		actuales[r.Receta.ID] = true
+	}
+	if len(recetaIDs) != len(actuales) {
+		return nil, ErrOrdenColeccionInvalido
+	}
+	vistos := make(map[uint]bool, len(recetaIDs))
+	for _, id := range recetaIDs {
+		if !actuales[id] || vistos[id] {
+			return nil, ErrOrdenColeccionInvalido
+		}
+		vistos[id] = true
+	}
+
+	if err := s.repo.ReorderColeccion(ctx, coleccionID, recetaIDs); err != nil {
+		return nil, fmt.Errorf("servicio favoritos: error reordenando colección %d: %w", coleccionID, err)
+	}
+	return s.ObtenerColeccion(ctx, userID, coleccionID)
+}
+
+// Compartir genera un token público nuevo para la colección. Si ya estaba
+// compartida, el token anterior deja de funcionar.
+func (s *favoritoService) Compartir(ctx context.Context, userID, coleccionID uint) (*Coleccion, error) {
+	coleccion, err := s.ObtenerColeccion(ctx, userID, coleccionID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := generarTokenPublico()
+	if err != nil {
+		return nil, fmt.Errorf("servicio favoritos: error generando token: %w", err)
+	}
+	coleccion.TokenPublico = &token
+	if err := s.actualizar(ctx, coleccion); err != nil {
+		return nil, err
+	}
+	log.Printf("Servicio: Colección ID %d compartida públicamente.\n", coleccionID)
+	return coleccion, nil
+}
+
+// DejarDeCompartir revoca el enlace público de la colección.
+func (s *favoritoService) DejarDeCompartir(ctx context.Context, userID, coleccionID uint) error {
+	coleccion, err := s.ObtenerColeccion(ctx, userID, coleccionID)
+	if err != nil {
+		return err
+	}
+	coleccion.TokenPublico = nil
+	return s.actualizar(ctx, coleccion)
+}
+
+// ObtenerColeccionCompartida devuelve una colección a partir de su token público, con sus
+// recetas publicadas.
+func (s *favoritoService) ObtenerColeccionCompartida(ctx context.Context, token string) (*Coleccion, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, ErrColeccionNotFound
+	}
+	coleccion, err := s.repo.GetColeccionByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrColeccionNotFound
+		}
+		return nil, fmt.Errorf("servicio favoritos: error obteniendo colección compartida: %w", err)
+	}
+	// Quien tiene el enlace no es el dueño: solo ve las recetas publicadas.
+	publicadas := coleccion.Recetas[:0]
+	for _, r := range coleccion.Recetas {
+		if r.Receta.EsPublica() {
+			publicadas = append(publicadas, r)
+		}
+	}
+	coleccion.Recetas, coleccion.TotalRecetas = publicadas, len(publicadas)
+	return coleccion, nil
+}
+
+func (s *favoritoService) actualizar(ctx context.Context, coleccion *Coleccion) error {
+	if err := s.repo.UpdateColeccion(ctx, coleccion); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrColeccionNotFound
+		}
+		return fmt.Errorf("servicio favoritos: error actualizando colección %d: %w", coleccion.ID, err)
+	}
+	return nil
+}
+
+// generarTokenPublico devuelve 32 bytes aleatorios en hexadecimal (no adivinable).
+func generarTokenPublico() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
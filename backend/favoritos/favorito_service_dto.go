@@ -0,0 +1,14 @@
+// backend/favoritos/favorito_service_dto.go
+package favoritos
+
+// ColeccionInput es el DTO para la entrada del servicio al crear/actualizar una colección.
+type ColeccionInput struct {
+	Nombre      string
+	Descripcion string
+}
+
+// AgregarRecetaInput es el DTO para la entrada del servicio al añadir una receta a una colección.
+type AgregarRecetaInput struct {
+	RecetaID uint
+	Posicion *int // Opcional: posición (0 = primera). nil = al final
+}
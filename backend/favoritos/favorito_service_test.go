@@ -0,0 +1,196 @@
+// backend/favoritos/favorito_service_test.go
+package favoritos_test // Usar paquete _test
+
+import (
+	"context"
+	"testing"
+
+	"backend/favoritos"                      // El paquete bajo test
+	favoritosMocks "backend/favoritos/mocks" // Mocks del paquete favoritos
+	"backend/recetas"                        // Para el tipo recetas.Receta y sus errores
+	recetasMocks "backend/recetas/mocks"     // Mock de RecetaService
+	"backend/shared/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type FavoritoServiceTestSuite struct {
+	suite.Suite
+	mockRepo      *favoritosMocks.FavoritoRepositoryMock
+	mockRecetaSvc *recetasMocks.RecetaServiceMock
+	service       favoritos.FavoritoService
+}
+
+func (s *FavoritoServiceTestSuite) SetupTest() {
+	s.mockRepo = new(favoritosMocks.FavoritoRepositoryMock)
+	s.mockRecetaSvc = new(recetasMocks.RecetaServiceMock)
+	s.service = favoritos.NewFavoritoService(s.mockRepo, s.mockRecetaSvc)
+}
+
+func TestFavoritoServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FavoritoServiceTestSuite))
+}
+
+// coleccionConRecetas crea una colección del usuario con las recetas dadas, en ese orden.
+func coleccionConRecetas(id, userID uint, recetaIDs ...uint) *favoritos.Coleccion {
+	col := &favoritos.Coleccion{ID: id, UserID: userID, Nombre: "Navidad"}
+	for i, rid := range recetaIDs {
+		col.Recetas = append(col.Recetas, favoritos.RecetaEnColeccion{Receta: recetas.Receta{ID: rid}, Posicion: i})
+	}
+	col.TotalRecetas = len(col.Recetas)
+	return col
+}
+
+func (s *FavoritoServiceTestSuite) TestAgregarFavorito_Success() {
+	ctx := context.Background()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(5), recetas.LectorUsuario(1)).Return(&recetas.Receta{ID: 5}, nil).Once()
+	s.mockRepo.On("AddFavorito", ctx, uint(1), uint(5)).Return(nil).Once()
+
+	s.NoError(s.service.AgregarFavorito(ctx, 1, 5))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *FavoritoServiceTestSuite) TestAgregarFavorito_Fail_RecetaNoExiste() {
+	ctx := context.Background()
+	// No existe, o es un borrador que el usuario no puede ver
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(99), recetas.LectorUsuario(1)).Return(nil, recetas.ErrRecetaNotFound).Once()
+
+	err := s.service.AgregarFavorito(ctx, 1, 99)
+
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "AddFavorito")
+}
+
+func (s *FavoritoServiceTestSuite) TestQuitarFavorito_NoEraFavorito() {
+	ctx := context.Background()
+	s.mockRepo.On("RemoveFavorito", ctx, uint(1), uint(5)).Return(repository.ErrRecordNotFound).Once()
+
+	s.ErrorIs(s.service.QuitarFavorito(ctx, 1, 5), favoritos.ErrFavoritoNotFound)
+}
+
+func (s *FavoritoServiceTestSuite) TestListarFavoritos_OmiteRecetasQueYaNoVe() {
+	ctx := context.Background()
+	yo, otro := uint(1), uint(2)
+	s.mockRepo.On("FindFavoritos", ctx, yo).Return([]recetas.Receta{
+		{ID: 3, AutorID: &otro, Estado: recetas.EstadoPublicada},
+		{ID: 4, AutorID: &otro, Estado: recetas.EstadoBorrador}, // Despublicada por su autor
+		{ID: 5, AutorID: &yo, Estado: recetas.EstadoBorrador},   // Borrador propio
+	}, nil).Once()
+
+	favoritas, err := s.service.ListarFavoritos(ctx, yo)
+
+	s.NoError(err)
+	s.Len(favoritas, 2)
+	s.Equal(uint(3), favoritas[0].ID)
+	s.Equal(uint(5), favoritas[1].ID)
+}
+
+func (s *FavoritoServiceTestSuite) TestRecetasFavoritas_DevuelveMapa() {
+	ctx := context.Background()
+	s.mockRepo.On("FindFavoritoIDs", ctx, uint(1), []uint{3, 4, 5}).Return([]uint{3, 5}, nil).Once()
+
+	favoritas, err := s.service.RecetasFavoritas(ctx, 1, []uint{3, 4, 5})
+
+	s.NoError(err)
+	s.True(favoritas[3])
+	s.False(favoritas[4])
+	s.True(favoritas[5])
+}
+
+func (s *FavoritoServiceTestSuite) TestCrearColeccion_Fail_NombreVacio() {
+	col, err := s.service.CrearColeccion(context.Background(), 1, favoritos.ColeccionInput{Nombre: "   "})
+
+	s.Nil(col)
+	s.ErrorIs(err, favoritos.ErrColeccionNombreInvalido)
+	s.mockRepo.AssertNotCalled(s.T(), "CreateColeccion")
+}
+
+func (s *FavoritoServiceTestSuite) TestObtenerColeccion_DeOtroUsuario_NotFound() {
+	ctx := context.Background()
+	s.mockRepo.On("GetColeccionByID", ctx, uint(4)).Return(coleccionConRecetas(4, 2), nil).Once()
+
+	col, err := s.service.ObtenerColeccion(ctx, 1, 4)
+
+	s.Nil(col)
+	s.ErrorIs(err, favoritos.ErrColeccionNotFound)
+}
+
+func (s *FavoritoServiceTestSuite) TestAgregarRecetaAColeccion_Duplicada() {
+	ctx := context.Background()
+	s.mockRepo.On("GetColeccionByID", ctx, uint(4)).Return(coleccionConRecetas(4, 1, 5), nil).Once()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(5), recetas.LectorUsuario(1)).Return(&recetas.Receta{ID: 5}, nil).Once()
+	s.mockRepo.On("AddRecetaToColeccion", ctx, uint(4), uint(5), (*int)(nil)).Return(repository.ErrDuplicateRecord).Once()
+
+	col, err := s.service.AgregarRecetaAColeccion(ctx, 1, 4, favoritos.AgregarRecetaInput{RecetaID: 5})
+
+	s.Nil(col)
+	s.ErrorIs(err, favoritos.ErrRecetaYaEnColeccion)
+}
+
+func (s *FavoritoServiceTestSuite) TestReordenarColeccion_Success() {
+	ctx := context.Background()
+	s.mockRepo.On("GetColeccionByID", ctx, uint(4)).Return(coleccionConRecetas(4, 1, 7, 3, 12), nil).Once()
+	s.mockRepo.On("ReorderColeccion", ctx, uint(4), []uint{12, 7, 3}).Return(nil).Once()
+	s.mockRepo.On("GetColeccionByID", ctx, uint(4)).Return(coleccionConRecetas(4, 1, 12, 7, 3), nil).Once()
+
+	col, err := s.service.ReordenarColeccion(ctx, 1, 4, []uint{12, 7, 3})
+
+	s.NoError(err)
+	s.Require().NotNil(col)
+	s.Equal(uint(12), col.Recetas[0].Receta.ID)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *FavoritoServiceTestSuite) TestReordenarColeccion_Fail_IDsNoCoinciden() {
+	ctx := context.Background()
+	s.mockRepo.On("GetColeccionByID", ctx, uint(4)).Return(coleccionConRecetas(4, 1, 7, 3), nil)
+
+	for _, orden := range [][]uint{{7}, {7, 7}, {7, 99}} {
+		col, err := s.service.ReordenarColeccion(ctx, 1, 4, orden)
+		s.Nil(col)
+		s.ErrorIs(err, favoritos.ErrOrdenColeccionInvalido)
+	}
+	s.mockRepo.AssertNotCalled(s.T(), "ReorderColeccion", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *FavoritoServiceTestSuite) TestCompartir_GeneraTokenNoAdivinable() {
+	ctx := context.Background()
+	s.mockRepo.On("GetColeccionByID", ctx, uint(4)).Return(coleccionConRecetas(4, 1), nil).Once()
+	s.mockRepo.On("UpdateColeccion", ctx, mock.MatchedBy(func(c *favoritos.Coleccion) bool {
+		return c.TokenPublico != nil && len(*c.TokenPublico) == 64
+	})).Return(nil).Once()
+
+	col, err := s.service.Compartir(ctx, 1, 4)
+
+	s.NoError(err)
+	s.Require().NotNil(col)
+	s.True(col.Compartida())
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *FavoritoServiceTestSuite) TestObtenerColeccionCompartida_TokenRevocado() {
+	ctx := context.Background()
+	s.mockRepo.On("GetColeccionByToken", ctx, "abc").Return(nil, repository.ErrRecordNotFound).Once()
+
+	col, err := s.service.ObtenerColeccionCompartida(ctx, "abc")
+
+	s.Nil(col)
+	s.ErrorIs(err, favoritos.ErrColeccionNotFound)
+}
+
+func (s *FavoritoServiceTestSuite) TestObtenerColeccionCompartida_SoloRecetasPublicadas() {
+	ctx := context.Background()
+	col := coleccionConRecetas(1, 5, 10, 11, 12)
+	col.Recetas[0].Receta.Estado = recetas.EstadoPublicada
+	col.Recetas[1].Receta.Estado = recetas.EstadoBorrador // Volvió a borrador tras añadirla
+	col.Recetas[2].Receta.Estado = recetas.EstadoArchivada
+	s.mockRepo.On("GetColeccionByToken", ctx, "abc").Return(col, nil).Once()
+
+	compartida, err := s.service.ObtenerColeccionCompartida(ctx, "abc")
+
+	s.Require().NoError(err)
+	s.Require().Len(compartida.Recetas, 1)
+	s.Equal(uint(10), compartida.Recetas[0].Receta.ID)
+	s.Equal(1, compartida.TotalRecetas)
+}
@@ -0,0 +1,101 @@
+// backend/favoritos/mocks/favorito_repository_mock.go
+package mocks
+
+import (
+	"backend/favoritos" // Para los tipos de dominio y la interfaz
+	"backend/recetas"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FavoritoRepositoryMock struct {
+	mock.Mock
+}
+
+// Asegurar que implementa la interfaz
+var _ favoritos.FavoritoRepository = (*FavoritoRepositoryMock)(nil)
+
+func (m *FavoritoRepositoryMock) AddFavorito(ctx context.Context, userID, recetaID uint) error {
+	args := m.Called(ctx, userID, recetaID)
+	return args.Error(0)
+}
+
+func (m *FavoritoRepositoryMock) RemoveFavorito(ctx context.Context, userID, recetaID uint) error {
+	args := m.Called(ctx, userID, recetaID)
+	return args.Error(0)
+}
+
+func (m *FavoritoRepositoryMock) FindFavoritos(ctx context.Context, userID uint) ([]recetas.Receta, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+
+func (m *FavoritoRepositoryMock) FindFavoritoIDs(ctx context.Context, userID uint, recetaIDs []uint) ([]uint, error) {
+	args := m.Called(ctx, userID, recetaIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uint), args.Error(1)
+}
+
+func (m *FavoritoRepositoryMock) CreateColeccion(ctx context.Context, coleccion *favoritos.Coleccion) error {
+	args := m.Called(ctx, coleccion)
+	// Simular que el repo asigna ID si el Create es exitoso
+	if args.Error(0) == nil && coleccion != nil {
+		coleccion.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *FavoritoRepositoryMock) GetColeccionByID(ctx context.Context, id uint) (*favoritos.Coleccion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*favoritos.Coleccion), args.Error(1)
+}
+
+func (m *FavoritoRepositoryMock) GetColeccionByToken(ctx context.Context, token string) (*favoritos.Coleccion, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*favoritos.Coleccion), args.Error(1)
+}
+
+func (m *FavoritoRepositoryMock) FindColeccionesByUserID(ctx context.Context, userID uint) ([]favoritos.Coleccion, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]favoritos.Coleccion), args.Error(1)
+}
+
+func (m *FavoritoRepositoryMock) UpdateColeccion(ctx context.Context, coleccion *favoritos.Coleccion) error {
+	args := m.Called(ctx, coleccion)
+	return args.Error(0)
+}
+
+func (m *FavoritoRepositoryMock) DeleteColeccion(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *FavoritoRepositoryMock) AddRecetaToColeccion(ctx context.Context, coleccionID, recetaID uint, posicion *int) error {
+	args := m.Called(ctx, coleccionID, recetaID, posicion)
+	return args.Error(0)
+}
+
+func (m *FavoritoRepositoryMock) RemoveRecetaFromColeccion(ctx context.Context, coleccionID, recetaID uint) error {
+	args := m.Called(ctx, coleccionID, recetaID)
+	return args.Error(0)
+}
+
+func (m *FavoritoRepositoryMock) ReorderColeccion(ctx context.Context, coleccionID uint, recetaIDs []uint) error {
+	args := m.Called(ctx, coleccionID, recetaIDs)
+	return args.Error(0)
+}
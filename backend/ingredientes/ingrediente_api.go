@@ -0,0 +1,262 @@
+// backend/ingredientes/ingrediente_api.go
+// Implementación con Gin de IngredienteHandler.
+package ingredientes
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"backend/recetas"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IngredienteHandler maneja las peticiones HTTP para Ingredientes.
+type IngredienteHandler struct {
+	service IngredienteService
+}
+
+// NewIngredienteHandler crea una nueva instancia de IngredienteHandler.
+func NewIngredienteHandler(s IngredienteService) *IngredienteHandler {
+	return &IngredienteHandler{service: s}
+}
+
+// --- Mapeadores Helper ---
+
+func mapIngredienteToResponseDTO(i Ingrediente) IngredienteResponseDTO {
+	return IngredienteResponseDTO{
+		ID:          i.ID,
+		Nombre:      i.Nombre,
+		Slug:        i.Slug,
+		Pasillo:     i.Pasillo,
+		DensidadGML: i.DensidadGML,
+	}
+}
+
+func mapLineasToResponseDTOs(lineas []RecetaIngrediente) []RecetaIngredienteResponseDTO {
+	responseDTOs := make([]RecetaIngredienteResponseDTO, 0, len(lineas))
+	for _, l := range lineas {
+		dto := RecetaIngredienteResponseDTO{Cantidad: l.Cantidad, Unidad: l.Unidad, Nota: l.Nota}
+		if l.Ingrediente != nil {
+			dto.Ingrediente = mapIngredienteToResponseDTO(*l.Ingrediente)
+		} else {
+			dto.Ingrediente = IngredienteResponseDTO{ID: l.IngredienteID}
+		}
+		responseDTOs = append(responseDTOs, dto)
+	}
+	return responseDTOs
+}
+
+// lectorDesdeContexto arma el lector de recetas con el usuario y el permiso de editor
+// que hayan dejado los middlewares de Auth (anónimo si no hay ninguno).
+func lectorDesdeContexto(c *gin.Context) recetas.Lector {
+	lector := recetas.Lector{Editor: c.GetBool("esAdmin")}
+	if v, exists := c.Get("userID"); exists {
+		if uid, ok := v.(uint); ok {
+			lector.UsuarioID = &uid
+		}
+	}
+	return lector
+}
+
+func parseIDParam(c *gin.Context, nombre string) (uint, error) {
+	idStr := c.Param(nombre)
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parámetro %s inválido: %s - %w", nombre, idStr, err)
+	}
+	return uint(idUint64), nil
+}
+
+// --- Catálogo de ingredientes ---
+
+// GetAll godoc
+// @Summary Lista los ingredientes del catálogo
+// @Tags Ingredientes
+// @Produce json
+// @Success 200 {array} IngredienteResponseDTO "Ingredientes"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /ingredientes [get]
+func (h *IngredienteHandler) GetAll(c *gin.Context) {
+	ingredientes, err := h.service.GetAll(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]IngredienteResponseDTO, 0, len(ingredientes))
+	for _, i := range ingredientes {
+		responseDTOs = append(responseDTOs, mapIngredienteToResponseDTO(i))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// GetByID godoc
+// @Summary Obtiene un ingrediente por ID
+// @Tags Ingredientes
+// @Produce json
+// @Param id path uint true "ID del Ingrediente"
+// @Success 200 {object} IngredienteResponseDTO "Ingrediente"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Ingrediente no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /ingredientes/{id} [get]
+func (h *IngredienteHandler) GetByID(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ingrediente, err := h.service.GetByID(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapIngredienteToResponseDTO(*ingrediente))
+}
+
+// Create godoc
+// @Summary Crea un ingrediente
+// @Tags Ingredientes
+// @Accept json
+// @Produce json
+// @Param ingrediente body IngredienteRequestDTO true "Datos del ingrediente"
+// @Success 201 {object} IngredienteResponseDTO "Ingrediente creado"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 409 {object} apitypes.ErrorResponse "Nombre duplicado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /ingredientes [post]
+// @Security ApiKeyAuth
+func (h *IngredienteHandler) Create(c *gin.Context) {
+	var req IngredienteRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ingrediente, err := h.service.Create(c.Request.Context(), IngredienteInputDTO{Nombre: req.Nombre, Pasillo: req.Pasillo, DensidadGML: req.DensidadGML})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapIngredienteToResponseDTO(*ingrediente))
+}
+
+// Update godoc
+// @Summary Actualiza un ingrediente
+// @Tags Ingredientes
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID del Ingrediente"
+// @Param ingrediente body IngredienteRequestDTO true "Datos del ingrediente"
+// @Success 200 {object} IngredienteResponseDTO "Ingrediente actualizado"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 404 {object} apitypes.ErrorResponse "Ingrediente no encontrado"
+// @Failure 409 {object} apitypes.ErrorResponse "Nombre duplicado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /ingredientes/{id} [put]
+// @Security ApiKeyAuth
+func (h *IngredienteHandler) Update(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req IngredienteRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ingrediente, err := h.service.Update(c.Request.Context(), id, IngredienteInputDTO{Nombre: req.Nombre, Pasillo: req.Pasillo, DensidadGML: req.DensidadGML})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapIngredienteToResponseDTO(*ingrediente))
+}
+
+// Delete godoc
+// @Summary Elimina un ingrediente que no esté en uso
+// @Tags Ingredientes
+// @Param id path uint true "ID del Ingrediente"
+// @Success 204 "Ingrediente eliminado"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Ingrediente no encontrado"
+// @Failure 409 {object} apitypes.ErrorResponse "Ingrediente en uso por alguna receta"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /ingredientes/{id} [delete]
+// @Security ApiKeyAuth
+func (h *IngredienteHandler) Delete(c *gin.Context) {
+	id, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// --- Ingredientes de una receta ---
+
+// GetByReceta godoc
+// @Summary Obtiene los ingredientes de una receta
+// @Description Las cantidades corresponden a las porciones base de la receta.
+// @Tags Ingredientes, Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Success 200 {array} RecetaIngredienteResponseDTO "Ingredientes en orden"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/ingredientes [get]
+func (h *IngredienteHandler) GetByReceta(c *gin.Context) {
+	recetaID, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	lineas, err := h.service.ObtenerDeReceta(c.Request.Context(), recetaID, lectorDesdeContexto(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapLineasToResponseDTOs(lineas))
+}
+
+// ReplaceForReceta godoc
+// @Summary Reemplaza los ingredientes de una receta
+// @Tags Ingredientes, Recetas
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param ingredientes body RecetaIngredientesRequestDTO true "Lista completa de ingredientes, en orden"
+// @Success 200 {array} RecetaIngredienteResponseDTO "Ingredientes guardados"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta o ingrediente no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/ingredientes [put]
+// @Security ApiKeyAuth
+func (h *IngredienteHandler) ReplaceForReceta(c *gin.Context) {
+	recetaID, err := parseIDParam(c, "id")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req RecetaIngredientesRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	lineasInput := make([]LineaIngredienteInput, 0, len(req.Ingredientes))
+	for _, l := range req.Ingredientes {
+		lineasInput = append(lineasInput, LineaIngredienteInput{IngredienteID: l.IngredienteID, Cantidad: l.Cantidad, Unidad: l.Unidad, Nota: l.Nota})
+	}
+	lineas, err := h.service.ReemplazarDeReceta(c.Request.Context(), recetaID, lectorDesdeContexto(c), lineasInput)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapLineasToResponseDTOs(lineas))
+}
@@ -0,0 +1,40 @@
+// backend/ingredientes/ingrediente_api_dto.go
+// DTOs de la API para Ingredientes.
+package ingredientes
+
+// IngredienteRequestDTO es el DTO de entrada para crear/actualizar un ingrediente.
+type IngredienteRequestDTO struct {
+	Nombre      string   `json:"nombre" binding:"required,min=2,max=100" example:"Harina de trigo"`
+	Pasillo     string   `json:"pasillo" binding:"max=60" example:"Panadería y harinas"`        // Opcional (por defecto "Otros")
+	DensidadGML *float64 `json:"densidad_gml,omitempty" binding:"omitempty,gt=0" example:"0.5"` // Gramos por ml (permite convertir tazas a gramos)
+}
+
+// IngredienteResponseDTO es el DTO de salida de un ingrediente.
+type IngredienteResponseDTO struct {
+	ID          uint     `json:"id" example:"3"`
+	Nombre      string   `json:"nombre" example:"Harina de trigo"`
+	Slug        string   `json:"slug" example:"harina-de-trigo"`
+	Pasillo     string   `json:"pasillo" example:"Panadería y harinas"`
+	DensidadGML *float64 `json:"densidad_gml,omitempty" example:"0.5"`
+}
+
+// LineaIngredienteDTO es una línea de ingrediente de una receta (entrada).
+type LineaIngredienteDTO struct {
+	IngredienteID uint    `json:"ingrediente_id" binding:"required,gt=0" example:"3"`
+	Cantidad      float64 `json:"cantidad" binding:"gte=0" example:"200"` // 0 = "al gusto"
+	Unidad        string  `json:"unidad" binding:"max=30" example:"g"`
+	Nota          string  `json:"nota,omitempty" binding:"max=150" example:"tamizada"`
+}
+
+// RecetaIngredientesRequestDTO reemplaza la lista completa de ingredientes de una receta.
+type RecetaIngredientesRequestDTO struct {
+	Ingredientes []LineaIngredienteDTO `json:"ingredientes" binding:"dive"`
+}
+
+// RecetaIngredienteResponseDTO es una línea de ingrediente de una receta (salida).
+type RecetaIngredienteResponseDTO struct {
+	Ingrediente IngredienteResponseDTO `json:"ingrediente"`
+	Cantidad    float64                `json:"cantidad" example:"200"`
+	Unidad      string                 `json:"unidad" example:"g"`
+	Nota        string                 `json:"nota,omitempty" example:"tamizada"`
+}
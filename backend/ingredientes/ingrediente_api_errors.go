@@ -0,0 +1,22 @@
+// backend/ingredientes/ingrediente_api_errors.go
+
+// Traducción de los errores de dominio de ingredientes a respuestas HTTP (ver shared/apperrors).
+
+package ingredientes
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrIngredienteNotFound, http.StatusNotFound, "ingrediente_no_encontrado")
+
+	apperrors.Registrar(ErrIngredienteNombreYaExiste, http.StatusConflict, "ingrediente_nombre_duplicado")
+	apperrors.Registrar(ErrIngredienteEnUso, http.StatusConflict, "ingrediente_en_uso")
+
+	apperrors.Registrar(ErrIngredienteNombreInvalido, http.StatusBadRequest, "ingrediente_nombre_invalido")
+	apperrors.Registrar(ErrIngredienteDensidadInvalida, http.StatusBadRequest, "ingrediente_densidad_invalida")
+	apperrors.Registrar(ErrLineaIngredienteInvalida, http.StatusBadRequest, "ingrediente_linea_invalida")
+}
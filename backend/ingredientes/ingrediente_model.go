@@ -0,0 +1,52 @@
+// Archivo: backend/ingredientes/ingrediente_model.go
+// Funcionalidad: Modelo de dominio para Ingredientes y los ingredientes de cada receta.
+// Capa: Dominio / Lógica de negocio.
+
+// Descripción:
+// Un Ingrediente es una entrada del catálogo ("Harina de trigo") con el pasillo
+// del supermercado donde se encuentra y, opcionalmente, su densidad para poder
+// convertir entre volumen y masa (1 taza de harina ≈ 120 g).
+// Cada receta tiene una lista ordenada de ingredientes con cantidad y unidad,
+// expresada para las porciones base de la receta (recetas.Receta.Porciones).
+
+package ingredientes
+
+import (
+	"errors"
+	"time"
+)
+
+// PasilloPorDefecto es el pasillo asignado a los ingredientes sin pasillo.
+const PasilloPorDefecto = "Otros"
+
+// Ingrediente representa un ingrediente del catálogo.
+type Ingrediente struct {
+	ID          uint
+	Nombre      string   // Nombre visible (ej: "Harina de trigo")
+	Slug        string   // Para búsquedas y URLs
+	Pasillo     string   // Pasillo/sección del supermercado (ej: "Panadería y harinas")
+	DensidadGML *float64 // Gramos por mililitro (nil = desconocida: no se convierte volumen<->masa)
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// RecetaIngrediente es una línea de ingrediente dentro de una receta.
+type RecetaIngrediente struct {
+	RecetaID      uint
+	IngredienteID uint
+	Ingrediente   *Ingrediente // Precargado al consultar
+	Cantidad      float64      // Para las porciones base de la receta (0 = "al gusto")
+	Unidad        string       // Texto libre normalizable (ej: "g", "taza", "cucharadas")
+	Nota          string       // Opcional (ej: "tamizada")
+	Orden         int
+}
+
+// Errores específicos del dominio de Ingredientes.
+var (
+	ErrIngredienteNotFound         = errors.New("ingrediente no encontrado")
+	ErrIngredienteNombreInvalido   = errors.New("el nombre del ingrediente no es válido o está vacío")
+	ErrIngredienteNombreYaExiste   = errors.New("ya existe un ingrediente con ese nombre")
+	ErrIngredienteDensidadInvalida = errors.New("la densidad del ingrediente debe ser mayor que 0")
+	ErrIngredienteEnUso            = errors.New("el ingrediente está en uso por alguna receta")
+	ErrLineaIngredienteInvalida    = errors.New("cada ingrediente de la receta debe tener un ingrediente válido y una cantidad no negativa")
+)
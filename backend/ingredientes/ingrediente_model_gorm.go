@@ -0,0 +1,110 @@
+// backend/ingredientes/ingrediente_model_gorm.go
+
+// Este archivo define los modelos de persistencia para ingredientes.
+// Utiliza GORM para la definición de las tablas y el mapeo de campos.
+
+package ingredientes
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"backend/recetas" // Para la relación con recetas.RecetaModel
+)
+
+// IngredienteModel representa la tabla 'ingredientes'.
+type IngredienteModel struct {
+	ID          uint     `gorm:"primaryKey"`
+	Nombre      string   `gorm:"type:varchar(100);not null;uniqueIndex:uk_ingredientes_nombre"`
+	Slug        string   `gorm:"type:varchar(120);not null;index"`
+	Pasillo     string   `gorm:"type:varchar(60);not null;default:'Otros'"`
+	DensidadGML *float64 `gorm:"type:decimal(6,3);default:null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt `gorm:"index"`
+}
+
+func (IngredienteModel) TableName() string {
+	return "ingredientes"
+}
+
+// RecetaIngredienteModel representa la tabla 'receta_ingredientes'.
+type RecetaIngredienteModel struct {
+	RecetaID      uint    `gorm:"primaryKey;autoIncrement:false"`
+	IngredienteID uint    `gorm:"primaryKey;autoIncrement:false;index"`
+	Cantidad      float64 `gorm:"type:decimal(10,3);not null;default:0"`
+	Unidad        string  `gorm:"type:varchar(30)"`
+	Nota          string  `gorm:"type:varchar(150)"`
+	Orden         int     `gorm:"not null;default:0"`
+
+	Receta      recetas.RecetaModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Ingrediente IngredienteModel    `gorm:"foreignKey:IngredienteID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+func (RecetaIngredienteModel) TableName() string {
+	return "receta_ingredientes"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *IngredienteModel) ToDomain() *Ingrediente {
+	if m == nil {
+		return nil
+	}
+	return &Ingrediente{
+		ID:          m.ID,
+		Nombre:      m.Nombre,
+		Slug:        m.Slug,
+		Pasillo:     m.Pasillo,
+		DensidadGML: m.DensidadGML,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func FromIngredienteDomain(d *Ingrediente) *IngredienteModel {
+	if d == nil {
+		return nil
+	}
+	return &IngredienteModel{
+		ID:          d.ID,
+		Nombre:      d.Nombre,
+		Slug:        d.Slug,
+		Pasillo:     d.Pasillo,
+		DensidadGML: d.DensidadGML,
+	}
+}
+
+// ToDomain convierte la línea al dominio; el ingrediente solo se mapea si fue precargado.
+func (m *RecetaIngredienteModel) ToDomain() *RecetaIngrediente {
+	if m == nil {
+		return nil
+	}
+	linea := &RecetaIngrediente{
+		RecetaID:      m.RecetaID,
+		IngredienteID: m.IngredienteID,
+		Cantidad:      m.Cantidad,
+		Unidad:        m.Unidad,
+		Nota:          m.Nota,
+		Orden:         m.Orden,
+	}
+	if m.Ingrediente.ID != 0 {
+		linea.Ingrediente = m.Ingrediente.ToDomain()
+	}
+	return linea
+}
+
+func FromRecetaIngredienteDomain(d *RecetaIngrediente) *RecetaIngredienteModel {
+	if d == nil {
+		return nil
+	}
+	return &RecetaIngredienteModel{
+		RecetaID:      d.RecetaID,
+		IngredienteID: d.IngredienteID,
+		Cantidad:      d.Cantidad,
+		Unidad:        d.Unidad,
+		Nota:          d.Nota,
+		Orden:         d.Orden,
+	}
+}
@@ -0,0 +1,26 @@
+// backend/ingredientes/ingrediente_repository.go
+// Funcionalidad: Interfaz para la persistencia de Ingredientes.
+// Capa: Repositorio (Abstracción).
+package ingredientes
+
+import "context"
+
+// IngredienteRepository define el contrato para las operaciones de datos de Ingredientes.
+type IngredienteRepository interface {
+	GetAll(ctx context.Context) ([]Ingrediente, error)
+	GetByID(ctx context.Context, id uint) (*Ingrediente, error)
+	GetByNombre(ctx context.Context, nombre string) (*Ingrediente, error)
+	// CountByIDs devuelve cuántos de los IDs dados existen (para validar líneas de receta).
+	CountByIDs(ctx context.Context, ids []uint) (int64, error)
+	Create(ctx context.Context, ingrediente *Ingrediente) error
+	Update(ctx context.Context, ingrediente *Ingrediente) error
+	Delete(ctx context.Context, id uint) error
+	// IsInUse indica si alguna receta usa el ingrediente.
+	IsInUse(ctx context.Context, id uint) (bool, error)
+
+	// FindByRecetaIDs devuelve las líneas de ingredientes (con el ingrediente precargado)
+	// de las recetas dadas, ordenadas por receta y orden.
+	FindByRecetaIDs(ctx context.Context, recetaIDs []uint) ([]RecetaIngrediente, error)
+	// ReplaceForReceta reemplaza todas las líneas de la receta en una transacción.
+	ReplaceForReceta(ctx context.Context, recetaID uint, lineas []RecetaIngrediente) error
+}
@@ -0,0 +1,156 @@
+// backend/ingredientes/ingrediente_repository_gorm.go
+// Funcionalidad: Implementación GORM de IngredienteRepository.
+// Capa: Repositorio (Implementación de Persistencia).
+package ingredientes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"backend/shared/repository"
+)
+
+type gormIngredienteRepository struct {
+	db *gorm.DB
+}
+
+// NewIngredienteRepository crea una instancia de la implementación GORM de IngredienteRepository.
+func NewIngredienteRepository(db *gorm.DB) IngredienteRepository {
+	return &gormIngredienteRepository{db: db}
+}
+
+func (r *gormIngredienteRepository) GetAll(ctx context.Context) ([]Ingrediente, error) {
+	var models []IngredienteModel
+	if err := r.db.WithContext(ctx).Order("nombre asc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm ingredientes: getall: %w", err)
+	}
+	ingredientes := make([]Ingrediente, 0, len(models))
+	for i := range models {
+		ingredientes = append(ingredientes, *models[i].ToDomain())
+	}
+	return ingredientes, nil
+}
+
+func (r *gormIngredienteRepository) GetByID(ctx context.Context, id uint) (*Ingrediente, error) {
+	var model IngredienteModel
+	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm ingredientes: getbyid %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormIngredienteRepository) GetByNombre(ctx context.Context, nombre string) (*Ingrediente, error) {
+	var model IngredienteModel
+	if err := r.db.WithContext(ctx).Where("nombre = ?", nombre).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm ingredientes: getbynombre %s: %w", nombre, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormIngredienteRepository) CountByIDs(ctx context.Context, ids []uint) (int64, error) {
+	var total int64
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	if err := r.db.WithContext(ctx).Model(&IngredienteModel{}).Where("id IN ?", ids).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("repo gorm ingredientes: countbyids: %w", err)
+	}
+	return total, nil
+}
+
+func (r *gormIngredienteRepository) Create(ctx context.Context, ingrediente *Ingrediente) error {
+	model := FromIngredienteDomain(ingrediente)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm ingredientes: create: %w", repository.TraducirError(err))
+	}
+	ingrediente.ID = model.ID
+	ingrediente.CreatedAt = model.CreatedAt
+	ingrediente.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *gormIngredienteRepository) Update(ctx context.Context, ingrediente *Ingrediente) error {
+	// Map para poder dejar la densidad en NULL.
+	result := r.db.WithContext(ctx).Model(&IngredienteModel{}).Where("id = ?", ingrediente.ID).Updates(map[string]interface{}{
+		"nombre":       ingrediente.Nombre,
+		"slug":         ingrediente.Slug,
+		"pasillo":      ingrediente.Pasillo,
+		"densidad_gml": ingrediente.DensidadGML,
+	})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm ingredientes: update %d: %w", ingrediente.ID, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormIngredienteRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&IngredienteModel{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm ingredientes: delete %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormIngredienteRepository) IsInUse(ctx context.Context, id uint) (bool, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&RecetaIngredienteModel{}).Where("ingrediente_id = ?", id).Count(&total).Error; err != nil {
+		return false, fmt.Errorf("repo gorm ingredientes: isinuse %d: %w", id, err)
+	}
+	return total > 0, nil
+}
+
+func (r *gormIngredienteRepository) FindByRecetaIDs(ctx context.Context, recetaIDs []uint) ([]RecetaIngrediente, error) {
+	lineas := make([]RecetaIngrediente, 0)
+	if len(recetaIDs) == 0 {
+		return lineas, nil
+	}
+	var models []RecetaIngredienteModel
+	err := r.db.WithContext(ctx).
+		Preload("Ingrediente", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }). // Un ingrediente borrado sigue en recetas antiguas
+		Where("receta_id IN ?", recetaIDs).
+		Order("receta_id asc, orden asc").
+		Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm ingredientes: findbyrecetaids: %w", err)
+	}
+	for i := range models {
+		lineas = append(lineas, *models[i].ToDomain())
+	}
+	return lineas, nil
+}
+
+func (r *gormIngredienteRepository) ReplaceForReceta(ctx context.Context, recetaID uint, lineas []RecetaIngrediente) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("receta_id = ?", recetaID).Delete(&RecetaIngredienteModel{}).Error; err != nil {
+			return fmt.Errorf("repo gorm ingredientes: replaceforreceta borrar %d: %w", recetaID, repository.TraducirError(err))
+		}
+		if len(lineas) == 0 {
+			return nil
+		}
+		models := make([]RecetaIngredienteModel, 0, len(lineas))
+		for i := range lineas {
+			m := FromRecetaIngredienteDomain(&lineas[i])
+			m.RecetaID = recetaID
+			models = append(models, *m)
+		}
+		if err := tx.Omit("Receta", "Ingrediente").Create(&models).Error; err != nil {
+			return fmt.Errorf("repo gorm ingredientes: replaceforreceta insertar %d: %w", recetaID, repository.TraducirError(err))
+		}
+		return nil
+	})
+}
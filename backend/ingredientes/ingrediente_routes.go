@@ -0,0 +1,30 @@
+// backend/ingredientes/ingrediente_routes.go
+package ingredientes
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterIngredienteRoutes registra las rutas del catálogo de ingredientes
+// y de los ingredientes de cada receta.
+func RegisterIngredienteRoutes(apiBaseGroup *gin.RouterGroup, h *IngredienteHandler) {
+	ingredienteRoutes := apiBaseGroup.Group("/ingredientes")
+	{
+		ingredienteRoutes.GET("", h.GetAll)
+		ingredienteRoutes.GET("/:id", h.GetByID)
+		ingredienteRoutes.POST("", h.Create)       // Necesita Auth Admin
+		ingredienteRoutes.PUT("/:id", h.Update)    // Necesita Auth Admin
+		ingredienteRoutes.DELETE("/:id", h.Delete) // Necesita Auth Admin
+	}
+
+	// Ingredientes anidados bajo la receta: /api/v1/recetas/:id/ingredientes
+	recetaIngredientes := apiBaseGroup.Group("/recetas/:id/ingredientes")
+	{
+		recetaIngredientes.GET("", h.GetByReceta)
+		recetaIngredientes.PUT("", h.ReplaceForReceta) // Necesita Auth (autor/editor)
+	}
+
+	log.Println("🛣️  Rutas de Ingredientes configuradas.")
+}
@@ -0,0 +1,226 @@
+// backend/ingredientes/ingrediente_service.go
+// Funcionalidad: Lógica de negocio para Ingredientes y los ingredientes de las recetas.
+// Capa: Servicio / Casos de Uso.
+package ingredientes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log" // Temporal, reemplazar con logger estructurado
+	"strings"
+
+	"github.com/gosimple/slug"
+
+	"backend/recetas" // Para validar que la receta exista
+	"backend/shared/repository"
+)
+
+// IngredienteService define el contrato para la lógica de negocio de Ingredientes.
+type IngredienteService interface {
+	GetAll(ctx context.Context) ([]Ingrediente, error)
+	GetByID(ctx context.Context, id uint) (*Ingrediente, error)
+	Create(ctx context.Context, input IngredienteInputDTO) (*Ingrediente, error)
+	Update(ctx context.Context, id uint, input IngredienteInputDTO) (*Ingrediente, error)
+	Delete(ctx context.Context, id uint) error
+
+	// El lector es quien pide: si no puede ver la receta (ej: un borrador ajeno), ErrRecetaNotFound.
+	ObtenerDeReceta(ctx context.Context, recetaID uint, lector recetas.Lector) ([]RecetaIngrediente, error)
+	ReemplazarDeReceta(ctx context.Context, recetaID uint, lector recetas.Lector, lineas []LineaIngredienteInput) ([]RecetaIngrediente, error)
+	// ObtenerDeRecetas agrupa por receta las líneas de ingredientes de varias recetas (sin validarlas).
+	ObtenerDeRecetas(ctx context.Context, recetaIDs []uint) (map[uint][]RecetaIngrediente, error)
+}
+
+type ingredienteService struct {
+	repo      IngredienteRepository
+	recetaSvc recetas.RecetaService // Dependencia del servicio de recetas
+}
+
+// NewIngredienteService crea una nueva instancia de IngredienteService.
+func NewIngredienteService(repo IngredienteRepository, recetaSvc recetas.RecetaService) IngredienteService {
+	return &ingredienteService{repo: repo, recetaSvc: recetaSvc}
+}
+
+func (s *ingredienteService) GetAll(ctx context.Context) ([]Ingrediente, error) {
+	ingredientes, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("servicio ingredientes: error al obtener todos: %w", err)
+	}
+	return ingredientes, nil
+}
+
+func (s *ingredienteService) GetByID(ctx context.Context, id uint) (*Ingrediente, error) {
+	ingrediente, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrIngredienteNotFound
+		}
+		return nil, fmt.Errorf("servicio ingredientes: error al obtener id %d: %w", id, err)
+	}
+	return ingrediente, nil
+}
+
+func (s *ingredienteService) Create(ctx context.Context, input IngredienteInputDTO) (*Ingrediente, error) {
+	nombre, pasillo, err := validarIngrediente(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verificarNombreLibre(ctx, nombre, 0); err != nil {
+		return nil, err
+	}
+
+	ingrediente := &Ingrediente{Nombre: nombre, Slug: slug.Make(nombre), Pasillo: pasillo, DensidadGML: input.DensidadGML}
+	if err := s.repo.Create(ctx, ingrediente); err != nil {
+		if errors.Is(err, repository.ErrDuplicateRecord) {
+			return nil, repository.ConCausa(ErrIngredienteNombreYaExiste, err)
+		}
+		return nil, fmt.Errorf("servicio ingredientes: error al crear: %w", err)
+	}
+	log.Printf("Servicio: Ingrediente '%s' creado con ID: %d\n", ingrediente.Nombre, ingrediente.ID)
+	return ingrediente, nil
+}
+
+func (s *ingredienteService) Update(ctx context.Context, id uint, input IngredienteInputDTO) (*Ingrediente, error) {
+	nombre, pasillo, err := validarIngrediente(input)
+	if err != nil {
+		return nil, err
+	}
+	ingrediente, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if nombre != ingrediente.Nombre {
+		if err := s.verificarNombreLibre(ctx, nombre, id); err != nil {
+			return nil, err
+		}
+	}
+
+	ingrediente.Nombre = nombre
+	ingrediente.Slug = slug.Make(nombre)
+	ingrediente.Pasillo = pasillo
+	ingrediente.DensidadGML = input.DensidadGML
+	if err := s.repo.Update(ctx, ingrediente); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrIngredienteNotFound
+		}
+		if errors.Is(err, repository.ErrDuplicateRecord) {
+			return nil, repository.ConCausa(ErrIngredienteNombreYaExiste, err)
+		}
+		return nil, fmt.Errorf("servicio ingredientes: error al actualizar %d: %w", id, err)
+	}
+	return ingrediente, nil
+}
+
+// Delete elimina un ingrediente que ninguna receta usa.
+func (s *ingredienteService) Delete(ctx context.Context, id uint) error {
+	enUso, err := s.repo.IsInUse(ctx, id)
+	if err != nil {
+		return fmt.Errorf("servicio ingredientes: error verificando uso de %d: %w", id, err)
+	}
+	if enUso {
+		return ErrIngredienteEnUso
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrIngredienteNotFound
+		}
+		if errors.Is(err, repository.ErrForeignKeyViolation) { // Una receta empezó a usarlo tras IsInUse
+			return repository.ConCausa(ErrIngredienteEnUso, err)
+		}
+		return fmt.Errorf("servicio ingredientes: error al eliminar %d: %w", id, err)
+	}
+	log.Printf("Servicio: Ingrediente ID %d eliminado.\n", id)
+	return nil
+}
+
+// ObtenerDeReceta devuelve los ingredientes de una receta, en orden.
+func (s *ingredienteService) ObtenerDeReceta(ctx context.Context, recetaID uint, lector recetas.Lector) ([]RecetaIngrediente, error) {
+	if _, err := s.recetaSvc.ObtenerVisible(ctx, recetaID, lector); err != nil {
+		return nil, fmt.Errorf("servicio ingredientes: error validando receta %d: %w", recetaID, err)
+	}
+	lineas, err := s.repo.FindByRecetaIDs(ctx, []uint{recetaID})
+	if err != nil {
+		return nil, fmt.Errorf("servicio ingredientes: error obteniendo ingredientes de receta %d: %w", recetaID, err)
+	}
+	return lineas, nil
+}
+
+// ReemplazarDeReceta sustituye la lista completa de ingredientes de una receta.
+// El orden de la lista se conserva. Un mismo ingrediente no puede repetirse.
+func (s *ingredienteService) ReemplazarDeReceta(ctx context.Context, recetaID uint, lector recetas.Lector, lineasInput []LineaIngredienteInput) ([]RecetaIngrediente, error) {
+	lineas := make([]RecetaIngrediente, 0, len(lineasInput))
+	ids := make([]uint, 0, len(lineasInput))
+	vistos := make(map[uint]bool, len(lineasInput))
+	for i, li := range lineasInput {
+		if li.IngredienteID == 0 || li.Cantidad < 0 || vistos[li.IngredienteID] {
+			return nil, ErrLineaIngredienteInvalida
+		}
+		vistos[li.IngredienteID] = true
+		ids = append(ids, li.IngredienteID)
+		lineas = append(lineas, RecetaIngrediente{
+			RecetaID:      recetaID,
+			IngredienteID: li.IngredienteID,
+			Cantidad:      li.Cantidad,
+			Unidad:        NormalizarUnidad(li.Unidad),
+			Nota:          strings.TrimSpace(li.Nota),
+			Orden:         i,
+		})
+	}
+
+	if _, err := s.recetaSvc.ObtenerVisible(ctx, recetaID, lector); err != nil {
+		return nil, fmt.Errorf("servicio ingredientes: error validando receta %d: %w", recetaID, err)
+	}
+	existentes, err := s.repo.CountByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("servicio ingredientes: error validando ingredientes: %w", err)
+	}
+	if existentes != int64(len(ids)) {
+		return nil, fmt.Errorf("%w: algún ingrediente no existe", ErrIngredienteNotFound)
+	}
+
+	if err := s.repo.ReplaceForReceta(ctx, recetaID, lineas); err != nil {
+		return nil, fmt.Errorf("servicio ingredientes: error guardando ingredientes de receta %d: %w", recetaID, err)
+	}
+	log.Printf("Servicio: %d ingrediente(s) asignados a RecetaID %d\n", len(lineas), recetaID)
+	return s.repo.FindByRecetaIDs(ctx, []uint{recetaID})
+}
+
+func (s *ingredienteService) ObtenerDeRecetas(ctx context.Context, recetaIDs []uint) (map[uint][]RecetaIngrediente, error) {
+	lineas, err := s.repo.FindByRecetaIDs(ctx, recetaIDs)
+	if err != nil {
+		return nil, fmt.Errorf("servicio ingredientes: error obteniendo ingredientes de recetas: %w", err)
+	}
+	porReceta := make(map[uint][]RecetaIngrediente, len(recetaIDs))
+	for _, l := range lineas {
+		porReceta[l.RecetaID] = append(porReceta[l.RecetaID], l)
+	}
+	return porReceta, nil
+}
+
+// --- Helpers ---
+
+func validarIngrediente(input IngredienteInputDTO) (string, string, error) {
+	nombre := strings.TrimSpace(input.Nombre)
+	if nombre == "" {
+		return "", "", ErrIngredienteNombreInvalido
+	}
+	if input.DensidadGML != nil && *input.DensidadGML <= 0 {
+		return "", "", ErrIngredienteDensidadInvalida
+	}
+	pasillo := strings.TrimSpace(input.Pasillo)
+	if pasillo == "" {
+		pasillo = PasilloPorDefecto
+	}
+	return nombre, pasillo, nil
+}
+
+func (s *ingredienteService) verificarNombreLibre(ctx context.Context, nombre string, idActual uint) error {
+	existente, err := s.repo.GetByNombre(ctx, nombre)
+	if err == nil && existente.ID != idActual {
+		return ErrIngredienteNombreYaExiste
+	}
+	if err != nil && !errors.Is(err, repository.ErrRecordNotFound) {
+		return fmt.Errorf("servicio ingredientes: error verificando nombre '%s': %w", nombre, err)
+	}
+	return nil
+}
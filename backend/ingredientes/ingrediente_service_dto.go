@@ -0,0 +1,17 @@
+// backend/ingredientes/ingrediente_service_dto.go
+package ingredientes
+
+// IngredienteInputDTO es el DTO de entrada del servicio para crear/actualizar un ingrediente.
+type IngredienteInputDTO struct {
+	Nombre      string
+	Pasillo     string   // Vacío = PasilloPorDefecto
+	DensidadGML *float64 // Opcional
+}
+
+// LineaIngredienteInput es una línea de ingrediente para asignar a una receta.
+type LineaIngredienteInput struct {
+	IngredienteID uint
+	Cantidad      float64
+	Unidad        string
+	Nota          string
+}
@@ -0,0 +1,165 @@
+// backend/ingredientes/ingrediente_service_test.go
+package ingredientes_test // Usar paquete _test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/ingredientes"                         // El paquete bajo test
+	ingredientesMocks "backend/ingredientes/mocks" // Mocks del paquete ingredientes
+	"backend/recetas"                              // Para el tipo recetas.Receta
+	recetasMocks "backend/recetas/mocks"           // Mock de RecetaService
+	"backend/shared/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type IngredienteServiceTestSuite struct {
+	suite.Suite
+	mockRepo      *ingredientesMocks.IngredienteRepositoryMock
+	mockRecetaSvc *recetasMocks.RecetaServiceMock
+	service       ingredientes.IngredienteService
+}
+
+func (s *IngredienteServiceTestSuite) SetupTest() {
+	s.mockRepo = new(ingredientesMocks.IngredienteRepositoryMock)
+	s.mockRecetaSvc = new(recetasMocks.RecetaServiceMock)
+	s.service = ingredientes.NewIngredienteService(s.mockRepo, s.mockRecetaSvc)
+}
+
+func TestIngredienteServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(IngredienteServiceTestSuite))
+}
+
+func (s *IngredienteServiceTestSuite) TestCreate_Success_PasilloPorDefecto() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByNombre", ctx, "Harina de trigo").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(i *ingredientes.Ingrediente) bool {
+		return i.Slug == "harina-de-trigo" && i.Pasillo == ingredientes.PasilloPorDefecto
+	})).Return(nil).Once()
+
+	ingrediente, err := s.service.Create(ctx, ingredientes.IngredienteInputDTO{Nombre: "  Harina de trigo "})
+
+	s.NoError(err)
+	s.Equal(uint(1), ingrediente.ID)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *IngredienteServiceTestSuite) TestCreate_Fail_NombreDuplicado() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByNombre", ctx, "Sal").Return(&ingredientes.Ingrediente{ID: 4, Nombre: "Sal"}, nil).Once()
+
+	ingrediente, err := s.service.Create(ctx, ingredientes.IngredienteInputDTO{Nombre: "Sal"})
+
+	s.Nil(ingrediente)
+	s.ErrorIs(err, ingredientes.ErrIngredienteNombreYaExiste)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *IngredienteServiceTestSuite) TestCreate_Fail_DensidadInvalida() {
+	densidad := -1.0
+	ingrediente, err := s.service.Create(context.Background(), ingredientes.IngredienteInputDTO{Nombre: "Leche", DensidadGML: &densidad})
+
+	s.Nil(ingrediente)
+	s.ErrorIs(err, ingredientes.ErrIngredienteDensidadInvalida)
+}
+
+func (s *IngredienteServiceTestSuite) TestDelete_Fail_EnUso() {
+	ctx := context.Background()
+	s.mockRepo.On("IsInUse", ctx, uint(3)).Return(true, nil).Once()
+
+	err := s.service.Delete(ctx, 3)
+
+	s.ErrorIs(err, ingredientes.ErrIngredienteEnUso)
+	s.mockRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *IngredienteServiceTestSuite) TestReemplazarDeReceta_Success_NormalizaYOrdena() {
+	ctx := context.Background()
+	input := []ingredientes.LineaIngredienteInput{
+		{IngredienteID: 3, Cantidad: 200, Unidad: " G "},
+		{IngredienteID: 5, Cantidad: 1, Unidad: "Tazas."},
+	}
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(1)).Return(&recetas.Receta{ID: 7}, nil).Once()
+	s.mockRepo.On("CountByIDs", ctx, []uint{3, 5}).Return(int64(2), nil).Once()
+	s.mockRepo.On("ReplaceForReceta", ctx, uint(7), mock.MatchedBy(func(l []ingredientes.RecetaIngrediente) bool {
+		return len(l) == 2 && l[0].Unidad == "g" && l[0].Orden == 0 && l[1].Unidad == "tazas" && l[1].Orden == 1
+	})).Return(nil).Once()
+	s.mockRepo.On("FindByRecetaIDs", ctx, []uint{7}).Return([]ingredientes.RecetaIngrediente{{RecetaID: 7}}, nil).Once()
+
+	lineas, err := s.service.ReemplazarDeReceta(ctx, 7, recetas.LectorUsuario(1), input)
+
+	s.NoError(err)
+	s.Len(lineas, 1)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecetaSvc.AssertExpectations(s.T())
+}
+
+func (s *IngredienteServiceTestSuite) TestReemplazarDeReceta_Fail_IngredienteRepetido() {
+	input := []ingredientes.LineaIngredienteInput{{IngredienteID: 3, Cantidad: 1}, {IngredienteID: 3, Cantidad: 2}}
+
+	lineas, err := s.service.ReemplazarDeReceta(context.Background(), 7, recetas.LectorUsuario(1), input)
+
+	s.Nil(lineas)
+	s.ErrorIs(err, ingredientes.ErrLineaIngredienteInvalida)
+	s.mockRecetaSvc.AssertNotCalled(s.T(), "ObtenerVisible", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *IngredienteServiceTestSuite) TestReemplazarDeReceta_Fail_IngredienteInexistente() {
+	ctx := context.Background()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(1)).Return(&recetas.Receta{ID: 7}, nil).Once()
+	s.mockRepo.On("CountByIDs", ctx, []uint{3, 99}).Return(int64(1), nil).Once()
+
+	_, err := s.service.ReemplazarDeReceta(ctx, 7, recetas.LectorUsuario(1), []ingredientes.LineaIngredienteInput{{IngredienteID: 3}, {IngredienteID: 99}})
+
+	s.ErrorIs(err, ingredientes.ErrIngredienteNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "ReplaceForReceta", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *IngredienteServiceTestSuite) TestObtenerDeReceta_Fail_BorradorAjeno() {
+	ctx := context.Background()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.Lector{}).Return(nil, recetas.ErrRecetaNotFound).Once()
+
+	lineas, err := s.service.ObtenerDeReceta(ctx, 7, recetas.Lector{})
+
+	s.Nil(lineas)
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "FindByRecetaIDs", mock.Anything, mock.Anything)
+}
+
+// --- Conversión de unidades ---
+
+func (s *IngredienteServiceTestSuite) TestACantidadBase_ConvierteUnidades() {
+	magnitud, base := ingredientes.ACantidadBase(1, "Taza")
+	s.Equal(ingredientes.MagnitudVolumen, magnitud)
+	s.Equal(240.0, base)
+
+	magnitud, base = ingredientes.ACantidadBase(1.5, "kg")
+	s.Equal(ingredientes.MagnitudMasa, magnitud)
+	s.Equal(1500.0, base)
+
+	magnitud, base = ingredientes.ACantidadBase(2, "dientes")
+	s.Equal(ingredientes.MagnitudDesconocida, magnitud)
+	s.Equal(2.0, base)
+}
+
+func (s *IngredienteServiceTestSuite) TestFormatearCantidad_EligeUnidadLegible() {
+	cantidad, unidad := ingredientes.FormatearCantidad(ingredientes.MagnitudMasa, 1250, "g")
+	s.Equal(1.25, cantidad)
+	s.Equal("kg", unidad)
+	s.Equal("1.25 kg", ingredientes.TextoCantidad(cantidad, unidad))
+}
+
+func (s *IngredienteServiceTestSuite) TestDelete_Fail_LlaveForaneaEnRepo() {
+	ctx := context.Background()
+	fk := &repository.ConstraintError{Base: repository.ErrForeignKeyViolation, Campo: "ingrediente_id", Causa: errors.New("Error 1451")}
+	s.mockRepo.On("IsInUse", ctx, uint(3)).Return(false, nil).Once()
+	s.mockRepo.On("Delete", ctx, uint(3)).Return(fk).Once()
+
+	err := s.service.Delete(ctx, 3)
+
+	s.ErrorIs(err, ingredientes.ErrIngredienteEnUso)
+	s.ErrorIs(err, repository.ErrForeignKeyViolation)
+}
@@ -0,0 +1,82 @@
+// backend/ingredientes/mocks/ingrediente_repository_mock.go
+package mocks
+
+import (
+	"backend/ingredientes" // Para los tipos de dominio y la interfaz
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type IngredienteRepositoryMock struct {
+	mock.Mock
+}
+
+// Asegurar que implementa la interfaz
+var _ ingredientes.IngredienteRepository = (*IngredienteRepositoryMock)(nil)
+
+func (m *IngredienteRepositoryMock) GetAll(ctx context.Context) ([]ingredientes.Ingrediente, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ingredientes.Ingrediente), args.Error(1)
+}
+
+func (m *IngredienteRepositoryMock) GetByID(ctx context.Context, id uint) (*ingredientes.Ingrediente, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ingredientes.Ingrediente), args.Error(1)
+}
+
+func (m *IngredienteRepositoryMock) GetByNombre(ctx context.Context, nombre string) (*ingredientes.Ingrediente, error) {
+	args := m.Called(ctx, nombre)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ingredientes.Ingrediente), args.Error(1)
+}
+
+func (m *IngredienteRepositoryMock) CountByIDs(ctx context.Context, ids []uint) (int64, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *IngredienteRepositoryMock) Create(ctx context.Context, ingrediente *ingredientes.Ingrediente) error {
+	args := m.Called(ctx, ingrediente)
+	// Simular que el repo asigna ID si el Create es exitoso
+	if args.Error(0) == nil && ingrediente != nil {
+		ingrediente.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *IngredienteRepositoryMock) Update(ctx context.Context, ingrediente *ingredientes.Ingrediente) error {
+	args := m.Called(ctx, ingrediente)
+	return args.Error(0)
+}
+
+func (m *IngredienteRepositoryMock) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *IngredienteRepositoryMock) IsInUse(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *IngredienteRepositoryMock) FindByRecetaIDs(ctx context.Context, recetaIDs []uint) ([]ingredientes.RecetaIngrediente, error) {
+	args := m.Called(ctx, recetaIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ingredientes.RecetaIngrediente), args.Error(1)
+}
+
+func (m *IngredienteRepositoryMock) ReplaceForReceta(ctx context.Context, recetaID uint, lineas []ingredientes.RecetaIngrediente) error {
+	args := m.Called(ctx, recetaID, lineas)
+	return args.Error(0)
+}
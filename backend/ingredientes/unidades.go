@@ -0,0 +1,106 @@
+// Archivo: backend/ingredientes/unidades.go
+// Funcionalidad: Normalización y conversión de unidades de medida de cocina.
+//
+// Todas las cantidades se llevan a una unidad base por magnitud:
+// masa -> gramos, volumen -> mililitros, conteo -> unidades.
+// Las unidades desconocidas ("al gusto", "diente") no se convierten y solo
+// se suman con la misma unidad literal.
+
+package ingredientes
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Magnitud agrupa las unidades convertibles entre sí.
+type Magnitud string
+
+const (
+	MagnitudMasa        Magnitud = "masa"
+	MagnitudVolumen     Magnitud = "volumen"
+	MagnitudConteo      Magnitud = "conteo"
+	MagnitudDesconocida Magnitud = ""
+)
+
+type unidadInfo struct {
+	magnitud Magnitud
+	factor   float64 // Multiplicador hasta la unidad base
+}
+
+// unidadesConocidas mapea cada forma escrita (en minúsculas, sin punto final) a su magnitud y factor.
+var unidadesConocidas = map[string]unidadInfo{
+	// Masa (base: g)
+	"g": {MagnitudMasa, 1}, "gr": {MagnitudMasa, 1}, "grs": {MagnitudMasa, 1}, "gramo": {MagnitudMasa, 1}, "gramos": {MagnitudMasa, 1},
+	"kg": {MagnitudMasa, 1000}, "kilo": {MagnitudMasa, 1000}, "kilos": {MagnitudMasa, 1000}, "kilogramo": {MagnitudMasa, 1000}, "kilogramos": {MagnitudMasa, 1000},
+	"mg": {MagnitudMasa, 0.001},
+	"lb": {MagnitudMasa, 453.592}, "libra": {MagnitudMasa, 453.592}, "libras": {MagnitudMasa, 453.592},
+	"oz": {MagnitudMasa, 28.3495}, "onza": {MagnitudMasa, 28.3495}, "onzas": {MagnitudMasa, 28.3495},
+	// Volumen (base: ml)
+	"ml": {MagnitudVolumen, 1}, "mililitro": {MagnitudVolumen, 1}, "mililitros": {MagnitudVolumen, 1},
+	"cl": {MagnitudVolumen, 10}, "dl": {MagnitudVolumen, 100},
+	"l": {MagnitudVolumen, 1000}, "lt": {MagnitudVolumen, 1000}, "litro": {MagnitudVolumen, 1000}, "litros": {MagnitudVolumen, 1000},
+	"taza": {MagnitudVolumen, 240}, "tazas": {MagnitudVolumen, 240},
+	"vaso": {MagnitudVolumen, 200}, "vasos": {MagnitudVolumen, 200},
+	"cda": {MagnitudVolumen, 15}, "cdas": {MagnitudVolumen, 15}, "cucharada": {MagnitudVolumen, 15}, "cucharadas": {MagnitudVolumen, 15},
+	"cdta": {MagnitudVolumen, 5}, "cdtas": {MagnitudVolumen, 5}, "cdita": {MagnitudVolumen, 5}, "cditas": {MagnitudVolumen, 5},
+	"cucharadita": {MagnitudVolumen, 5}, "cucharaditas": {MagnitudVolumen, 5},
+	// Conteo (base: unidad)
+	"": {MagnitudConteo, 1}, "u": {MagnitudConteo, 1}, "ud": {MagnitudConteo, 1}, "uds": {MagnitudConteo, 1},
+	"unidad": {MagnitudConteo, 1}, "unidades": {MagnitudConteo, 1}, "pieza": {MagnitudConteo, 1}, "piezas": {MagnitudConteo, 1},
+	"docena": {MagnitudConteo, 12}, "docenas": {MagnitudConteo, 12},
+}
+
+// NormalizarUnidad limpia el texto de una unidad ("Tazas." -> "tazas").
+func NormalizarUnidad(unidad string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(unidad)), ".")
+}
+
+// ACantidadBase convierte una cantidad a la unidad base de su magnitud.
+// Si la unidad no es conocida devuelve MagnitudDesconocida y la cantidad sin cambios.
+func ACantidadBase(cantidad float64, unidad string) (Magnitud, float64) {
+	info, ok := unidadesConocidas[NormalizarUnidad(unidad)]
+	if !ok {
+		return MagnitudDesconocida, cantidad
+	}
+	return info.magnitud, cantidad * info.factor
+}
+
+// FormatearCantidad expresa una cantidad en unidad base con la unidad más legible
+// (ej: 1500 g -> "1.5 kg", 250 ml -> "250 ml", 3 unidades -> "3").
+func FormatearCantidad(magnitud Magnitud, cantidadBase float64, unidadOriginal string) (float64, string) {
+	switch magnitud {
+	case MagnitudMasa:
+		if cantidadBase >= 1000 {
+			return redondear(cantidadBase / 1000), "kg"
+		}
+		return redondear(cantidadBase), "g"
+	case MagnitudVolumen:
+		if cantidadBase >= 1000 {
+			return redondear(cantidadBase / 1000), "l"
+		}
+		return redondear(cantidadBase), "ml"
+	case MagnitudConteo:
+		return redondear(cantidadBase), ""
+	default:
+		return redondear(cantidadBase), NormalizarUnidad(unidadOriginal)
+	}
+}
+
+// TextoCantidad devuelve "1.5 kg", "3" o "" (si la cantidad es 0, ej: "al gusto").
+func TextoCantidad(cantidad float64, unidad string) string {
+	if cantidad == 0 {
+		return unidad
+	}
+	numero := strconv.FormatFloat(cantidad, 'f', -1, 64)
+	if unidad == "" {
+		return numero
+	}
+	return fmt.Sprintf("%s %s", numero, unidad)
+}
+
+func redondear(v float64) float64 {
+	return math.Round(v*100) / 100
+}
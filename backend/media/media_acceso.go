@@ -0,0 +1,105 @@
+// backend/media/media_acceso.go
+// Funcionalidad: Quién puede ver cada archivo subido.
+// Capa: Servicio.
+
+// Descripción:
+// Un archivo es público si alguna de las entidades que lo usan lo es (ej: una receta publicada).
+// Si no, solo lo ven quienes pueden ver alguna de esas entidades (ej: el autor del borrador) y
+// quien lo subió. Cada característica aporta su Politica; las entidades sin política no dan
+// acceso a nadie, para que una característica nueva no publique archivos por descuido.
+//
+// Los archivos sin registro en 'media' (anteriores a la tabla) se consideran públicos, como
+// hasta ahora: no hay forma de saber a quién pertenecen. En las carpetas marcadas con
+// RestringirSinRegistro se deniegan: sus archivos antiguos se registran con un comando
+// (ej: cmd/fotosmedia para las fotos de recetas) y hasta entonces nadie los ve.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	"backend/shared/imagenes"
+	"backend/shared/repository"
+)
+
+// Visibilidad de un archivo (o de una entidad) para quien lo pide.
+type Visibilidad int
+
+const (
+	VisibilidadDenegada Visibilidad = iota // No puede verlo
+	VisibilidadPrivada                     // Puede verlo, pero no cualquiera: no se cachea en proxies
+	VisibilidadPublica                     // Lo ve cualquiera
+)
+
+// Politica decide quién ve los archivos que usa un tipo de entidad. La implementa cada
+// característica; se define aquí para que 'media' no dependa de ellas.
+type Politica interface {
+	// Visibilidad de la entidad 'entidadID' para el usuario (nil = anónimo).
+	Visibilidad(ctx context.Context, entidadID uint, usuarioID *uint) (Visibilidad, error)
+}
+
+// ControlAcceso calcula la visibilidad de los archivos a partir de sus usos.
+type ControlAcceso struct {
+	repo      MediaRepository
+	tamanos   []imagenes.Tamano // Para reconocer las variantes de cada archivo
+	politicas map[string]Politica
+	// Carpetas cuyos archivos sin registro se deniegan en vez de considerarse públicos
+	restringidas map[string]bool
+}
+
+// NewControlAcceso crea el control de acceso, sin políticas.
+func NewControlAcceso(repo MediaRepository) *ControlAcceso {
+	return &ControlAcceso{repo: repo, tamanos: imagenes.TamanosPorDefecto, politicas: make(map[string]Politica), restringidas: make(map[string]bool)}
+}
+
+// Registrar asigna la política de los archivos que usa 'entidad' (ej: "recetas").
+func (a *ControlAcceso) Registrar(entidad string, p Politica) *ControlAcceso {
+	a.politicas[entidad] = p
+	return a
+}
+
+// RestringirSinRegistro deniega los archivos sin registro en 'media' de la carpeta (ej: "recetas").
+func (a *ControlAcceso) RestringirSinRegistro(carpeta string) *ControlAcceso {
+	a.restringidas[path.Clean(carpeta)] = true
+	return a
+}
+
+// Visibilidad del archivo 'clave' (el principal o cualquiera de sus variantes) para el usuario.
+func (a *ControlAcceso) Visibilidad(ctx context.Context, clave string, usuarioID *uint) (Visibilidad, error) {
+	imagen := path.Join(path.Dir(clave), imagenes.Original(path.Base(clave), a.tamanos))
+	m, err := a.repo.GetByImagen(ctx, imagen)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		if a.restringidas[path.Dir(clave)] {
+			return VisibilidadDenegada, nil
+		}
+		return VisibilidadPublica, nil
+	}
+	if err != nil {
+		return VisibilidadDenegada, fmt.Errorf("acceso media: %w", err)
+	}
+
+	visibilidad := VisibilidadDenegada
+	if usuarioID != nil && m.PropietarioID != nil && *m.PropietarioID == *usuarioID {
+		visibilidad = VisibilidadPrivada
+	}
+	for _, u := range m.Usos {
+		p, ok := a.politicas[u.Entidad]
+		if !ok {
+			continue
+		}
+		v, err := p.Visibilidad(ctx, u.EntidadID, usuarioID)
+		if err != nil {
+			return VisibilidadDenegada, fmt.Errorf("acceso media: %s ID %d: %w", u.Entidad, u.EntidadID, err)
+		}
+		if v == VisibilidadPublica {
+			return v, nil
+		}
+		if v > visibilidad {
+			visibilidad = v
+		}
+	}
+	return visibilidad, nil
+}
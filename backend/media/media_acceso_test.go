@@ -0,0 +1,79 @@
+// backend/media/media_acceso_test.go
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"backend/media"
+	mediaMocks "backend/media/mocks"
+	"backend/shared/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// politicaFija da a cada entidad una visibilidad fija; el autor (usuario 1) ve las privadas.
+type politicaFija map[uint]media.Visibilidad
+
+func (p politicaFija) Visibilidad(ctx context.Context, entidadID uint, usuarioID *uint) (media.Visibilidad, error) {
+	v := p[entidadID]
+	if v == media.VisibilidadPrivada && (usuarioID == nil || *usuarioID != 1) {
+		return media.VisibilidadDenegada, nil
+	}
+	return v, nil
+}
+
+func TestControlAcceso_Visibilidad(t *testing.T) {
+	ctx := context.Background()
+	autor, otro, propietario := uint(1), uint(2), uint(3)
+	repo := new(mediaMocks.MediaRepositoryMock)
+	// Cualquier variante se busca por su imagen principal
+	repo.On("GetByImagen", ctx, "recetas/borrador").Return(&media.Media{ID: 1, PropietarioID: &propietario, Usos: []media.Uso{
+		{MediaID: 1, Entidad: "recetas", EntidadID: 10},
+	}}, nil)
+	repo.On("GetByImagen", ctx, "recetas/compartida").Return(&media.Media{ID: 2, Usos: []media.Uso{
+		{MediaID: 2, Entidad: "recetas", EntidadID: 10},
+		{MediaID: 2, Entidad: "recetas", EntidadID: 11},
+	}}, nil)
+	repo.On("GetByImagen", ctx, "recetas/sinpolitica").Return(&media.Media{ID: 3, Usos: []media.Uso{
+		{MediaID: 3, Entidad: "contactos", EntidadID: 5},
+	}}, nil)
+	repo.On("GetByImagen", ctx, "recetas/antigua").Return(nil, repository.ErrRecordNotFound)
+	acceso := media.NewControlAcceso(repo).Registrar("recetas", politicaFija{10: media.VisibilidadPrivada, 11: media.VisibilidadPublica})
+
+	casos := []struct {
+		clave   string
+		usuario *uint
+		want    media.Visibilidad
+	}{
+		{"recetas/borrador_thumb.webp", nil, media.VisibilidadDenegada},
+		{"recetas/borrador.jpg", &otro, media.VisibilidadDenegada},
+		{"recetas/borrador_card.jpg", &autor, media.VisibilidadPrivada},
+		{"recetas/borrador.jpg", &propietario, media.VisibilidadPrivada}, // Quien lo subió
+		{"recetas/compartida.webp", nil, media.VisibilidadPublica},       // Basta un uso público
+		{"recetas/sinpolitica.jpg", nil, media.VisibilidadDenegada},      // Entidad sin política
+		{"recetas/antigua.jpg", nil, media.VisibilidadPublica},           // Anterior a la tabla
+	}
+	for _, c := range casos {
+		v, err := acceso.Visibilidad(ctx, c.clave, c.usuario)
+		require.NoError(t, err, c.clave)
+		assert.Equal(t, c.want, v, "%s (usuario %v)", c.clave, c.usuario)
+	}
+}
+
+func TestControlAcceso_RestringirSinRegistro(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mediaMocks.MediaRepositoryMock)
+	repo.On("GetByImagen", ctx, "recetas/antigua").Return(nil, repository.ErrRecordNotFound)
+	repo.On("GetByImagen", ctx, "avatares/antigua").Return(nil, repository.ErrRecordNotFound)
+	acceso := media.NewControlAcceso(repo).RestringirSinRegistro("recetas")
+
+	v, err := acceso.Visibilidad(ctx, "recetas/antigua_thumb.jpg", nil)
+	require.NoError(t, err)
+	assert.Equal(t, media.VisibilidadDenegada, v)
+
+	v, err = acceso.Visibilidad(ctx, "avatares/antigua.jpg", nil) // Otra carpeta: sigue pública
+	require.NoError(t, err)
+	assert.Equal(t, media.VisibilidadPublica, v)
+}
@@ -0,0 +1,131 @@
+// backend/media/media_api.go
+// Funcionalidad: Servir los archivos subidos (driver de almacenamiento "local").
+// Capa: API / Handler.
+
+// Descripción:
+// Reemplaza al servidor estático de la carpeta de subidas. Cada petición se atiende así:
+//   - Con ?expira=&firma= (ver storage.Local.SignedURL): se sirve si la firma es válida,
+//     cacheable solo por el navegador y hasta que caduque.
+//   - Sin firma: según ControlAcceso. Los archivos públicos se sirven con un Cache-Control
+//     largo (sus nombres derivan del contenido, así que nunca cambian); los privados solo a
+//     quien tiene permiso y sin caché compartida. A los demás se les responde 404, como si
+//     el archivo no existiera.
+
+package media
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"backend/shared/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaHandler sirve los archivos del almacenamiento en disco.
+type MediaHandler struct {
+	acceso        *ControlAcceso
+	almacen       *storage.Local
+	maxAgePublico time.Duration // Cache-Control de los archivos públicos
+}
+
+// NewMediaHandler crea el handler que sirve los archivos de 'almacen'.
+func NewMediaHandler(acceso *ControlAcceso, almacen *storage.Local, maxAgePublico time.Duration) *MediaHandler {
+	return &MediaHandler{acceso: acceso, almacen: almacen, maxAgePublico: maxAgePublico}
+}
+
+// Servir godoc
+// @Summary Sirve un archivo subido
+// @Description Los archivos públicos (ej: fotos de recetas publicadas) se sirven a cualquiera con caché larga.
+// @Description Los privados (ej: fotos de borradores) requieren una URL firmada (las que devuelven los endpoints
+// @Description de recetas) o ser quien puede verlos. Un archivo sin permiso responde 404.
+// @Tags Media
+// @Param clave path string true "Clave del archivo, ej: recetas/3f2a...9c.jpg"
+// @Param expira query int false "Caducidad de la firma (Unix)"
+// @Param firma query string false "Firma HMAC de la URL"
+// @Success 200 {file} file "Archivo"
+// @Failure 403 {object} apitypes.ErrorResponse "Firma inválida o caducada"
+// @Failure 404 {object} apitypes.ErrorResponse "El archivo no existe o no se tiene permiso"
+// @Router /uploads/{clave} [get]
+func (h *MediaHandler) Servir(c *gin.Context) {
+	clave := strings.TrimPrefix(c.Param("clave"), "/")
+	if err := storage.ValidarClave(clave); err != nil {
+		_ = c.Error(fmt.Errorf("%w: %v", ErrMediaNotFound, err))
+		return
+	}
+	ctx := c.Request.Context()
+
+	var cacheControl string
+	if firma := c.Query(storage.ParamFirma); firma != "" {
+		vence, err := h.almacen.ComprobarFirma(clave, c.Query(storage.ParamExpira), firma)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		cacheControl = fmt.Sprintf("private, max-age=%d", int(time.Until(vence).Seconds()))
+	} else {
+		visibilidad, err := h.acceso.Visibilidad(ctx, clave, usuarioOpcional(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		switch visibilidad {
+		case VisibilidadPublica:
+			cacheControl = fmt.Sprintf("public, max-age=%d, immutable", int(h.maxAgePublico.Seconds()))
+		case VisibilidadPrivada:
+			cacheControl = "private, no-cache"
+		default:
+			_ = c.Error(fmt.Errorf("%w: %s", ErrMediaNotFound, clave))
+			return
+		}
+	}
+
+	archivo, err := h.almacen.Get(ctx, clave)
+	if errors.Is(err, storage.ErrNoExiste) {
+		_ = c.Error(fmt.Errorf("%w: %s", ErrMediaNotFound, clave))
+		return
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	defer archivo.Close()
+
+	c.Header("Cache-Control", cacheControl)
+	c.Header("X-Content-Type-Options", "nosniff")
+	// En disco el archivo admite Seek: ServeContent añade Range, Last-Modified y 304.
+	if f, ok := archivo.(interface {
+		io.ReadSeeker
+		Stat() (fs.FileInfo, error)
+	}); ok {
+		if info, err := f.Stat(); err == nil {
+			http.ServeContent(c.Writer, c.Request, path.Base(clave), info.ModTime(), f)
+			return
+		}
+	}
+	if tipo := mime.TypeByExtension(path.Ext(clave)); tipo != "" {
+		c.Header("Content-Type", tipo)
+	}
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, archivo); err != nil {
+		log.Printf("Handler: Error enviando %s: %v\n", clave, err)
+	}
+}
+
+// usuarioOpcional devuelve el ID del usuario autenticado, o nil si la petición es anónima.
+func usuarioOpcional(c *gin.Context) *uint {
+	if v, exists := c.Get("userID"); exists {
+		if uid, ok := v.(uint); ok {
+			return &uid
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// backend/media/media_api_errors.go
+
+// Traducción de los errores de archivos subidos a respuestas HTTP (ver shared/apperrors).
+
+package media
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+	"backend/shared/storage"
+)
+
+func init() {
+	apperrors.Registrar(ErrMediaNotFound, http.StatusNotFound, "media_no_encontrado")
+
+	// URLs firmadas (ver storage.Local.SignedURL): el cliente debe pedir una nueva.
+	apperrors.Registrar(storage.ErrFirmaInvalida, http.StatusForbidden, "firma_invalida")
+	apperrors.Registrar(storage.ErrFirmaVencida, http.StatusForbidden, "firma_vencida")
+}
@@ -0,0 +1,92 @@
+// Archivo: backend/media/media_model.go
+// Funcionalidad: Modelo de dominio de los archivos subidos (media).
+// Capa: Dominio / Lógica de negocio.
+
+// Descripción:
+// Cada archivo guardado en el almacenamiento (ver shared/storage) tiene un registro
+// con su clave, tamaño, hash, dimensiones, quién lo subió y qué entidades lo usan.
+// Las entidades (ej: recetas) guardan el ID del registro y obtienen la URL sin
+// consultar el almacenamiento.
+//
+// Reglas de Negocio:
+// - La clave deriva del hash del contenido: dos subidas iguales comparten registro.
+// - Un registro sin usos es huérfano: sus archivos se borran al liberar el último uso.
+// - Los usos se identifican por entidad y ID (ej: "recetas", 12); registrar dos veces
+//   el mismo uso no es un error (idempotente).
+
+package media
+
+import (
+	"errors"
+	"path"
+	"time"
+
+	"backend/shared/imagenes" // Variantes generadas de las imágenes
+)
+
+// Media es un archivo guardado en el almacenamiento.
+type Media struct {
+	ID            uint
+	Clave         string // Clave en el almacenamiento, ej: "recetas/3f2a...9c.jpg"
+	Tipo          string // MIME detectado al subirlo
+	Bytes         int64  // Tamaño del archivo original subido
+	Hash          string // SHA-256 del contenido subido, en hexadecimal
+	Ancho         int    // Dimensiones de la imagen principal (variante "full")
+	Alto          int
+	Variantes     []imagenes.Variante // Tamaños generados, guardados junto a Clave
+	BlurHash      string              // Versión borrosa para mostrar mientras carga ("" si no se calculó)
+	Color         string              // Color dominante, "#rrggbb" ("" si no se calculó)
+	PropietarioID *uint               // Usuario que lo subió (nil si no estaba autenticado)
+	Usos          []Uso               // Solo cuando se cargan explícitamente
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Uso indica que una entidad referencia un archivo.
+type Uso struct {
+	MediaID   uint
+	Entidad   string // Nombre de la entidad (tabla), ej: "recetas"
+	EntidadID uint
+	CreatedAt time.Time
+}
+
+// Archivo es el nombre del archivo principal (sin carpeta).
+func (m Media) Archivo() string {
+	return path.Base(m.Clave)
+}
+
+// Carpeta es la carpeta del almacenamiento que contiene el archivo y sus variantes.
+func (m Media) Carpeta() string {
+	return path.Dir(m.Clave)
+}
+
+// TieneMarcador indica si ya tiene BlurHash y color dominante.
+func (m Media) TieneMarcador() bool {
+	return m.BlurHash != "" && m.Color != ""
+}
+
+// Claves devuelve las claves de todos los archivos del registro: el principal y sus variantes.
+func (m Media) Claves() []string {
+	archivos := imagenes.Archivos(m.Archivo(), m.Variantes)
+	claves := make([]string, 0, len(archivos))
+	for _, archivo := range archivos {
+		claves = append(claves, path.Join(m.Carpeta(), archivo))
+	}
+	return claves
+}
+
+// RegistrarInput son los datos de un archivo recién guardado.
+type RegistrarInput struct {
+	Clave         string
+	Tipo          string
+	Bytes         int64
+	Hash          string
+	Ancho         int
+	Alto          int
+	Variantes     []imagenes.Variante
+	Marcador      imagenes.Marcador // BlurHash y color dominante (vacío si no se pudo calcular)
+	PropietarioID *uint
+}
+
+// ErrMediaNotFound se produce si el registro del archivo no existe.
+var ErrMediaNotFound = errors.New("archivo no encontrado")
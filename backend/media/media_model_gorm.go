@@ -0,0 +1,93 @@
+// backend/media/media_model_gorm.go
+
+// Este archivo define los modelos de persistencia de los archivos subidos y sus usos.
+// Utiliza GORM para la definición de las tablas y el mapeo de campos.
+
+package media
+
+import (
+	"time"
+
+	"backend/shared/imagenes"
+)
+
+// MediaModel representa la tabla 'media'.
+type MediaModel struct {
+	ID            uint                `gorm:"primaryKey"`
+	Clave         string              `gorm:"type:varchar(255);not null;uniqueIndex:uk_media_clave"`
+	Tipo          string              `gorm:"type:varchar(50);not null"`
+	Bytes         int64               `gorm:"not null"`
+	Hash          string              `gorm:"type:char(64);not null;index"`
+	Ancho         int                 `gorm:"not null"`
+	Alto          int                 `gorm:"not null"`
+	Variantes     []imagenes.Variante `gorm:"type:json;serializer:json"`
+	BlurHash      string              `gorm:"type:varchar(64);default:null"`
+	Color         string              `gorm:"type:char(7);default:null"`
+	PropietarioID *uint               `gorm:"index;default:null"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+
+	Usos []MediaUsoModel `gorm:"foreignKey:MediaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (MediaModel) TableName() string {
+	return "media"
+}
+
+// MediaUsoModel representa la tabla 'media_usos' (qué entidades usan cada archivo).
+type MediaUsoModel struct {
+	MediaID   uint   `gorm:"primaryKey;autoIncrement:false"`
+	Entidad   string `gorm:"type:varchar(50);primaryKey;index:idx_media_usos_entidad,priority:1"`
+	EntidadID uint   `gorm:"primaryKey;autoIncrement:false;index:idx_media_usos_entidad,priority:2"`
+	CreatedAt time.Time
+}
+
+func (MediaUsoModel) TableName() string {
+	return "media_usos"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *MediaModel) ToDomain() *Media {
+	if m == nil {
+		return nil
+	}
+	d := &Media{
+		ID:            m.ID,
+		Clave:         m.Clave,
+		Tipo:          m.Tipo,
+		Bytes:         m.Bytes,
+		Hash:          m.Hash,
+		Ancho:         m.Ancho,
+		Alto:          m.Alto,
+		Variantes:     m.Variantes,
+		BlurHash:      m.BlurHash,
+		Color:         m.Color,
+		PropietarioID: m.PropietarioID,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+	for _, u := range m.Usos {
+		d.Usos = append(d.Usos, Uso{MediaID: u.MediaID, Entidad: u.Entidad, EntidadID: u.EntidadID, CreatedAt: u.CreatedAt})
+	}
+	return d
+}
+
+func FromMediaDomain(d *Media) *MediaModel {
+	if d == nil {
+		return nil
+	}
+	return &MediaModel{
+		ID:            d.ID,
+		Clave:         d.Clave,
+		Tipo:          d.Tipo,
+		Bytes:         d.Bytes,
+		Hash:          d.Hash,
+		Ancho:         d.Ancho,
+		Alto:          d.Alto,
+		Variantes:     d.Variantes,
+		BlurHash:      d.BlurHash,
+		Color:         d.Color,
+		PropietarioID: d.PropietarioID,
+	}
+}
@@ -0,0 +1,201 @@
+// backend/media/media_recolector.go
+// Funcionalidad: Búsqueda y borrado de archivos subidos que nadie usa (recolector de huérfanos).
+// Capa: Servicio / Tarea en segundo plano.
+
+// Descripción:
+// Cruza los archivos del almacenamiento con los registros de 'media' y con las referencias
+// que las características guardan sin registro (ej: fotos de recetas anteriores a la tabla).
+// Un archivo es huérfano si no pertenece a ninguna imagen usada; se borra solo si es más
+// antiguo que el periodo de gracia, para no tocar subidas en curso. También informa del caso
+// contrario: entidades que apuntan a archivos que ya no están.
+//
+// Se ejecuta como tarea periódica (Iniciar) o a mano con cmd/huerfanos, que permite simularlo.
+
+package media
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"time"
+
+	"backend/shared/imagenes"
+	"backend/shared/storage"
+)
+
+// Referencia es un archivo que una entidad usa sin registro en 'media'.
+type Referencia struct {
+	Entidad   string // Nombre de la entidad (tabla), ej: "recetas"
+	EntidadID uint
+	Clave     string // Clave del archivo en el almacenamiento
+}
+
+// Referenciador enumera los archivos que una característica usa sin registro en 'media'.
+// Lo implementa cada característica; se define aquí para que 'media' no dependa de ellas.
+type Referenciador interface {
+	ReferenciasArchivos(ctx context.Context) ([]Referencia, error)
+}
+
+// Informe es el resultado de una pasada del recolector.
+type Informe struct {
+	Simulacro          bool             // true: no se borró nada
+	ArchivosRevisados  int              // Archivos del almacenamiento
+	Huerfanos          []storage.Objeto // Archivos sin uso más antiguos que la gracia (borrados si no es simulacro)
+	BytesHuerfanos     int64
+	RegistrosHuerfanos []uint       // Registros de 'media' sin usos (borrados si no es simulacro)
+	Faltantes          []Referencia // Entidades que apuntan a un archivo que no está
+	Errores            int          // Borrados fallidos (se reintentan en la siguiente pasada)
+}
+
+// Recolector busca y borra los archivos huérfanos.
+type Recolector struct {
+	repo            MediaRepository
+	almacen         storage.Storage
+	gracia          time.Duration
+	tamanos         []imagenes.Tamano // Para agrupar cada archivo con su imagen principal
+	referenciadores []Referenciador
+	ahora           func() time.Time
+}
+
+// NewRecolector crea el recolector. 'gracia' es la antigüedad mínima de un archivo o
+// registro sin uso para borrarlo (protege las subidas en curso).
+func NewRecolector(repo MediaRepository, almacen storage.Storage, gracia time.Duration) *Recolector {
+	return &Recolector{repo: repo, almacen: almacen, gracia: gracia, tamanos: imagenes.TamanosPorDefecto, ahora: time.Now}
+}
+
+// Registrar añade las referencias sin registro de una característica.
+func (r *Recolector) Registrar(ref Referenciador) *Recolector {
+	r.referenciadores = append(r.referenciadores, ref)
+	return r
+}
+
+// Iniciar ejecuta el recolector cada 'intervalo' hasta que se cancele ctx.
+// Bloquea: ejecútelo en una goroutine.
+func (r *Recolector) Iniciar(ctx context.Context, intervalo time.Duration, simular bool) {
+	log.Printf("🧹 Limpieza de archivos huérfanos iniciada (gracia %s, cada %s, simulacro %t).\n", r.gracia, intervalo, simular)
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("🧹 Limpieza de archivos huérfanos detenida.")
+			return
+		case <-ticker.C:
+		}
+		informe, err := r.Ejecutar(ctx, simular)
+		if err != nil {
+			log.Printf("Huérfanos: %v\n", err)
+			continue
+		}
+		informe.Loguear()
+	}
+}
+
+// Ejecutar hace una pasada. Si falla el listado o alguna consulta de referencias no borra
+// nada: sin la lista completa, un archivo en uso podría parecer huérfano.
+func (r *Recolector) Ejecutar(ctx context.Context, simular bool) (*Informe, error) {
+	objetos, err := r.almacen.Listar(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("recolector media: %w", err)
+	}
+	medias, err := r.repo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recolector media: %w", err)
+	}
+	var referencias []Referencia
+	for _, ref := range r.referenciadores {
+		refs, err := ref.ReferenciasArchivos(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("recolector media: referencias: %w", err)
+		}
+		referencias = append(referencias, refs...)
+	}
+
+	limite := r.ahora().Add(-r.gracia)
+	informe := &Informe{Simulacro: simular, ArchivosRevisados: len(objetos)}
+	existentes := make(map[string]bool, len(objetos))
+	for _, o := range objetos {
+		existentes[o.Clave] = true
+	}
+	usadas := make(map[string]bool) // Imágenes (carpeta/original) que no se pueden borrar
+
+	for _, ref := range referencias {
+		usadas[r.imagen(ref.Clave)] = true
+		if !existentes[ref.Clave] {
+			informe.Faltantes = append(informe.Faltantes, ref)
+		}
+	}
+	for _, m := range medias {
+		if len(m.Usos) > 0 || !m.CreatedAt.Before(limite) {
+			usadas[r.imagen(m.Clave)] = true
+			if !existentes[m.Clave] {
+				for _, u := range m.Usos {
+					informe.Faltantes = append(informe.Faltantes, Referencia{Entidad: u.Entidad, EntidadID: u.EntidadID, Clave: m.Clave})
+				}
+			}
+			continue
+		}
+		informe.RegistrosHuerfanos = append(informe.RegistrosHuerfanos, m.ID)
+		if simular {
+			continue
+		}
+		// El registro se borra antes que sus archivos; si ganó un uso mientras tanto, se conserva todo.
+		borrado, err := r.repo.DeleteSinUsos(ctx, m.ID)
+		if err != nil {
+			log.Printf("Huérfanos: no se pudo borrar el media ID %d: %v\n", m.ID, err)
+			informe.Errores++
+		}
+		if !borrado {
+			usadas[r.imagen(m.Clave)] = true
+		}
+	}
+
+	for _, o := range objetos {
+		if usadas[r.imagen(o.Clave)] || !o.Modificado.Before(limite) {
+			continue
+		}
+		informe.Huerfanos = append(informe.Huerfanos, o)
+		informe.BytesHuerfanos += o.Tamano
+		if simular {
+			continue
+		}
+		if err := r.almacen.Delete(ctx, o.Clave); err != nil {
+			log.Printf("Huérfanos: no se pudo borrar %s: %v\n", o.Clave, err)
+			informe.Errores++
+		}
+	}
+	sort.Slice(informe.Huerfanos, func(i, j int) bool { return informe.Huerfanos[i].Clave < informe.Huerfanos[j].Clave })
+	sort.Slice(informe.Faltantes, func(i, j int) bool {
+		a, b := informe.Faltantes[i], informe.Faltantes[j]
+		if a.Entidad != b.Entidad {
+			return a.Entidad < b.Entidad
+		}
+		return a.EntidadID < b.EntidadID
+	})
+	return informe, nil
+}
+
+// imagen identifica la imagen a la que pertenece un archivo: su carpeta y el nombre de la
+// principal, de modo que un archivo usado protege también sus variantes.
+func (r *Recolector) imagen(clave string) string {
+	return path.Join(path.Dir(clave), imagenes.Original(path.Base(clave), r.tamanos))
+}
+
+// Loguear resume el informe en el log (los detalles, uno por línea).
+func (i *Informe) Loguear() {
+	accion := "borrado(s)"
+	if i.Simulacro {
+		accion = "a borrar (simulacro)"
+	}
+	for _, o := range i.Huerfanos {
+		log.Printf("Huérfanos: archivo %s (%d bytes, %s) %s\n", o.Clave, o.Tamano, o.Modificado.Format(time.RFC3339), accion)
+	}
+	for _, f := range i.Faltantes {
+		log.Printf("Huérfanos: %s ID %d apunta a %s, que no está en el almacenamiento\n", f.Entidad, f.EntidadID, f.Clave)
+	}
+	log.Printf("Huérfanos: %d archivo(s) revisado(s), %d huérfano(s) %s (%d bytes), %d registro(s) sin uso, %d referencia(s) rota(s), %d error(es)\n",
+		i.ArchivosRevisados, len(i.Huerfanos), accion, i.BytesHuerfanos, len(i.RegistrosHuerfanos), len(i.Faltantes), i.Errores)
+}
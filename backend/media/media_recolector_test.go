@@ -0,0 +1,107 @@
+// backend/media/media_recolector_test.go
+package media_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/media"
+	mediaMocks "backend/media/mocks"
+	"backend/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// referenciasFijas es un Referenciador con una lista fija.
+type referenciasFijas []media.Referencia
+
+func (r referenciasFijas) ReferenciasArchivos(ctx context.Context) ([]media.Referencia, error) {
+	return r, nil
+}
+
+// escenario guarda los archivos en un almacenamiento en disco; los de 'antiguos' con fecha de hace una semana.
+func escenario(t *testing.T, nuevos, antiguos []string) (storage.Storage, string) {
+	dir := t.TempDir()
+	almacen := storage.NewLocal(dir, "/uploads")
+	haceUnaSemana := time.Now().Add(-7 * 24 * time.Hour)
+	for i, clave := range append(append([]string{}, nuevos...), antiguos...) {
+		require.NoError(t, almacen.Put(context.Background(), clave, strings.NewReader("x"), 1, ""))
+		if i >= len(nuevos) {
+			require.NoError(t, os.Chtimes(filepath.Join(dir, clave), haceUnaSemana, haceUnaSemana))
+		}
+	}
+	return almacen, dir
+}
+
+func TestRecolector_BorraSoloHuerfanosAntiguos(t *testing.T) {
+	ctx := context.Background()
+	almacen, dir := escenario(t,
+		[]string{"recetas/subiendo.jpg"}, // Sin uso pero dentro de la gracia
+		[]string{
+			"recetas/usada.jpg", "recetas/usada_thumb.webp", // Registro con uso (y su variante)
+			"recetas/antigua.jpg", "recetas/antigua_card.jpg", // Foto sin registro referenciada por una receta
+			"recetas/sinuso.jpg", "recetas/sinuso.webp", // Registro sin usos
+			"recetas/20250411_021531.jpg", // Nadie lo referencia
+		})
+	repo := new(mediaMocks.MediaRepositoryMock)
+	haceUnMes := time.Now().Add(-30 * 24 * time.Hour)
+	repo.On("GetAll", ctx).Return([]media.Media{
+		{ID: 1, Clave: "recetas/usada.jpg", CreatedAt: haceUnMes, Usos: []media.Uso{{MediaID: 1, Entidad: "recetas", EntidadID: 3}}},
+		{ID: 2, Clave: "recetas/sinuso.jpg", CreatedAt: haceUnMes},
+		{ID: 3, Clave: "recetas/borrada.jpg", CreatedAt: haceUnMes, Usos: []media.Uso{{MediaID: 3, Entidad: "recetas", EntidadID: 4}}},
+	}, nil)
+	repo.On("DeleteSinUsos", ctx, uint(2)).Return(true, nil).Once()
+	refs := referenciasFijas{
+		{Entidad: "recetas", EntidadID: 5, Clave: "recetas/antigua.jpg"},
+		{Entidad: "recetas", EntidadID: 6, Clave: "recetas/perdida.jpg"},
+	}
+	recolector := media.NewRecolector(repo, almacen, 48*time.Hour).Registrar(refs)
+
+	simulado, err := recolector.Ejecutar(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, simulado.Simulacro)
+	assert.Len(t, simulado.Huerfanos, 3)
+	assert.FileExists(t, filepath.Join(dir, "recetas/sinuso.jpg"), "el simulacro no borra")
+	repo.AssertNotCalled(t, "DeleteSinUsos", ctx, uint(2))
+
+	informe, err := recolector.Ejecutar(ctx, false)
+	require.NoError(t, err)
+
+	var huerfanos []string
+	for _, o := range informe.Huerfanos {
+		huerfanos = append(huerfanos, o.Clave)
+	}
+	assert.Equal(t, []string{"recetas/20250411_021531.jpg", "recetas/sinuso.jpg", "recetas/sinuso.webp"}, huerfanos)
+	assert.Equal(t, []uint{2}, informe.RegistrosHuerfanos)
+	assert.Equal(t, []media.Referencia{
+		{Entidad: "recetas", EntidadID: 4, Clave: "recetas/borrada.jpg"},
+		{Entidad: "recetas", EntidadID: 6, Clave: "recetas/perdida.jpg"},
+	}, informe.Faltantes)
+	assert.Zero(t, informe.Errores)
+	for _, clave := range huerfanos {
+		assert.NoFileExists(t, filepath.Join(dir, clave))
+	}
+	for _, clave := range []string{"recetas/subiendo.jpg", "recetas/usada_thumb.webp", "recetas/antigua_card.jpg"} {
+		assert.FileExists(t, filepath.Join(dir, clave))
+	}
+	repo.AssertExpectations(t)
+}
+
+func TestRecolector_ConservaElRegistroQueGanaUnUso(t *testing.T) {
+	ctx := context.Background()
+	almacen, dir := escenario(t, nil, []string{"recetas/abc.jpg"})
+	repo := new(mediaMocks.MediaRepositoryMock)
+	repo.On("GetAll", ctx).Return([]media.Media{{ID: 2, Clave: "recetas/abc.jpg", CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}}, nil)
+	repo.On("DeleteSinUsos", ctx, uint(2)).Return(false, nil).Once() // Alguien lo usó entre la consulta y el borrado
+
+	informe, err := media.NewRecolector(repo, almacen, time.Hour).Ejecutar(ctx, false)
+
+	require.NoError(t, err)
+	assert.Empty(t, informe.Huerfanos)
+	assert.FileExists(t, filepath.Join(dir, "recetas/abc.jpg"))
+}
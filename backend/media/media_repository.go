@@ -0,0 +1,40 @@
+// backend/media/media_repository.go
+// Funcionalidad: Interfaz para la persistencia de los archivos subidos y sus usos.
+// Capa: Repositorio (Abstracción).
+package media
+
+import (
+	"context"
+
+	"backend/shared/imagenes"
+)
+
+// MediaRepository define el contrato para las operaciones de datos de Media.
+type MediaRepository interface {
+	GetByID(ctx context.Context, id uint) (*Media, error)
+	// GetAll recupera todos los registros con sus usos (para buscar archivos huérfanos).
+	GetAll(ctx context.Context) ([]Media, error)
+	// GetByClave busca el registro de un archivo por su clave (repository.ErrRecordNotFound si no hay).
+	GetByClave(ctx context.Context, clave string) (*Media, error)
+	// GetByImagen busca, con sus usos, el registro cuyo archivo principal es la imagen "carpeta/nombre"
+	// (sin extensión, ver imagenes.Original). Sirve para cualquier variante del archivo.
+	GetByImagen(ctx context.Context, imagen string) (*Media, error)
+	// Create inserta el registro. Si otro ya tiene la misma clave, devuelve repository.ErrDuplicateRecord.
+	Create(ctx context.Context, m *Media) error
+	// ActualizarVariantes reemplaza las variantes y las dimensiones de la imagen principal.
+	ActualizarVariantes(ctx context.Context, id uint, ancho, alto int, variantes []imagenes.Variante) error
+	// ActualizarMarcador guarda el BlurHash y el color dominante de la imagen.
+	ActualizarMarcador(ctx context.Context, id uint, marcador imagenes.Marcador) error
+	// Delete borra el registro (y sus usos).
+	Delete(ctx context.Context, id uint) error
+	// DeleteSinUsos borra el registro solo si nadie lo usa, en la misma sentencia (sin carrera con
+	// un uso nuevo). Devuelve si lo borró.
+	DeleteSinUsos(ctx context.Context, id uint) (bool, error)
+
+	// AgregarUso registra que la entidad usa el archivo. Si ya estaba registrado, no hace nada.
+	AgregarUso(ctx context.Context, uso Uso) error
+	// QuitarUso elimina el uso (si no existía, no hace nada).
+	QuitarUso(ctx context.Context, uso Uso) error
+	// ContarUsos devuelve cuántas entidades usan el archivo.
+	ContarUsos(ctx context.Context, mediaID uint) (int64, error)
+}
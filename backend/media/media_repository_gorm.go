@@ -0,0 +1,156 @@
+// backend/media/media_repository_gorm.go
+// Funcionalidad: Implementación GORM de MediaRepository.
+// Capa: Repositorio (Implementación).
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"backend/shared/imagenes"
+	"backend/shared/repository"
+)
+
+type gormMediaRepository struct {
+	db *gorm.DB
+}
+
+// NewMediaRepository crea una nueva instancia del repositorio GORM de Media.
+func NewMediaRepository(db *gorm.DB) MediaRepository {
+	return &gormMediaRepository{db: db}
+}
+
+func (r *gormMediaRepository) GetByID(ctx context.Context, id uint) (*Media, error) {
+	var model MediaModel
+	if err := r.db.WithContext(ctx).Preload("Usos").First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm media: getbyid %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormMediaRepository) GetAll(ctx context.Context) ([]Media, error) {
+	var models []MediaModel
+	if err := r.db.WithContext(ctx).Preload("Usos").Order("id").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm media: getall: %w", err)
+	}
+	medias := make([]Media, 0, len(models))
+	for i := range models {
+		medias = append(medias, *models[i].ToDomain())
+	}
+	return medias, nil
+}
+
+func (r *gormMediaRepository) GetByClave(ctx context.Context, clave string) (*Media, error) {
+	var model MediaModel
+	if err := r.db.WithContext(ctx).Where("clave = ?", clave).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm media: getbyclave %s: %w", clave, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormMediaRepository) GetByImagen(ctx context.Context, imagen string) (*Media, error) {
+	// La clave es la imagen más su extensión: el prefijo usa el índice único de 'clave'.
+	patron := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(imagen) + ".%"
+	var model MediaModel
+	if err := r.db.WithContext(ctx).Preload("Usos").Where("clave LIKE ?", patron).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm media: getbyimagen %s: %w", imagen, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormMediaRepository) Create(ctx context.Context, m *Media) error {
+	model := FromMediaDomain(m)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm media: create %s: %w", m.Clave, repository.TraducirError(err))
+	}
+	m.ID = model.ID
+	m.CreatedAt = model.CreatedAt
+	m.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *gormMediaRepository) ActualizarVariantes(ctx context.Context, id uint, ancho, alto int, variantes []imagenes.Variante) error {
+	cambios := MediaModel{Ancho: ancho, Alto: alto, Variantes: variantes}
+	result := r.db.WithContext(ctx).Model(&MediaModel{}).Where("id = ?", id).Select("ancho", "alto", "variantes").Updates(&cambios)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm media: actualizarvariantes %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormMediaRepository) ActualizarMarcador(ctx context.Context, id uint, marcador imagenes.Marcador) error {
+	cambios := MediaModel{BlurHash: marcador.BlurHash, Color: marcador.Color}
+	result := r.db.WithContext(ctx).Model(&MediaModel{}).Where("id = ?", id).Select("blur_hash", "color").Updates(&cambios)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm media: actualizarmarcador %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete borra el registro; sus usos caen por la FK con CASCADE.
+func (r *gormMediaRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&MediaModel{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm media: delete %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormMediaRepository) DeleteSinUsos(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND NOT EXISTS (?)", id, r.db.Model(&MediaUsoModel{}).Select("1").Where("media_id = ?", id)).
+		Delete(&MediaModel{})
+	if result.Error != nil {
+		return false, fmt.Errorf("repo gorm media: deletesinusos %d: %w", id, repository.TraducirError(result.Error))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *gormMediaRepository) AgregarUso(ctx context.Context, uso Uso) error {
+	model := MediaUsoModel{MediaID: uso.MediaID, Entidad: uso.Entidad, EntidadID: uso.EntidadID}
+	if err := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model).Error; err != nil {
+		return fmt.Errorf("repo gorm media: agregaruso %d %s/%d: %w", uso.MediaID, uso.Entidad, uso.EntidadID, repository.TraducirError(err))
+	}
+	return nil
+}
+
+func (r *gormMediaRepository) QuitarUso(ctx context.Context, uso Uso) error {
+	err := r.db.WithContext(ctx).
+		Where("media_id = ? AND entidad = ? AND entidad_id = ?", uso.MediaID, uso.Entidad, uso.EntidadID).
+		Delete(&MediaUsoModel{}).Error
+	if err != nil {
+		return fmt.Errorf("repo gorm media: quitaruso %d %s/%d: %w", uso.MediaID, uso.Entidad, uso.EntidadID, err)
+	}
+	return nil
+}
+
+func (r *gormMediaRepository) ContarUsos(ctx context.Context, mediaID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&MediaUsoModel{}).Where("media_id = ?", mediaID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("repo gorm media: contarusos %d: %w", mediaID, err)
+	}
+	return count, nil
+}
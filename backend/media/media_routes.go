@@ -0,0 +1,20 @@
+// backend/media/media_routes.go
+
+// Este archivo define la ruta que sirve los archivos subidos al disco.
+
+package media
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMediaRoutes sirve los archivos bajo 'urlBase' (ej: "/uploads", ver storage.Local.URLBase).
+// Se registra en el router raíz, fuera de /api/v1, porque las URLs de los archivos no están versionadas.
+func RegisterMediaRoutes(router gin.IRoutes, urlBase string, h *MediaHandler) {
+	router.GET(urlBase+"/*clave", h.Servir)  // GET /uploads/recetas/3f2a...9c.jpg[?expira=...&firma=...]
+	router.HEAD(urlBase+"/*clave", h.Servir) // HEAD (mismas comprobaciones)
+
+	log.Println("🛣️  Rutas de Media configuradas.")
+}
@@ -0,0 +1,197 @@
+// backend/media/media_service.go
+// Funcionalidad: Lógica de negocio de los archivos subidos y sus usos.
+// Capa: Servicio / Casos de Uso.
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // Registra los decodificadores para leer las dimensiones
+	_ "image/png"
+	"io"
+	"log" // Temporal, reemplazar con logger estructurado
+	"net/http"
+
+	"backend/shared/repository"
+	"backend/shared/storage"
+)
+
+// MediaService define el contrato para la lógica de negocio de Media.
+type MediaService interface {
+	GetByID(ctx context.Context, id uint) (*Media, error)
+	// Registrar crea el registro de un archivo recién guardado o, si ya existía uno con la misma
+	// clave (misma imagen subida antes), lo devuelve actualizando sus variantes si cambiaron
+	// y su marcador (BlurHash y color) si no lo tenía.
+	Registrar(ctx context.Context, input RegistrarInput) (*Media, error)
+	// RegistrarArchivo registra un archivo que ya está en el almacenamiento pero no en la tabla
+	// (subido antes de que existiera): lee tipo, tamaño, hash y dimensiones del propio archivo.
+	// Devuelve storage.ErrNoExiste si el archivo no está.
+	RegistrarArchivo(ctx context.Context, clave string, propietarioID *uint) (*Media, error)
+	// Usar registra que la entidad usa el archivo.
+	Usar(ctx context.Context, mediaID uint, entidad string, entidadID uint) error
+	// Liberar quita el uso; si el archivo se queda sin usos, borra sus archivos y el registro.
+	Liberar(ctx context.Context, mediaID uint, entidad string, entidadID uint) error
+	// EliminarSiHuerfano borra el archivo si ninguna entidad lo usa (ej: tras una subida fallida).
+	EliminarSiHuerfano(ctx context.Context, id uint) error
+}
+
+type mediaService struct {
+	repo    MediaRepository
+	almacen storage.Storage // Donde están los archivos (para borrarlos)
+}
+
+// NewMediaService crea una nueva instancia de MediaService.
+func NewMediaService(repo MediaRepository, almacen storage.Storage) MediaService {
+	return &mediaService{repo: repo, almacen: almacen}
+}
+
+func (s *mediaService) GetByID(ctx context.Context, id uint) (*Media, error) {
+	m, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("servicio media: error al obtener %d: %w", id, err)
+	}
+	return m, nil
+}
+
+func (s *mediaService) Registrar(ctx context.Context, input RegistrarInput) (*Media, error) {
+	existente, err := s.buscarPorClave(ctx, input.Clave)
+	if err != nil {
+		return nil, err
+	}
+	if existente == nil {
+		m := &Media{
+			Clave:         input.Clave,
+			Tipo:          input.Tipo,
+			Bytes:         input.Bytes,
+			Hash:          input.Hash,
+			Ancho:         input.Ancho,
+			Alto:          input.Alto,
+			Variantes:     input.Variantes,
+			BlurHash:      input.Marcador.BlurHash,
+			Color:         input.Marcador.Color,
+			PropietarioID: input.PropietarioID,
+		}
+		err := s.repo.Create(ctx, m)
+		if err == nil {
+			log.Printf("Servicio: Media ID %d registrado (%s, %d bytes)\n", m.ID, m.Clave, m.Bytes)
+			return m, nil
+		}
+		if !errors.Is(err, repository.ErrDuplicateRecord) {
+			return nil, fmt.Errorf("servicio media: error al registrar %s: %w", input.Clave, err)
+		}
+		// Otra subida idéntica lo registró a la vez: usar el suyo.
+		if existente, err = s.buscarPorClave(ctx, input.Clave); err != nil || existente == nil {
+			return nil, fmt.Errorf("servicio media: error al registrar %s: %w", input.Clave, err)
+		}
+	}
+
+	if len(input.Variantes) > 0 && (len(existente.Variantes) != len(input.Variantes) || existente.Ancho != input.Ancho || existente.Alto != input.Alto) {
+		if err := s.repo.ActualizarVariantes(ctx, existente.ID, input.Ancho, input.Alto, input.Variantes); err != nil {
+			return nil, fmt.Errorf("servicio media: error al actualizar variantes de %d: %w", existente.ID, err)
+		}
+		existente.Ancho, existente.Alto, existente.Variantes = input.Ancho, input.Alto, input.Variantes
+	}
+	if input.Marcador.BlurHash != "" && !existente.TieneMarcador() {
+		if err := s.repo.ActualizarMarcador(ctx, existente.ID, input.Marcador); err != nil {
+			return nil, fmt.Errorf("servicio media: error al actualizar el marcador de %d: %w", existente.ID, err)
+		}
+		existente.BlurHash, existente.Color = input.Marcador.BlurHash, input.Marcador.Color
+	}
+	return existente, nil
+}
+
+func (s *mediaService) RegistrarArchivo(ctx context.Context, clave string, propietarioID *uint) (*Media, error) {
+	if existente, err := s.buscarPorClave(ctx, clave); err != nil || existente != nil {
+		return existente, err
+	}
+	r, err := s.almacen.Get(ctx, clave)
+	if err != nil {
+		return nil, fmt.Errorf("servicio media: error al leer %s: %w", clave, err)
+	}
+	defer r.Close()
+	datos, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("servicio media: error al leer %s: %w", clave, err)
+	}
+
+	suma := sha256.Sum256(datos)
+	input := RegistrarInput{
+		Clave:         clave,
+		Tipo:          http.DetectContentType(datos),
+		Bytes:         int64(len(datos)),
+		Hash:          hex.EncodeToString(suma[:]),
+		PropietarioID: propietarioID,
+	}
+	// Sin límites ni validación: el archivo ya se aceptó en su día. Si la cabecera no se
+	// entiende, se registra sin dimensiones.
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(datos)); err == nil {
+		input.Ancho, input.Alto = cfg.Width, cfg.Height
+	}
+	return s.Registrar(ctx, input)
+}
+
+// buscarPorClave devuelve nil (sin error) si no hay registro con esa clave.
+func (s *mediaService) buscarPorClave(ctx context.Context, clave string) (*Media, error) {
+	m, err := s.repo.GetByClave(ctx, clave)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("servicio media: error al buscar %s: %w", clave, err)
+	}
+	return m, nil
+}
+
+func (s *mediaService) Usar(ctx context.Context, mediaID uint, entidad string, entidadID uint) error {
+	if err := s.repo.AgregarUso(ctx, Uso{MediaID: mediaID, Entidad: entidad, EntidadID: entidadID}); err != nil {
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			return ErrMediaNotFound
+		}
+		return fmt.Errorf("servicio media: error al registrar uso de %d: %w", mediaID, err)
+	}
+	return nil
+}
+
+func (s *mediaService) Liberar(ctx context.Context, mediaID uint, entidad string, entidadID uint) error {
+	if err := s.repo.QuitarUso(ctx, Uso{MediaID: mediaID, Entidad: entidad, EntidadID: entidadID}); err != nil {
+		return fmt.Errorf("servicio media: error al liberar uso de %d: %w", mediaID, err)
+	}
+	return s.EliminarSiHuerfano(ctx, mediaID)
+}
+
+func (s *mediaService) EliminarSiHuerfano(ctx context.Context, id uint) error {
+	usos, err := s.repo.ContarUsos(ctx, id)
+	if err != nil {
+		return fmt.Errorf("servicio media: error al contar usos de %d: %w", id, err)
+	}
+	if usos > 0 {
+		return nil
+	}
+	m, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return nil // Ya estaba borrado
+	}
+	if err != nil {
+		return fmt.Errorf("servicio media: error al obtener %d: %w", id, err)
+	}
+	// Primero el registro: si falla el borrado de algún archivo, queda un archivo huérfano
+	// en el almacenamiento, pero nunca un registro que apunte a archivos borrados.
+	if err := s.repo.Delete(ctx, id); err != nil && !errors.Is(err, repository.ErrRecordNotFound) {
+		return fmt.Errorf("servicio media: error al eliminar %d: %w", id, err)
+	}
+	for _, clave := range m.Claves() {
+		if err := s.almacen.Delete(ctx, clave); err != nil {
+			log.Printf("Servicio: No se pudo borrar el archivo %s del media ID %d: %v\n", clave, id, err)
+		}
+	}
+	log.Printf("Servicio: Media ID %d sin usos eliminado (%s)\n", id, m.Clave)
+	return nil
+}
@@ -0,0 +1,156 @@
+// backend/media/media_service_test.go
+package media_test // Usar paquete _test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"backend/media"                  // El paquete bajo test
+	mediaMocks "backend/media/mocks" // Mocks del paquete media
+	"backend/shared/imagenes"
+	"backend/shared/repository"
+	"backend/shared/storage"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MediaServiceTestSuite struct {
+	suite.Suite
+	mockRepo *mediaMocks.MediaRepositoryMock
+	dir      string
+	almacen  storage.Storage
+	service  media.MediaService
+}
+
+func (s *MediaServiceTestSuite) SetupTest() {
+	s.mockRepo = new(mediaMocks.MediaRepositoryMock)
+	s.dir = s.T().TempDir()
+	s.almacen = storage.NewLocal(s.dir, "/uploads")
+	s.service = media.NewMediaService(s.mockRepo, s.almacen)
+}
+
+func TestMediaServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MediaServiceTestSuite))
+}
+
+var variantesFoto = []imagenes.Variante{
+	{Nombre: "thumb", Ancho: 320, Alto: 240, Archivo: "abc_thumb.jpg", ArchivoWebP: "abc_thumb.webp"},
+	{Nombre: "full", Ancho: 800, Alto: 600, Archivo: "abc.jpg", ArchivoWebP: "abc.webp"},
+}
+
+func (s *MediaServiceTestSuite) TestRegistrar_CreaElRegistro() {
+	ctx := context.Background()
+	input := media.RegistrarInput{Clave: "recetas/abc.jpg", Tipo: "image/jpeg", Bytes: 10, Hash: "abc", Ancho: 800, Alto: 600, Variantes: variantesFoto}
+	s.mockRepo.On("GetByClave", ctx, "recetas/abc.jpg").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(m *media.Media) bool { return m.Clave == "recetas/abc.jpg" && m.Hash == "abc" })).
+		Run(func(args mock.Arguments) { args.Get(1).(*media.Media).ID = 7 }).Return(nil).Once()
+
+	m, err := s.service.Registrar(ctx, input)
+
+	s.NoError(err)
+	s.Equal(uint(7), m.ID)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *MediaServiceTestSuite) TestRegistrar_ReutilizaLaMismaImagen() {
+	ctx := context.Background()
+	existente := &media.Media{ID: 3, Clave: "recetas/abc.jpg", Ancho: 800, Alto: 600, Variantes: variantesFoto}
+	s.mockRepo.On("GetByClave", ctx, "recetas/abc.jpg").Return(existente, nil).Once()
+
+	m, err := s.service.Registrar(ctx, media.RegistrarInput{Clave: "recetas/abc.jpg", Ancho: 800, Alto: 600, Variantes: variantesFoto})
+
+	s.NoError(err)
+	s.Equal(uint(3), m.ID)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *MediaServiceTestSuite) TestRegistrar_CompletaElMarcadorQueFaltaba() {
+	ctx := context.Background()
+	marcador := imagenes.Marcador{BlurHash: "L0TI:jfQfQfQfQfQfQfQfQfQfQfQ", Color: "#ff0000"}
+	existente := &media.Media{ID: 3, Clave: "recetas/abc.jpg", Ancho: 800, Alto: 600, Variantes: variantesFoto}
+	s.mockRepo.On("GetByClave", ctx, "recetas/abc.jpg").Return(existente, nil).Once()
+	s.mockRepo.On("ActualizarMarcador", ctx, uint(3), marcador).Return(nil).Once()
+
+	m, err := s.service.Registrar(ctx, media.RegistrarInput{Clave: "recetas/abc.jpg", Ancho: 800, Alto: 600, Variantes: variantesFoto, Marcador: marcador})
+
+	s.NoError(err)
+	s.Equal("#ff0000", m.Color)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *MediaServiceTestSuite) TestRegistrarArchivo_LeeLosDatosDelArchivo() {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	s.Require().NoError(png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 3))))
+	s.Require().NoError(s.almacen.Put(ctx, "recetas/antigua.png", bytes.NewReader(buf.Bytes()), int64(buf.Len()), ""))
+	s.mockRepo.On("GetByClave", ctx, "recetas/antigua.png").Return(nil, repository.ErrRecordNotFound).Twice()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(m *media.Media) bool {
+		return m.Tipo == "image/png" && m.Bytes == int64(buf.Len()) && len(m.Hash) == 64 && m.Ancho == 4 && m.Alto == 3
+	})).Return(nil).Once()
+
+	_, err := s.service.RegistrarArchivo(ctx, "recetas/antigua.png", nil)
+
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *MediaServiceTestSuite) TestRegistrarArchivo_ReutilizaElRegistro() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByClave", ctx, "recetas/antigua.png").Return(&media.Media{ID: 4}, nil).Once()
+
+	m, err := s.service.RegistrarArchivo(ctx, "recetas/antigua.png", nil) // Ni siquiera hace falta el archivo
+
+	s.NoError(err)
+	s.Equal(uint(4), m.ID)
+}
+
+func (s *MediaServiceTestSuite) TestRegistrarArchivo_Fail_NoExiste() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByClave", ctx, "recetas/perdida.png").Return(nil, repository.ErrRecordNotFound).Once()
+
+	_, err := s.service.RegistrarArchivo(ctx, "recetas/perdida.png", nil)
+
+	s.ErrorIs(err, storage.ErrNoExiste)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *MediaServiceTestSuite) TestLiberar_ConservaSiOtraEntidadLoUsa() {
+	ctx := context.Background()
+	s.mockRepo.On("QuitarUso", ctx, media.Uso{MediaID: 3, Entidad: "recetas", EntidadID: 1}).Return(nil).Once()
+	s.mockRepo.On("ContarUsos", ctx, uint(3)).Return(int64(1), nil).Once()
+
+	s.NoError(s.service.Liberar(ctx, 3, "recetas", 1))
+	s.mockRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *MediaServiceTestSuite) TestLiberar_BorraArchivosYRegistroSinUsos() {
+	ctx := context.Background()
+	m := &media.Media{ID: 3, Clave: "recetas/abc.jpg", Variantes: variantesFoto}
+	for _, clave := range m.Claves() {
+		s.Require().NoError(s.almacen.Put(ctx, clave, strings.NewReader("x"), 1, ""))
+	}
+	s.mockRepo.On("QuitarUso", ctx, media.Uso{MediaID: 3, Entidad: "recetas", EntidadID: 1}).Return(nil).Once()
+	s.mockRepo.On("ContarUsos", ctx, uint(3)).Return(int64(0), nil).Once()
+	s.mockRepo.On("GetByID", ctx, uint(3)).Return(m, nil).Once()
+	s.mockRepo.On("Delete", ctx, uint(3)).Return(nil).Once()
+
+	s.NoError(s.service.Liberar(ctx, 3, "recetas", 1))
+
+	restantes, _ := os.ReadDir(filepath.Join(s.dir, "recetas"))
+	s.Empty(restantes)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *MediaServiceTestSuite) TestUsar_Fail_NotFound() {
+	ctx := context.Background()
+	s.mockRepo.On("AgregarUso", ctx, media.Uso{MediaID: 9, Entidad: "recetas", EntidadID: 1}).Return(repository.ErrForeignKeyViolation).Once()
+
+	s.ErrorIs(s.service.Usar(ctx, 9, "recetas", 1), media.ErrMediaNotFound)
+}
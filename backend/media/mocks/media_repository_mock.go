@@ -0,0 +1,90 @@
+// backend/media/mocks/media_repository_mock.go
+package mocks
+
+import (
+	"context"
+
+	"backend/media" // Para los tipos de dominio y la interfaz
+	"backend/shared/imagenes"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MediaRepositoryMock struct {
+	mock.Mock
+}
+
+// Asegurar que implementa la interfaz
+var _ media.MediaRepository = (*MediaRepositoryMock)(nil)
+
+func (m *MediaRepositoryMock) GetByID(ctx context.Context, id uint) (*media.Media, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*media.Media), args.Error(1)
+}
+
+func (m *MediaRepositoryMock) GetByImagen(ctx context.Context, imagen string) (*media.Media, error) {
+	args := m.Called(ctx, imagen)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*media.Media), args.Error(1)
+}
+
+func (m *MediaRepositoryMock) GetAll(ctx context.Context) ([]media.Media, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]media.Media), args.Error(1)
+}
+
+func (m *MediaRepositoryMock) DeleteSinUsos(ctx context.Context, id uint) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MediaRepositoryMock) GetByClave(ctx context.Context, clave string) (*media.Media, error) {
+	args := m.Called(ctx, clave)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*media.Media), args.Error(1)
+}
+
+func (m *MediaRepositoryMock) Create(ctx context.Context, med *media.Media) error {
+	args := m.Called(ctx, med)
+	return args.Error(0)
+}
+
+func (m *MediaRepositoryMock) ActualizarVariantes(ctx context.Context, id uint, ancho, alto int, variantes []imagenes.Variante) error {
+	args := m.Called(ctx, id, ancho, alto, variantes)
+	return args.Error(0)
+}
+
+func (m *MediaRepositoryMock) ActualizarMarcador(ctx context.Context, id uint, marcador imagenes.Marcador) error {
+	args := m.Called(ctx, id, marcador)
+	return args.Error(0)
+}
+
+func (m *MediaRepositoryMock) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MediaRepositoryMock) AgregarUso(ctx context.Context, uso media.Uso) error {
+	args := m.Called(ctx, uso)
+	return args.Error(0)
+}
+
+func (m *MediaRepositoryMock) QuitarUso(ctx context.Context, uso media.Uso) error {
+	args := m.Called(ctx, uso)
+	return args.Error(0)
+}
+
+func (m *MediaRepositoryMock) ContarUsos(ctx context.Context, mediaID uint) (int64, error) {
+	args := m.Called(ctx, mediaID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,72 @@
+// backend/planificador/mocks/plan_repository_mock.go
+package mocks
+
+import (
+	"backend/planificador" // Para los tipos de dominio y la interfaz
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type PlanRepositoryMock struct {
+	mock.Mock
+}
+
+// Asegurar que implementa la interfaz
+var _ planificador.PlanRepository = (*PlanRepositoryMock)(nil)
+
+func (m *PlanRepositoryMock) Create(ctx context.Context, plan *planificador.PlanSemanal) error {
+	args := m.Called(ctx, plan)
+	// Simular que el repo asigna ID si el Create es exitoso
+	if args.Error(0) == nil && plan != nil {
+		plan.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *PlanRepositoryMock) GetByUserSemana(ctx context.Context, userID uint, semanaInicio time.Time) (*planificador.PlanSemanal, error) {
+	args := m.Called(ctx, userID, semanaInicio)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*planificador.PlanSemanal), args.Error(1)
+}
+
+func (m *PlanRepositoryMock) FindByUserID(ctx context.Context, userID uint) ([]planificador.PlanSemanal, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]planificador.PlanSemanal), args.Error(1)
+}
+
+func (m *PlanRepositoryMock) Delete(ctx context.Context, planID uint) error {
+	args := m.Called(ctx, planID)
+	return args.Error(0)
+}
+
+func (m *PlanRepositoryMock) UpsertComida(ctx context.Context, comida *planificador.ComidaPlan) error {
+	args := m.Called(ctx, comida)
+	return args.Error(0)
+}
+
+func (m *PlanRepositoryMock) DeleteComida(ctx context.Context, planID uint, dia int, momento planificador.MomentoComida) error {
+	args := m.Called(ctx, planID, dia, momento)
+	return args.Error(0)
+}
+
+func (m *PlanRepositoryMock) SwapComidas(ctx context.Context, planID uint, diaA int, momentoA planificador.MomentoComida, diaB int, momentoB planificador.MomentoComida) error {
+	args := m.Called(ctx, planID, diaA, momentoA, diaB, momentoB)
+	return args.Error(0)
+}
+
+func (m *PlanRepositoryMock) MarcarRecetaEliminada(ctx context.Context, recetaID uint) (int64, error) {
+	args := m.Called(ctx, recetaID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *PlanRepositoryMock) DesmarcarRecetaEliminada(ctx context.Context, recetaID uint) (int64, error) {
+	args := m.Called(ctx, recetaID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,333 @@
+// backend/planificador/plan_api.go
+// Implementación con Gin de PlanHandler.
+package planificador
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/recetas" // Para mapear recetas a su DTO
+)
+
+// formatoSemana es el formato de fecha de las semanas en la URL y en los DTOs.
+const formatoSemana = "2006-01-02"
+
+// errUsuarioNoIdentificado se produce si un handler protegido se ejecuta sin userID en el contexto.
+var errUsuarioNoIdentificado = errors.New("planificador: userID ausente en el contexto")
+
+// PlanHandler maneja las peticiones HTTP del Planificador.
+type PlanHandler struct {
+	service PlanService
+}
+
+// NewPlanHandler crea una nueva instancia de PlanHandler.
+func NewPlanHandler(s PlanService) *PlanHandler {
+	return &PlanHandler{service: s}
+}
+
+// --- Mapeadores Helper ---
+
+func mapPlanToResponseDTO(plan PlanSemanal) PlanResponseDTO {
+	comidas := make([]ComidaResponseDTO, 0, len(plan.Comidas))
+	for _, c := range plan.Comidas {
+		dto := ComidaResponseDTO{
+			Dia:             c.Dia,
+			Momento:         string(c.Momento),
+			Porciones:       c.Porciones,
+			RecetaEliminada: c.RecetaEliminada,
+			RecetaID:        c.RecetaID,
+		}
+		if c.Receta != nil && !c.RecetaEliminada {
+			recetaDTO := recetas.ToRecetaResponseDTO(*c.Receta, recetas.LectorUsuario(plan.UserID))
+			dto.Receta = &recetaDTO
+		}
+		comidas = append(comidas, dto)
+	}
+	return PlanResponseDTO{
+		ID:           plan.ID,
+		SemanaInicio: plan.SemanaInicio.Format(formatoSemana),
+		Comidas:      comidas,
+		CreatedAt:    plan.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    plan.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// usuarioIDDesdeContexto obtiene el ID del usuario autenticado (lo deja el middleware de Auth).
+func usuarioIDDesdeContexto(c *gin.Context) (uint, error) {
+	if v, exists := c.Get("userID"); exists {
+		if uid, ok := v.(uint); ok {
+			return uid, nil
+		}
+	}
+	return 0, errUsuarioNoIdentificado
+}
+
+func parseSemana(valor string) (time.Time, error) {
+	semana, err := time.Parse(formatoSemana, valor)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrSemanaInvalida, valor)
+	}
+	return semana, nil
+}
+
+// usuarioYSemana obtiene el usuario autenticado y la semana de la URL.
+func usuarioYSemana(c *gin.Context) (uint, time.Time, error) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	semana, err := parseSemana(c.Param("semana"))
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return userID, semana, nil
+}
+
+func (h *PlanHandler) responderPlan(c *gin.Context, status int, plan *PlanSemanal, err error) {
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(status, mapPlanToResponseDTO(*plan))
+}
+
+// --- Handlers ---
+
+// GetPlanes godoc
+// @Summary Lista mis planes semanales
+// @Tags Planificador
+// @Produce json
+// @Success 200 {array} PlanResponseDTO "Planes (sin comidas), los más recientes primero"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes [get]
+// @Security ApiKeyAuth
+func (h *PlanHandler) GetPlanes(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	planes, err := h.service.ListarPlanes(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]PlanResponseDTO, 0, len(planes))
+	for _, p := range planes {
+		responseDTOs = append(responseDTOs, mapPlanToResponseDTO(p))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// CreatePlan godoc
+// @Summary Crea el plan de una semana
+// @Description Crea el plan (uno por usuario y semana). La semana puede indicarse con cualquier fecha; se normaliza al lunes.
+// @Tags Planificador
+// @Accept json
+// @Produce json
+// @Param plan body CrearPlanRequestDTO true "Semana y comidas iniciales"
+// @Success 201 {object} PlanResponseDTO "Plan creado"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 409 {object} apitypes.ErrorResponse "Ya existe un plan para esa semana"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes [post]
+// @Security ApiKeyAuth
+func (h *PlanHandler) CreatePlan(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req CrearPlanRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	semana, err := parseSemana(req.Semana)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	input := CrearPlanInput{Semana: semana, Comidas: make([]ComidaInput, 0, len(req.Comidas))}
+	for _, ci := range req.Comidas {
+		input.Comidas = append(input.Comidas, ComidaInput{
+			Dia: ci.Dia, Momento: MomentoComida(ci.Momento), RecetaID: ci.RecetaID, Porciones: ci.Porciones,
+		})
+	}
+	plan, err := h.service.CrearPlan(c.Request.Context(), userID, input)
+	h.responderPlan(c, http.StatusCreated, plan, err)
+}
+
+// GetPlan godoc
+// @Summary Obtiene el plan de una semana
+// @Tags Planificador
+// @Produce json
+// @Param semana path string true "Cualquier fecha de la semana (AAAA-MM-DD)"
+// @Success 200 {object} PlanResponseDTO "Plan con sus comidas"
+// @Failure 400 {object} apitypes.ErrorResponse "Semana inválida"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Plan no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes/{semana} [get]
+// @Security ApiKeyAuth
+func (h *PlanHandler) GetPlan(c *gin.Context) {
+	userID, semana, err := usuarioYSemana(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	plan, err := h.service.ObtenerPlan(c.Request.Context(), userID, semana)
+	h.responderPlan(c, http.StatusOK, plan, err)
+}
+
+// DeletePlan godoc
+// @Summary Elimina el plan de una semana
+// @Tags Planificador
+// @Param semana path string true "Cualquier fecha de la semana (AAAA-MM-DD)"
+// @Success 204 "Plan eliminado"
+// @Failure 400 {object} apitypes.ErrorResponse "Semana inválida"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Plan no encontrado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes/{semana} [delete]
+// @Security ApiKeyAuth
+func (h *PlanHandler) DeletePlan(c *gin.Context) {
+	userID, semana, err := usuarioYSemana(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.EliminarPlan(c.Request.Context(), userID, semana); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CopiarSemanaAnterior godoc
+// @Summary Crea el plan de la semana copiando el de la semana anterior
+// @Description Las comidas cuya receta fue eliminada no se copian.
+// @Tags Planificador
+// @Produce json
+// @Param semana path string true "Cualquier fecha de la semana destino (AAAA-MM-DD)"
+// @Success 201 {object} PlanResponseDTO "Plan creado"
+// @Failure 400 {object} apitypes.ErrorResponse "Semana inválida"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "No hay plan en la semana anterior"
+// @Failure 409 {object} apitypes.ErrorResponse "Ya existe un plan para esa semana"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes/{semana}/copiar-anterior [post]
+// @Security ApiKeyAuth
+func (h *PlanHandler) CopiarSemanaAnterior(c *gin.Context) {
+	userID, semana, err := usuarioYSemana(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	plan, err := h.service.CopiarSemanaAnterior(c.Request.Context(), userID, semana)
+	h.responderPlan(c, http.StatusCreated, plan, err)
+}
+
+// AsignarComida godoc
+// @Summary Asigna (o reemplaza) la receta de un hueco
+// @Tags Planificador
+// @Accept json
+// @Produce json
+// @Param semana path string true "Cualquier fecha de la semana (AAAA-MM-DD)"
+// @Param comida body ComidaRequestDTO true "Hueco, receta y porciones"
+// @Success 200 {object} PlanResponseDTO "Plan actualizado"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Plan o receta no encontrados"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes/{semana}/comidas [put]
+// @Security ApiKeyAuth
+func (h *PlanHandler) AsignarComida(c *gin.Context) {
+	userID, semana, err := usuarioYSemana(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req ComidaRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	input := ComidaInput{Dia: req.Dia, Momento: MomentoComida(req.Momento), RecetaID: req.RecetaID, Porciones: req.Porciones}
+	plan, err := h.service.AsignarComida(c.Request.Context(), userID, semana, input)
+	h.responderPlan(c, http.StatusOK, plan, err)
+}
+
+// QuitarComida godoc
+// @Summary Vacía un hueco del plan
+// @Tags Planificador
+// @Param semana path string true "Cualquier fecha de la semana (AAAA-MM-DD)"
+// @Param dia path int true "Día (1 = lunes ... 7 = domingo)"
+// @Param momento path string true "desayuno, almuerzo o cena"
+// @Success 204 "Hueco vaciado"
+// @Failure 400 {object} apitypes.ErrorResponse "Hueco inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Plan no encontrado o hueco vacío"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes/{semana}/comidas/{dia}/{momento} [delete]
+// @Security ApiKeyAuth
+func (h *PlanHandler) QuitarComida(c *gin.Context) {
+	userID, semana, err := usuarioYSemana(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	dia, err := strconv.Atoi(c.Param("dia"))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("%w: día %q", ErrHuecoInvalido, c.Param("dia")))
+		return
+	}
+	if err := h.service.QuitarComida(c.Request.Context(), userID, semana, dia, MomentoComida(c.Param("momento"))); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// IntercambiarComidas godoc
+// @Summary Intercambia dos comidas del plan
+// @Description Intercambia el contenido de dos huecos; cualquiera de ellos puede estar vacío (equivale a mover).
+// @Tags Planificador
+// @Accept json
+// @Produce json
+// @Param semana path string true "Cualquier fecha de la semana (AAAA-MM-DD)"
+// @Param intercambio body IntercambioRequestDTO true "Huecos a intercambiar"
+// @Success 200 {object} PlanResponseDTO "Plan actualizado"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 404 {object} apitypes.ErrorResponse "Plan no encontrado o ambos huecos vacíos"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/planes/{semana}/intercambiar [post]
+// @Security ApiKeyAuth
+func (h *PlanHandler) IntercambiarComidas(c *gin.Context) {
+	userID, semana, err := usuarioYSemana(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req IntercambioRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	input := IntercambioInput{
+		DiaA: req.Origen.Dia, MomentoA: MomentoComida(req.Origen.Momento),
+		DiaB: req.Destino.Dia, MomentoB: MomentoComida(req.Destino.Momento),
+	}
+	plan, err := h.service.IntercambiarComidas(c.Request.Context(), userID, semana, input)
+	h.responderPlan(c, http.StatusOK, plan, err)
+}
@@ -0,0 +1,50 @@
+// backend/planificador/plan_api_dto.go
+// DTOs de la API del Planificador semanal.
+package planificador
+
+import "backend/recetas" // Los huecos anidan el DTO de receta
+
+// ComidaRequestDTO es el DTO de entrada para asignar una receta a un hueco.
+type ComidaRequestDTO struct {
+	Dia       int    `json:"dia" binding:"required,min=1,max=7" example:"1"`                         // 1 = lunes ... 7 = domingo
+	Momento   string `json:"momento" binding:"required,oneof=desayuno almuerzo cena" example:"cena"` // desayuno, almuerzo o cena
+	RecetaID  uint   `json:"receta_id" binding:"required,gt=0" example:"7"`
+	Porciones int    `json:"porciones" binding:"required,min=1" example:"4"`
+}
+
+// CrearPlanRequestDTO es el DTO de entrada para crear un plan semanal.
+type CrearPlanRequestDTO struct {
+	Semana  string             `json:"semana" binding:"required" example:"2025-05-19"` // Cualquier fecha de la semana (AAAA-MM-DD)
+	Comidas []ComidaRequestDTO `json:"comidas" binding:"dive"`
+}
+
+// HuecoDTO identifica un hueco del plan.
+type HuecoDTO struct {
+	Dia     int    `json:"dia" binding:"required,min=1,max=7" example:"1"`
+	Momento string `json:"momento" binding:"required,oneof=desayuno almuerzo cena" example:"almuerzo"`
+}
+
+// IntercambioRequestDTO es el DTO de entrada para intercambiar dos huecos.
+type IntercambioRequestDTO struct {
+	Origen  HuecoDTO `json:"origen" binding:"required"`
+	Destino HuecoDTO `json:"destino" binding:"required"`
+}
+
+// ComidaResponseDTO es un hueco ocupado del plan.
+type ComidaResponseDTO struct {
+	Dia             int                        `json:"dia" example:"1"`
+	Momento         string                     `json:"momento" example:"cena"`
+	Porciones       int                        `json:"porciones" example:"4"`
+	RecetaEliminada bool                       `json:"receta_eliminada" example:"false"` // true: la receta ya no existe, hay que reemplazarla
+	RecetaID        *uint                      `json:"receta_id,omitempty" example:"7"`
+	Receta          *recetas.RecetaResponseDTO `json:"receta,omitempty"` // Ausente si la receta fue eliminada
+}
+
+// PlanResponseDTO es el DTO de salida de un plan semanal.
+type PlanResponseDTO struct {
+	ID           uint                `json:"id" example:"3"`
+	SemanaInicio string              `json:"semana_inicio" example:"2025-05-19"` // Lunes de la semana
+	Comidas      []ComidaResponseDTO `json:"comidas"`
+	CreatedAt    string              `json:"created_at" example:"2025-05-17T10:00:00Z"`
+	UpdatedAt    string              `json:"updated_at" example:"2025-05-17T10:00:00Z"`
+}
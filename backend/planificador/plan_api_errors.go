@@ -0,0 +1,24 @@
+// backend/planificador/plan_api_errors.go
+
+// Traducción de los errores de dominio del planificador a respuestas HTTP (ver shared/apperrors).
+
+package planificador
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrPlanNotFound, http.StatusNotFound, "plan_no_encontrado")
+	apperrors.Registrar(ErrPlanAnteriorNotFound, http.StatusNotFound, "plan_anterior_no_encontrado")
+	apperrors.Registrar(ErrComidaNotFound, http.StatusNotFound, "comida_no_encontrada")
+
+	apperrors.Registrar(ErrPlanYaExiste, http.StatusConflict, "plan_duplicado")
+
+	apperrors.Registrar(ErrHuecoInvalido, http.StatusBadRequest, "plan_hueco_invalido")
+	apperrors.Registrar(ErrPorcionesInvalidas, http.StatusBadRequest, "plan_porciones_invalidas")
+	apperrors.Registrar(ErrSemanaInvalida, http.StatusBadRequest, "plan_semana_invalida")
+	apperrors.Registrar(ErrComidasDuplicadas, http.StatusBadRequest, "plan_comidas_duplicadas")
+}
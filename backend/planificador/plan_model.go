@@ -0,0 +1,98 @@
+// Archivo: backend/planificador/plan_model.go
+// Funcionalidad: Modelo de dominio para el Planificador semanal de comidas.
+// Capa: Dominio / Lógica de negocio.
+
+// Descripción:
+// Cada usuario tiene como máximo un plan por semana (la semana empieza el lunes).
+// Cada plan tiene huecos (día + momento: desayuno, almuerzo, cena) que apuntan
+// a una Receta con un número de porciones.
+//
+// Reglas de Negocio:
+// - Un plan por usuario y semana; la semana se identifica por su lunes.
+// - Un hueco (día + momento) tiene como máximo una receta.
+// - Si una receta se elimina, los huecos que la usan quedan marcados con
+//   RecetaEliminada = true (no se borran, para que el usuario vea qué reemplazar).
+
+package planificador
+
+import (
+	"errors"
+	"time"
+
+	"backend/recetas" // Los huecos apuntan a recetas de dominio
+)
+
+// MomentoComida identifica el momento del día de un hueco.
+type MomentoComida string
+
+const (
+	MomentoDesayuno MomentoComida = "desayuno"
+	MomentoAlmuerzo MomentoComida = "almuerzo"
+	MomentoCena     MomentoComida = "cena"
+)
+
+// EsValido indica si el momento es uno de los valores conocidos.
+func (m MomentoComida) EsValido() bool {
+	switch m {
+	case MomentoDesayuno, MomentoAlmuerzo, MomentoCena:
+		return true
+	}
+	return false
+}
+
+// Días de la semana: 1 = lunes ... 7 = domingo.
+const (
+	DiaLunes   = 1
+	DiaDomingo = 7
+)
+
+// PlanSemanal representa el plan de comidas de un usuario para una semana.
+type PlanSemanal struct {
+	ID           uint
+	UserID       uint
+	SemanaInicio time.Time // Lunes de la semana (00:00 UTC)
+	Comidas      []ComidaPlan
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Comida devuelve el hueco indicado, o nil si está vacío.
+func (p *PlanSemanal) Comida(dia int, momento MomentoComida) *ComidaPlan {
+	for i := range p.Comidas {
+		if p.Comidas[i].Dia == dia && p.Comidas[i].Momento == momento {
+			return &p.Comidas[i]
+		}
+	}
+	return nil
+}
+
+// ComidaPlan es un hueco del plan con su receta asignada.
+type ComidaPlan struct {
+	ID              uint
+	PlanID          uint
+	Dia             int           // 1 = lunes ... 7 = domingo
+	Momento         MomentoComida // desayuno, almuerzo o cena
+	RecetaID        *uint         // nil si la receta fue eliminada definitivamente
+	Receta          *recetas.Receta
+	Porciones       int
+	RecetaEliminada bool // La receta ya no existe: el usuario debe reemplazarla
+}
+
+// InicioDeSemana normaliza una fecha al lunes de su semana (00:00 UTC).
+func InicioDeSemana(t time.Time) time.Time {
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	desplazamiento := (int(t.Weekday()) + 6) % 7 // lunes = 0 ... domingo = 6
+	return t.AddDate(0, 0, -desplazamiento)
+}
+
+// Errores específicos del dominio del Planificador.
+var (
+	ErrPlanNotFound         = errors.New("plan semanal no encontrado")
+	ErrPlanYaExiste         = errors.New("ya existe un plan para esa semana")
+	ErrPlanAnteriorNotFound = errors.New("no hay plan en la semana anterior para copiar")
+	ErrComidaNotFound       = errors.New("no hay ninguna comida en ese hueco del plan")
+	ErrHuecoInvalido        = errors.New("el día debe estar entre 1 (lunes) y 7 (domingo) y el momento ser desayuno, almuerzo o cena")
+	ErrPorcionesInvalidas   = errors.New("las porciones deben ser al menos 1")
+	ErrSemanaInvalida       = errors.New("la semana debe indicarse como fecha AAAA-MM-DD")
+	ErrComidasDuplicadas    = errors.New("el plan contiene más de una comida para el mismo hueco")
+)
@@ -0,0 +1,125 @@
+// backend/planificador/plan_model_gorm.go
+
+// Este archivo define los modelos de persistencia del planificador semanal.
+// Utiliza GORM para la definición de las tablas y el mapeo de campos.
+
+package planificador
+
+import (
+	"time"
+
+	"backend/recetas" // Para la relación con recetas.RecetaModel
+)
+
+// PlanSemanalModel representa la tabla 'planes_semanales'.
+type PlanSemanalModel struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;uniqueIndex:uk_planes_user_semana,priority:1"`
+	SemanaInicio time.Time `gorm:"type:date;not null;uniqueIndex:uk_planes_user_semana,priority:2"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	Comidas []ComidaPlanModel `gorm:"foreignKey:PlanID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (PlanSemanalModel) TableName() string {
+	return "planes_semanales"
+}
+
+// ComidaPlanModel representa la tabla 'plan_comidas' (un hueco del plan).
+type ComidaPlanModel struct {
+	ID              uint   `gorm:"primaryKey"`
+	PlanID          uint   `gorm:"not null;uniqueIndex:uk_plan_comidas_hueco,priority:1"`
+	Dia             int    `gorm:"not null;uniqueIndex:uk_plan_comidas_hueco,priority:2"`
+	Momento         string `gorm:"type:varchar(20);not null;uniqueIndex:uk_plan_comidas_hueco,priority:3"`
+	RecetaID        *uint  `gorm:"index"`
+	Porciones       int    `gorm:"not null;default:1"`
+	RecetaEliminada bool   `gorm:"not null;default:false"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+
+	// Si la receta se purga definitivamente, el hueco queda sin receta (y marcado).
+	Receta *recetas.RecetaModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+}
+
+func (ComidaPlanModel) TableName() string {
+	return "plan_comidas"
+}
+
+// --- Funciones de Mapeo ---
+
+// ToDomain convierte el modelo de plan al dominio, incluyendo las comidas precargadas.
+func (m *PlanSemanalModel) ToDomain() *PlanSemanal {
+	if m == nil {
+		return nil
+	}
+	plan := &PlanSemanal{
+		ID:           m.ID,
+		UserID:       m.UserID,
+		SemanaInicio: m.SemanaInicio,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+		Comidas:      make([]ComidaPlan, 0, len(m.Comidas)),
+	}
+	for i := range m.Comidas {
+		plan.Comidas = append(plan.Comidas, *m.Comidas[i].ToDomain())
+	}
+	return plan
+}
+
+// ToDomain convierte el modelo de hueco al dominio. Si la receta no se pudo
+// precargar (soft delete) el hueco se considera con receta eliminada aunque
+// la marca aún no se haya persistido.
+func (m *ComidaPlanModel) ToDomain() *ComidaPlan {
+	if m == nil {
+		return nil
+	}
+	comida := &ComidaPlan{
+		ID:              m.ID,
+		PlanID:          m.PlanID,
+		Dia:             m.Dia,
+		Momento:         MomentoComida(m.Momento),
+		RecetaID:        m.RecetaID,
+		Porciones:       m.Porciones,
+		RecetaEliminada: m.RecetaEliminada,
+	}
+	if m.Receta != nil && m.Receta.ID != 0 {
+		comida.Receta = m.Receta.ToDomain()
+	} else {
+		comida.RecetaEliminada = true
+	}
+	return comida
+}
+
+// FromPlanSemanalDomain convierte el plan de dominio (con sus comidas) al modelo GORM.
+func FromPlanSemanalDomain(d *PlanSemanal) *PlanSemanalModel {
+	if d == nil {
+		return nil
+	}
+	m := &PlanSemanalModel{
+		ID:           d.ID,
+		UserID:       d.UserID,
+		SemanaInicio: d.SemanaInicio,
+		Comidas:      make([]ComidaPlanModel, 0, len(d.Comidas)),
+	}
+	for i := range d.Comidas {
+		m.Comidas = append(m.Comidas, *FromComidaPlanDomain(&d.Comidas[i]))
+	}
+	return m
+}
+
+// FromComidaPlanDomain convierte un hueco de dominio al modelo GORM.
+func FromComidaPlanDomain(d *ComidaPlan) *ComidaPlanModel {
+	if d == nil {
+		return nil
+	}
+	return &ComidaPlanModel{
+		ID:              d.ID,
+		PlanID:          d.PlanID,
+		Dia:             d.Dia,
+		Momento:         string(d.Momento),
+		RecetaID:        d.RecetaID,
+		Porciones:       d.Porciones,
+		RecetaEliminada: d.RecetaEliminada,
+	}
+}
@@ -0,0 +1,34 @@
+// backend/planificador/plan_repository.go
+// Funcionalidad: Interfaz para la persistencia de Planes semanales.
+// Capa: Repositorio (Abstracción).
+package planificador
+
+import (
+	"context"
+	"time"
+)
+
+// PlanRepository define el contrato para las operaciones de datos del Planificador.
+type PlanRepository interface {
+	// Create guarda el plan junto con sus comidas.
+	Create(ctx context.Context, plan *PlanSemanal) error
+	// GetByUserSemana devuelve el plan del usuario para la semana que empieza en semanaInicio,
+	// con sus comidas y recetas precargadas.
+	GetByUserSemana(ctx context.Context, userID uint, semanaInicio time.Time) (*PlanSemanal, error)
+	// FindByUserID devuelve los planes del usuario (sin comidas), los más recientes primero.
+	FindByUserID(ctx context.Context, userID uint) ([]PlanSemanal, error)
+	Delete(ctx context.Context, planID uint) error
+
+	// UpsertComida asigna la receta y porciones del hueco (crea el hueco si no existía).
+	UpsertComida(ctx context.Context, comida *ComidaPlan) error
+	// DeleteComida vacía un hueco (repository.ErrRecordNotFound si ya estaba vacío).
+	DeleteComida(ctx context.Context, planID uint, dia int, momento MomentoComida) error
+	// SwapComidas intercambia el contenido de dos huecos del plan en una transacción.
+	// Cualquiera de los dos puede estar vacío.
+	SwapComidas(ctx context.Context, planID uint, diaA int, momentoA MomentoComida, diaB int, momentoB MomentoComida) error
+
+	// MarcarRecetaEliminada marca todos los huecos que usan la receta. Devuelve cuántos se marcaron.
+	MarcarRecetaEliminada(ctx context.Context, recetaID uint) (int64, error)
+	// DesmarcarRecetaEliminada quita la marca de los huecos que aún apuntan a la receta (restaurada).
+	DesmarcarRecetaEliminada(ctx context.Context, recetaID uint) (int64, error)
+}
@@ -0,0 +1,166 @@
+// backend/planificador/plan_repository_gorm.go
+// Funcionalidad: Implementación GORM de PlanRepository.
+// Capa: Repositorio (Implementación de Persistencia).
+package planificador
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"backend/shared/repository"
+)
+
+type gormPlanRepository struct {
+	db *gorm.DB
+}
+
+// NewPlanRepository crea una instancia de la implementación GORM de PlanRepository.
+func NewPlanRepository(db *gorm.DB) PlanRepository {
+	return &gormPlanRepository{db: db}
+}
+
+func (r *gormPlanRepository) Create(ctx context.Context, plan *PlanSemanal) error {
+	model := FromPlanSemanalDomain(plan)
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return fmt.Errorf("repo gorm planificador: create: %w", repository.TraducirError(err))
+	}
+	plan.ID = model.ID
+	plan.CreatedAt = model.CreatedAt
+	plan.UpdatedAt = model.UpdatedAt
+	for i := range plan.Comidas {
+		plan.Comidas[i].ID = model.Comidas[i].ID
+		plan.Comidas[i].PlanID = model.ID
+	}
+	return nil
+}
+
+func (r *gormPlanRepository) GetByUserSemana(ctx context.Context, userID uint, semanaInicio time.Time) (*PlanSemanal, error) {
+	var model PlanSemanalModel
+	err := r.db.WithContext(ctx).
+		Preload("Comidas", func(tx *gorm.DB) *gorm.DB { return tx.Order("dia asc, momento asc") }).
+		Preload("Comidas.Receta").
+		Preload("Comidas.Receta.Categoria").
+		Preload("Comidas.Receta.FotoMedia").
+		Where("user_id = ? AND semana_inicio = ?", userID, semanaInicio.Format("2006-01-02")).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm planificador: getbyusersemana user %d: %w", userID, err)
+	}
+	return model.ToDomain(), nil
+}
+
+func (r *gormPlanRepository) FindByUserID(ctx context.Context, userID uint) ([]PlanSemanal, error) {
+	var models []PlanSemanalModel
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("semana_inicio desc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm planificador: findbyuserid %d: %w", userID, err)
+	}
+	planes := make([]PlanSemanal, 0, len(models))
+	for i := range models {
+		planes = append(planes, *models[i].ToDomain())
+	}
+	return planes, nil
+}
+
+func (r *gormPlanRepository) Delete(ctx context.Context, planID uint) error {
+	result := r.db.WithContext(ctx).Delete(&PlanSemanalModel{}, planID)
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm planificador: delete %d: %w", planID, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormPlanRepository) UpsertComida(ctx context.Context, comida *ComidaPlan) error {
+	model := FromComidaPlanDomain(comida)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		// Clave única: (plan_id, dia, momento)
+		DoUpdates: clause.AssignmentColumns([]string{"receta_id", "porciones", "receta_eliminada", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("repo gorm planificador: upsertcomida plan %d: %w", comida.PlanID, repository.TraducirError(err))
+	}
+	comida.ID = model.ID
+	return nil
+}
+
+func (r *gormPlanRepository) DeleteComida(ctx context.Context, planID uint, dia int, momento MomentoComida) error {
+	result := r.db.WithContext(ctx).
+		Where("plan_id = ? AND dia = ? AND momento = ?", planID, dia, string(momento)).
+		Delete(&ComidaPlanModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm planificador: deletecomida plan %d: %w", planID, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *gormPlanRepository) SwapComidas(ctx context.Context, planID uint, diaA int, momentoA MomentoComida, diaB int, momentoB MomentoComida) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var huecos []ComidaPlanModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("plan_id = ? AND ((dia = ? AND momento = ?) OR (dia = ? AND momento = ?))",
+				planID, diaA, string(momentoA), diaB, string(momentoB)).
+			Find(&huecos).Error
+		if err != nil {
+			return fmt.Errorf("repo gorm planificador: swapcomidas buscar: %w", err)
+		}
+
+		// Mover cada hueco existente al otro lado. Para no chocar con la clave única,
+		// se borran y se vuelven a insertar con el día/momento intercambiados.
+		if len(huecos) == 0 {
+			return nil
+		}
+		ids := make([]uint, 0, len(huecos))
+		for _, h := range huecos {
+			ids = append(ids, h.ID)
+		}
+		if err := tx.Delete(&ComidaPlanModel{}, ids).Error; err != nil {
+			return fmt.Errorf("repo gorm planificador: swapcomidas borrar: %w", repository.TraducirError(err))
+		}
+		for _, h := range huecos {
+			nuevo := h
+			nuevo.ID = 0
+			if h.Dia == diaA && h.Momento == string(momentoA) {
+				nuevo.Dia, nuevo.Momento = diaB, string(momentoB)
+			} else {
+				nuevo.Dia, nuevo.Momento = diaA, string(momentoA)
+			}
+			if err := tx.Omit("Receta").Create(&nuevo).Error; err != nil {
+				return fmt.Errorf("repo gorm planificador: swapcomidas insertar: %w", repository.TraducirError(err))
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gormPlanRepository) MarcarRecetaEliminada(ctx context.Context, recetaID uint) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&ComidaPlanModel{}).
+		Where("receta_id = ? AND receta_eliminada = ?", recetaID, false).
+		Update("receta_eliminada", true)
+	if result.Error != nil {
+		return 0, fmt.Errorf("repo gorm planificador: marcarrecetaeliminada %d: %w", recetaID, repository.TraducirError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *gormPlanRepository) DesmarcarRecetaEliminada(ctx context.Context, recetaID uint) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&ComidaPlanModel{}).
+		Where("receta_id = ? AND receta_eliminada = ?", recetaID, true).
+		Update("receta_eliminada", false)
+	if result.Error != nil {
+		return 0, fmt.Errorf("repo gorm planificador: desmarcarrecetaeliminada %d: %w", recetaID, repository.TraducirError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,27 @@
+// backend/planificador/plan_routes.go
+package planificador
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterPlanRoutes registra las rutas del Planificador semanal.
+// requireAuth es el middleware que exige un usuario autenticado (se inyecta desde main).
+func RegisterPlanRoutes(apiBaseGroup *gin.RouterGroup, h *PlanHandler, requireAuth gin.HandlerFunc) {
+	// Rutas del usuario autenticado: /api/v1/me/planes
+	planRoutes := apiBaseGroup.Group("/me/planes", requireAuth)
+	{
+		planRoutes.GET("", h.GetPlanes)
+		planRoutes.POST("", h.CreatePlan)
+		planRoutes.GET("/:semana", h.GetPlan)
+		planRoutes.DELETE("/:semana", h.DeletePlan)
+		planRoutes.POST("/:semana/copiar-anterior", h.CopiarSemanaAnterior)
+		planRoutes.POST("/:semana/intercambiar", h.IntercambiarComidas)
+		planRoutes.PUT("/:semana/comidas", h.AsignarComida)
+		planRoutes.DELETE("/:semana/comidas/:dia/:momento", h.QuitarComida)
+	}
+
+	log.Println("🛣️  Rutas del Planificador configuradas.")
+}
@@ -0,0 +1,303 @@
+// backend/planificador/plan_service.go
+// Funcionalidad: Lógica de negocio del Planificador semanal de comidas.
+// Capa: Servicio / Casos de Uso.
+package planificador
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log" // Temporal, reemplazar con logger estructurado
+	"time"
+
+	"backend/recetas" // Para validar que las recetas existan
+	"backend/shared/repository"
+)
+
+// PlanService define el contrato para la lógica de negocio del Planificador.
+// Las semanas se reciben como cualquier fecha dentro de ellas y se normalizan al lunes.
+type PlanService interface {
+	ListarPlanes(ctx context.Context, userID uint) ([]PlanSemanal, error)
+	ObtenerPlan(ctx context.Context, userID uint, semana time.Time) (*PlanSemanal, error)
+	CrearPlan(ctx context.Context, userID uint, input CrearPlanInput) (*PlanSemanal, error)
+	CopiarSemanaAnterior(ctx context.Context, userID uint, semana time.Time) (*PlanSemanal, error)
+	AsignarComida(ctx context.Context, userID uint, semana time.Time, input ComidaInput) (*PlanSemanal, error)
+	QuitarComida(ctx context.Context, userID uint, semana time.Time, dia int, momento MomentoComida) error
+	IntercambiarComidas(ctx context.Context, userID uint, semana time.Time, input IntercambioInput) (*PlanSemanal, error)
+	EliminarPlan(ctx context.Context, userID uint, semana time.Time) error
+}
+
+type planService struct {
+	repo      PlanRepository
+	recetaSvc recetas.RecetaService // Dependencia del servicio de recetas
+}
+
+// NewPlanService crea una nueva instancia de PlanService.
+func NewPlanService(repo PlanRepository, recetaSvc recetas.RecetaService) PlanService {
+	return &planService{repo: repo, recetaSvc: recetaSvc}
+}
+
+// ListarPlanes devuelve los planes del usuario (sin comidas).
+func (s *planService) ListarPlanes(ctx context.Context, userID uint) ([]PlanSemanal, error) {
+	planes, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio planificador: error listando planes de user %d: %w", userID, err)
+	}
+	return planes, nil
+}
+
+// ObtenerPlan devuelve el plan del usuario para la semana indicada.
+func (s *planService) ObtenerPlan(ctx context.Context, userID uint, semana time.Time) (*PlanSemanal, error) {
+	plan, err := s.repo.GetByUserSemana(ctx, userID, InicioDeSemana(semana))
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrPlanNotFound
+		}
+		return nil, fmt.Errorf("servicio planificador: error obteniendo plan: %w", err)
+	}
+	return plan, nil
+}
+
+// CrearPlan crea el plan de la semana con las comidas indicadas (puede ir vacío).
+func (s *planService) CrearPlan(ctx context.Context, userID uint, input CrearPlanInput) (*PlanSemanal, error) {
+	semanaInicio := InicioDeSemana(input.Semana)
+
+	comidas := make([]ComidaPlan, 0, len(input.Comidas))
+	ocupados := make(map[string]bool, len(input.Comidas))
+	for _, ci := range input.Comidas {
+		if err := validarComida(ci); err != nil {
+			return nil, err
+		}
+		clave := fmt.Sprintf("%d-%s", ci.Dia, ci.Momento)
+		if ocupados[clave] {
+			return nil, ErrComidasDuplicadas
+		}
+		ocupados[clave] = true
+		comidas = append(comidas, nuevaComida(ci))
+	}
+	if err := s.validarRecetas(ctx, userID, comidas); err != nil {
+		return nil, err
+	}
+	if err := s.verificarSemanaLibre(ctx, userID, semanaInicio); err != nil {
+		return nil, err
+	}
+
+	plan := &PlanSemanal{UserID: userID, SemanaInicio: semanaInicio, Comidas: comidas}
+	if err := s.repo.Create(ctx, plan); err != nil {
+		if errors.Is(err, repository.ErrDuplicateRecord) { // uk_planes_user_semana
+			return nil, repository.ConCausa(ErrPlanYaExiste, err)
+		}
+		return nil, fmt.Errorf("servicio planificador: error creando plan: %w", err)
+	}
+	log.Printf("Servicio: Plan ID %d creado para UserID %d, semana %s\n", plan.ID, userID, semanaInicio.Format("2006-01-02"))
+	return s.ObtenerPlan(ctx, userID, semanaInicio)
+}
+
+// CopiarSemanaAnterior crea el plan de la semana copiando las comidas de la semana previa.
+// Los huecos cuya receta fue eliminada o que el usuario ya no puede ver (ej: volvió a
+// borrador) no se copian.
+func (s *planService) CopiarSemanaAnterior(ctx context.Context, userID uint, semana time.Time) (*PlanSemanal, error) {
+	semanaInicio := InicioDeSemana(semana)
+	anterior, err := s.ObtenerPlan(ctx, userID, semanaInicio.AddDate(0, 0, -7))
+	if err != nil {
+		if errors.Is(err, ErrPlanNotFound) {
+			return nil, ErrPlanAnteriorNotFound
+		}
+		return nil, err
+	}
+	if err := s.verificarSemanaLibre(ctx, userID, semanaInicio); err != nil {
+		return nil, err
+	}
+
+	plan := &PlanSemanal{UserID: userID, SemanaInicio: semanaInicio, Comidas: make([]ComidaPlan, 0, len(anterior.Comidas))}
+	visibles := make(map[uint]bool, len(anterior.Comidas))
+	for _, c := range anterior.Comidas {
+		if c.RecetaEliminada || c.RecetaID == nil {
+			continue
+		}
+		visible, comprobada := visibles[*c.RecetaID]
+		if !comprobada {
+			_, err := s.recetaSvc.ObtenerVisible(ctx, *c.RecetaID, recetas.LectorUsuario(userID))
+			if err != nil && !errors.Is(err, recetas.ErrRecetaNotFound) {
+				return nil, fmt.Errorf("servicio planificador: error validando receta %d: %w", *c.RecetaID, err)
+			}
+			visible = err == nil
+			visibles[*c.RecetaID] = visible
+		}
+		if !visible {
+			continue
+		}
+		plan.Comidas = append(plan.Comidas, ComidaPlan{
+			Dia:       c.Dia,
+			Momento:   c.Momento,
+			RecetaID:  c.RecetaID,
+			Porciones: c.Porciones,
+		})
+	}
+	if err := s.repo.Create(ctx, plan); err != nil {
+		if errors.Is(err, repository.ErrDuplicateRecord) { // uk_planes_user_semana
+			return nil, repository.ConCausa(ErrPlanYaExiste, err)
+		}
+		return nil, fmt.Errorf("servicio planificador: error copiando plan: %w", err)
+	}
+	log.Printf("Servicio: Plan ID %d creado copiando la semana anterior (plan ID %d)\n", plan.ID, anterior.ID)
+	return s.ObtenerPlan(ctx, userID, semanaInicio)
+}
+
+// AsignarComida pone (o reemplaza) la receta de un hueco del plan.
+func (s *planService) AsignarComida(ctx context.Context, userID uint, semana time.Time, input ComidaInput) (*PlanSemanal, error) {
+	if err := validarComida(input); err != nil {
+		return nil, err
+	}
+	plan, err := s.ObtenerPlan(ctx, userID, semana)
+	if err != nil {
+		return nil, err
+	}
+	comida := nuevaComida(input)
+	comida.PlanID = plan.ID
+	if err := s.validarRecetas(ctx, userID, []ComidaPlan{comida}); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpsertComida(ctx, &comida); err != nil {
+		return nil, fmt.Errorf("servicio planificador: error asignando comida: %w", err)
+	}
+	return s.ObtenerPlan(ctx, userID, semana)
+}
+
+// QuitarComida vacía un hueco del plan.
+func (s *planService) QuitarComida(ctx context.Context, userID uint, semana time.Time, dia int, momento MomentoComida) error {
+	if !huecoValido(dia, momento) {
+		return ErrHuecoInvalido
+	}
+	plan, err := s.ObtenerPlan(ctx, userID, semana)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteComida(ctx, plan.ID, dia, momento); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrComidaNotFound
+		}
+		return fmt.Errorf("servicio planificador: error quitando comida: %w", err)
+	}
+	return nil
+}
+
+// IntercambiarComidas intercambia el contenido de dos huecos del plan.
+func (s *planService) IntercambiarComidas(ctx context.Context, userID uint, semana time.Time, input IntercambioInput) (*PlanSemanal, error) {
+	if !huecoValido(input.DiaA, input.MomentoA) || !huecoValido(input.DiaB, input.MomentoB) {
+		return nil, ErrHuecoInvalido
+	}
+	plan, err := s.ObtenerPlan(ctx, userID, semana)
+	if err != nil {
+		return nil, err
+	}
+	if input.DiaA == input.DiaB && input.MomentoA == input.MomentoB {
+		return plan, nil // Nada que intercambiar
+	}
+	if plan.Comida(input.DiaA, input.MomentoA) == nil && plan.Comida(input.DiaB, input.MomentoB) == nil {
+		return nil, ErrComidaNotFound
+	}
+	if err := s.repo.SwapComidas(ctx, plan.ID, input.DiaA, input.MomentoA, input.DiaB, input.MomentoB); err != nil {
+		return nil, fmt.Errorf("servicio planificador: error intercambiando comidas: %w", err)
+	}
+	return s.ObtenerPlan(ctx, userID, semana)
+}
+
+// EliminarPlan elimina el plan de la semana con todas sus comidas.
+func (s *planService) EliminarPlan(ctx context.Context, userID uint, semana time.Time) error {
+	plan, err := s.ObtenerPlan(ctx, userID, semana)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, plan.ID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrPlanNotFound
+		}
+		return fmt.Errorf("servicio planificador: error eliminando plan %d: %w", plan.ID, err)
+	}
+	log.Printf("Servicio: Plan ID %d eliminado.\n", plan.ID)
+	return nil
+}
+
+// --- Helpers ---
+
+func huecoValido(dia int, momento MomentoComida) bool {
+	return dia >= DiaLunes && dia <= DiaDomingo && momento.EsValido()
+}
+
+func validarComida(ci ComidaInput) error {
+	if !huecoValido(ci.Dia, ci.Momento) {
+		return ErrHuecoInvalido
+	}
+	if ci.Porciones < 1 {
+		return ErrPorcionesInvalidas
+	}
+	return nil
+}
+
+func nuevaComida(ci ComidaInput) ComidaPlan {
+	recetaID := ci.RecetaID
+	return ComidaPlan{Dia: ci.Dia, Momento: ci.Momento, RecetaID: &recetaID, Porciones: ci.Porciones}
+}
+
+// validarRecetas comprueba (una vez por receta) que todas las recetas referenciadas existan
+// y que el usuario pueda verlas (no se planifican borradores ajenos).
+func (s *planService) validarRecetas(ctx context.Context, userID uint, comidas []ComidaPlan) error {
+	vistas := make(map[uint]bool, len(comidas))
+	for _, c := range comidas {
+		if c.RecetaID == nil || vistas[*c.RecetaID] {
+			continue
+		}
+		vistas[*c.RecetaID] = true
+		if _, err := s.recetaSvc.ObtenerVisible(ctx, *c.RecetaID, recetas.LectorUsuario(userID)); err != nil {
+			return fmt.Errorf("servicio planificador: error validando receta %d: %w", *c.RecetaID, err)
+		}
+	}
+	return nil
+}
+
+func (s *planService) verificarSemanaLibre(ctx context.Context, userID uint, semanaInicio time.Time) error {
+	_, err := s.repo.GetByUserSemana(ctx, userID, semanaInicio)
+	if err == nil {
+		return ErrPlanYaExiste
+	}
+	if !errors.Is(err, repository.ErrRecordNotFound) {
+		return fmt.Errorf("servicio planificador: error verificando semana: %w", err)
+	}
+	return nil
+}
+
+// --- Consistencia ante recetas eliminadas ---
+
+type recetaEliminadaListener struct {
+	repo PlanRepository
+}
+
+// NewRecetaEliminadaListener devuelve el listener que marca los huecos de los planes
+// cuando se elimina una receta, y los desmarca si se restaura de la papelera.
+// Se registra en recetas.NewRecetaService.
+func NewRecetaEliminadaListener(repo PlanRepository) recetas.RecetaEliminadaListener {
+	return &recetaEliminadaListener{repo: repo}
+}
+
+func (l *recetaEliminadaListener) RecetaEliminada(ctx context.Context, recetaID uint) error {
+	marcados, err := l.repo.MarcarRecetaEliminada(ctx, recetaID)
+	if err != nil {
+		return fmt.Errorf("servicio planificador: error marcando huecos de receta %d: %w", recetaID, err)
+	}
+	if marcados > 0 {
+		log.Printf("Servicio: %d comida(s) de planes marcadas por eliminación de receta ID %d\n", marcados, recetaID)
+	}
+	return nil
+}
+
+func (l *recetaEliminadaListener) RecetaRestaurada(ctx context.Context, recetaID uint) error {
+	desmarcados, err := l.repo.DesmarcarRecetaEliminada(ctx, recetaID)
+	if err != nil {
+		return fmt.Errorf("servicio planificador: error desmarcando huecos de receta %d: %w", recetaID, err)
+	}
+	if desmarcados > 0 {
+		log.Printf("Servicio: %d comida(s) de planes recuperan la receta restaurada ID %d\n", desmarcados, recetaID)
+	}
+	return nil
+}
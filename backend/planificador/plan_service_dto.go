@@ -0,0 +1,26 @@
+// backend/planificador/plan_service_dto.go
+package planificador
+
+import "time"
+
+// ComidaInput es el DTO de entrada del servicio para asignar una receta a un hueco.
+type ComidaInput struct {
+	Dia       int
+	Momento   MomentoComida
+	RecetaID  uint
+	Porciones int
+}
+
+// CrearPlanInput es el DTO de entrada del servicio para crear un plan semanal.
+type CrearPlanInput struct {
+	Semana  time.Time // Cualquier fecha de la semana; se normaliza al lunes
+	Comidas []ComidaInput
+}
+
+// IntercambioInput identifica los dos huecos cuyo contenido se intercambia.
+type IntercambioInput struct {
+	DiaA     int
+	MomentoA MomentoComida
+	DiaB     int
+	MomentoB MomentoComida
+}
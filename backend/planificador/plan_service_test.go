@@ -0,0 +1,229 @@
+// backend/planificador/plan_service_test.go
+package planificador_test // Usar paquete _test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/planificador"                         // El paquete bajo test
+	planificadorMocks "backend/planificador/mocks" // Mocks del paquete planificador
+	"backend/recetas"                              // Para el tipo recetas.Receta y sus errores
+	recetasMocks "backend/recetas/mocks"           // Mock de RecetaService
+	"backend/shared/repository"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type PlanServiceTestSuite struct {
+	suite.Suite
+	mockRepo      *planificadorMocks.PlanRepositoryMock
+	mockRecetaSvc *recetasMocks.RecetaServiceMock
+	service       planificador.PlanService
+	lunes         time.Time
+}
+
+func (s *PlanServiceTestSuite) SetupTest() {
+	s.mockRepo = new(planificadorMocks.PlanRepositoryMock)
+	s.mockRecetaSvc = new(recetasMocks.RecetaServiceMock)
+	s.service = planificador.NewPlanService(s.mockRepo, s.mockRecetaSvc)
+	s.lunes = time.Date(2025, 5, 19, 0, 0, 0, 0, time.UTC)
+}
+
+func TestPlanServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PlanServiceTestSuite))
+}
+
+func uintPtr(v uint) *uint { return &v }
+
+func (s *PlanServiceTestSuite) TestInicioDeSemana_NormalizaAlLunes() {
+	jueves := time.Date(2025, 5, 22, 18, 30, 0, 0, time.UTC)
+	domingo := time.Date(2025, 5, 25, 9, 0, 0, 0, time.UTC)
+
+	s.Equal(s.lunes, planificador.InicioDeSemana(jueves))
+	s.Equal(s.lunes, planificador.InicioDeSemana(domingo))
+	s.Equal(s.lunes, planificador.InicioDeSemana(s.lunes))
+}
+
+func (s *PlanServiceTestSuite) TestCrearPlan_Success() {
+	ctx := context.Background()
+	input := planificador.CrearPlanInput{
+		Semana: time.Date(2025, 5, 21, 0, 0, 0, 0, time.UTC), // Miércoles
+		Comidas: []planificador.ComidaInput{
+			{Dia: 1, Momento: planificador.MomentoCena, RecetaID: 7, Porciones: 2},
+			{Dia: 2, Momento: planificador.MomentoCena, RecetaID: 7, Porciones: 4},
+		},
+	}
+	creado := &planificador.PlanSemanal{ID: 1, UserID: 9, SemanaInicio: s.lunes}
+
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(9)).Return(&recetas.Receta{ID: 7}, nil).Once() // Una sola vez por receta
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(p *planificador.PlanSemanal) bool {
+		return p.UserID == 9 && p.SemanaInicio.Equal(s.lunes) && len(p.Comidas) == 2
+	})).Return(nil).Once()
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(creado, nil).Once()
+
+	plan, err := s.service.CrearPlan(ctx, 9, input)
+
+	s.NoError(err)
+	s.Equal(creado, plan)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecetaSvc.AssertExpectations(s.T())
+}
+
+func (s *PlanServiceTestSuite) TestCrearPlan_Fail_RecetaNoVisible() {
+	ctx := context.Background()
+	input := planificador.CrearPlanInput{
+		Semana:  s.lunes,
+		Comidas: []planificador.ComidaInput{{Dia: 1, Momento: planificador.MomentoCena, RecetaID: 8, Porciones: 2}},
+	}
+
+	// Borrador de otro autor: para el usuario no existe
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(8), recetas.LectorUsuario(9)).Return(nil, recetas.ErrRecetaNotFound).Once()
+
+	plan, err := s.service.CrearPlan(ctx, 9, input)
+
+	s.Nil(plan)
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *PlanServiceTestSuite) TestCrearPlan_Fail_SemanaOcupada() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(&planificador.PlanSemanal{ID: 3}, nil).Once()
+
+	plan, err := s.service.CrearPlan(ctx, 9, planificador.CrearPlanInput{Semana: s.lunes})
+
+	s.Nil(plan)
+	s.ErrorIs(err, planificador.ErrPlanYaExiste)
+	s.mockRepo.AssertNotCalled(s.T(), "Create")
+}
+
+func (s *PlanServiceTestSuite) TestCrearPlan_Fail_HuecoDuplicado() {
+	input := planificador.CrearPlanInput{Semana: s.lunes, Comidas: []planificador.ComidaInput{
+		{Dia: 1, Momento: planificador.MomentoCena, RecetaID: 7, Porciones: 2},
+		{Dia: 1, Momento: planificador.MomentoCena, RecetaID: 8, Porciones: 2},
+	}}
+
+	plan, err := s.service.CrearPlan(context.Background(), 9, input)
+
+	s.Nil(plan)
+	s.ErrorIs(err, planificador.ErrComidasDuplicadas)
+}
+
+func (s *PlanServiceTestSuite) TestAsignarComida_Fail_MomentoInvalido() {
+	input := planificador.ComidaInput{Dia: 1, Momento: "merienda", RecetaID: 7, Porciones: 2}
+
+	plan, err := s.service.AsignarComida(context.Background(), 9, s.lunes, input)
+
+	s.Nil(plan)
+	s.ErrorIs(err, planificador.ErrHuecoInvalido)
+	s.mockRepo.AssertNotCalled(s.T(), "UpsertComida")
+}
+
+func (s *PlanServiceTestSuite) TestCopiarSemanaAnterior_OmiteRecetasEliminadas() {
+	ctx := context.Background()
+	semanaAnterior := s.lunes.AddDate(0, 0, -7)
+	anterior := &planificador.PlanSemanal{ID: 2, UserID: 9, SemanaInicio: semanaAnterior, Comidas: []planificador.ComidaPlan{
+		{Dia: 1, Momento: planificador.MomentoCena, RecetaID: uintPtr(7), Porciones: 2},
+		{Dia: 3, Momento: planificador.MomentoAlmuerzo, RecetaID: uintPtr(8), Porciones: 2, RecetaEliminada: true},
+	}}
+
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), semanaAnterior).Return(anterior, nil).Once()
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(9)).Return(&recetas.Receta{ID: 7}, nil).Once()
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(p *planificador.PlanSemanal) bool {
+		return p.SemanaInicio.Equal(s.lunes) && len(p.Comidas) == 1 && *p.Comidas[0].RecetaID == 7
+	})).Return(nil).Once()
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(&planificador.PlanSemanal{ID: 1}, nil).Once()
+
+	plan, err := s.service.CopiarSemanaAnterior(ctx, 9, s.lunes)
+
+	s.NoError(err)
+	s.Require().NotNil(plan)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *PlanServiceTestSuite) TestCopiarSemanaAnterior_OmiteRecetasQueYaNoVe() {
+	ctx := context.Background()
+	semanaAnterior := s.lunes.AddDate(0, 0, -7)
+	anterior := &planificador.PlanSemanal{ID: 2, UserID: 9, SemanaInicio: semanaAnterior, Comidas: []planificador.ComidaPlan{
+		{Dia: 1, Momento: planificador.MomentoCena, RecetaID: uintPtr(7), Porciones: 2},
+		{Dia: 2, Momento: planificador.MomentoCena, RecetaID: uintPtr(8), Porciones: 2},
+		{Dia: 3, Momento: planificador.MomentoCena, RecetaID: uintPtr(8), Porciones: 2},
+	}}
+
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), semanaAnterior).Return(anterior, nil).Once()
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(7), recetas.LectorUsuario(9)).Return(&recetas.Receta{ID: 7}, nil).Once()
+	s.mockRecetaSvc.On("ObtenerVisible", ctx, uint(8), recetas.LectorUsuario(9)).Return(nil, recetas.ErrRecetaNotFound).Once() // Volvió a borrador
+	s.mockRepo.On("Create", ctx, mock.MatchedBy(func(p *planificador.PlanSemanal) bool {
+		return len(p.Comidas) == 1 && *p.Comidas[0].RecetaID == 7
+	})).Return(nil).Once()
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(&planificador.PlanSemanal{ID: 1}, nil).Once()
+
+	_, err := s.service.CopiarSemanaAnterior(ctx, 9, s.lunes)
+
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+	s.mockRecetaSvc.AssertExpectations(s.T())
+}
+
+func (s *PlanServiceTestSuite) TestCopiarSemanaAnterior_SinPlanAnterior() {
+	ctx := context.Background()
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes.AddDate(0, 0, -7)).Return(nil, repository.ErrRecordNotFound).Once()
+
+	plan, err := s.service.CopiarSemanaAnterior(ctx, 9, s.lunes)
+
+	s.Nil(plan)
+	s.ErrorIs(err, planificador.ErrPlanAnteriorNotFound)
+}
+
+func (s *PlanServiceTestSuite) TestIntercambiarComidas_Success() {
+	ctx := context.Background()
+	plan := &planificador.PlanSemanal{ID: 4, UserID: 9, SemanaInicio: s.lunes, Comidas: []planificador.ComidaPlan{
+		{Dia: 1, Momento: planificador.MomentoCena, RecetaID: uintPtr(7), Porciones: 2},
+	}}
+	input := planificador.IntercambioInput{DiaA: 1, MomentoA: planificador.MomentoCena, DiaB: 2, MomentoB: planificador.MomentoAlmuerzo}
+
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(plan, nil).Twice()
+	s.mockRepo.On("SwapComidas", ctx, uint(4), 1, planificador.MomentoCena, 2, planificador.MomentoAlmuerzo).Return(nil).Once()
+
+	_, err := s.service.IntercambiarComidas(ctx, 9, s.lunes, input)
+
+	s.NoError(err)
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *PlanServiceTestSuite) TestRecetaEliminadaListener_MarcaHuecos() {
+	ctx := context.Background()
+	listener := planificador.NewRecetaEliminadaListener(s.mockRepo)
+	s.mockRepo.On("MarcarRecetaEliminada", ctx, uint(7)).Return(int64(3), nil).Once()
+
+	s.NoError(listener.RecetaEliminada(ctx, 7))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *PlanServiceTestSuite) TestRecetaEliminadaListener_DesmarcaHuecosAlRestaurar() {
+	ctx := context.Background()
+	listener, ok := planificador.NewRecetaEliminadaListener(s.mockRepo).(recetas.RecetaRestauradaListener)
+	s.Require().True(ok, "Debe enterarse de las restauraciones")
+	s.mockRepo.On("DesmarcarRecetaEliminada", ctx, uint(7)).Return(int64(2), nil).Once()
+
+	s.NoError(listener.RecetaRestaurada(ctx, 7))
+	s.mockRepo.AssertExpectations(s.T())
+}
+
+func (s *PlanServiceTestSuite) TestCrearPlan_Fail_DuplicadoEnRepo() {
+	ctx := context.Background()
+	duplicado := &repository.ConstraintError{Base: repository.ErrDuplicateRecord, Campo: "user_semana", Causa: errors.New("Error 1062")}
+	s.mockRepo.On("GetByUserSemana", ctx, uint(9), s.lunes).Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRepo.On("Create", ctx, mock.Anything).Return(duplicado).Once()
+
+	plan, err := s.service.CrearPlan(ctx, 9, planificador.CrearPlanInput{Semana: s.lunes})
+
+	s.Nil(plan)
+	s.ErrorIs(err, planificador.ErrPlanYaExiste)
+}
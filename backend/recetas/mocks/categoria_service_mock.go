@@ -4,6 +4,8 @@ package mocks
 import (
 	"backend/categorias" // Para la interfaz y tipos de dominio de Categoria
 	"context"
+	"time"
+	"backend/shared/i18n"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -46,4 +48,55 @@ func (m *CategoriaServiceMock) Update(ctx context.Context, id uint, input catego
 // Delete es un mock de la función Delete de la interfaz CategoriaService.
 func (m *CategoriaServiceMock) Delete(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id); return args.Error(0)
-}
\ No newline at end of file
+}
+
+// --- Papelera ---
+
+func (m *CategoriaServiceMock) ListarPapelera(ctx context.Context) ([]categorias.Categoria, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]categorias.Categoria), args.Error(1)
+}
+
+func (m *CategoriaServiceMock) RestaurarDePapelera(ctx context.Context, id uint) (*categorias.Categoria, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*categorias.Categoria), args.Error(1)
+}
+
+func (m *CategoriaServiceMock) PurgarDePapelera(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *CategoriaServiceMock) PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error) {
+	args := m.Called(ctx, antesDe)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *CategoriaServiceMock) Traducir(ctx context.Context, idioma i18n.Idioma, cats []categorias.Categoria) ([]categorias.Categoria, error) {
+	args := m.Called(ctx, idioma, cats)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]categorias.Categoria), args.Error(1)
+}
+
+func (m *CategoriaServiceMock) ListarTraducciones(ctx context.Context, id uint) ([]categorias.CategoriaTraduccion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]categorias.CategoriaTraduccion), args.Error(1)
+}
+
+func (m *CategoriaServiceMock) GuardarTraduccion(ctx context.Context, id uint, idioma i18n.Idioma, input categorias.CategoriaTraduccionInputDTO) (*categorias.CategoriaTraduccion, error) {
+	args := m.Called(ctx, id, idioma, input)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*categorias.CategoriaTraduccion), args.Error(1)
+}
+
+func (m *CategoriaServiceMock) EliminarTraduccion(ctx context.Context, id uint, idioma i18n.Idioma) error {
+	args := m.Called(ctx, id, idioma)
+	return args.Error(0)
+}
@@ -0,0 +1,56 @@
+// backend/recetas/mocks/media_service_mock.go
+package mocks
+
+import (
+	"backend/media" // Para la interfaz y tipos de dominio de Media
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MediaServiceMock es una implementación mock de MediaService.
+type MediaServiceMock struct {
+	mock.Mock
+}
+
+// Verifica que MediaServiceMock implementa la interfaz MediaService.
+var _ media.MediaService = (*MediaServiceMock)(nil)
+
+func (m *MediaServiceMock) GetByID(ctx context.Context, id uint) (*media.Media, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*media.Media), args.Error(1)
+}
+
+func (m *MediaServiceMock) Registrar(ctx context.Context, input media.RegistrarInput) (*media.Media, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*media.Media), args.Error(1)
+}
+
+func (m *MediaServiceMock) RegistrarArchivo(ctx context.Context, clave string, propietarioID *uint) (*media.Media, error) {
+	args := m.Called(ctx, clave, propietarioID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*media.Media), args.Error(1)
+}
+
+func (m *MediaServiceMock) Usar(ctx context.Context, mediaID uint, entidad string, entidadID uint) error {
+	args := m.Called(ctx, mediaID, entidad, entidadID)
+	return args.Error(0)
+}
+
+func (m *MediaServiceMock) Liberar(ctx context.Context, mediaID uint, entidad string, entidadID uint) error {
+	args := m.Called(ctx, mediaID, entidad, entidadID)
+	return args.Error(0)
+}
+
+func (m *MediaServiceMock) EliminarSiHuerfano(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -4,6 +4,8 @@ package mocks
 import (
 	"backend/recetas" // Para los tipos de dominio y la interfaz
 	"context"
+	"time"
+	"backend/shared/i18n"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -41,4 +43,114 @@ func (m *RecetaRepositoryMock) FindByCategoriaID(ctx context.Context, categoriaI
 	args := m.Called(ctx, categoriaID)
 	if args.Get(0) == nil { return nil, args.Error(1) }
 	return args.Get(0).([]recetas.Receta), args.Error(1)
-}
\ No newline at end of file
+}
+func (m *RecetaRepositoryMock) FindRevisiones(ctx context.Context, recetaID uint) ([]recetas.RecetaRevision, error) {
+	args := m.Called(ctx, recetaID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.RecetaRevision), args.Error(1)
+}
+func (m *RecetaRepositoryMock) GetRevision(ctx context.Context, recetaID uint, numero int) (*recetas.RecetaRevision, error) {
+	args := m.Called(ctx, recetaID, numero)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*recetas.RecetaRevision), args.Error(1)
+}
+func (m *RecetaRepositoryMock) Find(ctx context.Context, filtro recetas.FiltroRecetas) ([]recetas.Receta, error) {
+	args := m.Called(ctx, filtro)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaRepositoryMock) ActualizarFoto(ctx context.Context, id uint, foto string, fotoID *uint) (*uint, error) {
+	args := m.Called(ctx, id, foto, fotoID)
+	anterior, _ := args.Get(0).(*uint)
+	return anterior, args.Error(1)
+}
+func (m *RecetaRepositoryMock) FindFotos(ctx context.Context, recetaID uint) ([]recetas.RecetaFoto, error) {
+	args := m.Called(ctx, recetaID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.RecetaFoto), args.Error(1)
+}
+func (m *RecetaRepositoryMock) GetRecetaIDDeFoto(ctx context.Context, fotoID uint) (uint, error) {
+	args := m.Called(ctx, fotoID)
+	return args.Get(0).(uint), args.Error(1)
+}
+func (m *RecetaRepositoryMock) CreateFoto(ctx context.Context, foto *recetas.RecetaFoto) error {
+	args := m.Called(ctx, foto); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) UpdateFoto(ctx context.Context, foto *recetas.RecetaFoto) error {
+	args := m.Called(ctx, foto); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) ReordenarFotos(ctx context.Context, recetaID uint, ids []uint) error {
+	args := m.Called(ctx, recetaID, ids); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) DeleteFoto(ctx context.Context, recetaID, fotoID uint) error {
+	args := m.Called(ctx, recetaID, fotoID); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) FindFotosSinMedia(ctx context.Context) ([]recetas.Receta, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaRepositoryMock) AsignarFotoMedia(ctx context.Context, id, fotoID uint) error {
+	args := m.Called(ctx, id, fotoID); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) UpdateEstado(ctx context.Context, id uint, estado recetas.EstadoReceta, publicarEn *time.Time) error {
+	args := m.Called(ctx, id, estado, publicarEn); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) PublicarProgramadas(ctx context.Context, ahora time.Time) (int64, error) {
+	args := m.Called(ctx, ahora)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// --- Papelera ---
+func (m *RecetaRepositoryMock) FindEliminadas(ctx context.Context) ([]recetas.Receta, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaRepositoryMock) GetEliminadaByID(ctx context.Context, id uint) (*recetas.Receta, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaRepositoryMock) Restaurar(ctx context.Context, id uint, slug string) error {
+	args := m.Called(ctx, id, slug); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) Purgar(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) PurgarEliminadasAntesDe(ctx context.Context, limite time.Time) (int64, error) {
+	args := m.Called(ctx, limite)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// --- Traducciones ---
+func (m *RecetaRepositoryMock) FindTraducciones(ctx context.Context, recetaID uint) ([]recetas.RecetaTraduccion, error) {
+	args := m.Called(ctx, recetaID)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.RecetaTraduccion), args.Error(1)
+}
+func (m *RecetaRepositoryMock) FindTraduccionesEnIdioma(ctx context.Context, idioma i18n.Idioma, recetaIDs []uint) ([]recetas.RecetaTraduccion, error) {
+	args := m.Called(ctx, idioma, recetaIDs)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.RecetaTraduccion), args.Error(1)
+}
+func (m *RecetaRepositoryMock) GuardarTraduccion(ctx context.Context, traduccion *recetas.RecetaTraduccion) error {
+	args := m.Called(ctx, traduccion); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) DeleteTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma) error {
+	args := m.Called(ctx, recetaID, idioma); return args.Error(0)
+}
+func (m *RecetaRepositoryMock) SlugTraducidoEnUso(ctx context.Context, idioma i18n.Idioma, slug string, recetaID uint) (bool, error) {
+	args := m.Called(ctx, idioma, slug, recetaID)
+	return args.Bool(0), args.Error(1)
+}
+func (m *RecetaRepositoryMock) GetBySlugTraducido(ctx context.Context, idioma i18n.Idioma, slug string) (*recetas.Receta, error) {
+	args := m.Called(ctx, idioma, slug)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaRepositoryMock) FindSinTraduccion(ctx context.Context, idioma i18n.Idioma) ([]recetas.Receta, error) {
+	args := m.Called(ctx, idioma)
+	if args.Get(0) == nil { return nil, args.Error(1) }
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
@@ -0,0 +1,231 @@
+// backend/recetas/mocks/receta_service_mock.go
+package mocks
+
+import (
+	"backend/media"
+	"backend/recetas" // Para la interfaz RecetaService y los tipos de dominio
+	"backend/shared/i18n"
+	"context"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+// RecetaServiceMock es una implementación mock de RecetaService.
+// La usan los paquetes que dependen de recetas (ej: comentarios).
+type RecetaServiceMock struct {
+	mock.Mock
+}
+
+// Verifica que RecetaServiceMock implementa la interfaz RecetaService.
+var _ recetas.RecetaService = (*RecetaServiceMock)(nil)
+
+func (m *RecetaServiceMock) GetAll(ctx context.Context) ([]recetas.Receta, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) GetByID(ctx context.Context, id uint) (*recetas.Receta, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) ObtenerVisible(ctx context.Context, id uint, lector recetas.Lector) (*recetas.Receta, error) {
+	args := m.Called(ctx, id, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) GetBySlug(ctx context.Context, slug string) (*recetas.Receta, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) Create(ctx context.Context, input recetas.RecetaInputDTO) (*recetas.Receta, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) Update(ctx context.Context, id uint, input recetas.RecetaInputDTO) (*recetas.Receta, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) Delete(ctx context.Context, id uint, lector recetas.Lector) error {
+	args := m.Called(ctx, id, lector)
+	return args.Error(0)
+}
+func (m *RecetaServiceMock) FindByCategoriaID(ctx context.Context, categoriaID uint) ([]recetas.Receta, error) {
+	args := m.Called(ctx, categoriaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) ListarRevisiones(ctx context.Context, recetaID uint, lector recetas.Lector) ([]recetas.RecetaRevision, error) {
+	args := m.Called(ctx, recetaID, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.RecetaRevision), args.Error(1)
+}
+func (m *RecetaServiceMock) ObtenerRevision(ctx context.Context, recetaID uint, numero int, lector recetas.Lector) (*recetas.RecetaRevision, error) {
+	args := m.Called(ctx, recetaID, numero, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.RecetaRevision), args.Error(1)
+}
+func (m *RecetaServiceMock) CompararRevisiones(ctx context.Context, recetaID uint, desde, hasta int, lector recetas.Lector) ([]recetas.CambioCampo, error) {
+	args := m.Called(ctx, recetaID, desde, hasta, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.CambioCampo), args.Error(1)
+}
+func (m *RecetaServiceMock) RestaurarRevision(ctx context.Context, recetaID uint, numero int, lector recetas.Lector) (*recetas.Receta, error) {
+	args := m.Called(ctx, recetaID, numero, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+
+func (m *RecetaServiceMock) ListarDeAutor(ctx context.Context, autorID uint) ([]recetas.Receta, error) {
+	args := m.Called(ctx, autorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) ListarPorEstado(ctx context.Context, estado *recetas.EstadoReceta) ([]recetas.Receta, error) {
+	args := m.Called(ctx, estado)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) CambiarEstado(ctx context.Context, id uint, input recetas.CambioEstadoInput) (*recetas.Receta, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) PublicarProgramadas(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// --- Papelera ---
+func (m *RecetaServiceMock) ListarPapelera(ctx context.Context) ([]recetas.Receta, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) RestaurarDePapelera(ctx context.Context, id uint) (*recetas.Receta, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) PurgarDePapelera(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+func (m *RecetaServiceMock) PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error) {
+	args := m.Called(ctx, antesDe)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// --- Traducciones ---
+func (m *RecetaServiceMock) Traducir(ctx context.Context, idioma i18n.Idioma, recs []recetas.Receta) ([]recetas.Receta, error) {
+	args := m.Called(ctx, idioma, recs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) BuscarPorSlug(ctx context.Context, idioma i18n.Idioma, slug string) (*recetas.Receta, error) {
+	args := m.Called(ctx, idioma, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.Receta), args.Error(1)
+}
+func (m *RecetaServiceMock) ListarTraducciones(ctx context.Context, recetaID uint) ([]recetas.RecetaTraduccion, error) {
+	args := m.Called(ctx, recetaID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.RecetaTraduccion), args.Error(1)
+}
+func (m *RecetaServiceMock) GuardarTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma, input recetas.RecetaTraduccionInputDTO) (*recetas.RecetaTraduccion, error) {
+	args := m.Called(ctx, recetaID, idioma, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.RecetaTraduccion), args.Error(1)
+}
+func (m *RecetaServiceMock) EliminarTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma) error {
+	args := m.Called(ctx, recetaID, idioma)
+	return args.Error(0)
+}
+func (m *RecetaServiceMock) ListarTraduccionesFaltantes(ctx context.Context, idioma *i18n.Idioma) ([]recetas.TraduccionesFaltantes, error) {
+	args := m.Called(ctx, idioma)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.TraduccionesFaltantes), args.Error(1)
+}
+
+// --- Galería de fotos ---
+func (m *RecetaServiceMock) AgregarFoto(ctx context.Context, recetaID uint, foto *media.Media, input recetas.RecetaFotoInputDTO, lector recetas.Lector) (*recetas.RecetaFoto, error) {
+	args := m.Called(ctx, recetaID, foto, input, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.RecetaFoto), args.Error(1)
+}
+func (m *RecetaServiceMock) EditarFoto(ctx context.Context, recetaID, fotoID uint, input recetas.RecetaFotoInputDTO, lector recetas.Lector) (*recetas.RecetaFoto, error) {
+	args := m.Called(ctx, recetaID, fotoID, input, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.RecetaFoto), args.Error(1)
+}
+func (m *RecetaServiceMock) ReordenarFotos(ctx context.Context, recetaID uint, ids []uint, lector recetas.Lector) ([]recetas.RecetaFoto, error) {
+	args := m.Called(ctx, recetaID, ids, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]recetas.RecetaFoto), args.Error(1)
+}
+func (m *RecetaServiceMock) QuitarFoto(ctx context.Context, recetaID, fotoID uint, lector recetas.Lector) error {
+	args := m.Called(ctx, recetaID, fotoID, lector)
+	return args.Error(0)
+}
+func (m *RecetaServiceMock) ReemplazarPortada(ctx context.Context, recetaID uint, foto *media.Media, lector recetas.Lector) (*recetas.RecetaFoto, error) {
+	args := m.Called(ctx, recetaID, foto, lector)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*recetas.RecetaFoto), args.Error(1)
+}
+func (m *RecetaServiceMock) QuitarPortada(ctx context.Context, recetaID uint, lector recetas.Lector) error {
+	args := m.Called(ctx, recetaID, lector)
+	return args.Error(0)
+}
@@ -0,0 +1,61 @@
+// backend/recetas/receta_acceso.go
+
+// Este archivo decide quién ve las fotos de las recetas (ver media.ControlAcceso):
+// las de recetas publicadas, cualquiera; las de borradores, en revisión o archivadas,
+// solo su autor (Receta.VisiblePara); las de recetas en la papelera, nadie.
+// Los demás, editores incluidos, reciben URLs firmadas en las respuestas de la API (ver urlArchivo).
+
+package recetas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"backend/media"
+	"backend/shared/repository"
+)
+
+type politicaFotos struct {
+	recetaRepo RecetaRepository
+	galeria    bool // true: el uso es de una foto de la galería (EntidadMediaGaleria), no de la receta
+}
+
+// NewPoliticaFotos crea la política de las fotos de recetas (se registra con EntidadMedia).
+func NewPoliticaFotos(recetaRepo RecetaRepository) media.Politica {
+	return &politicaFotos{recetaRepo: recetaRepo}
+}
+
+// NewPoliticaGaleria crea la política de las fotos de galería (se registra con EntidadMediaGaleria).
+func NewPoliticaGaleria(recetaRepo RecetaRepository) media.Politica {
+	return &politicaFotos{recetaRepo: recetaRepo, galeria: true}
+}
+
+// Visibilidad de la receta (o de la receta de la foto de galería) para el usuario.
+func (p *politicaFotos) Visibilidad(ctx context.Context, entidadID uint, usuarioID *uint) (media.Visibilidad, error) {
+	recetaID := entidadID
+	if p.galeria {
+		id, err := p.recetaRepo.GetRecetaIDDeFoto(ctx, entidadID)
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return media.VisibilidadDenegada, nil
+		}
+		if err != nil {
+			return media.VisibilidadDenegada, fmt.Errorf("recetas: acceso a la foto %d: %w", entidadID, err)
+		}
+		recetaID = id
+	}
+	receta, err := p.recetaRepo.GetByID(ctx, recetaID) // No encuentra las de la papelera
+	if errors.Is(err, repository.ErrRecordNotFound) {
+		return media.VisibilidadDenegada, nil
+	}
+	if err != nil {
+		return media.VisibilidadDenegada, fmt.Errorf("recetas: acceso a la receta %d: %w", recetaID, err)
+	}
+	switch {
+	case receta.EsPublica():
+		return media.VisibilidadPublica, nil
+	case receta.VisiblePara(Lector{UsuarioID: usuarioID}):
+		return media.VisibilidadPrivada, nil
+	}
+	return media.VisibilidadDenegada, nil
+}
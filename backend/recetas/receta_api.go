@@ -16,65 +16,179 @@ package recetas // Paquete de la característica 'recetas'
 
 import (
 	// Importar paquetes necesarios
+	"context"            // Para la interfaz FavoritosResolver
 	"backend/categorias" // Para tipos como categorias.CategoriaResponseDTO y errores como categorias.ErrCategoriaNotFound
-	//"errors"         // Para errors.Is
+	"errors"         // Para errUsuarioNoIdentificado
 	"fmt"            // Para formatear errores si es necesario pasarlos al middleware
-	// "log" // Ya no es tan necesario aquí si el middleware loguea centralmente
+	"log"            // Para loguear fallos no críticos (ej: marcar favoritos)
 	"net/http"
+	"net/url"       // Para reconocer fotos con URL externa
+	"path"          // Para las claves y URLs de la foto y sus variantes
 	"strconv"
-	//"strings" // Para strings.Contains en el manejo de errores específico
+	"strings" // Para unir el srcset
 	"time"    // Para formatear CreatedAt/UpdatedAt
+	"backend/media"       // Registro de las fotos subidas
+	"backend/shared/i18n" // Idioma de la petición para traducir el contenido
+	"backend/shared/imagenes" // Variantes de las fotos subidas
+	"backend/shared/storage"  // Almacenamiento de las fotos (disco o S3)
+	utils "backend/shared/utilis" // Para guardar las fotos y construir su URL pública
 	"github.com/gin-gonic/gin" // El framework web
 	// "backend/shared/apitypes" // No es necesario importar aquí si el middleware lo usa
 )
 
+// errUsuarioNoIdentificado se produce si un handler protegido se ejecuta sin userID en el contexto.
+var errUsuarioNoIdentificado = errors.New("recetas: userID ausente en el contexto")
+
+// FavoritosResolver indica qué recetas son favoritas de un usuario.
+// Lo implementa el servicio de favoritos; se define aquí para evitar que
+// 'recetas' dependa de 'favoritos' (que ya depende de 'recetas').
+type FavoritosResolver interface {
+	RecetasFavoritas(ctx context.Context, userID uint, recetaIDs []uint) (map[uint]bool, error)
+}
+
 // RecetaHandler maneja las peticiones HTTP relacionadas con Recetas.
 type RecetaHandler struct {
-	service RecetaService // Dependencia de la interfaz RecetaService (de este paquete)
+	service     RecetaService       // Dependencia de la interfaz RecetaService (de este paquete)
+	favoritos   FavoritosResolver   // Opcional: si es nil, no se informa 'es_favorito'
+	media       media.MediaService  // Registro de los archivos subidos
+	almacen     storage.Storage     // Dónde se guardan las fotos (disco o bucket S3)
+	limitesFoto utils.LimitesImagen // Límites de las fotos subidas (cero = por defecto)
 }
 
 // NewRecetaHandler es la Factory Function para crear el handler.
-func NewRecetaHandler(s RecetaService) *RecetaHandler {
-	return &RecetaHandler{service: s}
+func NewRecetaHandler(s RecetaService, favoritos FavoritosResolver, mediaSvc media.MediaService, almacen storage.Storage, limitesFoto utils.LimitesImagen) *RecetaHandler {
+	return &RecetaHandler{service: s, favoritos: favoritos, media: mediaSvc, almacen: almacen, limitesFoto: limitesFoto}
 }
 
 // --- Mapeadores Helper (Internos al Handler) ---
 
 // mapDomainRecetaToResponseDTO convierte un domain.Receta a un RecetaResponseDTO.
-func mapDomainRecetaToResponseDTO(receta Receta) RecetaResponseDTO {
+// 'lector' es quien recibe la respuesta: decide si las URLs de las fotos van firmadas (ver firmarPara).
+func mapDomainRecetaToResponseDTO(receta Receta, lector Lector) RecetaResponseDTO {
 	var catDTO categorias.CategoriaResponseDTO // Tipo del paquete 'categorias'
 	if receta.Categoria != nil {
 		catDTO = categorias.CategoriaResponseDTO{
 			ID:     receta.Categoria.ID,
 			Nombre: receta.Categoria.Nombre,
 			Slug:   receta.Categoria.Slug,
+			Idioma: idiomaDeContenido(receta.Categoria.Idioma),
 		}
 	}
-	return RecetaResponseDTO{
+	privada := firmarPara(receta, lector)
+	dto := RecetaResponseDTO{
 		ID:                receta.ID,
 		Nombre:            receta.Nombre,
 		Slug:              receta.Slug,
 		TiempoPreparacion: receta.TiempoPreparacion,
+		Porciones:         receta.Porciones,
 		Descripcion:       receta.Descripcion,
 		Foto:              receta.Foto,
+		FotoID:            receta.FotoID,
+		Imagen:            mapFotoToImagenDTO(receta.Foto, receta.FotoMedia, privada),
+		Fotos:             mapFotosToResponseDTO(receta.Fotos, privada),
 		CreatedAt:         receta.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:         receta.UpdatedAt.Format(time.RFC3339),
 		Categoria:         catDTO,
+		Idioma:            idiomaDeContenido(receta.Idioma),
+	}
+	dto.Estado = string(receta.Estado)
+	if receta.PublicarEn != nil {
+		publicarEn := receta.PublicarEn.Format(time.RFC3339)
+		dto.PublicarEn = &publicarEn
+	}
+	if receta.EliminadaEn != nil {
+		eliminadaEn := receta.EliminadaEn.Format(time.RFC3339)
+		dto.EliminadaEn = &eliminadaEn
+	}
+	return dto
+}
+
+// idiomaDeContenido devuelve el idioma a informar: el de la traducción aplicada o,
+// si no se aplicó ninguna, el idioma por defecto.
+func idiomaDeContenido(idioma i18n.Idioma) string {
+	if idioma == "" {
+		return string(i18n.IdiomaPorDefecto)
 	}
+	return string(idioma)
+}
+
+// ToRecetaResponseDTO expone el mapeo de receta a DTO para otros paquetes
+// que anidan recetas en sus respuestas (ej: colecciones).
+func ToRecetaResponseDTO(receta Receta, lector Lector) RecetaResponseDTO {
+	return mapDomainRecetaToResponseDTO(receta, lector)
 }
 
 // mapDomainRecetasToResponseDTOs convierte un slice de domain.Receta a un slice de RecetaResponseDTO.
-func mapDomainRecetasToResponseDTOs(recetas []Receta) []RecetaResponseDTO {
+func mapDomainRecetasToResponseDTOs(recetas []Receta, lector Lector) []RecetaResponseDTO {
 	responseDTOs := make([]RecetaResponseDTO, 0, len(recetas))
 	for _, r := range recetas {
-		responseDTOs = append(responseDTOs, mapDomainRecetaToResponseDTO(r))
+		responseDTOs = append(responseDTOs, mapDomainRecetaToResponseDTO(r, lector))
 	}
 	return responseDTOs
 }
 
+// firmarPara indica si las fotos de la receta se sirven a 'lector' con URL firmada: solo si la
+// receta no es pública y el lector puede verla. A cualquier otro se le da la URL pública, que no
+// sirve el archivo mientras la receta no se publique (ver media.ControlAcceso).
+func firmarPara(receta Receta, lector Lector) bool {
+	return !receta.EsPublica() && receta.VisiblePara(lector)
+}
+
+// marcarFavoritos rellena EsFavorito en los DTOs si la petición está autenticada.
+// Un fallo al consultar favoritos no debe romper el listado: se loguea y se omite el campo.
+func (h *RecetaHandler) marcarFavoritos(c *gin.Context, dtos []RecetaResponseDTO) {
+	if h.favoritos == nil || len(dtos) == 0 {
+		return
+	}
+	v, exists := c.Get("userID")
+	if !exists {
+		return
+	}
+	userID, ok := v.(uint)
+	if !ok {
+		return
+	}
+
+	ids := make([]uint, 0, len(dtos))
+	for _, dto := range dtos {
+		ids = append(ids, dto.ID)
+	}
+	favoritas, err := h.favoritos.RecetasFavoritas(c.Request.Context(), userID, ids)
+	if err != nil {
+		log.Printf("Handler: no se pudieron consultar favoritos de UserID %d: %v\n", userID, err)
+		return
+	}
+	for i := range dtos {
+		esFavorito := favoritas[dtos[i].ID]
+		dtos[i].EsFavorito = &esFavorito
+	}
+}
+
+// usuarioOpcional devuelve el ID del usuario autenticado, o nil si la petición es anónima.
+func usuarioOpcional(c *gin.Context) *uint {
+	if v, exists := c.Get("userID"); exists {
+		if uid, ok := v.(uint); ok {
+			return &uid
+		}
+	}
+	return nil
+}
+
+// lectorDesdeContexto devuelve quién hace la petición: el usuario (si lo hay) y si es
+// editor (lo marca middleware.Admins con la clave "esAdmin").
+func lectorDesdeContexto(c *gin.Context) Lector {
+	return Lector{UsuarioID: usuarioOpcional(c), Editor: c.GetBool("esAdmin")}
+}
+
+// traducir devuelve las recetas en el idioma de la petición (con respaldo al idioma por defecto).
+func (h *RecetaHandler) traducir(c *gin.Context, recs []Receta) ([]Receta, error) {
+	ctx := c.Request.Context()
+	return h.service.Traducir(ctx, i18n.IdiomaDe(ctx), recs)
+}
+
 // --- Métodos del Handler (Refactorizados para delegar errores) ---
 
-// GetAll maneja GET /recetas
+// GetAll maneja GET /recetas (solo recetas publicadas)
 // Las anotaciones Swagger deberían actualizar sus @Failure para usar apitypes.ErrorResponse
 func (h *RecetaHandler) GetAll(c *gin.Context) {
 	domainRecetas, err := h.service.GetAll(c.Request.Context())
@@ -82,7 +196,13 @@ func (h *RecetaHandler) GetAll(c *gin.Context) {
 		_ = c.Error(err) // Pasar error al middleware
 		return
 	}
-	c.JSON(http.StatusOK, mapDomainRecetasToResponseDTOs(domainRecetas))
+	if domainRecetas, err = h.traducir(c, domainRecetas); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := mapDomainRecetasToResponseDTOs(domainRecetas, lectorDesdeContexto(c))
+	h.marcarFavoritos(c, responseDTOs)
+	c.JSON(http.StatusOK, responseDTOs)
 }
 
 // GetByID maneja GET /recetas/:id
@@ -115,7 +235,46 @@ func (h *RecetaHandler) GetByID(c *gin.Context) {
 		_ = c.Error(err) // Pasar error (ej: ErrRecetaNotFound) al middleware
 		return
 	}
-	c.JSON(http.StatusOK, mapDomainRecetaToResponseDTO(*domainReceta))
+	h.responderReceta(c, domainReceta)
+}
+
+// responderReceta responde con el detalle de una receta, traducida y con es_favorito.
+func (h *RecetaHandler) responderReceta(c *gin.Context, domainReceta *Receta) {
+	// Los borradores solo los ven su autor y los editores; para el resto la receta no existe.
+	if !domainReceta.VisiblePara(lectorDesdeContexto(c)) {
+		_ = c.Error(ErrRecetaNotFound)
+		return
+	}
+	traducidas, err := h.traducir(c, []Receta{*domainReceta})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := []RecetaResponseDTO{mapDomainRecetaToResponseDTO(traducidas[0], lectorDesdeContexto(c))}
+	h.marcarFavoritos(c, responseDTOs)
+	c.JSON(http.StatusOK, responseDTOs[0])
+}
+
+// GetBySlug godoc
+// @Summary Obtiene una receta por su slug
+// @Description El slug se busca primero entre las traducciones al idioma de la petición y después
+// @Description entre los slugs del idioma por defecto. La receta se devuelve en el idioma de la petición.
+// @Tags Recetas
+// @Produce json
+// @Param   slug path string true "Slug de la receta" example:"paella-de-mariscos"
+// @Param   lang query string false "Idioma (tiene prioridad sobre Accept-Language)" example:"en"
+// @Success 200 {object} RecetaResponseDTO "Receta encontrada"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /recetas/slug/{slug} [get]
+func (h *RecetaHandler) GetBySlug(c *gin.Context) {
+	ctx := c.Request.Context()
+	domainReceta, err := h.service.BuscarPorSlug(ctx, i18n.IdiomaDe(ctx), c.Param("slug"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.responderReceta(c, domainReceta)
 }
 
 // Create maneja POST /recetas
@@ -128,6 +287,7 @@ func (h *RecetaHandler) GetByID(c *gin.Context) {
 // @Param   receta body RecetaRequestDTO true "Datos de la Receta a Crear"
 // @Success 201 {object} RecetaResponseDTO "Receta creada exitosamente"
 // @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos (ej: validación, categoría ID no existe)"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
 // @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
 // @Router /recetas [post]
 // @Security ApiKeyAuth
@@ -138,13 +298,20 @@ func (h *RecetaHandler) Create(c *gin.Context) {
 		_ = c.Error(err) // Pasar error de validación de Gin
 		return
 	}
+	usuarioID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	serviceInput := RecetaInputDTO{ // DTO de Servicio de este paquete
 		Nombre:            req.Nombre,
 		CategoriaID:       req.CategoriaID,
 		TiempoPreparacion: req.TiempoPreparacion,
+		Porciones:         req.Porciones,
 		Descripcion:       req.Descripcion,
 		Foto:              req.Foto,
+		UsuarioID:         &usuarioID, // Autor de la receta y de la revisión
 	}
 
 	nuevaDomainReceta, err := h.service.Create(c.Request.Context(), serviceInput)
@@ -152,7 +319,7 @@ func (h *RecetaHandler) Create(c *gin.Context) {
 		_ = c.Error(err) // Pasar error del servicio (ej: ErrRecetaSinCategoria, ErrRecetaNombreInvalido)
 		return
 	}
-	c.JSON(http.StatusCreated, mapDomainRecetaToResponseDTO(*nuevaDomainReceta))
+	c.JSON(http.StatusCreated, mapDomainRecetaToResponseDTO(*nuevaDomainReceta, lectorDesdeContexto(c)))
 }
 
 // Update maneja PUT /recetas/:id
@@ -166,6 +333,8 @@ func (h *RecetaHandler) Create(c *gin.Context) {
 // @Param   receta body RecetaRequestDTO true "Datos de la Receta a Actualizar"
 // @Success 200 {object} RecetaResponseDTO "Receta actualizada exitosamente"
 // @Failure 400 {object} apitypes.ErrorResponse "Datos de entrada inválidos (ej: validación, categoría ID no existe)"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
 // @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
 // @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
 // @Router /recetas/{id} [put]
@@ -184,13 +353,21 @@ func (h *RecetaHandler) Update(c *gin.Context) {
 		_ = c.Error(err) // Pasar error de validación de Gin
 		return
 	}
+	usuarioID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 
 	serviceInput := RecetaInputDTO{
 		Nombre:            req.Nombre,
 		CategoriaID:       req.CategoriaID,
 		TiempoPreparacion: req.TiempoPreparacion,
+		Porciones:         req.Porciones,
 		Descripcion:       req.Descripcion,
 		Foto:              req.Foto,
+		UsuarioID:         &usuarioID, // Autor de la revisión
+		Editor:            c.GetBool("esAdmin"),
 	}
 
 	domainRecetaActualizada, err := h.service.Update(c.Request.Context(), id, serviceInput)
@@ -198,7 +375,7 @@ func (h *RecetaHandler) Update(c *gin.Context) {
 		_ = c.Error(err) // Pasar error del servicio
 		return
 	}
-	c.JSON(http.StatusOK, mapDomainRecetaToResponseDTO(*domainRecetaActualizada))
+	c.JSON(http.StatusOK, mapDomainRecetaToResponseDTO(*domainRecetaActualizada, lectorDesdeContexto(c)))
 }
 
 // Delete maneja DELETE /recetas/:id
@@ -211,6 +388,8 @@ func (h *RecetaHandler) Update(c *gin.Context) {
 // @Param   id path uint true "ID de la Receta a Eliminar" example:"1"
 // @Success 204 "Sin contenido (eliminación exitosa)"
 // @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
 // @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
 // @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
 // @Router /recetas/{id} [delete]
@@ -224,7 +403,12 @@ func (h *RecetaHandler) Delete(c *gin.Context) {
 	}
 	id := uint(idUint64)
 
-	err := h.service.Delete(c.Request.Context(), id)
+	if _, err := usuarioIDDesdeContexto(c); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	err := h.service.Delete(c.Request.Context(), id, lectorDesdeContexto(c))
 	if err != nil {
 		_ = c.Error(err) // Pasar error del servicio
 		return
@@ -260,5 +444,990 @@ func (h *RecetaHandler) FindByCategoria(c *gin.Context) {
 		_ = c.Error(err) // Pasar error del servicio
 		return
 	}
-	c.JSON(http.StatusOK, mapDomainRecetasToResponseDTOs(domainRecetas))
-}
\ No newline at end of file
+	if domainRecetas, err = h.traducir(c, domainRecetas); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := mapDomainRecetasToResponseDTOs(domainRecetas, lectorDesdeContexto(c))
+	h.marcarFavoritos(c, responseDTOs)
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// --- Foto ---
+
+// CarpetaFotos es la subcarpeta de uploads/ donde se guardan las fotos de recetas.
+const CarpetaFotos = "recetas"
+
+// SubirFoto godoc
+// @Summary Sube o reemplaza la foto de una receta
+// @Description Reemplaza la portada de la galería (la anterior sale de ella, y sus textos pasan a la nueva). Recibe la imagen en el campo 'foto' (multipart/form-data) y genera las variantes thumb, card y full
+// @Description en su formato y en WebP, sin metadatos EXIF/GPS. El archivo se nombra con el hash de su contenido:
+// @Description subir la misma imagen dos veces reutiliza el archivo y su registro en 'media'. La foto anterior se
+// @Description borra del almacenamiento después de guardar la nueva referencia, si ya no la usa nadie.
+// @Tags Recetas
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param foto formData file true "Imagen JPEG o PNG (tipo detectado por contenido; tamaño y dimensiones máximos en la configuración 'uploads')"
+// @Success 200 {object} FotoResponseDTO "Foto guardada"
+// @Failure 400 {object} apitypes.ErrorResponse "Archivo ausente o imagen no válida"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/foto [post]
+func (h *RecetaHandler) SubirFoto(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ctx := c.Request.Context()
+	receta, lector, err := h.recetaEditable(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	m, err := h.guardarFoto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := h.service.ReemplazarPortada(ctx, id, m, lector); err != nil {
+		h.descartarFoto(ctx, m)
+		_ = c.Error(err)
+		return
+	}
+	nombre := m.Archivo()
+	imagen := mapFotoToImagenDTO(nombre, m, firmarPara(*receta, lector))
+	c.JSON(http.StatusOK, FotoResponseDTO{
+		Foto:   nombre,
+		FotoID: m.ID,
+		URL:    utils.URLAbsoluta(c, imagen.Src),
+		Imagen: imagen,
+	})
+}
+
+// EliminarFoto godoc
+// @Summary Quita la foto de una receta
+// @Description Quita la portada de la galería (la siguiente foto pasa a ser la portada) y después borra sus archivos
+// @Description si nadie más los usa. Si no tenía foto, no hace nada.
+// @Tags Recetas
+// @Param id path uint true "ID de la Receta"
+// @Success 204 "Foto eliminada"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/foto [delete]
+func (h *RecetaHandler) EliminarFoto(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.QuitarPortada(c.Request.Context(), id, lectorDesdeContexto(c)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// recetaEditable obtiene la receta si quien hace la petición puede editarla (autor o editor).
+// Los endpoints de fotos la comprueban antes de escribir en el almacenamiento, y con ella
+// deciden si firmar las URLs de la respuesta.
+func (h *RecetaHandler) recetaEditable(c *gin.Context, id uint) (*Receta, Lector, error) {
+	lector := lectorDesdeContexto(c)
+	receta, err := h.service.ObtenerVisible(c.Request.Context(), id, lector)
+	if err != nil {
+		return nil, lector, err
+	}
+	if !receta.EditablePor(lector) {
+		return nil, lector, ErrRecetaSoloAutor
+	}
+	return receta, lector, nil
+}
+
+// guardarFoto valida la imagen del campo 'foto', la guarda con sus variantes y la registra en 'media'.
+// Quien la llama ya comprobó con recetaEditable que puede cambiar las fotos de la receta.
+func (h *RecetaHandler) guardarFoto(c *gin.Context) (*media.Media, error) {
+	file, err := c.FormFile("foto")
+	if err != nil {
+		return nil, fmt.Errorf("%w: falta el archivo 'foto' (multipart/form-data): %v", utils.ErrImagenInvalida, err)
+	}
+	ctx := c.Request.Context()
+
+	guardada, err := utils.GuardarImagen(ctx, h.almacen, file, CarpetaFotos, h.limitesFoto)
+	if err != nil {
+		return nil, err
+	}
+	nombre := path.Base(guardada.Clave)
+	// Genera thumb, card y full (que reemplaza al original) en JPEG/PNG y WebP, sin metadatos EXIF/GPS.
+	// También en una subida repetida: GuardarImagen acaba de reescribir el original en la clave de "full".
+	variantes, err := imagenes.Procesar(ctx, h.almacen, guardada.Clave, guardada.Datos, imagenes.TamanosPorDefecto)
+	if err != nil {
+		if !guardada.Existente {
+			h.eliminarFotos(ctx, []string{nombre})
+		}
+		if errors.Is(err, imagenes.ErrImagenIlegible) {
+			err = fmt.Errorf("%w: %v", utils.ErrImagenInvalida, err)
+		}
+		return nil, err
+	}
+	// El marcador se muestra mientras carga la foto: si no se puede calcular, la foto sirve igual.
+	marcador, err := imagenes.MarcadorDe(ctx, h.almacen, guardada.Clave, variantes)
+	if err != nil {
+		log.Printf("Handler: %v\n", err)
+	}
+	m, err := h.media.Registrar(ctx, media.RegistrarInput{
+		Clave:         guardada.Clave,
+		Tipo:          guardada.Tipo,
+		Bytes:         guardada.Bytes,
+		Hash:          guardada.Hash,
+		Ancho:         anchoFull(variantes, guardada.Ancho),
+		Alto:          altoFull(variantes, guardada.Alto),
+		Variantes:     variantes,
+		Marcador:      marcador,
+		PropietarioID: usuarioOpcional(c),
+	})
+	if err != nil {
+		if !guardada.Existente {
+			h.eliminarFotos(ctx, imagenes.Archivos(nombre, variantes))
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// descartarFoto borra una foto recién registrada que la receta no llegó a usar
+// (si otra receta la usa, se conserva).
+func (h *RecetaHandler) descartarFoto(ctx context.Context, m *media.Media) {
+	if err := h.media.EliminarSiHuerfano(context.WithoutCancel(ctx), m.ID); err != nil {
+		log.Printf("Handler: %v\n", err)
+	}
+}
+
+// --- Galería de fotos ---
+
+// GetFotos godoc
+// @Summary Lista la galería de fotos de una receta
+// @Description En orden. La portada es la marcada o, si no hay ninguna, la primera.
+// @Description Como el detalle, las recetas no publicadas solo las ven su autor y los editores (con URLs firmadas).
+// @Tags Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Success 200 {array} RecetaFotoResponseDTO "Fotos"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/fotos [get]
+func (h *RecetaHandler) GetFotos(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	receta, err := h.service.GetByID(c.Request.Context(), id) // Precarga la galería
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	lector := lectorDesdeContexto(c)
+	if !receta.VisiblePara(lector) {
+		_ = c.Error(ErrRecetaNotFound)
+		return
+	}
+	c.JSON(http.StatusOK, mapFotosToResponseDTO(receta.Fotos, firmarPara(*receta, lector)))
+}
+
+// AgregarFotoGaleria godoc
+// @Summary Añade una foto a la galería de una receta
+// @Description Recibe la imagen en el campo 'foto' (multipart/form-data), con el mismo procesado que POST /recetas/{id}/foto,
+// @Description y la añade al final de la galería. Si se marca como portada, la receta pasa a mostrarla como su foto.
+// @Tags Recetas
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param foto formData file true "Imagen JPEG o PNG"
+// @Param leyenda formData string false "Leyenda (máx. 300 caracteres)"
+// @Param texto_alt formData string false "Texto alternativo (máx. 300 caracteres)"
+// @Param portada formData bool false "Marcarla como portada"
+// @Success 201 {object} RecetaFotoResponseDTO "Foto añadida"
+// @Failure 400 {object} apitypes.ErrorResponse "Archivo ausente, imagen no válida o textos demasiado largos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 409 {object} apitypes.ErrorResponse "La imagen ya está en la galería o la galería está llena"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/fotos [post]
+func (h *RecetaHandler) AgregarFotoGaleria(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var requestBody RecetaFotoRequestDTO
+	if err := c.ShouldBind(&requestBody); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	ctx := c.Request.Context()
+	receta, lector, err := h.recetaEditable(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	m, err := h.guardarFoto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	foto, err := h.service.AgregarFoto(ctx, id, m, mapFotoRequestToInput(requestBody), lector)
+	if err != nil {
+		h.descartarFoto(ctx, m)
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, mapFotoToResponseDTO(*foto, firmarPara(*receta, lector)))
+}
+
+// EditarFotoGaleria godoc
+// @Summary Edita una foto de la galería
+// @Description Cambia la leyenda, el texto alternativo o la marca de portada. Los campos omitidos no cambian.
+// @Tags Recetas
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param fotoId path uint true "ID de la foto"
+// @Param foto body RecetaFotoRequestDTO true "Cambios"
+// @Success 200 {object} RecetaFotoResponseDTO "Foto editada"
+// @Failure 400 {object} apitypes.ErrorResponse "Textos demasiado largos"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta o foto no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/fotos/{fotoId} [patch]
+func (h *RecetaHandler) EditarFotoGaleria(c *gin.Context) {
+	id, fotoID, err := parseRecetaYFoto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var requestBody RecetaFotoRequestDTO
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	receta, lector, err := h.recetaEditable(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	foto, err := h.service.EditarFoto(c.Request.Context(), id, fotoID, mapFotoRequestToInput(requestBody), lector)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapFotoToResponseDTO(*foto, firmarPara(*receta, lector)))
+}
+
+// ReordenarFotos godoc
+// @Summary Reordena la galería de una receta
+// @Description Recibe los IDs de todas las fotos de la galería en el nuevo orden.
+// @Tags Recetas
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param orden body OrdenFotosRequestDTO true "Nuevo orden"
+// @Success 200 {array} RecetaFotoResponseDTO "Galería reordenada"
+// @Failure 400 {object} apitypes.ErrorResponse "Faltan fotos, sobran o se repiten"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/fotos [put]
+func (h *RecetaHandler) ReordenarFotos(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var requestBody OrdenFotosRequestDTO
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	receta, lector, err := h.recetaEditable(c, id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	fotos, err := h.service.ReordenarFotos(c.Request.Context(), id, requestBody.IDs, lector)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapFotosToResponseDTO(fotos, firmarPara(*receta, lector)))
+}
+
+// QuitarFotoGaleria godoc
+// @Summary Quita una foto de la galería
+// @Description Si era la portada, pasa a serlo la primera de las restantes. Los archivos se borran si nadie más los usa.
+// @Tags Recetas
+// @Param id path uint true "ID de la Receta"
+// @Param fotoId path uint true "ID de la foto"
+// @Success 204 "Foto quitada"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta ni editor"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta o foto no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /recetas/{id}/fotos/{fotoId} [delete]
+func (h *RecetaHandler) QuitarFotoGaleria(c *gin.Context) {
+	id, fotoID, err := parseRecetaYFoto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.QuitarFoto(c.Request.Context(), id, fotoID, lectorDesdeContexto(c)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// parseRecetaYFoto extrae los IDs de receta y de foto de la URL.
+func parseRecetaYFoto(c *gin.Context) (uint, uint, error) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		return 0, 0, err
+	}
+	fotoID, err := strconv.ParseUint(c.Param("fotoId"), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %q", ErrRecetaFotoNotFound, c.Param("fotoId"))
+	}
+	return id, uint(fotoID), nil
+}
+
+// mapFotoRequestToInput convierte la petición en la entrada del servicio.
+func mapFotoRequestToInput(dto RecetaFotoRequestDTO) RecetaFotoInputDTO {
+	return RecetaFotoInputDTO{Leyenda: dto.Leyenda, TextoAlt: dto.TextoAlt, Portada: dto.Portada}
+}
+
+func mapFotoToResponseDTO(foto RecetaFoto, privada bool) RecetaFotoResponseDTO {
+	return RecetaFotoResponseDTO{
+		ID:       foto.ID,
+		MediaID:  foto.MediaID,
+		Orden:    foto.Orden,
+		Leyenda:  foto.Leyenda,
+		TextoAlt: foto.TextoAlt,
+		Portada:  foto.Portada,
+		Imagen:   mapFotoToImagenDTO("", foto.Media, privada),
+	}
+}
+
+// mapFotosToResponseDTO mapea la galería. La portada calculada sale marcada aunque no lo esté en la BD.
+func mapFotosToResponseDTO(fotos []RecetaFoto, privada bool) []RecetaFotoResponseDTO {
+	dtos := make([]RecetaFotoResponseDTO, len(fotos))
+	portada := PortadaDe(fotos)
+	for i, f := range fotos {
+		dtos[i] = mapFotoToResponseDTO(f, privada)
+		dtos[i].Portada = portada != nil && f.ID == portada.ID
+	}
+	return dtos
+}
+
+// eliminarFotos borra del almacenamiento archivos recién subidos que no llegaron a registrarse. Un fallo
+// solo se loguea: los archivos sobrantes no afectan a la respuesta.
+func (h *RecetaHandler) eliminarFotos(ctx context.Context, archivos []string) {
+	ctx = context.WithoutCancel(ctx) // Limpiar aunque el cliente haya cortado la conexión
+	for _, archivo := range archivos {
+		if err := utils.EliminarImagen(ctx, h.almacen, CarpetaFotos, archivo); err != nil {
+			log.Printf("Handler: %v\n", err)
+		}
+	}
+}
+
+// anchoFull y altoFull son las dimensiones de la variante principal (la original si no hay variantes).
+func anchoFull(variantes []imagenes.Variante, original int) int {
+	for _, v := range variantes {
+		if v.Nombre == imagenes.VarianteFull {
+			return v.Ancho
+		}
+	}
+	return original
+}
+
+func altoFull(variantes []imagenes.Variante, original int) int {
+	for _, v := range variantes {
+		if v.Nombre == imagenes.VarianteFull {
+			return v.Alto
+		}
+	}
+	return original
+}
+
+// mapFotoToImagenDTO arma la imagen de una foto a partir de su registro en 'media', sin consultar el
+// almacenamiento. Las fotos sin registro (anteriores a la tabla o URLs externas) solo tienen Src.
+// Con 'privada' (receta no publicada que el lector puede ver, ver firmarPara) las URLs van firmadas.
+func mapFotoToImagenDTO(foto string, m *media.Media, privada bool) *ImagenDTO {
+	if m != nil {
+		dto := mapVariantesToImagenDTO(m.Carpeta(), m.Variantes, privada)
+		if dto == nil {
+			dto = &ImagenDTO{Src: urlArchivo(m.Carpeta(), m.Archivo(), privada), Ancho: m.Ancho, Alto: m.Alto}
+		}
+		dto.BlurHash, dto.Color = m.BlurHash, m.Color
+		return dto
+	}
+	if foto == "" {
+		return nil
+	}
+	if esURLExterna(foto) {
+		return &ImagenDTO{Src: foto}
+	}
+	return &ImagenDTO{Src: urlArchivo(CarpetaFotos, path.Base(foto), privada)}
+}
+
+// esURLExterna indica si la foto es una URL absoluta (no un archivo del almacenamiento).
+func esURLExterna(foto string) bool {
+	u, err := url.Parse(foto)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// mapVariantesToImagenDTO arma el srcset de las variantes guardadas en 'carpeta' (nil si la foto no tiene).
+func mapVariantesToImagenDTO(carpeta string, variantes []imagenes.Variante, privada bool) *ImagenDTO {
+	if len(variantes) == 0 {
+		return nil
+	}
+	dto := &ImagenDTO{Variantes: make([]VarianteDTO, 0, len(variantes))}
+	var srcset, srcsetWebP []string
+	for _, v := range variantes {
+		url := urlArchivo(carpeta, v.Archivo, privada)
+		urlWebP := urlArchivo(carpeta, v.ArchivoWebP, privada)
+		dto.Variantes = append(dto.Variantes, VarianteDTO{Nombre: v.Nombre, Ancho: v.Ancho, Alto: v.Alto, URL: url, URLWebP: urlWebP})
+		srcset = append(srcset, fmt.Sprintf("%s %dw", url, v.Ancho))
+		srcsetWebP = append(srcsetWebP, fmt.Sprintf("%s %dw", urlWebP, v.Ancho))
+		if v.Ancho >= dto.Ancho {
+			dto.Src, dto.Ancho, dto.Alto = url, v.Ancho, v.Alto
+		}
+	}
+	dto.Srcset = strings.Join(srcset, ", ")
+	dto.SrcsetWebP = strings.Join(srcsetWebP, ", ")
+	return dto
+}
+
+// urlArchivo es la URL de un archivo del almacenamiento: la pública o, si es 'privada', una firmada
+// con caducidad (ver storage.URLPrivada). Usa el almacenamiento predeterminado porque otros
+// paquetes (favoritos, planificador) también mapean recetas.
+func urlArchivo(carpeta, archivo string, privada bool) string {
+	clave := path.Join(carpeta, archivo)
+	if privada {
+		return storage.URLPrivada(clave)
+	}
+	return storage.Predeterminado().URL(clave)
+}
+
+// --- Historial de revisiones ---
+
+func mapRevisionToResponseDTO(r RecetaRevision, conDescripcion bool) RevisionResponseDTO {
+	dto := RevisionResponseDTO{
+		Numero:            r.Numero,
+		AutorID:           r.AutorID,
+		Nombre:            r.Nombre,
+		Slug:              r.Slug,
+		TiempoPreparacion: r.TiempoPreparacion,
+		Porciones:         r.Porciones,
+		Foto:              r.Foto,
+		CategoriaID:       r.CategoriaID,
+		CreatedAt:         r.CreatedAt.Format(time.RFC3339),
+	}
+	if conDescripcion {
+		dto.Descripcion = r.Descripcion
+	}
+	return dto
+}
+
+// parseRecetaYRevision obtiene el ID de la receta y el número de revisión de la URL.
+func parseRecetaYRevision(c *gin.Context) (uint, int, error) {
+	idStr := c.Param("id")
+	idUint64, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("ID de receta inválido en URL: %s - %w", idStr, err)
+	}
+	numero, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || numero < 1 {
+		return 0, 0, fmt.Errorf("%w: %q", ErrRevisionNotFound, c.Param("rev"))
+	}
+	return uint(idUint64), numero, nil
+}
+
+// GetRevisiones godoc
+// @Summary Lista el historial de revisiones de una receta
+// @Description Cada creación y actualización guarda una revisión inmutable con su autor y fecha. La más reciente primero (sin la descripción completa).
+// @Tags Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Success 200 {array} RevisionResponseDTO "Revisiones"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada (o no publicada y el usuario no es su autor ni editor)"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /recetas/{id}/revisiones [get]
+func (h *RecetaHandler) GetRevisiones(c *gin.Context) {
+	idStr := c.Param("id")
+	idUint64, errConv := strconv.ParseUint(idStr, 10, 32)
+	if errConv != nil {
+		_ = c.Error(fmt.Errorf("ID de receta inválido en URL: %s - %w", idStr, errConv))
+		return
+	}
+	revisiones, err := h.service.ListarRevisiones(c.Request.Context(), uint(idUint64), lectorDesdeContexto(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]RevisionResponseDTO, 0, len(revisiones))
+	for _, r := range revisiones {
+		responseDTOs = append(responseDTOs, mapRevisionToResponseDTO(r, false))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// GetRevision godoc
+// @Summary Obtiene una revisión de una receta
+// @Tags Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param rev path int true "Número de revisión"
+// @Success 200 {object} RevisionResponseDTO "Revisión completa"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta o revisión no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /recetas/{id}/revisiones/{rev} [get]
+func (h *RecetaHandler) GetRevision(c *gin.Context) {
+	recetaID, numero, err := parseRecetaYRevision(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	revision, err := h.service.ObtenerRevision(c.Request.Context(), recetaID, numero, lectorDesdeContexto(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapRevisionToResponseDTO(*revision, true))
+}
+
+// DiffRevision godoc
+// @Summary Compara una revisión con otra, campo a campo
+// @Description Por defecto compara con la revisión inmediatamente anterior. Use ?desde=N para elegir otra (0 = revisión vacía).
+// @Tags Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param rev path int true "Número de revisión"
+// @Param desde query int false "Revisión con la que comparar (por defecto rev-1)"
+// @Success 200 {object} DiffRevisionesResponseDTO "Campos modificados"
+// @Failure 400 {object} apitypes.ErrorResponse "ID inválido"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta o revisión no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /recetas/{id}/revisiones/{rev}/diff [get]
+func (h *RecetaHandler) DiffRevision(c *gin.Context) {
+	recetaID, numero, err := parseRecetaYRevision(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	desde := numero - 1
+	if v := c.Query("desde"); v != "" {
+		if desde, err = strconv.Atoi(v); err != nil || desde < 0 {
+			_ = c.Error(fmt.Errorf("%w: %q", ErrRevisionNotFound, v))
+			return
+		}
+	}
+
+	cambios, err := h.service.CompararRevisiones(c.Request.Context(), recetaID, desde, numero, lectorDesdeContexto(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	response := DiffRevisionesResponseDTO{Desde: desde, Hasta: numero, Cambios: make([]CambioCampoDTO, 0, len(cambios))}
+	for _, cc := range cambios {
+		response.Cambios = append(response.Cambios, CambioCampoDTO{Campo: cc.Campo, Antes: cc.Antes, Despues: cc.Despues})
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// RestaurarRevision godoc
+// @Summary Restaura una receta a una revisión anterior
+// @Description Copia el contenido de la revisión en la receta. El historial no se pierde: la restauración se guarda como una revisión nueva. Solo el autor de la receta o un editor.
+// @Tags Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param rev path int true "Número de revisión a restaurar"
+// @Success 200 {object} RecetaResponseDTO "Receta restaurada"
+// @Failure 400 {object} apitypes.ErrorResponse "La categoría de la revisión ya no existe"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor de la receta"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta o revisión no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno del servidor"
+// @Router /recetas/{id}/revisiones/{rev}/restaurar [post]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) RestaurarRevision(c *gin.Context) {
+	recetaID, numero, err := parseRecetaYRevision(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := usuarioIDDesdeContexto(c); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	receta, err := h.service.RestaurarRevision(c.Request.Context(), recetaID, numero, lectorDesdeContexto(c))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainRecetaToResponseDTO(*receta, lectorDesdeContexto(c)))
+}
+
+// --- Flujo de publicación ---
+
+// usuarioIDDesdeContexto obtiene el ID del usuario autenticado (lo deja el middleware de Auth).
+func usuarioIDDesdeContexto(c *gin.Context) (uint, error) {
+	if uid := usuarioOpcional(c); uid != nil {
+		return *uid, nil
+	}
+	return 0, errUsuarioNoIdentificado
+}
+
+// parseRecetaID obtiene el :id de la URL.
+func parseRecetaID(c *gin.Context) (uint, error) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("ID de receta inválido en URL: %s - %w", idStr, err)
+	}
+	return uint(id), nil
+}
+
+// cambiarEstado aplica el cambio de estado pedido en el cuerpo, como autor o como editor.
+func (h *RecetaHandler) cambiarEstado(c *gin.Context, comoEditor bool) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var req CambioEstadoRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	receta, err := h.service.CambiarEstado(c.Request.Context(), id, CambioEstadoInput{
+		Estado:     EstadoReceta(req.Estado),
+		PublicarEn: req.PublicarEn,
+		UsuarioID:  usuarioOpcional(c),
+		ComoEditor: comoEditor,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainRecetaToResponseDTO(*receta, lectorDesdeContexto(c)))
+}
+
+// GetMisRecetas godoc
+// @Summary Lista mis recetas
+// @Description Devuelve las recetas del usuario autenticado en cualquier estado (borradores incluidos).
+// @Tags Recetas
+// @Produce json
+// @Success 200 {array} RecetaResponseDTO "Recetas del autor, las más recientes primero"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /me/recetas [get]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) GetMisRecetas(c *gin.Context) {
+	userID, err := usuarioIDDesdeContexto(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	domainRecetas, err := h.service.ListarDeAutor(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := mapDomainRecetasToResponseDTOs(domainRecetas, lectorDesdeContexto(c))
+	h.marcarFavoritos(c, responseDTOs)
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// CambiarEstado godoc
+// @Summary Envía a revisión o retira una receta propia
+// @Description El autor puede pasar su receta de borrador a en_revision y viceversa. Publicar y archivar lo hacen los editores.
+// @Tags Recetas
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param estado body CambioEstadoRequestDTO true "Nuevo estado"
+// @Success 200 {object} RecetaResponseDTO "Receta con su nuevo estado"
+// @Failure 400 {object} apitypes.ErrorResponse "Estado inválido"
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es el autor o el estado requiere un editor"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 409 {object} apitypes.ErrorResponse "Transición no permitida desde el estado actual"
+// @Router /recetas/{id}/estado [patch]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) CambiarEstado(c *gin.Context) {
+	if _, err := usuarioIDDesdeContexto(c); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	h.cambiarEstado(c, false)
+}
+
+// GetRecetasAdmin godoc
+// @Summary Lista recetas por estado (editores)
+// @Tags Recetas
+// @Produce json
+// @Param estado query string false "borrador, en_revision, publicada o archivada (vacío = todas)"
+// @Success 200 {array} RecetaResponseDTO "Recetas, las más recientes primero"
+// @Failure 400 {object} apitypes.ErrorResponse "Estado inválido"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas [get]
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es editor"
+// @Security ApiKeyAuth
+func (h *RecetaHandler) GetRecetasAdmin(c *gin.Context) {
+	var estado *EstadoReceta
+	if v := c.Query("estado"); v != "" {
+		e := EstadoReceta(v)
+		estado = &e
+	}
+	domainRecetas, err := h.service.ListarPorEstado(c.Request.Context(), estado)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainRecetasToResponseDTOs(domainRecetas, lectorDesdeContexto(c)))
+}
+
+// CambiarEstadoAdmin godoc
+// @Summary Cambia el estado de una receta (editores)
+// @Description Publica (en el momento o programada con publicar_en futura), archiva o devuelve a borrador.
+// @Tags Recetas
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param estado body CambioEstadoRequestDTO true "Nuevo estado"
+// @Success 200 {object} RecetaResponseDTO "Receta con su nuevo estado"
+// @Failure 400 {object} apitypes.ErrorResponse "Estado o publicar_en inválidos"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 409 {object} apitypes.ErrorResponse "Transición no permitida desde el estado actual"
+// @Router /admin/recetas/{id}/estado [patch]
+// @Failure 401 {object} apitypes.ErrorResponse "No autenticado"
+// @Failure 403 {object} apitypes.ErrorResponse "No es editor"
+// @Security ApiKeyAuth
+func (h *RecetaHandler) CambiarEstadoAdmin(c *gin.Context) {
+	h.cambiarEstado(c, true)
+}
+
+// --- Papelera ---
+
+// GetPapelera godoc
+// @Summary Lista las recetas de la papelera
+// @Tags Recetas
+// @Produce json
+// @Success 200 {array} RecetaResponseDTO "Recetas eliminadas (con eliminada_en), las más recientes primero"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas/papelera [get]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) GetPapelera(c *gin.Context) {
+	domainRecetas, err := h.service.ListarPapelera(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainRecetasToResponseDTOs(domainRecetas, lectorDesdeContexto(c)))
+}
+
+// RestaurarDePapelera godoc
+// @Summary Restaura una receta de la papelera
+// @Description Si otra receta ocupa su slug, se restaura con el primer 'slug-N' libre.
+// @Tags Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Success 200 {object} RecetaResponseDTO "Receta restaurada"
+// @Failure 400 {object} apitypes.ErrorResponse "Su categoría está eliminada"
+// @Failure 404 {object} apitypes.ErrorResponse "La receta no está en la papelera"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas/papelera/{id}/restaurar [post]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) RestaurarDePapelera(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	receta, err := h.service.RestaurarDePapelera(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapDomainRecetaToResponseDTO(*receta, lectorDesdeContexto(c)))
+}
+
+// PurgarDePapelera godoc
+// @Summary Borra definitivamente una receta de la papelera
+// @Tags Recetas
+// @Param id path uint true "ID de la Receta"
+// @Success 204 "Receta purgada"
+// @Failure 404 {object} apitypes.ErrorResponse "La receta no está en la papelera"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas/papelera/{id} [delete]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) PurgarDePapelera(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.PurgarDePapelera(c.Request.Context(), id); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// --- Traducciones ---
+
+func mapTraduccionToResponseDTO(t RecetaTraduccion) RecetaTraduccionResponseDTO {
+	return RecetaTraduccionResponseDTO{
+		Idioma:      string(t.Idioma),
+		Nombre:      t.Nombre,
+		Slug:        t.Slug,
+		Descripcion: t.Descripcion,
+		UpdatedAt:   t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// GetTraducciones godoc
+// @Summary Lista las traducciones de una receta
+// @Tags Recetas
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Success 200 {array} RecetaTraduccionResponseDTO "Traducciones, ordenadas por idioma"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas/{id}/traducciones [get]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) GetTraducciones(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	traducciones, err := h.service.ListarTraducciones(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]RecetaTraduccionResponseDTO, 0, len(traducciones))
+	for _, t := range traducciones {
+		responseDTOs = append(responseDTOs, mapTraduccionToResponseDTO(t))
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
+
+// GuardarTraduccion godoc
+// @Summary Crea o reemplaza la traducción de una receta
+// @Description El slug se genera a partir del nombre y es único dentro del idioma.
+// @Tags Recetas
+// @Accept json
+// @Produce json
+// @Param id path uint true "ID de la Receta"
+// @Param idioma path string true "Idioma soportado distinto del idioma por defecto" example:"en"
+// @Param traduccion body RecetaTraduccionRequestDTO true "Contenido traducido"
+// @Success 200 {object} RecetaTraduccionResponseDTO "Traducción guardada"
+// @Failure 400 {object} apitypes.ErrorResponse "Datos inválidos o idioma no traducible"
+// @Failure 404 {object} apitypes.ErrorResponse "Receta no encontrada"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas/{id}/traducciones/{idioma} [put]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) GuardarTraduccion(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	var requestBody RecetaTraduccionRequestDTO
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	input := RecetaTraduccionInputDTO{Nombre: requestBody.Nombre, Descripcion: requestBody.Descripcion}
+	traduccion, err := h.service.GuardarTraduccion(c.Request.Context(), id, i18n.Idioma(c.Param("idioma")), input)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, mapTraduccionToResponseDTO(*traduccion))
+}
+
+// EliminarTraduccion godoc
+// @Summary Elimina la traducción de una receta
+// @Tags Recetas
+// @Param id path uint true "ID de la Receta"
+// @Param idioma path string true "Idioma de la traducción" example:"en"
+// @Success 204 "Traducción eliminada"
+// @Failure 400 {object} apitypes.ErrorResponse "Idioma no traducible"
+// @Failure 404 {object} apitypes.ErrorResponse "La receta no tiene traducción a ese idioma"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas/{id}/traducciones/{idioma} [delete]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) EliminarTraduccion(c *gin.Context) {
+	id, err := parseRecetaID(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.service.EliminarTraduccion(c.Request.Context(), id, i18n.Idioma(c.Param("idioma"))); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetTraduccionesFaltantes godoc
+// @Summary Lista las recetas a las que les falta alguna traducción
+// @Description Sin 'idioma', considera todos los idiomas soportados distintos del idioma por defecto.
+// @Tags Recetas
+// @Produce json
+// @Param idioma query string false "Idioma a comprobar" example:"en"
+// @Success 200 {array} TraduccionesFaltantesDTO "Recetas (fuera de la papelera) sin traducir, las más recientes primero"
+// @Failure 400 {object} apitypes.ErrorResponse "Idioma no traducible"
+// @Failure 500 {object} apitypes.ErrorResponse "Error interno"
+// @Router /admin/recetas/traducciones/faltantes [get]
+// @Security ApiKeyAuth
+func (h *RecetaHandler) GetTraduccionesFaltantes(c *gin.Context) {
+	var idioma *i18n.Idioma
+	if v := c.Query("idioma"); v != "" {
+		i := i18n.Idioma(v)
+		idioma = &i
+	}
+	faltantes, err := h.service.ListarTraduccionesFaltantes(c.Request.Context(), idioma)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	responseDTOs := make([]TraduccionesFaltantesDTO, 0, len(faltantes))
+	for _, f := range faltantes {
+		idiomas := make([]string, 0, len(f.Idiomas))
+		for _, i := range f.Idiomas {
+			idiomas = append(idiomas, string(i))
+		}
+		responseDTOs = append(responseDTOs, TraduccionesFaltantesDTO{
+			RecetaID:         f.Receta.ID,
+			Nombre:           f.Receta.Nombre,
+			Slug:             f.Receta.Slug,
+			Estado:           string(f.Receta.Estado),
+			IdiomasFaltantes: idiomas,
+		})
+	}
+	c.JSON(http.StatusOK, responseDTOs)
+}
@@ -19,7 +19,10 @@ package recetas // Paquete de la característica 'recetas'
 
 // Importar el DTO de respuesta de categoría del paquete 'categorias'
 // para poder anidarlo en nuestra RecetaResponseDTO.
-import "backend/categorias"
+import (
+	"backend/categorias"
+	"time"
+)
 
 // RecetaRequestDTO define la estructura para crear/actualizar recetas desde la API.
 // Utiliza tags 'json' para el binding del cuerpo de la petición y 'binding' para validaciones de Gin.
@@ -28,6 +31,7 @@ type RecetaRequestDTO struct {
 	CategoriaID       uint   `json:"categoria_id" binding:"required,gt=0" example:"1"`                   // @description ID de la categoría a la que pertenece
 	TiempoPreparacion string `json:"tiempo_preparacion" binding:"required,max=50" example:"1 hora 30 mins"` // @description Tiempo estimado de preparación
 	Descripcion       string `json:"descripcion" binding:"required" example:"Una deliciosa paella tradicional..."` // @description Pasos o descripción de la receta
+	Porciones         int    `json:"porciones,omitempty" binding:"omitempty,min=1,max=100" example:"4"`   // @description Porciones que rinde (opcional, por defecto 4)
 	Foto              string `json:"foto,omitempty" example:"paella.jpg"`                               // @description Nombre del archivo de imagen o URL (opcional en request)
 	// Ingredientes se manejarán probablemente a través de otro mecanismo o un array de IDs/structs.
 	// Ej: IngredientesID []uint `json:"ingredientes_id,omitempty"`
@@ -42,9 +46,138 @@ type RecetaResponseDTO struct {
 	Nombre            string                          `json:"nombre" example:"Paella de Mariscos"`
 	Slug              string                          `json:"slug" example:"paella-de-mariscos"`
 	TiempoPreparacion string                          `json:"tiempo_preparacion" example:"1 hora 30 mins"`
+	Porciones         int                             `json:"porciones" example:"4"`
 	Descripcion       string                          `json:"descripcion" example:"Una deliciosa paella tradicional..."`
 	Foto              string                          `json:"foto,omitempty" example:"uploads/recetas/paella.jpg"` // URL completa o path relativo accesible
+	FotoID            *uint                           `json:"foto_id,omitempty" example:"12"` // Registro del archivo en 'media' (no en fotos antiguas o externas)
+	Imagen            *ImagenDTO                      `json:"imagen,omitempty"` // URL y variantes de la foto (solo Src en fotos anteriores al procesado)
+	Fotos             []RecetaFotoResponseDTO         `json:"fotos,omitempty"` // Galería, en orden (solo en el detalle). La portada es Foto/Imagen
 	CreatedAt         string                          `json:"created_at" example:"2025-05-17T10:00:00Z"` // Formato consistente (ej: RFC3339)
 	UpdatedAt         string                          `json:"updated_at" example:"2025-05-17T10:00:00Z"` // Formato consistente
 	Categoria         categorias.CategoriaResponseDTO `json:"categoria"` // Objeto de Categoría anidado (usando el DTO de 'categorias')  
-}
\ No newline at end of file
+	EsFavorito        *bool                           `json:"es_favorito,omitempty" example:"true"` // Solo si la petición está autenticada
+	Estado            string                          `json:"estado" example:"publicada"` // borrador, en_revision, publicada o archivada
+	PublicarEn        *string                         `json:"publicar_en,omitempty" example:"2025-05-20T08:00:00Z"` // Fecha de publicación (futura = programada)
+	EliminadaEn       *string                         `json:"eliminada_en,omitempty" example:"2025-05-21T09:00:00Z"` // Solo en la papelera
+	Idioma            string                          `json:"idioma" example:"es"` // Idioma de nombre, slug y descripción (el pedido o, sin traducción, el por defecto)
+}
+// --- Historial de revisiones ---
+
+// RevisionResponseDTO es una revisión (versión inmutable) de una receta.
+type RevisionResponseDTO struct {
+	Numero            int    `json:"numero" example:"3"`
+	AutorID           *uint  `json:"autor_id,omitempty" example:"9"` // Ausente si se guardó sin autenticación
+	Nombre            string `json:"nombre" example:"Paella de Mariscos"`
+	Slug              string `json:"slug" example:"paella-de-mariscos"`
+	TiempoPreparacion string `json:"tiempo_preparacion" example:"1 hora 30 mins"`
+	Porciones         int    `json:"porciones" example:"4"`
+	Descripcion       string `json:"descripcion,omitempty" example:"Una deliciosa paella tradicional..."` // Solo en el detalle
+	Foto              string `json:"foto,omitempty" example:"paella.jpg"`
+	CategoriaID       uint   `json:"categoria_id" example:"1"`
+	CreatedAt         string `json:"created_at" example:"2025-05-17T10:00:00Z"`
+}
+
+// CambioCampoDTO es la diferencia de un campo entre dos revisiones.
+type CambioCampoDTO struct {
+	Campo   string `json:"campo" example:"tiempo_preparacion"`
+	Antes   string `json:"antes" example:"1 hora"`
+	Despues string `json:"despues" example:"1 hora 30 mins"`
+}
+
+// DiffRevisionesResponseDTO es la comparación campo a campo de dos revisiones.
+type DiffRevisionesResponseDTO struct {
+	Desde   int              `json:"desde" example:"2"` // 0 = revisión vacía
+	Hasta   int              `json:"hasta" example:"3"`
+	Cambios []CambioCampoDTO `json:"cambios"`
+}
+
+// --- Flujo de publicación ---
+
+// CambioEstadoRequestDTO pide mover una receta a otro estado.
+type CambioEstadoRequestDTO struct {
+	Estado     string     `json:"estado" binding:"required" example:"publicada"`              // borrador, en_revision, publicada o archivada
+	PublicarEn *time.Time `json:"publicar_en,omitempty" example:"2025-05-20T08:00:00Z"` // Solo al publicar: fecha futura = programar
+}
+
+// FotoResponseDTO es la foto de una receta tras subirla.
+type FotoResponseDTO struct {
+	Foto   string     `json:"foto" example:"20250517_100000_1a2b3c4d.jpg"` // Valor guardado en la receta
+	FotoID uint       `json:"foto_id" example:"12"` // Registro del archivo en 'media'
+	URL    string     `json:"url" example:"http://localhost:8080/uploads/recetas/20250517_100000_1a2b3c4d.jpg?expira=1747476000&firma=..."` // Firmada con caducidad si la receta no es pública
+	Imagen *ImagenDTO `json:"imagen"` // Variantes generadas
+}
+
+// --- Galería de fotos ---
+
+// RecetaFotoRequestDTO son los datos de una foto de la galería: campos del formulario al
+// añadirla, cuerpo JSON al editarla. Los omitidos no cambian.
+type RecetaFotoRequestDTO struct {
+	Leyenda  *string `json:"leyenda" form:"leyenda" binding:"omitempty,max=300" example:"Emplatado final"`
+	TextoAlt *string `json:"texto_alt" form:"texto_alt" binding:"omitempty,max=300" example:"Paella en su paellera vista desde arriba"`
+	Portada  *bool   `json:"portada" form:"portada" example:"true"`
+}
+
+// OrdenFotosRequestDTO es el nuevo orden de la galería: los IDs de todas sus fotos.
+type OrdenFotosRequestDTO struct {
+	IDs []uint `json:"ids" binding:"required" example:"3,1,2"`
+}
+
+// RecetaFotoResponseDTO es una foto de la galería de una receta.
+type RecetaFotoResponseDTO struct {
+	ID       uint       `json:"id" example:"5"`
+	MediaID  uint       `json:"media_id" example:"12"` // Registro del archivo en 'media'
+	Orden    int        `json:"orden" example:"1"`
+	Leyenda  string     `json:"leyenda,omitempty" example:"Emplatado final"`
+	TextoAlt string     `json:"texto_alt,omitempty" example:"Paella en su paellera vista desde arriba"`
+	Portada  bool       `json:"portada" example:"true"` // La portada calculada: la marcada o, si no hay, la primera
+	Imagen   *ImagenDTO `json:"imagen"`
+}
+
+// ImagenDTO agrupa las variantes de una foto para usarlas directamente en <img srcset> o <picture>.
+// Las URLs dependen del almacenamiento: relativas al servidor de la API (disco) o absolutas (S3, CDN).
+// Las de recetas no publicadas van firmadas y caducan (storage.firma_minutos): no deben guardarse.
+type ImagenDTO struct {
+	Src        string        `json:"src" example:"/uploads/recetas/20250517_100000_1a2b3c4d.jpg"` // Variante más grande
+	Ancho      int           `json:"ancho" example:"1600"`
+	Alto       int           `json:"alto" example:"1067"`
+	Srcset     string        `json:"srcset" example:"/uploads/recetas/20250517_100000_1a2b3c4d_thumb.jpg 320w, /uploads/recetas/20250517_100000_1a2b3c4d.jpg 1600w"`
+	SrcsetWebP string        `json:"srcset_webp" example:"/uploads/recetas/20250517_100000_1a2b3c4d_thumb.webp 320w, /uploads/recetas/20250517_100000_1a2b3c4d.webp 1600w"`
+	Variantes  []VarianteDTO `json:"variantes"` // De menor a mayor
+	BlurHash   string        `json:"blurhash,omitempty" example:"LEHV6nWB2yk8pyo0adR*.7kCMdnj"` // Para mostrar mientras carga (ver blurha.sh)
+	Color      string        `json:"color,omitempty" example:"#a0522d"`                        // Color dominante, de fondo mientras carga
+}
+
+// VarianteDTO es un tamaño concreto de una foto.
+type VarianteDTO struct {
+	Nombre  string `json:"nombre" example:"thumb"` // thumb, card o full
+	Ancho   int    `json:"ancho" example:"320"`
+	Alto    int    `json:"alto" example:"213"`
+	URL     string `json:"url" example:"/uploads/recetas/20250517_100000_1a2b3c4d_thumb.jpg"`
+	URLWebP string `json:"url_webp" example:"/uploads/recetas/20250517_100000_1a2b3c4d_thumb.webp"`
+}
+
+// --- Traducciones ---
+
+// RecetaTraduccionRequestDTO es el contenido de una receta en otro idioma (el idioma va en la URL).
+type RecetaTraduccionRequestDTO struct {
+	Nombre      string `json:"nombre" binding:"required,min=3,max=150" example:"Seafood Paella"`
+	Descripcion string `json:"descripcion" binding:"required" example:"A delicious traditional paella..."` // Descripción o pasos
+}
+
+// RecetaTraduccionResponseDTO es una traducción guardada.
+type RecetaTraduccionResponseDTO struct {
+	Idioma      string `json:"idioma" example:"en"`
+	Nombre      string `json:"nombre" example:"Seafood Paella"`
+	Slug        string `json:"slug" example:"seafood-paella"`
+	Descripcion string `json:"descripcion" example:"A delicious traditional paella..."`
+	UpdatedAt   string `json:"updated_at" example:"2025-05-17T10:00:00Z"`
+}
+
+// TraduccionesFaltantesDTO es una receta con los idiomas a los que aún no está traducida.
+type TraduccionesFaltantesDTO struct {
+	RecetaID         uint     `json:"receta_id" example:"1"`
+	Nombre           string   `json:"nombre" example:"Paella de Mariscos"`
+	Slug             string   `json:"slug" example:"paella-de-mariscos"`
+	Estado           string   `json:"estado" example:"publicada"`
+	IdiomasFaltantes []string `json:"idiomas_faltantes" example:"en"`
+}
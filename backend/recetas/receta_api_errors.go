@@ -0,0 +1,36 @@
+// backend/recetas/receta_api_errors.go
+
+// Traducción de los errores de dominio de recetas a respuestas HTTP (ver shared/apperrors).
+
+package recetas
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+)
+
+func init() {
+	apperrors.Registrar(ErrRecetaNotFound, http.StatusNotFound, "receta_no_encontrada")
+	apperrors.Registrar(ErrRevisionNotFound, http.StatusNotFound, "revision_no_encontrada")
+	apperrors.Registrar(ErrRecetaTraduccionNotFound, http.StatusNotFound, "receta_traduccion_no_encontrada")
+	apperrors.Registrar(ErrRecetaFotoNotFound, http.StatusNotFound, "receta_foto_no_encontrada")
+
+	apperrors.Registrar(ErrRecetaNombreInvalido, http.StatusBadRequest, "receta_nombre_invalido")
+	apperrors.Registrar(ErrRecetaPorcionesInvalidas, http.StatusBadRequest, "receta_porciones_invalidas")
+	apperrors.Registrar(ErrRecetaIngredientesInvalidos, http.StatusBadRequest, "receta_ingredientes_invalidos")
+	apperrors.Registrar(ErrEstadoRecetaInvalido, http.StatusBadRequest, "receta_estado_invalido")
+	apperrors.Registrar(ErrPublicarEnInvalido, http.StatusBadRequest, "receta_publicar_en_invalido")
+	// Por ejemplo, crear una receta con un CategoriaID que no existe.
+	apperrors.Registrar(ErrRecetaSinCategoria, http.StatusBadRequest, "receta_sin_categoria")
+	apperrors.Registrar(ErrRecetaFotoTextoInvalido, http.StatusBadRequest, "receta_foto_texto_invalido")
+	apperrors.Registrar(ErrOrdenFotosInvalido, http.StatusBadRequest, "receta_orden_fotos_invalido")
+
+	apperrors.Registrar(ErrTransicionEstadoInvalida, http.StatusConflict, "receta_transicion_invalida")
+	apperrors.Registrar(ErrRecetaFotoDuplicada, http.StatusConflict, "receta_foto_duplicada")
+	apperrors.Registrar(ErrRecetaDemasiadasFotos, http.StatusConflict, "receta_demasiadas_fotos")
+
+	apperrors.Registrar(ErrRecetaNoEsDelAutor, http.StatusForbidden, "receta_no_es_del_autor")
+	apperrors.Registrar(ErrCambioEstadoSoloEditor, http.StatusForbidden, "receta_cambio_estado_solo_editor")
+	apperrors.Registrar(ErrRecetaSoloAutor, http.StatusForbidden, "receta_solo_autor")
+}
@@ -9,6 +9,8 @@ import (
                            // entonces no se necesitaría este import.
                            // ASUMIREMOS QUE 'Categoria' está en el paquete 'categorias'.
 	"backend/categorias" // Importamos el paquete donde está definido domain.Categoria
+	"backend/shared/i18n" // Para el idioma del contenido
+	"backend/media" // Archivo de la foto (ver media_model.go)
 	"errors"             // Para definir errores específicos del dominio
 )
 
@@ -20,19 +22,38 @@ type Receta struct {
 	Nombre            string    // Nombre de la receta
 	Slug              string    // Slug para URLs
 	TiempoPreparacion string    // Tiempo de preparación/cocción (ej: "30 minutos")
+	Porciones         int       // Porciones que rinde la receta (base para escalar ingredientes)
 	Foto              string    // Nombre/ruta del archivo de foto o URL
+	FotoID            *uint     // Registro del archivo de Foto (nil en fotos antiguas o URLs externas)
+	FotoMedia         *media.Media // Registro de FotoID precargado (con sus variantes)
+	Fotos             []RecetaFoto // Galería ordenada; Foto es su portada (solo en las lecturas de una receta)
 	Descripcion       string    // Descripción o pasos
+	AutorID           *uint     // Usuario que la creó (nil si se creó sin autenticación)
+	EditorID          *uint     // Usuario de la última modificación (autor de la revisión más reciente)
+	Estado            EstadoReceta // Estado en el flujo de publicación (ver receta_publicacion.go)
+	PublicarEn        *time.Time   // Fecha de publicación (programada si Estado = en_revision)
+	EliminadaEn       *time.Time   // Solo en la papelera: cuándo se eliminó
+	Idioma            i18n.Idioma  // Idioma de Nombre, Slug y Descripcion; vacío = idioma por defecto (ver receta_traduccion.go)
 	// Fecha       time.Time // Fecha de creación/publicación - GORM puede manejar CreatedAt/UpdatedAt
 	CreatedAt         time.Time // Manejado por GORM o explícitamente
 	UpdatedAt         time.Time // Manejado por GORM o explícitamente
 }
 
+// PorcionesPorDefecto es el rendimiento asumido si la receta no lo indica.
+const PorcionesPorDefecto = 4
+
+// EntidadMedia identifica a las recetas en los usos de los archivos (ver media.Uso).
+const EntidadMedia = "recetas"
+
 // Errores específicos del dominio Receta
 var (
 	ErrRecetaNotFound          = errors.New("receta no encontrada")
 	ErrRecetaNombreInvalido    = errors.New("el nombre de la receta no es válido o está vacío")
 	ErrRecetaSinCategoria      = errors.New("la receta debe pertenecer a una categoría válida")
 	ErrRecetaIngredientesInvalidos = errors.New("los ingredientes proporcionados para la receta no son válidos")
+	ErrRecetaPorcionesInvalidas    = errors.New("las porciones de la receta deben ser al menos 1")
+	ErrRevisionNotFound            = errors.New("revisión de la receta no encontrada")
+	ErrRecetaSoloAutor             = errors.New("solo el autor de la receta o un editor puede modificarla")
 	// ... otros errores que puedan surgir ...
 )
 
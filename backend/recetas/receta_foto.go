@@ -0,0 +1,67 @@
+// backend/recetas/receta_foto.go
+
+// Este archivo define la galería de fotos de una receta.
+//
+// Cada receta tiene una lista ordenada de fotos (pasos del proceso, resultado final...),
+// cada una con su leyenda y su texto alternativo (accesibilidad). Una de ellas puede
+// marcarse como portada; si ninguna lo está, la portada es la primera según el orden.
+// Receta.Foto y Receta.FotoID se mantienen como la portada calculada, para los clientes
+// que solo conocen una foto (y los endpoints antiguos /recetas/:id/foto, que la reemplazan).
+// Las recetas con foto anterior a la galería la incorporan como primera foto al editarla.
+//
+// Cada foto de la galería registra un uso de su archivo (ver media.Uso) con la entidad
+// EntidadMediaGaleria, aparte del uso de la portada (EntidadMedia).
+
+package recetas
+
+import (
+	"errors"
+	"time"
+
+	"backend/media"
+)
+
+// RecetaFoto es una foto de la galería de una receta.
+type RecetaFoto struct {
+	ID        uint
+	RecetaID  uint
+	MediaID   uint         // Archivo de la foto
+	Media     *media.Media // Precargado en las lecturas (con sus variantes)
+	Orden     int          // Posición en la galería, desde 1
+	Leyenda   string       // Texto visible bajo la foto (ej: "Sofreír la cebolla")
+	TextoAlt  string       // Descripción para lectores de pantalla (atributo alt)
+	Portada   bool         // Marcada como portada (como mucho una por receta)
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const (
+	// EntidadMediaGaleria identifica a las fotos de la galería en los usos de los archivos.
+	EntidadMediaGaleria = "receta_fotos"
+	// MaxFotosPorReceta limita el tamaño de la galería.
+	MaxFotosPorReceta = 30
+	// MaxLongitudTextoFoto es la longitud máxima de la leyenda y del texto alternativo.
+	MaxLongitudTextoFoto = 300
+)
+
+// PortadaDe devuelve la foto de portada de la galería (la marcada o, si no hay, la primera);
+// nil si la galería está vacía. 'fotos' debe estar ordenada.
+func PortadaDe(fotos []RecetaFoto) *RecetaFoto {
+	for i := range fotos {
+		if fotos[i].Portada {
+			return &fotos[i]
+		}
+	}
+	if len(fotos) == 0 {
+		return nil
+	}
+	return &fotos[0]
+}
+
+var (
+	ErrRecetaFotoNotFound      = errors.New("la foto no pertenece a la galería de la receta")
+	ErrRecetaFotoDuplicada     = errors.New("la foto ya está en la galería de la receta")
+	ErrRecetaDemasiadasFotos   = errors.New("la galería de la receta está completa")
+	ErrRecetaFotoTextoInvalido = errors.New("la leyenda y el texto alternativo no pueden superar 300 caracteres")
+	ErrOrdenFotosInvalido      = errors.New("el nuevo orden debe incluir cada foto de la galería exactamente una vez")
+)
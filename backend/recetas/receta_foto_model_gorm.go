@@ -0,0 +1,78 @@
+// backend/recetas/receta_foto_model_gorm.go
+
+// Este archivo define el modelo de persistencia de la galería de fotos de recetas.
+// Las fotos se conservan mientras la receta está en la papelera y se borran en
+// cascada al purgarla. Un archivo de 'media' no se puede borrar mientras alguna
+// galería lo use (RESTRICT).
+
+package recetas
+
+import (
+	"time"
+
+	"backend/media"
+)
+
+// RecetaFotoModel representa la tabla 'receta_fotos'.
+// Una fila por receta y archivo: la misma imagen no se repite en una galería.
+type RecetaFotoModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	RecetaID  uint   `gorm:"not null;uniqueIndex:uk_receta_fotos_media,priority:1;index:idx_receta_fotos_orden,priority:1"`
+	MediaID   uint   `gorm:"not null;uniqueIndex:uk_receta_fotos_media,priority:2"`
+	Orden     int    `gorm:"not null;index:idx_receta_fotos_orden,priority:2"`
+	Leyenda   string `gorm:"type:varchar(300)"`
+	TextoAlt  string `gorm:"type:varchar(300)"`
+	Portada   bool   `gorm:"not null;default:false"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Media *media.MediaModel `gorm:"foreignKey:MediaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+func (RecetaFotoModel) TableName() string {
+	return "receta_fotos"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *RecetaFotoModel) ToDomain() *RecetaFoto {
+	if m == nil {
+		return nil
+	}
+	return &RecetaFoto{
+		ID:        m.ID,
+		RecetaID:  m.RecetaID,
+		MediaID:   m.MediaID,
+		Media:     m.Media.ToDomain(), // nil si no se precargó
+		Orden:     m.Orden,
+		Leyenda:   m.Leyenda,
+		TextoAlt:  m.TextoAlt,
+		Portada:   m.Portada,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func FromRecetaFotoDomain(d *RecetaFoto) *RecetaFotoModel {
+	if d == nil {
+		return nil
+	}
+	return &RecetaFotoModel{
+		ID:       d.ID,
+		RecetaID: d.RecetaID,
+		MediaID:  d.MediaID,
+		Orden:    d.Orden,
+		Leyenda:  d.Leyenda,
+		TextoAlt: d.TextoAlt,
+		Portada:  d.Portada,
+	}
+}
+
+// RecetaFotoModelsToDomains convierte un slice de modelos GORM a dominio.
+func RecetaFotoModelsToDomains(models []RecetaFotoModel) []RecetaFoto {
+	fotos := make([]RecetaFoto, 0, len(models))
+	for i := range models {
+		fotos = append(fotos, *models[i].ToDomain())
+	}
+	return fotos
+}
@@ -10,6 +10,8 @@ import (
 	// Necesitamos importar el paquete 'categorias' para referenciar 'categorias.CategoriaModel'
 	// y el 'categorias.Categoria' (struct de dominio) en los mapeadores.
 	"backend/categorias"
+	"backend/shared/database" // Para migrar los índices únicos
+	"backend/media" // Relación con el archivo de la foto
 	"time"
 
 	"gorm.io/gorm"
@@ -20,13 +22,26 @@ import (
 type RecetaModel struct {
 	ID                uint           `gorm:"primaryKey"`
 	Nombre            string         `gorm:"type:varchar(150);not null"`
-	Slug              string         `gorm:"type:varchar(180);uniqueIndex:uk_recetas_slug"` // Asumo que slug debe ser único
+	Slug              string         `gorm:"type:varchar(180);uniqueIndex:uk_recetas_slug,priority:1"` // Único entre las recetas no eliminadas (ver Activo)
 	TiempoPreparacion string         `gorm:"type:varchar(50)"`
+	Porciones         int            `gorm:"not null;default:4"` // Rendimiento de la receta
 	Descripcion       string         `gorm:"type:text"`
 	Foto              string         `gorm:"type:varchar(100);default:null"` // Permitir NULL si la foto es opcional
+	FotoID            *uint          `gorm:"index;default:null"` // Registro en 'media' del archivo de Foto
+	FotoMedia         *media.MediaModel `gorm:"foreignKey:FotoID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Fotos             []RecetaFotoModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"` // Galería (ver receta_foto.go)
+	AutorID           *uint          `gorm:"index;default:null"` // Usuario creador
+	EditorID          *uint          `gorm:"default:null"`       // Usuario de la última modificación
+	// Las recetas anteriores al flujo de publicación quedan publicadas al migrar;
+	// las nuevas se crean como borrador desde el servicio.
+	Estado            string         `gorm:"type:varchar(20);not null;default:'publicada';index"`
+	PublicarEn        *time.Time     `gorm:"index;default:null"`
 	CreatedAt         time.Time      // GORM maneja esto
 	UpdatedAt         time.Time      // GORM maneja esto
 	DeletedAt         gorm.DeletedAt `gorm:"index"` // Para soft delete (opcional)
+	// Activo es 1 si la fila no está eliminada y NULL en la papelera (columna generada, solo lectura).
+	// Forma parte de uk_recetas_slug para que el slug solo sea único entre las recetas activas.
+	Activo            *bool          `gorm:"->;type:tinyint(1) GENERATED ALWAYS AS (IF(deleted_at IS NULL, 1, NULL)) VIRTUAL;uniqueIndex:uk_recetas_slug,priority:2"`
 
 	// --- Relación con Categoria (Belongs To) ---
 	CategoriaID       uint                    // Columna de clave foránea explícita
@@ -44,6 +59,12 @@ func (RecetaModel) TableName() string {
 	return "recetas" // Nombre de la tabla en plural, siguiendo convenciones o tu preferencia
 }
 
+// MigrarIndicesUnicos convierte el UNIQUE(slug) anterior a la papelera en UNIQUE(slug, activo).
+// Ejecutar después de AutoMigrate.
+func MigrarIndicesUnicos(db *gorm.DB) error {
+	return database.AsegurarIndiceUnico(db, "recetas", "uk_recetas_slug", "slug", "activo")
+}
+
 // --- Funciones de Mapeo ---
 
 // ToDomain convierte el modelo de persistencia (RecetaModel) al modelo de dominio (Receta de este paquete).
@@ -60,19 +81,32 @@ func (m *RecetaModel) ToDomain() *Receta {
 		domainCategoria = m.Categoria.ToDomain()
 	}
 
-	return &Receta{ // Crear instancia de Receta (dominio de este paquete)
+	receta := &Receta{ // Crear instancia de Receta (dominio de este paquete)
 		ID:                m.ID,
 		Nombre:            m.Nombre,
 		Slug:              m.Slug,
 		TiempoPreparacion: m.TiempoPreparacion,
+		Porciones:         m.Porciones,
 		Descripcion:       m.Descripcion,
 		Foto:              m.Foto,
+		FotoID:            m.FotoID,
+		FotoMedia:         m.FotoMedia.ToDomain(), // nil si no se precargó o no tiene
+		Fotos:             RecetaFotoModelsToDomains(m.Fotos), // Vacía si no se precargó
+		AutorID:           m.AutorID,
+		EditorID:          m.EditorID,
+		Estado:            EstadoReceta(m.Estado),
+		PublicarEn:        m.PublicarEn,
 		CreatedAt:         m.CreatedAt,
 		UpdatedAt:         m.UpdatedAt,
 		CategoriaID:       m.CategoriaID,
 		Categoria:         domainCategoria, // Asignar el *categorias.Categoria (dominio) mapeado
 		// Ingredientes:    // Se mapearán cuando implementemos la relación con ingredientes
 	}
+	if m.DeletedAt.Valid { // Solo al consultar la papelera (Unscoped)
+		eliminadaEn := m.DeletedAt.Time
+		receta.EliminadaEn = &eliminadaEn
+	}
+	return receta
 }
 
 // FromRecetaDomain convierte un modelo de dominio (Receta de este paquete) al modelo de persistencia (RecetaModel).
@@ -89,8 +123,14 @@ func FromRecetaDomain(d *Receta) *RecetaModel { // Recibe *Receta (dominio de es
 		Nombre:            d.Nombre,
 		Slug:              d.Slug,
 		TiempoPreparacion: d.TiempoPreparacion,
+		Porciones:         d.Porciones,
 		Descripcion:       d.Descripcion,
 		Foto:              d.Foto,
+		FotoID:            d.FotoID,
+		AutorID:           d.AutorID,
+		EditorID:          d.EditorID,
+		Estado:            string(d.Estado),
+		PublicarEn:        d.PublicarEn,
 		CategoriaID:       d.CategoriaID, // Muy importante para la FK
 		// Categoria (el struct categorias.CategoriaModel) no se asigna desde d.Categoria (el struct domain) aquí.
 		// GORM la asociará si CategoriaID está presente y CategoriaModel ya existe con ese ID.
@@ -0,0 +1,112 @@
+// backend/recetas/receta_publicacion.go
+
+// Este archivo define el flujo de publicación de una receta:
+//
+//	borrador -> en_revision -> publicada -> archivada
+//
+// - El autor crea la receta como borrador y la envía a revisión (o la retira).
+// - Un editor la publica en el momento o la programa con PublicarEn: la receta
+//   queda en_revision con fecha y el PublicadorProgramado la publica al llegar la hora.
+// - Un editor puede archivar una receta publicada o devolverla a borrador.
+// - Solo las recetas publicadas aparecen en los listados públicos; el autor ve
+//   también las suyas en cualquier estado, y los editores (los admins, ver
+//   middleware.Admins) todas.
+
+package recetas
+
+import (
+	"errors"
+	"time"
+)
+
+// EstadoReceta es el estado de una receta en el flujo de publicación.
+type EstadoReceta string
+
+const (
+	EstadoBorrador   EstadoReceta = "borrador"
+	EstadoEnRevision EstadoReceta = "en_revision"
+	EstadoPublicada  EstadoReceta = "publicada"
+	EstadoArchivada  EstadoReceta = "archivada"
+)
+
+// EsValido indica si el estado es uno de los valores conocidos.
+func (e EstadoReceta) EsValido() bool {
+	switch e {
+	case EstadoBorrador, EstadoEnRevision, EstadoPublicada, EstadoArchivada:
+		return true
+	}
+	return false
+}
+
+// transicionesPermitidas indica a qué estados se puede pasar desde cada estado.
+var transicionesPermitidas = map[EstadoReceta][]EstadoReceta{
+	EstadoBorrador:   {EstadoEnRevision},
+	EstadoEnRevision: {EstadoBorrador, EstadoPublicada},
+	EstadoPublicada:  {EstadoArchivada, EstadoBorrador},
+	EstadoArchivada:  {EstadoBorrador, EstadoPublicada},
+}
+
+// PuedeCambiarEstado indica si la transición de 'desde' a 'hacia' está permitida.
+func PuedeCambiarEstado(desde, hacia EstadoReceta) bool {
+	for _, e := range transicionesPermitidas[desde] {
+		if e == hacia {
+			return true
+		}
+	}
+	return false
+}
+
+// EsPublica indica si la receta aparece en los listados públicos.
+func (r Receta) EsPublica() bool {
+	return r.Estado == EstadoPublicada
+}
+
+// Programada indica si la receta espera al PublicadorProgramado.
+func (r Receta) Programada() bool {
+	return r.Estado == EstadoEnRevision && r.PublicarEn != nil
+}
+
+// EsDelAutor indica si el usuario es el autor de la receta.
+func (r Receta) EsDelAutor(userID uint) bool {
+	return r.AutorID != nil && *r.AutorID == userID
+}
+
+// Lector es quien consulta una receta: el usuario (nil = anónimo) y si es editor.
+type Lector struct {
+	UsuarioID *uint
+	Editor    bool // Los editores ven las recetas en cualquier estado
+}
+
+// LectorUsuario es el lector de un usuario identificado sin permisos de editor.
+func LectorUsuario(userID uint) Lector {
+	return Lector{UsuarioID: &userID}
+}
+
+// VisiblePara indica si el lector puede ver la receta: las publicadas las ve
+// cualquiera; las demás, su autor y los editores.
+func (r Receta) VisiblePara(l Lector) bool {
+	return r.EsPublica() || l.Editor || (l.UsuarioID != nil && r.EsDelAutor(*l.UsuarioID))
+}
+
+// EditablePor indica si el lector puede modificar la receta: su autor y los editores.
+// Las recetas sin autor solo las modifican los editores.
+func (r Receta) EditablePor(l Lector) bool {
+	return l.Editor || (l.UsuarioID != nil && r.EsDelAutor(*l.UsuarioID))
+}
+
+// CambioEstadoInput son los datos para mover una receta en el flujo de publicación.
+type CambioEstadoInput struct {
+	Estado     EstadoReceta
+	PublicarEn *time.Time // Solo con Estado = publicada; en el futuro = programar
+	UsuarioID  *uint      // Usuario que pide el cambio
+	ComoEditor bool       // false = el autor (solo borrador <-> en_revision)
+}
+
+// Errores del flujo de publicación.
+var (
+	ErrEstadoRecetaInvalido     = errors.New("estado de receta inválido (use borrador, en_revision, publicada o archivada)")
+	ErrTransicionEstadoInvalida = errors.New("la receta no puede pasar a ese estado desde su estado actual")
+	ErrPublicarEnInvalido       = errors.New("publicar_en solo se admite al publicar")
+	ErrRecetaNoEsDelAutor       = errors.New("solo el autor puede cambiar el estado de la receta")
+	ErrCambioEstadoSoloEditor   = errors.New("solo un editor puede publicar o archivar recetas")
+)
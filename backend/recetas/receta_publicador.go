@@ -0,0 +1,48 @@
+// backend/recetas/receta_publicador.go
+
+// Este archivo define la tarea en segundo plano que publica las recetas programadas
+// (en_revision con PublicarEn) cuando llega su fecha.
+
+package recetas
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PublicadorProgramado ejecuta RecetaService.PublicarProgramadas cada 'intervalo'.
+type PublicadorProgramado struct {
+	service   RecetaService
+	intervalo time.Duration
+}
+
+// NewPublicadorProgramado crea el publicador. El intervalo debe ser positivo.
+func NewPublicadorProgramado(service RecetaService, intervalo time.Duration) *PublicadorProgramado {
+	return &PublicadorProgramado{service: service, intervalo: intervalo}
+}
+
+// Iniciar publica las recetas vencidas al arrancar y luego en cada intervalo,
+// hasta que se cancele ctx. Bloquea: ejecútelo en una goroutine.
+func (p *PublicadorProgramado) Iniciar(ctx context.Context) {
+	log.Printf("⏰ Publicador de recetas programadas iniciado (cada %s).\n", p.intervalo)
+	ticker := time.NewTicker(p.intervalo)
+	defer ticker.Stop()
+
+	for {
+		p.ejecutar(ctx)
+		select {
+		case <-ctx.Done():
+			log.Println("⏰ Publicador de recetas programadas detenido.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ejecutar hace una pasada; los errores se loguean y se reintenta en la siguiente.
+func (p *PublicadorProgramado) ejecutar(ctx context.Context) {
+	if _, err := p.service.PublicarProgramadas(ctx); err != nil {
+		log.Printf("Publicador: error publicando recetas programadas: %v\n", err)
+	}
+}
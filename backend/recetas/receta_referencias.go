@@ -0,0 +1,98 @@
+// backend/recetas/receta_referencias.go
+
+// Este archivo informa al recolector de archivos huérfanos (media.Recolector) de las fotos
+// de recetas que no tienen registro en 'media': las subidas antes de existir la tabla.
+// Las demás ya constan como usos de su registro. RegistrarFotosSinMedia (cmd/fotosmedia)
+// les crea el registro para que el control de acceso y el recolector las traten igual.
+
+package recetas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log" // Temporal, reemplazar con logger estructurado
+	"path"
+
+	"backend/media"
+	"backend/shared/storage"
+)
+
+type referenciasFotos struct {
+	recetaRepo RecetaRepository
+}
+
+// NewReferenciasFotos crea el Referenciador de las fotos de recetas (se registra en media.NewRecolector).
+func NewReferenciasFotos(recetaRepo RecetaRepository) media.Referenciador {
+	return &referenciasFotos{recetaRepo: recetaRepo}
+}
+
+// ReferenciasArchivos devuelve las fotos sin registro, incluidas las de recetas en la papelera
+// (se pueden restaurar). Las URLs externas no están en el almacenamiento y se omiten.
+func (r *referenciasFotos) ReferenciasArchivos(ctx context.Context) ([]media.Referencia, error) {
+	recs, err := r.recetaRepo.FindFotosSinMedia(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recetas: referencias de fotos: %w", err)
+	}
+	referencias := make([]media.Referencia, 0, len(recs))
+	for _, rec := range recs {
+		if esURLExterna(rec.Foto) {
+			continue
+		}
+		referencias = append(referencias, media.Referencia{
+			Entidad:   EntidadMedia,
+			EntidadID: rec.ID,
+			Clave:     path.Join(CarpetaFotos, path.Base(rec.Foto)),
+		})
+	}
+	return referencias, nil
+}
+
+// InformeFotosSinMedia resume una pasada de RegistrarFotosSinMedia.
+type InformeFotosSinMedia struct {
+	Revisadas   int      // Recetas con foto sin registro (sin contar URLs externas)
+	Registradas int      // Fotos registradas y enlazadas a su receta
+	Faltantes   []string // Claves de fotos cuyo archivo no está en el almacenamiento
+	Errores     int
+}
+
+// RegistrarFotosSinMedia crea el registro en 'media' de las fotos antiguas, con el uso de su
+// receta, y lo enlaza en foto_id. Con 'simular' solo cuenta lo que haría.
+func RegistrarFotosSinMedia(ctx context.Context, recetaRepo RecetaRepository, mediaSvc media.MediaService, simular bool) (*InformeFotosSinMedia, error) {
+	recs, err := recetaRepo.FindFotosSinMedia(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recetas: registrar fotos sin media: %w", err)
+	}
+	informe := &InformeFotosSinMedia{}
+	for _, rec := range recs {
+		if err := ctx.Err(); err != nil {
+			return informe, err
+		}
+		if esURLExterna(rec.Foto) {
+			continue
+		}
+		informe.Revisadas++
+		clave := path.Join(CarpetaFotos, path.Base(rec.Foto))
+		if simular {
+			continue
+		}
+		m, err := mediaSvc.RegistrarArchivo(ctx, clave, nil)
+		if errors.Is(err, storage.ErrNoExiste) {
+			informe.Faltantes = append(informe.Faltantes, clave)
+			continue
+		}
+		if err == nil {
+			err = mediaSvc.Usar(ctx, m.ID, EntidadMedia, rec.ID)
+		}
+		if err == nil {
+			err = recetaRepo.AsignarFotoMedia(ctx, rec.ID, m.ID)
+		}
+		if err != nil {
+			log.Printf("Recetas: No se pudo registrar la foto %s de la receta ID %d: %v\n", clave, rec.ID, err)
+			informe.Errores++
+			continue
+		}
+		informe.Registradas++
+	}
+	return informe, nil
+}
@@ -0,0 +1,57 @@
+// backend/recetas/receta_referencias_test.go
+package recetas_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/media"
+	"backend/recetas"
+	"backend/recetas/mocks"
+	"backend/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrarFotosSinMedia(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RecetaRepositoryMock)
+	mediaSvc := new(mocks.MediaServiceMock)
+	repo.On("FindFotosSinMedia", ctx).Return([]recetas.Receta{
+		{ID: 1, Foto: "uploads/recetas/tarta.jpg"},
+		{ID: 2, Foto: "https://cdn.example.com/sopa.jpg"}, // Externa: se omite
+		{ID: 3, Foto: "perdida.jpg"},
+		{ID: 4, Foto: "rota.jpg"},
+	}, nil)
+	mediaSvc.On("RegistrarArchivo", ctx, "recetas/tarta.jpg", (*uint)(nil)).Return(&media.Media{ID: 7}, nil).Once()
+	mediaSvc.On("RegistrarArchivo", ctx, "recetas/perdida.jpg", (*uint)(nil)).Return(nil, storage.ErrNoExiste).Once()
+	mediaSvc.On("RegistrarArchivo", ctx, "recetas/rota.jpg", (*uint)(nil)).Return(nil, errors.New("disco")).Once()
+	mediaSvc.On("Usar", ctx, uint(7), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	repo.On("AsignarFotoMedia", ctx, uint(1), uint(7)).Return(nil).Once()
+
+	informe, err := recetas.RegistrarFotosSinMedia(ctx, repo, mediaSvc, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, informe.Revisadas)
+	assert.Equal(t, 1, informe.Registradas)
+	assert.Equal(t, []string{"recetas/perdida.jpg"}, informe.Faltantes)
+	assert.Equal(t, 1, informe.Errores)
+	repo.AssertExpectations(t)
+	mediaSvc.AssertExpectations(t)
+}
+
+func TestRegistrarFotosSinMedia_Simular(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RecetaRepositoryMock)
+	mediaSvc := new(mocks.MediaServiceMock)
+	repo.On("FindFotosSinMedia", ctx).Return([]recetas.Receta{{ID: 1, Foto: "tarta.jpg"}}, nil)
+
+	informe, err := recetas.RegistrarFotosSinMedia(ctx, repo, mediaSvc, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, informe.Revisadas)
+	assert.Zero(t, informe.Registradas)
+	mediaSvc.AssertNotCalled(t, "RegistrarArchivo")
+}
@@ -7,6 +7,8 @@ package recetas // Pertenece al paquete de la característica 'recetas'
 
 import (
 	"context"
+	"time"
+	"backend/shared/i18n"
 	// "backend/shared/repositoryerrors" // Si tuvieras errores comunes de repo en shared
 	// O definir errores específicos aquí si es necesario, aunque ErrRecordNotFound podría venir de shared
 	"errors" // Por ahora, para definir un error base si es necesario
@@ -23,6 +25,13 @@ var (
 	ErrRepoGeneneral = errors.New("repositorio: ocurrió un error inesperado")
 )
 
+// FiltroRecetas restringe las recetas devueltas por Find. Los campos vacíos no filtran.
+type FiltroRecetas struct {
+	Estados     []EstadoReceta
+	AutorID     *uint
+	CategoriaID *uint
+}
+
 // RecetaRepository define el contrato para las operaciones de datos de Recetas.
 // Nota: Devuelve y acepta *Receta (el tipo de dominio de este paquete).
 type RecetaRepository interface {
@@ -35,11 +44,13 @@ type RecetaRepository interface {
 	// GetBySlug recupera una receta por su slug, con su categoría precargada.
 	GetBySlug(ctx context.Context, slug string) (*Receta, error)
 
-	// Create inserta una nueva receta en la base de datos.
+	// Create inserta una nueva receta en la base de datos y guarda su revisión 1.
 	// El *Receta de entrada se modifica para incluir el ID generado.
+	// EditorID se registra como autor de la revisión.
 	Create(ctx context.Context, receta *Receta) error
 
-	// Update actualiza una receta existente en la base de datos.
+	// Update actualiza una receta existente (todos los campos editables, incluidos los vacíos)
+	// y guarda el resultado como una nueva revisión, en la misma transacción.
 	Update(ctx context.Context, receta *Receta) error
 
 	// Delete elimina una receta por su ID.
@@ -48,8 +59,96 @@ type RecetaRepository interface {
 	// FindByCategoriaID recupera todas las recetas pertenecientes a una categoría específica.
 	FindByCategoriaID(ctx context.Context, categoriaID uint) ([]Receta, error)
 
+	// Find recupera las recetas que cumplen el filtro (con su categoría precargada), las más recientes primero.
+	Find(ctx context.Context, filtro FiltroRecetas) ([]Receta, error)
+
+	// ActualizarFoto reemplaza la foto ("" y nil = sin foto) y devuelve el ID de media anterior, en una transacción
+	// que bloquea la fila para que dos subidas simultáneas no pierdan la referencia a un archivo (no crea revisión).
+	ActualizarFoto(ctx context.Context, id uint, foto string, fotoID *uint) (*uint, error)
+
+	// --- Galería de fotos (ver receta_foto.go) ---
+
+	// FindFotos recupera la galería de una receta (con los archivos precargados), en orden.
+	FindFotos(ctx context.Context, recetaID uint) ([]RecetaFoto, error)
+
+	// GetRecetaIDDeFoto devuelve la receta a la que pertenece una foto de galería (ErrRecordNotFound si no existe).
+	GetRecetaIDDeFoto(ctx context.Context, fotoID uint) (uint, error)
+
+	// CreateFoto añade la foto al final de la galería (asigna ID y Orden). Si es portada, desmarca la anterior.
+	// ErrRecordNotFound si la receta no existe; ErrDuplicateRecord si el archivo ya está en la galería.
+	CreateFoto(ctx context.Context, foto *RecetaFoto) error
+
+	// UpdateFoto guarda Leyenda, TextoAlt y Portada (ErrRecordNotFound si la foto no es de la receta).
+	UpdateFoto(ctx context.Context, foto *RecetaFoto) error
+
+	// ReordenarFotos asigna a las fotos el orden de 'ids' (todas las de la galería).
+	ReordenarFotos(ctx context.Context, recetaID uint, ids []uint) error
+
+	// DeleteFoto quita una foto de la galería (ErrRecordNotFound si no es de la receta).
+	DeleteFoto(ctx context.Context, recetaID, fotoID uint) error
+
+	// FindFotosSinMedia recupera las recetas (incluidas las de la papelera) con foto pero sin
+	// registro en 'media' (fotos anteriores a la tabla o URLs externas). Solo carga ID y Foto.
+	FindFotosSinMedia(ctx context.Context) ([]Receta, error)
+
+	// AsignarFotoMedia enlaza la foto antigua con su registro en 'media', también en la papelera.
+	// Solo si foto_id sigue vacío; no cambia la foto ni crea revisión.
+	AsignarFotoMedia(ctx context.Context, id, fotoID uint) error
+
+	// UpdateEstado cambia el estado de publicación y la fecha de publicación (no crea revisión).
+	UpdateEstado(ctx context.Context, id uint, estado EstadoReceta, publicarEn *time.Time) error
+
+	// PublicarProgramadas publica las recetas en revisión cuya PublicarEn ya pasó. Devuelve cuántas.
+	PublicarProgramadas(ctx context.Context, ahora time.Time) (int64, error)
+
+	// FindRevisiones recupera el historial de revisiones de una receta, la más reciente primero.
+	FindRevisiones(ctx context.Context, recetaID uint) ([]RecetaRevision, error)
+
+	// GetRevision recupera una revisión por su número (repository.ErrRecordNotFound si no existe).
+	GetRevision(ctx context.Context, recetaID uint, numero int) (*RecetaRevision, error)
+
 	// (A futuro, cuando integremos ingredientes)
 	// AddIngredienteToReceta(ctx context.Context, recetaID uint, ingredienteID uint, cantidad string) error
 	// RemoveIngredienteFromReceta(ctx context.Context, recetaID uint, ingredienteID uint) error
 	// GetIngredientesByRecetaID(ctx context.Context, recetaID uint) ([]Ingrediente, error) // Asumiendo que Ingrediente es un tipo de dominio
+
+	// --- Papelera (registros con soft delete) ---
+
+	// FindEliminadas recupera las recetas de la papelera, las eliminadas más recientemente primero.
+	FindEliminadas(ctx context.Context) ([]Receta, error)
+
+	// GetEliminadaByID recupera una receta de la papelera (ErrRecordNotFound si no está eliminada).
+	GetEliminadaByID(ctx context.Context, id uint) (*Receta, error)
+
+	// Restaurar saca la receta de la papelera con el slug indicado (puede diferir del original).
+	Restaurar(ctx context.Context, id uint, slug string) error
+
+	// Purgar borra definitivamente una receta de la papelera.
+	Purgar(ctx context.Context, id uint) error
+
+	// PurgarEliminadasAntesDe borra definitivamente las recetas eliminadas antes de 'limite'. Devuelve cuántas.
+	PurgarEliminadasAntesDe(ctx context.Context, limite time.Time) (int64, error)
+
+	// --- Traducciones (ver receta_traduccion.go) ---
+
+	// FindTraducciones recupera las traducciones de una receta, ordenadas por idioma.
+	FindTraducciones(ctx context.Context, recetaID uint) ([]RecetaTraduccion, error)
+
+	// FindTraduccionesEnIdioma recupera las traducciones a 'idioma' de las recetas indicadas.
+	FindTraduccionesEnIdioma(ctx context.Context, idioma i18n.Idioma, recetaIDs []uint) ([]RecetaTraduccion, error)
+
+	// GuardarTraduccion crea la traducción o reemplaza la existente para su idioma.
+	GuardarTraduccion(ctx context.Context, traduccion *RecetaTraduccion) error
+
+	// DeleteTraduccion borra una traducción (ErrRecordNotFound si no existe).
+	DeleteTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma) error
+
+	// SlugTraducidoEnUso indica si otra receta (distinta de 'recetaID') usa el slug en ese idioma.
+	SlugTraducidoEnUso(ctx context.Context, idioma i18n.Idioma, slug string, recetaID uint) (bool, error)
+
+	// GetBySlugTraducido recupera la receta (sin traducir) cuya traducción a 'idioma' tiene ese slug.
+	GetBySlugTraducido(ctx context.Context, idioma i18n.Idioma, slug string) (*Receta, error)
+
+	// FindSinTraduccion recupera las recetas sin traducción a 'idioma', las más recientes primero.
+	FindSinTraduccion(ctx context.Context, idioma i18n.Idioma) ([]Receta, error)
 }
\ No newline at end of file
@@ -12,8 +12,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 	"gorm.io/gorm"
-	//"gorm.io/gorm/clause" // Para Preload anidado si es necesario
+	"gorm.io/gorm/clause" // Para bloquear la fila al numerar revisiones y el upsert de traducciones
+	"backend/shared/i18n"
 	"backend/shared/repository"
 )
 
@@ -32,7 +34,7 @@ func (r *gormRecetaRepository) GetAll(ctx context.Context) ([]Receta, error) {
 	var models []RecetaModel
 	// ¡IMPORTANTE! Preload("Categoria") para cargar la relación.
 	// GORM usará el struct CategoriaModel (del paquete 'categorias') definido en RecetaModel.
-	if err := r.db.WithContext(ctx).Preload("Categoria").Order("id desc").Find(&models).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Categoria").Preload("FotoMedia").Order("id desc").Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("repo gorm recetas: getall: %w", err)
 	}
 	return RecetaModelsToDomains(models), nil // Usar el mapeador de RecetaModel
@@ -42,7 +44,7 @@ func (r *gormRecetaRepository) GetAll(ctx context.Context) ([]Receta, error) {
 func (r *gormRecetaRepository) GetByID(ctx context.Context, id uint) (*Receta, error) {
 	var model RecetaModel
 	// ¡IMPORTANTE! Preload("Categoria")
-	if err := r.db.WithContext(ctx).Preload("Categoria").First(&model, id).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Categoria").Preload("FotoMedia").Preload("Fotos", ordenFotos).Preload("Fotos.Media").First(&model, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Podríamos devolver nuestro propio ErrRecordNotFound del paquete 'recetas' si lo definimos
 			return nil, repository.ErrRecordNotFound // Asumiendo que ErrRecordNotFound está definido en este paquete (en repository.go)
@@ -56,7 +58,7 @@ func (r *gormRecetaRepository) GetByID(ctx context.Context, id uint) (*Receta, e
 func (r *gormRecetaRepository) GetBySlug(ctx context.Context, slug string) (*Receta, error) {
 	var model RecetaModel
 	// ¡IMPORTANTE! Preload("Categoria")
-	if err := r.db.WithContext(ctx).Preload("Categoria").Where("slug = ?", slug).First(&model).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Categoria").Preload("FotoMedia").Preload("Fotos", ordenFotos).Preload("Fotos.Media").Where("slug = ?", slug).First(&model).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, repository.ErrRecordNotFound
 		}
@@ -65,13 +67,19 @@ func (r *gormRecetaRepository) GetBySlug(ctx context.Context, slug string) (*Rec
 	return model.ToDomain(), nil
 }
 
-// Create crea una receta en la base de datos.
+// Create crea una receta en la base de datos junto con su revisión 1, en una transacción.
 func (r *gormRecetaRepository) Create(ctx context.Context, receta *Receta) error {
 	model := FromRecetaDomain(receta) // Mapear dominio a modelo GORM
 	// GORM se encargará de la CategoriaID si está presente en el modelo.
 	// Si quisiéramos asociar un CategoriaModel completo, la lógica sería diferente.
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
-		return fmt.Errorf("repo gorm recetas: create: %w", err)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return fmt.Errorf("repo gorm recetas: create: %w", repository.TraducirError(err))
+		}
+		return crearRevision(tx, NuevaRevision(*model.ToDomain(), model.EditorID), 1)
+	})
+	if err != nil {
+		return err
 	}
 	// Actualizar el ID en el objeto de dominio original
 	receta.ID = model.ID
@@ -82,30 +90,60 @@ func (r *gormRecetaRepository) Create(ctx context.Context, receta *Receta) error
 	return nil
 }
 
-// Update actualiza una receta existente en la base de datos.
+// columnasEditables son las columnas que Update escribe (incluidos los valores vacíos).
+var columnasEditables = []string{
+	"nombre", "slug", "tiempo_preparacion", "porciones", "descripcion", "foto", "foto_id", "categoria_id", "editor_id", "updated_at",
+}
+
+// Update actualiza una receta existente y guarda el resultado como una nueva revisión,
+// en una transacción. Si la receta no tenía historial (creada antes de existir las
+// revisiones), primero se guarda su contenido anterior como revisión 1.
 func (r *gormRecetaRepository) Update(ctx context.Context, receta *Receta) error {
 	model := FromRecetaDomain(receta)
-	// Para Update, es crucial que el modelo tenga el ID correcto.
-	// GORM .Updates solo actualiza campos no cero, o usa .Select para especificar.
-	// Si quieres actualizar CategoriaID, asegúrate que esté en el modelo.
-	result := r.db.WithContext(ctx).Model(&RecetaModel{}).Where("id = ?", model.ID).Updates(model)
-	if result.Error != nil {
-		return fmt.Errorf("repo gorm recetas: update %d: %w", model.ID, result.Error)
-	}
-	if result.RowsAffected == 0 {
-		return repository.ErrRecordNotFound // ID no encontrado para actualizar
-	}
-	// Podrías querer recargar el modelo para obtener el UpdatedAt actualizado por la BD
-	// y luego actualizar el objeto de dominio 'receta' si es necesario.
-	// Por ahora, asumimos que la actualización fue exitosa.
-	return nil
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bloquear la fila para que dos ediciones simultáneas no obtengan el mismo número de revisión.
+		var anterior RecetaModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&anterior, model.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repository.ErrRecordNotFound // ID no encontrado para actualizar
+			}
+			return fmt.Errorf("repo gorm recetas: update %d bloquear: %w", model.ID, err)
+		}
+
+		ultima, err := ultimaRevision(tx, model.ID)
+		if err != nil {
+			return err
+		}
+		if ultima == 0 {
+			ultima = 1
+			if err := crearRevision(tx, NuevaRevision(*anterior.ToDomain(), anterior.EditorID), ultima); err != nil {
+				return err
+			}
+		}
+
+		// Select explícito: Updates con struct ignora los valores vacíos.
+		if err := tx.Model(&RecetaModel{}).Where("id = ?", model.ID).Select(columnasEditables).Updates(model).Error; err != nil {
+			return fmt.Errorf("repo gorm recetas: update %d: %w", model.ID, repository.TraducirError(err))
+		}
+		var actualizada RecetaModel
+		if err := tx.First(&actualizada, model.ID).Error; err != nil {
+			return fmt.Errorf("repo gorm recetas: update %d recargar: %w", model.ID, err)
+		}
+		receta.UpdatedAt = actualizada.UpdatedAt
+
+		nueva := NuevaRevision(*actualizada.ToDomain(), model.EditorID)
+		if len(DiferenciarRevisiones(NuevaRevision(*anterior.ToDomain(), nil), nueva)) == 0 {
+			return nil // Guardar sin cambios no crea una revisión
+		}
+		return crearRevision(tx, nueva, ultima+1)
+	})
 }
 
 // Delete elimina una receta de la base de datos.
 func (r *gormRecetaRepository) Delete(ctx context.Context, id uint) error {
 	result := r.db.WithContext(ctx).Delete(&RecetaModel{}, id)
 	if result.Error != nil {
-		return fmt.Errorf("repo gorm recetas: delete %d: %w", id, result.Error)
+		return fmt.Errorf("repo gorm recetas: delete %d: %w", id, repository.TraducirError(result.Error))
 	}
 	if result.RowsAffected == 0 {
 		return repository.ErrRecordNotFound // ID no encontrado para borrar
@@ -117,8 +155,423 @@ func (r *gormRecetaRepository) Delete(ctx context.Context, id uint) error {
 func (r *gormRecetaRepository) FindByCategoriaID(ctx context.Context, categoriaID uint) ([]Receta, error) {
 	var models []RecetaModel
 	// Preload Categoria también aquí para consistencia
-	if err := r.db.WithContext(ctx).Preload("Categoria").Where("categoria_id = ?", categoriaID).Order("id desc").Find(&models).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Categoria").Preload("FotoMedia").Where("categoria_id = ?", categoriaID).Order("id desc").Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("repo gorm recetas: findbycategoriaid %d: %w", categoriaID, err)
 	}
 	return RecetaModelsToDomains(models), nil
-}
\ No newline at end of file
+}
+
+// --- Revisiones ---
+
+// ultimaRevision devuelve el número de la revisión más reciente de la receta (0 si no tiene).
+func ultimaRevision(tx *gorm.DB, recetaID uint) (int, error) {
+	var ultima int
+	err := tx.Model(&RecetaRevisionModel{}).
+		Where("receta_id = ?", recetaID).
+		Select("COALESCE(MAX(numero), 0)").
+		Scan(&ultima).Error
+	if err != nil {
+		return 0, fmt.Errorf("repo gorm recetas: ultima revision %d: %w", recetaID, err)
+	}
+	return ultima, nil
+}
+
+func crearRevision(tx *gorm.DB, revision RecetaRevision, numero int) error {
+	revision.Numero = numero
+	if err := tx.Create(FromRecetaRevisionDomain(&revision)).Error; err != nil {
+		return fmt.Errorf("repo gorm recetas: crear revision %d de receta %d: %w", numero, revision.RecetaID, repository.TraducirError(err))
+	}
+	return nil
+}
+
+// FindRevisiones devuelve las revisiones de una receta, la más reciente primero.
+func (r *gormRecetaRepository) FindRevisiones(ctx context.Context, recetaID uint) ([]RecetaRevision, error) {
+	var models []RecetaRevisionModel
+	if err := r.db.WithContext(ctx).Where("receta_id = ?", recetaID).Order("numero desc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: findrevisiones %d: %w", recetaID, err)
+	}
+	revisiones := make([]RecetaRevision, 0, len(models))
+	for i := range models {
+		revisiones = append(revisiones, *models[i].ToDomain())
+	}
+	return revisiones, nil
+}
+
+// GetRevision devuelve una revisión concreta de una receta.
+func (r *gormRecetaRepository) GetRevision(ctx context.Context, recetaID uint, numero int) (*RecetaRevision, error) {
+	var model RecetaRevisionModel
+	err := r.db.WithContext(ctx).Where("receta_id = ? AND numero = ?", recetaID, numero).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm recetas: getrevision %d/%d: %w", recetaID, numero, err)
+	}
+	return model.ToDomain(), nil
+}
+
+// --- Flujo de publicación ---
+
+// Find encuentra las recetas que cumplen el filtro.
+func (r *gormRecetaRepository) Find(ctx context.Context, filtro FiltroRecetas) ([]Receta, error) {
+	query := r.db.WithContext(ctx).Preload("Categoria").Preload("FotoMedia")
+	if len(filtro.Estados) > 0 {
+		query = query.Where("estado IN ?", filtro.Estados)
+	}
+	if filtro.AutorID != nil {
+		query = query.Where("autor_id = ?", *filtro.AutorID)
+	}
+	if filtro.CategoriaID != nil {
+		query = query.Where("categoria_id = ?", *filtro.CategoriaID)
+	}
+	var models []RecetaModel
+	if err := query.Order("id desc").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: find: %w", err)
+	}
+	return RecetaModelsToDomains(models), nil
+}
+
+// ActualizarFoto cambia las columnas foto y foto_id y devuelve el foto_id anterior.
+func (r *gormRecetaRepository) ActualizarFoto(ctx context.Context, id uint, foto string, fotoID *uint) (*uint, error) {
+	var anterior RecetaModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "foto_id").First(&anterior, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repository.ErrRecordNotFound
+			}
+			return fmt.Errorf("repo gorm recetas: actualizarfoto %d bloquear: %w", id, err)
+		}
+		cambios := RecetaModel{Foto: foto, FotoID: fotoID}
+		if err := tx.Model(&RecetaModel{}).Where("id = ?", id).Select("foto", "foto_id").Updates(&cambios).Error; err != nil {
+			return fmt.Errorf("repo gorm recetas: actualizarfoto %d: %w", id, repository.TraducirError(err))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return anterior.FotoID, nil
+}
+
+func (r *gormRecetaRepository) FindFotosSinMedia(ctx context.Context) ([]Receta, error) {
+	var models []RecetaModel
+	err := r.db.WithContext(ctx).Unscoped().Select("id", "foto").
+		Where("foto <> '' AND foto_id IS NULL").Order("id").Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: findfotossinmedia: %w", err)
+	}
+	return RecetaModelsToDomains(models), nil
+}
+
+func (r *gormRecetaRepository) AsignarFotoMedia(ctx context.Context, id, fotoID uint) error {
+	err := r.db.WithContext(ctx).Unscoped().Model(&RecetaModel{}).
+		Where("id = ? AND foto_id IS NULL", id).Update("foto_id", fotoID).Error
+	if err != nil {
+		return fmt.Errorf("repo gorm recetas: asignarfotomedia %d: %w", id, repository.TraducirError(err))
+	}
+	return nil
+}
+
+// --- Galería de fotos ---
+
+// ordenFotos ordena la galería al precargarla.
+func ordenFotos(db *gorm.DB) *gorm.DB {
+	return db.Order("orden, id")
+}
+
+// FindFotos recupera la galería de una receta con sus archivos, en orden.
+func (r *gormRecetaRepository) FindFotos(ctx context.Context, recetaID uint) ([]RecetaFoto, error) {
+	var models []RecetaFotoModel
+	err := ordenFotos(r.db.WithContext(ctx).Preload("Media")).Where("receta_id = ?", recetaID).Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: findfotos %d: %w", recetaID, err)
+	}
+	return RecetaFotoModelsToDomains(models), nil
+}
+
+// GetRecetaIDDeFoto devuelve el ID de la receta de una foto de galería.
+func (r *gormRecetaRepository) GetRecetaIDDeFoto(ctx context.Context, fotoID uint) (uint, error) {
+	var model RecetaFotoModel
+	if err := r.db.WithContext(ctx).Select("id", "receta_id").First(&model, fotoID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, repository.ErrRecordNotFound
+		}
+		return 0, fmt.Errorf("repo gorm recetas: getrecetaiddefoto %d: %w", fotoID, err)
+	}
+	return model.RecetaID, nil
+}
+
+// CreateFoto añade la foto al final de la galería; si es portada, desmarca la anterior.
+func (r *gormRecetaRepository) CreateFoto(ctx context.Context, foto *RecetaFoto) error {
+	model := FromRecetaFotoDomain(foto)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Bloquear la receta serializa las altas de una misma galería (el orden no se repite).
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&RecetaModel{}, foto.RecetaID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return repository.ErrRecordNotFound
+			}
+			return err
+		}
+		var ultimo int
+		if err := tx.Model(&RecetaFotoModel{}).Where("receta_id = ?", foto.RecetaID).Select("COALESCE(MAX(orden), 0)").Scan(&ultimo).Error; err != nil {
+			return err
+		}
+		model.Orden = ultimo + 1
+		if model.Portada {
+			if err := desmarcarPortada(tx, foto.RecetaID); err != nil {
+				return err
+			}
+		}
+		return tx.Omit("Media").Create(model).Error
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("repo gorm recetas: createfoto %d: %w", foto.RecetaID, repository.TraducirError(err))
+	}
+	foto.ID, foto.Orden = model.ID, model.Orden
+	foto.CreatedAt, foto.UpdatedAt = model.CreatedAt, model.UpdatedAt
+	return nil
+}
+
+// UpdateFoto guarda la leyenda, el texto alternativo y la marca de portada (desmarcando la anterior).
+func (r *gormRecetaRepository) UpdateFoto(ctx context.Context, foto *RecetaFoto) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if foto.Portada {
+			if err := desmarcarPortada(tx, foto.RecetaID); err != nil {
+				return err
+			}
+		}
+		cambios := RecetaFotoModel{Leyenda: foto.Leyenda, TextoAlt: foto.TextoAlt, Portada: foto.Portada}
+		result := tx.Model(&RecetaFotoModel{}).Where("id = ? AND receta_id = ?", foto.ID, foto.RecetaID).
+			Select("leyenda", "texto_alt", "portada").Updates(&cambios)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return repository.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("repo gorm recetas: updatefoto %d: %w", foto.ID, repository.TraducirError(err))
+	}
+	return nil
+}
+
+// desmarcarPortada quita la marca de portada a todas las fotos de la receta.
+func desmarcarPortada(tx *gorm.DB, recetaID uint) error {
+	return tx.Model(&RecetaFotoModel{}).Where("receta_id = ? AND portada = ?", recetaID, true).Update("portada", false).Error
+}
+
+// ReordenarFotos asigna el orden 1..n según 'ids' (el servicio ya comprobó que son todas).
+func (r *gormRecetaRepository) ReordenarFotos(ctx context.Context, recetaID uint, ids []uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&RecetaFotoModel{}).Where("id = ? AND receta_id = ?", id, recetaID).Update("orden", i+1).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("repo gorm recetas: reordenarfotos %d: %w", recetaID, err)
+	}
+	return nil
+}
+
+// DeleteFoto quita una foto de la galería.
+func (r *gormRecetaRepository) DeleteFoto(ctx context.Context, recetaID, fotoID uint) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND receta_id = ?", fotoID, recetaID).Delete(&RecetaFotoModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm recetas: deletefoto %d: %w", fotoID, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+// UpdateEstado cambia estado y publicar_en (el servicio ya verificó que la receta existe).
+func (r *gormRecetaRepository) UpdateEstado(ctx context.Context, id uint, estado EstadoReceta, publicarEn *time.Time) error {
+	err := r.db.WithContext(ctx).Model(&RecetaModel{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"estado":      string(estado),
+		"publicar_en": publicarEn,
+	}).Error
+	if err != nil {
+		return fmt.Errorf("repo gorm recetas: updateestado %d: %w", id, repository.TraducirError(err))
+	}
+	return nil
+}
+
+// PublicarProgramadas publica en una sola sentencia las recetas programadas vencidas.
+func (r *gormRecetaRepository) PublicarProgramadas(ctx context.Context, ahora time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&RecetaModel{}).
+		Where("estado = ? AND publicar_en IS NOT NULL AND publicar_en <= ?", string(EstadoEnRevision), ahora).
+		Update("estado", string(EstadoPublicada))
+	if result.Error != nil {
+		return 0, fmt.Errorf("repo gorm recetas: publicarprogramadas: %w", repository.TraducirError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
+
+// --- Papelera ---
+
+// conCategoriaAunEliminada precarga la categoría aunque también esté en la papelera (y la foto).
+func conCategoriaAunEliminada(db *gorm.DB) *gorm.DB {
+	return db.Preload("Categoria", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).Preload("FotoMedia")
+}
+
+// FindEliminadas encuentra las recetas con soft delete.
+func (r *gormRecetaRepository) FindEliminadas(ctx context.Context) ([]Receta, error) {
+	var models []RecetaModel
+	err := conCategoriaAunEliminada(r.db.WithContext(ctx).Unscoped()).
+		Where("deleted_at IS NOT NULL").Order("deleted_at desc").Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: findeliminadas: %w", err)
+	}
+	return RecetaModelsToDomains(models), nil
+}
+
+// GetEliminadaByID encuentra una receta con soft delete por su ID.
+func (r *gormRecetaRepository) GetEliminadaByID(ctx context.Context, id uint) (*Receta, error) {
+	var model RecetaModel
+	err := conCategoriaAunEliminada(r.db.WithContext(ctx).Unscoped()).
+		Where("id = ? AND deleted_at IS NOT NULL", id).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm recetas: geteliminadabyid %d: %w", id, err)
+	}
+	return model.ToDomain(), nil
+}
+
+// Restaurar quita el soft delete y fija el slug.
+func (r *gormRecetaRepository) Restaurar(ctx context.Context, id uint, slug string) error {
+	result := r.db.WithContext(ctx).Unscoped().Model(&RecetaModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]interface{}{"deleted_at": nil, "slug": slug})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm recetas: restaurar %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purgar borra la fila; revisiones, traducciones, ingredientes y favoritos caen por sus FK con CASCADE.
+func (r *gormRecetaRepository) Purgar(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(&RecetaModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm recetas: purgar %d: %w", id, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+// PurgarEliminadasAntesDe borra las recetas eliminadas antes de 'limite'.
+func (r *gormRecetaRepository) PurgarEliminadasAntesDe(ctx context.Context, limite time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", limite).Delete(&RecetaModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("repo gorm recetas: purgareliminadasantesde: %w", repository.TraducirError(result.Error))
+	}
+	return result.RowsAffected, nil
+}
+
+// --- Traducciones ---
+
+// FindTraducciones recupera las traducciones de una receta, ordenadas por idioma.
+func (r *gormRecetaRepository) FindTraducciones(ctx context.Context, recetaID uint) ([]RecetaTraduccion, error) {
+	var models []RecetaTraduccionModel
+	if err := r.db.WithContext(ctx).Where("receta_id = ?", recetaID).Order("idioma").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: findtraducciones %d: %w", recetaID, err)
+	}
+	return RecetaTraduccionModelsToDomains(models), nil
+}
+
+// FindTraduccionesEnIdioma recupera las traducciones a 'idioma' de las recetas indicadas.
+func (r *gormRecetaRepository) FindTraduccionesEnIdioma(ctx context.Context, idioma i18n.Idioma, recetaIDs []uint) ([]RecetaTraduccion, error) {
+	if len(recetaIDs) == 0 {
+		return []RecetaTraduccion{}, nil
+	}
+	var models []RecetaTraduccionModel
+	if err := r.db.WithContext(ctx).Where("idioma = ? AND receta_id IN ?", string(idioma), recetaIDs).Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: findtraduccionesenidioma %s: %w", idioma, err)
+	}
+	return RecetaTraduccionModelsToDomains(models), nil
+}
+
+// GuardarTraduccion inserta la traducción o reemplaza la existente para el mismo idioma.
+func (r *gormRecetaRepository) GuardarTraduccion(ctx context.Context, traduccion *RecetaTraduccion) error {
+	model := FromRecetaTraduccionDomain(traduccion)
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "receta_id"}, {Name: "idioma"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nombre", "slug", "descripcion", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("repo gorm recetas: guardartraduccion %d (%s): %w", traduccion.RecetaID, traduccion.Idioma, repository.TraducirError(err))
+	}
+	traduccion.CreatedAt, traduccion.UpdatedAt = model.CreatedAt, model.UpdatedAt
+	return nil
+}
+
+// DeleteTraduccion borra la traducción de una receta a un idioma.
+func (r *gormRecetaRepository) DeleteTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma) error {
+	result := r.db.WithContext(ctx).Where("receta_id = ? AND idioma = ?", recetaID, string(idioma)).Delete(&RecetaTraduccionModel{})
+	if result.Error != nil {
+		return fmt.Errorf("repo gorm recetas: deletetraduccion %d (%s): %w", recetaID, idioma, repository.TraducirError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrRecordNotFound
+	}
+	return nil
+}
+
+// SlugTraducidoEnUso indica si otra receta usa el slug en ese idioma.
+func (r *gormRecetaRepository) SlugTraducidoEnUso(ctx context.Context, idioma i18n.Idioma, slug string, recetaID uint) (bool, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&RecetaTraduccionModel{}).
+		Where("idioma = ? AND slug = ? AND receta_id <> ?", string(idioma), slug, recetaID).
+		Count(&total).Error
+	if err != nil {
+		return false, fmt.Errorf("repo gorm recetas: slugtraducidoenuso %s (%s): %w", slug, idioma, err)
+	}
+	return total > 0, nil
+}
+
+// GetBySlugTraducido recupera la receta cuya traducción a 'idioma' tiene ese slug.
+// Devuelve la receta con su contenido original; el servicio aplica la traducción.
+func (r *gormRecetaRepository) GetBySlugTraducido(ctx context.Context, idioma i18n.Idioma, slug string) (*Receta, error) {
+	var model RecetaModel
+	err := r.db.WithContext(ctx).Preload("Categoria").Preload("FotoMedia").Preload("Fotos", ordenFotos).Preload("Fotos.Media").
+		Joins("JOIN receta_traducciones ON receta_traducciones.receta_id = recetas.id").
+		Where("receta_traducciones.idioma = ? AND receta_traducciones.slug = ?", string(idioma), slug).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("repo gorm recetas: getbyslugtraducido %s (%s): %w", slug, idioma, err)
+	}
+	return model.ToDomain(), nil
+}
+
+// FindSinTraduccion recupera las recetas (fuera de la papelera) sin traducción a 'idioma'.
+func (r *gormRecetaRepository) FindSinTraduccion(ctx context.Context, idioma i18n.Idioma) ([]Receta, error) {
+	var models []RecetaModel
+	err := r.db.WithContext(ctx).Preload("Categoria").Preload("FotoMedia").
+		Where("NOT EXISTS (SELECT 1 FROM receta_traducciones WHERE receta_traducciones.receta_id = recetas.id AND receta_traducciones.idioma = ?)", string(idioma)).
+		Order("id desc").Find(&models).Error
+	if err != nil {
+		return nil, fmt.Errorf("repo gorm recetas: findsintraduccion %s: %w", idioma, err)
+	}
+	return RecetaModelsToDomains(models), nil
+}
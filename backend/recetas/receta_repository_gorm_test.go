@@ -14,6 +14,7 @@ import (
 	"testing"
 	"time" // Necesario para time.Now() y WithinDuration
 
+	"backend/media"      // Las recetas referencian sus fotos
 	"backend/categorias" // Necesitaremos crear categorías para las recetas y sus tipos
 	"backend/recetas"    // El paquete bajo test y sus tipos
 	"backend/shared/config"
@@ -55,9 +56,13 @@ func (s *RecetaRepositoryIntegrationTestSuite) SetupSuite() {
 	s.db = db
 	s.T().Logf("SetupSuite: Conexión a BD '%s' exitosa.", s.dbName)
 
-	s.T().Log("SetupSuite: Ejecutando AutoMigrate para CategoriaModel y RecetaModel...")
-	// ¡IMPORTANTE! Migrar AMBOS modelos para que GORM cree la FK correctamente.
-	err = s.db.AutoMigrate(&categorias.CategoriaModel{}, &recetas.RecetaModel{})
+	s.T().Log("SetupSuite: Ejecutando AutoMigrate para CategoriaModel, RecetaModel y sus tablas hijas...")
+	// ¡IMPORTANTE! Migrar todos los modelos que usa el repositorio (Create/Update guardan la
+	// revisión en la misma transacción) para que GORM cree las FK correctamente.
+	err = s.db.AutoMigrate(
+		&categorias.CategoriaModel{}, &media.MediaModel{}, &media.MediaUsoModel{},
+		&recetas.RecetaModel{}, &recetas.RecetaFotoModel{}, &recetas.RecetaRevisionModel{}, &recetas.RecetaTraduccionModel{},
+	)
 	s.Require().NoError(err, "SetupSuite: Falló AutoMigrate para CategoriaModel y/o RecetaModel")
 	s.T().Log("SetupSuite: Tablas 'categorias' y 'recetas' aseguradas/creadas.")
 
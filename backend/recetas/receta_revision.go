@@ -0,0 +1,77 @@
+// backend/recetas/receta_revision.go
+
+// Este archivo define las revisiones de una receta: una copia inmutable del
+// contenido editable de la receta guardada en cada creación y actualización,
+// con su autor y fecha. Permite ver el historial, comparar dos revisiones
+// campo a campo y restaurar una versión anterior (lo que crea una revisión nueva).
+
+package recetas
+
+import (
+	"strconv"
+	"time"
+)
+
+// RecetaRevision es una versión inmutable del contenido de una receta.
+type RecetaRevision struct {
+	ID                uint
+	RecetaID          uint
+	Numero            int   // Consecutivo por receta, empezando en 1
+	AutorID           *uint // Usuario que guardó esta versión (nil = anónimo o anterior al historial)
+	Nombre            string
+	Slug              string
+	TiempoPreparacion string
+	Porciones         int
+	Descripcion       string
+	Foto              string
+	CategoriaID       uint
+	CreatedAt         time.Time
+}
+
+// NuevaRevision copia el contenido editable de la receta en una revisión (sin número).
+func NuevaRevision(receta Receta, autorID *uint) RecetaRevision {
+	return RecetaRevision{
+		RecetaID:          receta.ID,
+		AutorID:           autorID,
+		Nombre:            receta.Nombre,
+		Slug:              receta.Slug,
+		TiempoPreparacion: receta.TiempoPreparacion,
+		Porciones:         receta.Porciones,
+		Descripcion:       receta.Descripcion,
+		Foto:              receta.Foto,
+		CategoriaID:       receta.CategoriaID,
+	}
+}
+
+// CambioCampo describe la diferencia de un campo entre dos revisiones.
+type CambioCampo struct {
+	Campo   string // Nombre del campo en la API (ej: "descripcion")
+	Antes   string
+	Despues string
+}
+
+// camposRevision lista los campos comparables con su nombre en la API.
+var camposRevision = []struct {
+	nombre string
+	valor  func(r RecetaRevision) string
+}{
+	{"nombre", func(r RecetaRevision) string { return r.Nombre }},
+	{"categoria_id", func(r RecetaRevision) string { return strconv.FormatUint(uint64(r.CategoriaID), 10) }},
+	{"tiempo_preparacion", func(r RecetaRevision) string { return r.TiempoPreparacion }},
+	{"porciones", func(r RecetaRevision) string { return strconv.Itoa(r.Porciones) }},
+	{"descripcion", func(r RecetaRevision) string { return r.Descripcion }},
+	{"foto", func(r RecetaRevision) string { return r.Foto }},
+}
+
+// DiferenciarRevisiones devuelve los campos que cambian de 'desde' a 'hasta', en orden fijo.
+// Una revisión vacía (RecetaRevision{}) como 'desde' muestra todo el contenido como nuevo.
+func DiferenciarRevisiones(desde, hasta RecetaRevision) []CambioCampo {
+	cambios := make([]CambioCampo, 0)
+	for _, campo := range camposRevision {
+		antes, despues := campo.valor(desde), campo.valor(hasta)
+		if antes != despues {
+			cambios = append(cambios, CambioCampo{Campo: campo.nombre, Antes: antes, Despues: despues})
+		}
+	}
+	return cambios
+}
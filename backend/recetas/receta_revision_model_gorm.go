@@ -0,0 +1,71 @@
+// backend/recetas/receta_revision_model_gorm.go
+
+// Este archivo define el modelo de persistencia de las revisiones de recetas.
+// Las filas solo se insertan: nunca se actualizan ni se borran desde la aplicación.
+
+package recetas
+
+import "time"
+
+// RecetaRevisionModel representa la tabla 'receta_revisiones'.
+type RecetaRevisionModel struct {
+	ID                uint   `gorm:"primaryKey"`
+	RecetaID          uint   `gorm:"not null;uniqueIndex:uk_receta_revisiones_numero,priority:1"`
+	Numero            int    `gorm:"not null;uniqueIndex:uk_receta_revisiones_numero,priority:2"`
+	AutorID           *uint  `gorm:"index;default:null"`
+	Nombre            string `gorm:"type:varchar(150);not null"`
+	Slug              string `gorm:"type:varchar(180);not null"`
+	TiempoPreparacion string `gorm:"type:varchar(50)"`
+	Porciones         int    `gorm:"not null"`
+	Descripcion       string `gorm:"type:text"`
+	Foto              string `gorm:"type:varchar(100);default:null"`
+	CategoriaID       uint   `gorm:"not null"`
+	CreatedAt         time.Time
+
+	Receta RecetaModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (RecetaRevisionModel) TableName() string {
+	return "receta_revisiones"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *RecetaRevisionModel) ToDomain() *RecetaRevision {
+	if m == nil {
+		return nil
+	}
+	return &RecetaRevision{
+		ID:                m.ID,
+		RecetaID:          m.RecetaID,
+		Numero:            m.Numero,
+		AutorID:           m.AutorID,
+		Nombre:            m.Nombre,
+		Slug:              m.Slug,
+		TiempoPreparacion: m.TiempoPreparacion,
+		Porciones:         m.Porciones,
+		Descripcion:       m.Descripcion,
+		Foto:              m.Foto,
+		CategoriaID:       m.CategoriaID,
+		CreatedAt:         m.CreatedAt,
+	}
+}
+
+func FromRecetaRevisionDomain(d *RecetaRevision) *RecetaRevisionModel {
+	if d == nil {
+		return nil
+	}
+	return &RecetaRevisionModel{
+		ID:                d.ID,
+		RecetaID:          d.RecetaID,
+		Numero:            d.Numero,
+		AutorID:           d.AutorID,
+		Nombre:            d.Nombre,
+		Slug:              d.Slug,
+		TiempoPreparacion: d.TiempoPreparacion,
+		Porciones:         d.Porciones,
+		Descripcion:       d.Descripcion,
+		Foto:              d.Foto,
+		CategoriaID:       d.CategoriaID,
+	}
+}
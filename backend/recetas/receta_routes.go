@@ -13,19 +13,35 @@ import (
 
 // RegisterRecetaRoutes registra las rutas específicas para la entidad Receta.
 // Recibe el grupo de router BASE de la API (ej: el que se crea con router.Group("/api/v1"))
-// y el handler específico para recetas.
-func RegisterRecetaRoutes(apiBaseGroup *gin.RouterGroup, h *RecetaHandler) {
+// y el handler específico para recetas. requireAuth protege las rutas del autor y
+// requireAdmin las de los editores (/admin/recetas).
+func RegisterRecetaRoutes(apiBaseGroup *gin.RouterGroup, h *RecetaHandler, requireAuth, requireAdmin gin.HandlerFunc) {
 	// Crear un subgrupo específico para recetas a partir del grupo base.
 	// Esto resultará en rutas como /api/v1/recetas
 	recetaRoutes := apiBaseGroup.Group("/recetas")
 	{
 		recetaRoutes.GET("", h.GetAll)                 // GET /api/v1/recetas
-		recetaRoutes.POST("", h.Create)                // POST /api/v1/recetas
+		recetaRoutes.POST("", requireAuth, h.Create)   // POST /api/v1/recetas (autor)
 		recetaRoutes.GET("/:id", h.GetByID)            // GET /api/v1/recetas/:id
-		recetaRoutes.PUT("/:id", h.Update)             // PUT /api/v1/recetas/:id
-		recetaRoutes.DELETE("/:id", h.Delete)          // DELETE /api/v1/recetas/:id
-		// Podríamos añadir GET /slug/:slug si implementamos GetBySlug en el handler
-		// recetaRoutes.GET("/slug/:slug", h.GetBySlug)
+		recetaRoutes.PUT("/:id", requireAuth, h.Update)    // PUT /api/v1/recetas/:id (autor o editor)
+		recetaRoutes.DELETE("/:id", requireAuth, h.Delete) // DELETE /api/v1/recetas/:id (autor o editor)
+		recetaRoutes.PATCH("/:id/estado", requireAuth, h.CambiarEstado) // PATCH /api/v1/recetas/:id/estado (autor)
+		recetaRoutes.POST("/:id/foto", requireAuth, h.SubirFoto)      // POST /api/v1/recetas/:id/foto (multipart, campo 'foto'; autor o editor)
+		recetaRoutes.DELETE("/:id/foto", requireAuth, h.EliminarFoto) // DELETE /api/v1/recetas/:id/foto (quita la portada; autor o editor)
+
+		// Galería de fotos (solo el autor o un editor la cambian)
+		recetaRoutes.GET("/:id/fotos", h.GetFotos)                         // GET /api/v1/recetas/:id/fotos
+		recetaRoutes.POST("/:id/fotos", requireAuth, h.AgregarFotoGaleria)              // POST /api/v1/recetas/:id/fotos (multipart, campo 'foto')
+		recetaRoutes.PUT("/:id/fotos", requireAuth, h.ReordenarFotos)                   // PUT /api/v1/recetas/:id/fotos {"ids": [...]}
+		recetaRoutes.PATCH("/:id/fotos/:fotoId", requireAuth, h.EditarFotoGaleria)      // PATCH /api/v1/recetas/:id/fotos/:fotoId
+		recetaRoutes.DELETE("/:id/fotos/:fotoId", requireAuth, h.QuitarFotoGaleria)     // DELETE /api/v1/recetas/:id/fotos/:fotoId
+
+		// Historial de revisiones
+		recetaRoutes.GET("/:id/revisiones", h.GetRevisiones)                        // GET /api/v1/recetas/:id/revisiones
+		recetaRoutes.GET("/:id/revisiones/:rev", h.GetRevision)                     // GET /api/v1/recetas/:id/revisiones/:rev
+		recetaRoutes.GET("/:id/revisiones/:rev/diff", h.DiffRevision)               // GET /api/v1/recetas/:id/revisiones/:rev/diff?desde=N
+		recetaRoutes.POST("/:id/revisiones/:rev/restaurar", requireAuth, h.RestaurarRevision) // POST /api/v1/recetas/:id/revisiones/:rev/restaurar (autor o editor)
+		recetaRoutes.GET("/slug/:slug", h.GetBySlug)                               // GET /api/v1/recetas/slug/:slug (slug en el idioma de la petición)
 	}
 
 	// (Opcional) Rutas para obtener recetas por categoría.
@@ -59,6 +75,27 @@ func RegisterRecetaRoutes(apiBaseGroup *gin.RouterGroup, h *RecetaHandler) {
 		recetasPorCategoria.GET("", h.FindByCategoria)
 	}
 
+	// Recetas del autor en cualquier estado
+	apiBaseGroup.GET("/me/recetas", requireAuth, h.GetMisRecetas) // GET /api/v1/me/recetas
+
+	// Solo editores (admins)
+	recetasAdminRoutes := apiBaseGroup.Group("/admin/recetas", requireAdmin)
+	{
+		recetasAdminRoutes.GET("", h.GetRecetasAdmin)                  // GET /api/v1/admin/recetas?estado=en_revision
+		recetasAdminRoutes.PATCH("/:id/estado", h.CambiarEstadoAdmin) // PATCH /api/v1/admin/recetas/:id/estado
+
+		// Papelera
+		recetasAdminRoutes.GET("/papelera", h.GetPapelera)                           // GET /api/v1/admin/recetas/papelera
+		recetasAdminRoutes.POST("/papelera/:id/restaurar", h.RestaurarDePapelera)   // POST /api/v1/admin/recetas/papelera/:id/restaurar
+		recetasAdminRoutes.DELETE("/papelera/:id", h.PurgarDePapelera)              // DELETE /api/v1/admin/recetas/papelera/:id
+
+		// Traducciones
+		recetasAdminRoutes.GET("/traducciones/faltantes", h.GetTraduccionesFaltantes)   // GET /api/v1/admin/recetas/traducciones/faltantes?idioma=en
+		recetasAdminRoutes.GET("/:id/traducciones", h.GetTraducciones)                 // GET /api/v1/admin/recetas/:id/traducciones
+		recetasAdminRoutes.PUT("/:id/traducciones/:idioma", h.GuardarTraduccion)       // PUT /api/v1/admin/recetas/:id/traducciones/en
+		recetasAdminRoutes.DELETE("/:id/traducciones/:idioma", h.EliminarTraduccion)   // DELETE /api/v1/admin/recetas/:id/traducciones/en
+	}
+
 
 	log.Println("🛣️  Rutas de Recetas configuradas.")
 }
\ No newline at end of file
@@ -10,10 +10,16 @@ import (
 	"errors" // Para errors.Is y crear nuevos errores
 	"fmt" // Para formateo básico de errores si no usamos helper/middleware
 	"log" // Temporal, reemplazar con logger estructurado
+	"sort" // Para ordenar las traducciones faltantes
 	"strings" // Para formatear errores
+	"time"
+	"unicode/utf8" // Longitud de los textos de las fotos
 	"backend/categorias" // Importar para usar la interfaz CategoriaService y errores de dominio de categoría
 	"github.com/gosimple/slug" // Para generar slugs
+	"backend/shared/i18n" // Idiomas de las traducciones
+	"backend/media" // Registro de los archivos de las fotos
 	"backend/shared/repository" // Importar para usar la interfaz RecetaRepository y errores de dominio de receta
+	utils "backend/shared/utilis" // Para buscar un slug libre al restaurar
 )
 
 // RecetaService define el contrato para la lógica de negocio de Recetas.
@@ -24,13 +30,93 @@ type RecetaService interface {
 	GetBySlug(ctx context.Context, slug string) (*Receta, error) // Devuelve una receta por su slug
 	Create(ctx context.Context, input RecetaInputDTO) (*Receta, error)           // Devuelve la receta creada
 	Update(ctx context.Context, id uint, input RecetaInputDTO) (*Receta, error) // Devuelve la receta actualizada
-	Delete(ctx context.Context, id uint) error
+	Delete(ctx context.Context, id uint, lector Lector) error
 	FindByCategoriaID(ctx context.Context, categoriaID uint) ([]Receta, error) // Devuelve recetas por categoría
+
+	// --- Galería de fotos (ver receta_foto.go) ---
+	// Ninguna crea revisión. Tras cada cambio, Foto/FotoID pasan a ser la portada de la galería,
+	// y los archivos que ya no usa nadie se borran del almacenamiento.
+	// Solo pueden cambiarla el autor de la receta o un editor (ver Receta.EditablePor).
+
+	// AgregarFoto añade el archivo registrado 'm' al final de la galería.
+	AgregarFoto(ctx context.Context, recetaID uint, m *media.Media, input RecetaFotoInputDTO, lector Lector) (*RecetaFoto, error)
+	// EditarFoto cambia la leyenda, el texto alternativo o la marca de portada (nil = sin cambios).
+	EditarFoto(ctx context.Context, recetaID, fotoID uint, input RecetaFotoInputDTO, lector Lector) (*RecetaFoto, error)
+	// ReordenarFotos ordena la galería según 'ids', que debe contener todas sus fotos.
+	ReordenarFotos(ctx context.Context, recetaID uint, ids []uint, lector Lector) ([]RecetaFoto, error)
+	QuitarFoto(ctx context.Context, recetaID, fotoID uint, lector Lector) error
+	// ReemplazarPortada pone 'm' como portada en el lugar de la actual, que sale de la galería
+	// (POST /recetas/:id/foto, anterior a la galería).
+	ReemplazarPortada(ctx context.Context, recetaID uint, m *media.Media, lector Lector) (*RecetaFoto, error)
+	// QuitarPortada quita la portada de la galería, o la foto si la receta no tiene galería
+	// (DELETE /recetas/:id/foto, anterior a la galería).
+	QuitarPortada(ctx context.Context, recetaID uint, lector Lector) error
+
+	// --- Historial de revisiones ---
+	// El historial es visible para quien puede ver la receta (ver ObtenerVisible).
+	ListarRevisiones(ctx context.Context, recetaID uint, lector Lector) ([]RecetaRevision, error) // La más reciente primero
+	ObtenerRevision(ctx context.Context, recetaID uint, numero int, lector Lector) (*RecetaRevision, error)
+	// CompararRevisiones devuelve los campos que cambian de la revisión 'desde' a 'hasta' (desde = 0: revisión vacía).
+	CompararRevisiones(ctx context.Context, recetaID uint, desde, hasta int, lector Lector) ([]CambioCampo, error)
+	// RestaurarRevision vuelve la receta al contenido de una revisión anterior, creando una revisión nueva.
+	// Solo puede hacerlo el autor de la receta o un editor (ver Receta.EditablePor).
+	RestaurarRevision(ctx context.Context, recetaID uint, numero int, lector Lector) (*Receta, error)
+
+	// --- Flujo de publicación ---
+	// GetAll y FindByCategoriaID solo devuelven recetas publicadas; GetByID no filtra por estado.
+	// ObtenerVisible es GetByID para lo que pide un usuario (también desde otras características:
+	// comentarios, favoritos, planes...): ErrRecetaNotFound si el lector no puede verla.
+	ObtenerVisible(ctx context.Context, id uint, lector Lector) (*Receta, error)
+	ListarDeAutor(ctx context.Context, autorID uint) ([]Receta, error) // Todas las del autor, en cualquier estado
+	ListarPorEstado(ctx context.Context, estado *EstadoReceta) ([]Receta, error) // Para editores (nil = todos los estados)
+	CambiarEstado(ctx context.Context, id uint, input CambioEstadoInput) (*Receta, error)
+	// PublicarProgramadas publica las recetas cuya fecha programada ya llegó. Devuelve cuántas.
+	PublicarProgramadas(ctx context.Context) (int64, error)
+
+	// --- Papelera ---
+	ListarPapelera(ctx context.Context) ([]Receta, error) // Eliminadas más recientemente primero
+	// RestaurarDePapelera devuelve la receta eliminada a su sitio; si otra receta ocupa
+	// su slug, se le asigna el primer 'slug-N' libre.
+	RestaurarDePapelera(ctx context.Context, id uint) (*Receta, error)
+	PurgarDePapelera(ctx context.Context, id uint) error // Borrado definitivo
+	// PurgarVencidas borra definitivamente las recetas eliminadas antes de 'antesDe' (ver papelera.Purgador).
+	PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error)
+
+	// --- Traducciones (ver receta_traduccion.go) ---
+	// Traducir devuelve las recetas (y sus categorías) en 'idioma' cuando tienen traducción;
+	// las demás, y todas si 'idioma' es el idioma por defecto, se devuelven sin cambios.
+	// No filtra por estado: se aplica sobre lo que ya devolvió otro método.
+	Traducir(ctx context.Context, idioma i18n.Idioma, recetas []Receta) ([]Receta, error)
+	// BuscarPorSlug busca el slug entre las traducciones a 'idioma' y, si no está, entre los
+	// slugs del idioma por defecto. Devuelve la receta sin traducir (como GetBySlug).
+	BuscarPorSlug(ctx context.Context, idioma i18n.Idioma, slug string) (*Receta, error)
+	ListarTraducciones(ctx context.Context, recetaID uint) ([]RecetaTraduccion, error)
+	// GuardarTraduccion crea o reemplaza la traducción; el slug se genera del nombre y es único por idioma.
+	GuardarTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma, input RecetaTraduccionInputDTO) (*RecetaTraduccion, error)
+	EliminarTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma) error
+	// ListarTraduccionesFaltantes devuelve las recetas sin traducción a 'idioma' (nil = a
+	// cualquiera de los idiomas traducibles), las más recientes primero.
+	ListarTraduccionesFaltantes(ctx context.Context, idioma *i18n.Idioma) ([]TraduccionesFaltantes, error)
+}
+
+// RecetaEliminadaListener es notificado después de eliminar una receta, para que
+// otras características que la referencian (ej: planes de comidas) queden consistentes.
+// Se define aquí para que 'recetas' no dependa de esos paquetes.
+type RecetaEliminadaListener interface {
+	RecetaEliminada(ctx context.Context, recetaID uint) error
+}
+
+// RecetaRestauradaListener lo implementan los RecetaEliminadaListener que deshacen lo
+// hecho en RecetaEliminada cuando la receta vuelve de la papelera.
+type RecetaRestauradaListener interface {
+	RecetaRestaurada(ctx context.Context, recetaID uint) error
 }
 
 type recetaService struct { // no exportado
 	recetaRepo    RecetaRepository    // Dependencia de la interfaz del repo de este paquete
 	categoriaSvc  categorias.CategoriaService // Dependencia de la interfaz de CategoriaService del paquete 'categorias'
+	mediaSvc      media.MediaService          // Registro de los archivos de las fotos y sus usos
+	eliminadaListeners []RecetaEliminadaListener // Notificados tras un Delete exitoso
 	// logger      *zap.Logger       // Idealmente inyectar logger
 }
 
@@ -38,20 +124,24 @@ type recetaService struct { // no exportado
 func NewRecetaService(
 	recetaRepo RecetaRepository,
 	categoriaSvc categorias.CategoriaService,
+	mediaSvc media.MediaService,
+	eliminadaListeners ...RecetaEliminadaListener, // Opcional
 	/* logger *zap.Logger */
 ) RecetaService {
 	return &recetaService{
 		recetaRepo:    recetaRepo,
 		categoriaSvc:  categoriaSvc,
+		mediaSvc:      mediaSvc,
+		eliminadaListeners: eliminadaListeners,
 		// logger: logger,
 	}
 }
 
 // --- Implementación de Métodos ---
 
-// GetAll obtiene todas las recetas.
+// GetAll obtiene todas las recetas publicadas.
 func (s *recetaService) GetAll(ctx context.Context) ([]Receta, error) {
-	recs, err := s.recetaRepo.GetAll(ctx)
+	recs, err := s.recetaRepo.Find(ctx, FiltroRecetas{Estados: []EstadoReceta{EstadoPublicada}})
 	if err != nil {
 		// s.logger.Error("Error en servicio GetAll Recetas", zap.Error(err))
 		return nil, fmt.Errorf("servicio recetas: error al obtener todas: %w", err)
@@ -72,6 +162,32 @@ func (s *recetaService) GetByID(ctx context.Context, id uint) (*Receta, error) {
 	return rec, nil
 }
 
+// ObtenerVisible obtiene una receta si el lector puede verla (ver Receta.VisiblePara).
+// Para quien no puede verla, la receta no existe.
+func (s *recetaService) ObtenerVisible(ctx context.Context, id uint, lector Lector) (*Receta, error) {
+	rec, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !rec.VisiblePara(lector) {
+		return nil, ErrRecetaNotFound
+	}
+	return rec, nil
+}
+
+// obtenerEditable es ObtenerVisible para modificar la receta: si el lector la ve pero no
+// es su autor ni editor, ErrRecetaSoloAutor.
+func (s *recetaService) obtenerEditable(ctx context.Context, id uint, lector Lector) (*Receta, error) {
+	rec, err := s.ObtenerVisible(ctx, id, lector)
+	if err != nil {
+		return nil, err
+	}
+	if !rec.EditablePor(lector) {
+		return nil, ErrRecetaSoloAutor
+	}
+	return rec, nil
+}
+
 // GetBySlug obtiene una receta por su slug.
 func (s *recetaService) GetBySlug(ctx context.Context, slug string) (*Receta, error) {
 	rec, err := s.recetaRepo.GetBySlug(ctx, slug)
@@ -95,6 +211,13 @@ func (s *recetaService) Create(ctx context.Context, input RecetaInputDTO) (*Rece
 	if input.CategoriaID == 0 {
 		return nil, ErrRecetaSinCategoria // Error de dominio
 	}
+	if input.Porciones < 0 {
+		return nil, ErrRecetaPorcionesInvalidas
+	}
+	porciones := input.Porciones
+	if porciones == 0 {
+		porciones = PorcionesPorDefecto
+	}
 
 	// 2. Validar que la CategoriaID exista (usando CategoriaService)
 	_, err := s.categoriaSvc.GetByID(ctx, input.CategoriaID)
@@ -121,15 +244,22 @@ func (s *recetaService) Create(ctx context.Context, input RecetaInputDTO) (*Rece
 		Nombre:            nombreLimpio,
 		Slug:              slugReceta,
 		TiempoPreparacion: input.TiempoPreparacion,
+		Porciones:         porciones,
 		Descripcion:       input.Descripcion,
 		Foto:              input.Foto, // El servicio podría procesar/validar la foto aquí
 		CategoriaID:       input.CategoriaID,
+		AutorID:           input.UsuarioID,
+		EditorID:          input.UsuarioID, // Autor de la revisión 1
+		Estado:            EstadoBorrador,  // Visible solo para el autor hasta que se publique
 		// Categoria (el struct) no se asigna aquí, el repo lo carga con Preload si se consulta
 	}
 
 	// 5. Llamar al repositorio para crear
 	if err := s.recetaRepo.Create(ctx, nuevaReceta); err != nil {
 		// s.logger.Error("Error en repo.Create Receta", zap.Error(err))
+		if errors.Is(err, repository.ErrForeignKeyViolation) { // La categoría se borró tras validarla
+			return nil, repository.ConCausa(ErrRecetaSinCategoria, err)
+		}
 		return nil, fmt.Errorf("servicio recetas: error al crear: %w", err)
 	}
 
@@ -148,6 +278,9 @@ func (s *recetaService) Update(ctx context.Context, id uint, input RecetaInputDT
 	if input.CategoriaID == 0 {
 		return nil, ErrRecetaSinCategoria
 	}
+	if input.Porciones < 0 {
+		return nil, ErrRecetaPorcionesInvalidas
+	}
 
 	// 2. Validar que la CategoriaID exista
 	_, err := s.categoriaSvc.GetByID(ctx, input.CategoriaID)
@@ -159,23 +292,28 @@ func (s *recetaService) Update(ctx context.Context, id uint, input RecetaInputDT
 		return nil, fmt.Errorf("servicio recetas: error validando categoría %d: %w", input.CategoriaID, err)
 	}
 
-	// 3. Obtener receta existente para actualizar
-	recetaAActualizar, err := s.recetaRepo.GetByID(ctx, id)
+	// 3. Obtener receta existente para actualizar (solo su autor o un editor)
+	recetaAActualizar, err := s.obtenerEditable(ctx, id, Lector{UsuarioID: input.UsuarioID, Editor: input.Editor})
 	if err != nil {
-		if errors.Is(err, repository.ErrRecordNotFound) {
-			return nil, ErrRecetaNotFound
-		}
-		// s.logger.Error("Error buscando receta para Update", zap.Uint("id", id), zap.Error(err))
-		return nil, fmt.Errorf("servicio recetas: error buscando para update %d: %w", id, err)
+		return nil, err
 	}
 
 	// 4. Actualizar campos
 	recetaAActualizar.Nombre = nombreLimpio
 	recetaAActualizar.Slug = slug.Make(nombreLimpio) // Regenerar slug
 	recetaAActualizar.TiempoPreparacion = input.TiempoPreparacion
+	if input.Porciones > 0 { // 0 = conservar las porciones actuales
+		recetaAActualizar.Porciones = input.Porciones
+	}
 	recetaAActualizar.Descripcion = input.Descripcion
-	recetaAActualizar.Foto = input.Foto
+	fotoAnterior := recetaAActualizar.FotoID
+	if input.Foto != "" && input.Foto != recetaAActualizar.Foto { // Sin foto en la petición = conservar la actual
+		recetaAActualizar.Foto = input.Foto
+		recetaAActualizar.FotoID = nil // El archivo registrado era el de la foto anterior
+		recetaAActualizar.FotoMedia = nil
+	}
 	recetaAActualizar.CategoriaID = input.CategoriaID
+	recetaAActualizar.EditorID = input.UsuarioID // Autor de la nueva revisión
 	// Categoria (el struct) se actualizará en la BD a través de CategoriaID
 	// y se cargará con Preload si se consulta de nuevo.
 
@@ -185,24 +323,25 @@ func (s *recetaService) Update(ctx context.Context, id uint, input RecetaInputDT
 		if errors.Is(err, repository.ErrRecordNotFound) { // Si se borró justo antes
             return nil, ErrRecetaNotFound
         }
+		if errors.Is(err, repository.ErrForeignKeyViolation) {
+			return nil, repository.ConCausa(ErrRecetaSinCategoria, err)
+		}
 		return nil, fmt.Errorf("servicio recetas: error al actualizar: %w", err)
 	}
 
 	log.Printf("Servicio: Receta ID %d actualizada a nombre '%s'\n", recetaAActualizar.ID, recetaAActualizar.Nombre)
+	if recetaAActualizar.FotoID == nil {
+		s.liberarFoto(ctx, id, fotoAnterior)
+	}
 	// Devolver la receta actualizada (podría ser el mismo puntero o uno recargado)
 	return recetaAActualizar, nil
 }
 
-// Delete elimina una receta por su ID.
-func (s *recetaService) Delete(ctx context.Context, id uint) error {
-	// 1. Verificar si existe antes de borrar (buena práctica)
-	_, err := s.recetaRepo.GetByID(ctx, id)
-	if err != nil {
-		if errors.Is(err, repository.ErrRecordNotFound) {
-			return ErrRecetaNotFound
-		}
-		// s.logger.Error("Error buscando receta para Delete", zap.Uint("id", id), zap.Error(err))
-		return fmt.Errorf("servicio recetas: error buscando para delete %d: %w", id, err)
+// Delete elimina una receta por su ID (solo su autor o un editor).
+func (s *recetaService) Delete(ctx context.Context, id uint, lector Lector) error {
+	// 1. Verificar si existe y si el lector puede borrarla
+	if _, err := s.obtenerEditable(ctx, id, lector); err != nil {
+		return err
 	}
 
 	// 2. Llamar al repositorio para eliminar
@@ -215,10 +354,18 @@ func (s *recetaService) Delete(ctx context.Context, id uint) error {
 	}
 
 	log.Printf("Servicio: Receta ID %d eliminada.\n", id)
+
+	// 3. Notificar a las características dependientes. La receta ya está eliminada,
+	//    así que un fallo aquí se loguea pero no revierte ni falla el Delete.
+	for _, l := range s.eliminadaListeners {
+		if err := l.RecetaEliminada(ctx, id); err != nil {
+			log.Printf("Servicio: Error notificando eliminación de receta ID %d: %v\n", id, err)
+		}
+	}
 	return nil
 }
 
-// FindByCategoriaID encuentra todas las recetas de una categoría específica.
+// FindByCategoriaID encuentra las recetas publicadas de una categoría específica.
 func (s *recetaService) FindByCategoriaID(ctx context.Context, categoriaID uint) ([]Receta, error) {
 	// 1. Validar que la CategoriaID exista (opcional, pero bueno para consistencia)
 	_, err := s.categoriaSvc.GetByID(ctx, categoriaID)
@@ -233,10 +380,779 @@ func (s *recetaService) FindByCategoriaID(ctx context.Context, categoriaID uint)
 		return nil, fmt.Errorf("servicio recetas: error validando categoría %d: %w", categoriaID, err)
 	}
 
-	recs, err := s.recetaRepo.FindByCategoriaID(ctx, categoriaID)
+	recs, err := s.recetaRepo.Find(ctx, FiltroRecetas{Estados: []EstadoReceta{EstadoPublicada}, CategoriaID: &categoriaID})
 	if err != nil {
 		// s.logger.Error("Error en servicio FindByCategoriaID", zap.Uint("categoriaID", categoriaID), zap.Error(err))
 		return nil, fmt.Errorf("servicio recetas: error buscando por categoriaID %d: %w", categoriaID, err)
 	}
 	return recs, nil
-}
\ No newline at end of file
+}
+
+// --- Historial de revisiones ---
+
+// ListarRevisiones devuelve el historial de una receta que el lector puede ver.
+func (s *recetaService) ListarRevisiones(ctx context.Context, recetaID uint, lector Lector) ([]RecetaRevision, error) {
+	if _, err := s.ObtenerVisible(ctx, recetaID, lector); err != nil {
+		return nil, err
+	}
+	revisiones, err := s.recetaRepo.FindRevisiones(ctx, recetaID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al listar revisiones de %d: %w", recetaID, err)
+	}
+	return revisiones, nil
+}
+
+// ObtenerRevision devuelve una revisión de una receta que el lector puede ver.
+func (s *recetaService) ObtenerRevision(ctx context.Context, recetaID uint, numero int, lector Lector) (*RecetaRevision, error) {
+	if _, err := s.ObtenerVisible(ctx, recetaID, lector); err != nil {
+		return nil, err
+	}
+	return s.obtenerRevision(ctx, recetaID, numero)
+}
+
+// CompararRevisiones compara dos revisiones de la misma receta.
+func (s *recetaService) CompararRevisiones(ctx context.Context, recetaID uint, desde, hasta int, lector Lector) ([]CambioCampo, error) {
+	revHasta, err := s.ObtenerRevision(ctx, recetaID, hasta, lector)
+	if err != nil {
+		return nil, err
+	}
+	revDesde := &RecetaRevision{} // desde = 0: todo el contenido aparece como nuevo
+	if desde != 0 {
+		if revDesde, err = s.obtenerRevision(ctx, recetaID, desde); err != nil {
+			return nil, err
+		}
+	}
+	return DiferenciarRevisiones(*revDesde, *revHasta), nil
+}
+
+// RestaurarRevision copia el contenido de la revisión en la receta. El historial no se
+// reescribe: la restauración queda como una revisión nueva del usuario que la pide.
+func (s *recetaService) RestaurarRevision(ctx context.Context, recetaID uint, numero int, lector Lector) (*Receta, error) {
+	receta, err := s.obtenerEditable(ctx, recetaID, lector)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.obtenerRevision(ctx, recetaID, numero)
+	if err != nil {
+		return nil, err
+	}
+
+	// La categoría de la revisión puede haber sido eliminada después.
+	if _, err := s.categoriaSvc.GetByID(ctx, revision.CategoriaID); err != nil {
+		if errors.Is(err, categorias.ErrCategoriaNotFound) {
+			return nil, fmt.Errorf("%w: la categoría ID %d de la revisión %d ya no existe", ErrRecetaSinCategoria, revision.CategoriaID, numero)
+		}
+		return nil, fmt.Errorf("servicio recetas: error validando categoría %d: %w", revision.CategoriaID, err)
+	}
+
+	receta.Nombre = revision.Nombre
+	receta.Slug = revision.Slug
+	receta.TiempoPreparacion = revision.TiempoPreparacion
+	receta.Porciones = revision.Porciones
+	receta.Descripcion = revision.Descripcion
+	fotoAnterior := receta.FotoID
+	if receta.Foto != revision.Foto { // El archivo registrado actual no es el de la revisión
+		receta.Foto = revision.Foto
+		receta.FotoID = nil
+		receta.FotoMedia = nil
+	}
+	receta.CategoriaID = revision.CategoriaID
+	receta.Categoria = nil // Puede haber cambiado; se recarga abajo
+	receta.EditorID = lector.UsuarioID
+
+	if err := s.recetaRepo.Update(ctx, receta); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrRecetaNotFound
+		}
+		return nil, fmt.Errorf("servicio recetas: error al restaurar revisión %d de %d: %w", numero, recetaID, err)
+	}
+	log.Printf("Servicio: Receta ID %d restaurada a la revisión %d\n", recetaID, numero)
+	if receta.FotoID == nil {
+		s.liberarFoto(ctx, recetaID, fotoAnterior)
+	}
+	return s.GetByID(ctx, recetaID)
+}
+
+func (s *recetaService) obtenerRevision(ctx context.Context, recetaID uint, numero int) (*RecetaRevision, error) {
+	revision, err := s.recetaRepo.GetRevision(ctx, recetaID, numero)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("servicio recetas: error al obtener revisión %d de %d: %w", numero, recetaID, err)
+	}
+	return revision, nil
+}
+
+// --- Flujo de publicación ---
+
+// ListarDeAutor devuelve todas las recetas de un autor, en cualquier estado.
+func (s *recetaService) ListarDeAutor(ctx context.Context, autorID uint) ([]Receta, error) {
+	recs, err := s.recetaRepo.Find(ctx, FiltroRecetas{AutorID: &autorID})
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al listar recetas del autor %d: %w", autorID, err)
+	}
+	return recs, nil
+}
+
+// ListarPorEstado devuelve las recetas en un estado (nil = todas) para los editores.
+func (s *recetaService) ListarPorEstado(ctx context.Context, estado *EstadoReceta) ([]Receta, error) {
+	filtro := FiltroRecetas{}
+	if estado != nil {
+		if !estado.EsValido() {
+			return nil, ErrEstadoRecetaInvalido
+		}
+		filtro.Estados = []EstadoReceta{*estado}
+	}
+	recs, err := s.recetaRepo.Find(ctx, filtro)
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al listar recetas por estado: %w", err)
+	}
+	return recs, nil
+}
+
+// CambiarEstado mueve una receta en el flujo de publicación.
+// El autor solo puede alternar entre borrador y en_revision; publicar (en el momento
+// o programado con PublicarEn) y archivar quedan para los editores.
+func (s *recetaService) CambiarEstado(ctx context.Context, id uint, input CambioEstadoInput) (*Receta, error) {
+	if !input.Estado.EsValido() {
+		return nil, ErrEstadoRecetaInvalido
+	}
+	if input.PublicarEn != nil && input.Estado != EstadoPublicada {
+		return nil, ErrPublicarEnInvalido
+	}
+
+	receta, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.ComoEditor {
+		if input.UsuarioID == nil || !receta.EsDelAutor(*input.UsuarioID) {
+			return nil, ErrRecetaNoEsDelAutor
+		}
+		if input.Estado != EstadoBorrador && input.Estado != EstadoEnRevision {
+			return nil, ErrCambioEstadoSoloEditor
+		}
+	}
+
+	if !PuedeCambiarEstado(receta.Estado, input.Estado) {
+		return nil, fmt.Errorf("%w: de %s a %s", ErrTransicionEstadoInvalida, receta.Estado, input.Estado)
+	}
+
+	ahora := time.Now()
+	nuevoEstado := input.Estado
+	var publicarEn *time.Time // Sin fecha salvo al publicar
+	if input.Estado == EstadoPublicada {
+		if input.PublicarEn != nil && input.PublicarEn.After(ahora) {
+			// Programada: sigue en revisión hasta que el PublicadorProgramado la publique.
+			nuevoEstado = EstadoEnRevision
+			publicarEn = input.PublicarEn
+		} else {
+			publicarEn = &ahora
+		}
+	}
+
+	if err := s.recetaRepo.UpdateEstado(ctx, id, nuevoEstado, publicarEn); err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al cambiar estado de %d: %w", id, err)
+	}
+
+	receta.Estado = nuevoEstado
+	receta.PublicarEn = publicarEn
+	log.Printf("Servicio: Receta ID %d pasa a estado '%s'\n", id, nuevoEstado)
+	return receta, nil
+}
+
+// PublicarProgramadas publica las recetas programadas cuya fecha ya llegó.
+func (s *recetaService) PublicarProgramadas(ctx context.Context) (int64, error) {
+	n, err := s.recetaRepo.PublicarProgramadas(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("servicio recetas: error al publicar programadas: %w", err)
+	}
+	if n > 0 {
+		log.Printf("Servicio: %d receta(s) programada(s) publicada(s)\n", n)
+	}
+	return n, nil
+}
+
+// --- Papelera ---
+
+// ListarPapelera devuelve las recetas eliminadas.
+func (s *recetaService) ListarPapelera(ctx context.Context) ([]Receta, error) {
+	recs, err := s.recetaRepo.FindEliminadas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al listar papelera: %w", err)
+	}
+	return recs, nil
+}
+
+// RestaurarDePapelera restaura una receta eliminada; su categoría debe seguir activa.
+func (s *recetaService) RestaurarDePapelera(ctx context.Context, id uint) (*Receta, error) {
+	receta, err := s.recetaRepo.GetEliminadaByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrRecetaNotFound
+		}
+		return nil, fmt.Errorf("servicio recetas: error buscando receta %d en papelera: %w", id, err)
+	}
+
+	if _, err := s.categoriaSvc.GetByID(ctx, receta.CategoriaID); err != nil {
+		if errors.Is(err, categorias.ErrCategoriaNotFound) {
+			return nil, fmt.Errorf("%w (causa original: %w): restaure primero la categoría ID %d",
+				ErrRecetaSinCategoria, err, receta.CategoriaID)
+		}
+		return nil, fmt.Errorf("servicio recetas: error validando categoría %d: %w", receta.CategoriaID, err)
+	}
+
+	slugLibre, err := utils.SlugDisponible(ctx, receta.Slug, func(ctx context.Context, candidato string) (bool, error) {
+		existente, err := s.recetaRepo.GetBySlug(ctx, candidato)
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return false, nil
+		}
+		return err == nil && existente.ID != id, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error buscando slug libre para %d: %w", id, err)
+	}
+
+	if err := s.recetaRepo.Restaurar(ctx, id, slugLibre); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrRecetaNotFound // Restaurada o purgada entre tanto
+		}
+		return nil, fmt.Errorf("servicio recetas: error al restaurar %d: %w", id, err)
+	}
+	if slugLibre != receta.Slug {
+		log.Printf("Servicio: Receta ID %d restaurada con slug '%s' (el original '%s' estaba ocupado)\n", id, slugLibre, receta.Slug)
+	} else {
+		log.Printf("Servicio: Receta ID %d restaurada de la papelera\n", id)
+	}
+
+	// Como en Delete, un fallo de los listeners se loguea pero no deshace la restauración.
+	for _, l := range s.eliminadaListeners {
+		if rl, ok := l.(RecetaRestauradaListener); ok {
+			if err := rl.RecetaRestaurada(ctx, id); err != nil {
+				log.Printf("Servicio: Error notificando restauración de receta ID %d: %v\n", id, err)
+			}
+		}
+	}
+	return s.GetByID(ctx, id)
+}
+
+// PurgarDePapelera borra definitivamente una receta de la papelera.
+func (s *recetaService) PurgarDePapelera(ctx context.Context, id uint) error {
+	receta, err := s.recetaRepo.GetEliminadaByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrRecetaNotFound
+		}
+		return fmt.Errorf("servicio recetas: error buscando para purgar %d: %w", id, err)
+	}
+	fotos, err := s.recetaRepo.FindFotos(ctx, id) // La galería cae en cascada con la receta
+	if err != nil {
+		return fmt.Errorf("servicio recetas: error buscando fotos para purgar %d: %w", id, err)
+	}
+	if err := s.recetaRepo.Purgar(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrRecetaNotFound
+		}
+		return fmt.Errorf("servicio recetas: error al purgar %d: %w", id, err)
+	}
+	log.Printf("Servicio: Receta ID %d purgada definitivamente\n", id)
+	s.liberarFoto(ctx, id, receta.FotoID)
+	s.liberarGaleria(ctx, fotos)
+	return nil
+}
+
+// PurgarVencidas borra definitivamente las recetas eliminadas antes de 'antesDe'.
+func (s *recetaService) PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error) {
+	// Las fotos se liberan después del borrado, así que se anotan antes.
+	eliminadas, err := s.recetaRepo.FindEliminadas(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("servicio recetas: error al buscar vencidas: %w", err)
+	}
+	var vencidas []Receta
+	for _, r := range eliminadas {
+		if r.EliminadaEn != nil && r.EliminadaEn.Before(antesDe) {
+			if r.Fotos, err = s.recetaRepo.FindFotos(ctx, r.ID); err != nil {
+				return 0, fmt.Errorf("servicio recetas: error buscando fotos de %d: %w", r.ID, err)
+			}
+			vencidas = append(vencidas, r)
+		}
+	}
+	n, err := s.recetaRepo.PurgarEliminadasAntesDe(ctx, antesDe)
+	if err != nil {
+		return 0, fmt.Errorf("servicio recetas: error al purgar vencidas: %w", err)
+	}
+	for _, r := range vencidas {
+		s.liberarFoto(ctx, r.ID, r.FotoID)
+		s.liberarGaleria(ctx, r.Fotos)
+	}
+	return n, nil
+}
+
+// --- Foto ---
+
+// cambiarFoto reemplaza la foto de una receta por el archivo registrado 'm' (nil = quitarla).
+func (s *recetaService) cambiarFoto(ctx context.Context, id uint, m *media.Media) error {
+	foto, fotoID := "", (*uint)(nil)
+	if m != nil {
+		// El uso se registra antes de asignarla: si la receta no existe, sobra un uso
+		// (se libera abajo), pero nunca queda una receta con un archivo sin uso.
+		if err := s.mediaSvc.Usar(ctx, m.ID, EntidadMedia, id); err != nil {
+			return fmt.Errorf("servicio recetas: error al registrar foto de %d: %w", id, err)
+		}
+		foto, fotoID = m.Archivo(), &m.ID
+	}
+	anterior, err := s.recetaRepo.ActualizarFoto(ctx, id, foto, fotoID)
+	if err != nil {
+		if m != nil {
+			s.liberarFoto(ctx, id, fotoID)
+		}
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrRecetaNotFound
+		}
+		return fmt.Errorf("servicio recetas: error al cambiar foto de %d: %w", id, err)
+	}
+	if m == nil {
+		log.Printf("Servicio: Foto de la receta ID %d eliminada\n", id)
+	} else {
+		log.Printf("Servicio: Foto de la receta ID %d cambiada a '%s' (media ID %d)\n", id, foto, m.ID)
+	}
+	if anterior != nil && (fotoID == nil || *anterior != *fotoID) {
+		s.liberarFoto(ctx, id, anterior)
+	}
+	return nil
+}
+
+// liberarFoto quita el uso del archivo por la receta (se borra si nadie más lo usa).
+// La receta ya está guardada, así que un fallo se loguea pero no se devuelve.
+func (s *recetaService) liberarFoto(ctx context.Context, id uint, fotoID *uint) {
+	if fotoID == nil {
+		return
+	}
+	if err := s.mediaSvc.Liberar(ctx, *fotoID, EntidadMedia, id); err != nil {
+		log.Printf("Servicio: Error liberando la foto (media ID %d) de la receta ID %d: %v\n", *fotoID, id, err)
+	}
+}
+
+// liberarGaleria quita los usos de los archivos de las fotos de una galería ya borrada.
+func (s *recetaService) liberarGaleria(ctx context.Context, fotos []RecetaFoto) {
+	for _, f := range fotos {
+		if err := s.mediaSvc.Liberar(ctx, f.MediaID, EntidadMediaGaleria, f.ID); err != nil {
+			log.Printf("Servicio: Error liberando la foto ID %d (media ID %d) de la receta ID %d: %v\n", f.ID, f.MediaID, f.RecetaID, err)
+		}
+	}
+}
+
+// --- Galería de fotos ---
+
+// AgregarFoto añade una foto al final de la galería.
+func (s *recetaService) AgregarFoto(ctx context.Context, recetaID uint, m *media.Media, input RecetaFotoInputDTO, lector Lector) (*RecetaFoto, error) {
+	foto := &RecetaFoto{RecetaID: recetaID, MediaID: m.ID, Media: m}
+	if err := aplicarInputFoto(foto, input); err != nil {
+		return nil, err
+	}
+	receta, err := s.obtenerEditable(ctx, recetaID, lector)
+	if err != nil {
+		return nil, err
+	}
+	fotos, err := s.galeria(ctx, receta)
+	if err != nil {
+		return nil, err
+	}
+	if len(fotos) >= MaxFotosPorReceta {
+		return nil, ErrRecetaDemasiadasFotos
+	}
+	if err := s.crearFoto(ctx, foto); err != nil {
+		return nil, err
+	}
+	log.Printf("Servicio: Foto ID %d (media ID %d) añadida a la galería de la receta ID %d\n", foto.ID, m.ID, recetaID)
+	return foto, s.sincronizarPortada(ctx, receta)
+}
+
+// EditarFoto cambia los textos o la marca de portada de una foto.
+func (s *recetaService) EditarFoto(ctx context.Context, recetaID, fotoID uint, input RecetaFotoInputDTO, lector Lector) (*RecetaFoto, error) {
+	receta, err := s.obtenerEditable(ctx, recetaID, lector)
+	if err != nil {
+		return nil, err
+	}
+	foto, err := buscarFoto(receta.Fotos, fotoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := aplicarInputFoto(foto, input); err != nil {
+		return nil, err
+	}
+	if err := s.recetaRepo.UpdateFoto(ctx, foto); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, ErrRecetaFotoNotFound
+		}
+		return nil, fmt.Errorf("servicio recetas: error al editar foto %d de %d: %w", fotoID, recetaID, err)
+	}
+	return foto, s.sincronizarPortada(ctx, receta)
+}
+
+// ReordenarFotos cambia el orden de la galería.
+func (s *recetaService) ReordenarFotos(ctx context.Context, recetaID uint, ids []uint, lector Lector) ([]RecetaFoto, error) {
+	receta, err := s.obtenerEditable(ctx, recetaID, lector)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(receta.Fotos) {
+		return nil, ErrOrdenFotosInvalido
+	}
+	pendientes := make(map[uint]bool, len(receta.Fotos))
+	for _, f := range receta.Fotos {
+		pendientes[f.ID] = true
+	}
+	for _, id := range ids {
+		if !pendientes[id] { // Ajena a la galería o repetida
+			return nil, fmt.Errorf("%w: foto %d", ErrOrdenFotosInvalido, id)
+		}
+		delete(pendientes, id)
+	}
+	if err := s.recetaRepo.ReordenarFotos(ctx, recetaID, ids); err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al reordenar fotos de %d: %w", recetaID, err)
+	}
+	if err := s.sincronizarPortada(ctx, receta); err != nil {
+		return nil, err
+	}
+	fotos, err := s.recetaRepo.FindFotos(ctx, recetaID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al obtener fotos de %d: %w", recetaID, err)
+	}
+	return fotos, nil
+}
+
+// QuitarFoto saca una foto de la galería; su archivo se borra si nadie más lo usa.
+func (s *recetaService) QuitarFoto(ctx context.Context, recetaID, fotoID uint, lector Lector) error {
+	receta, err := s.obtenerEditable(ctx, recetaID, lector)
+	if err != nil {
+		return err
+	}
+	foto, err := buscarFoto(receta.Fotos, fotoID)
+	if err != nil {
+		return err
+	}
+	return s.quitarFoto(ctx, receta, *foto)
+}
+
+// ReemplazarPortada pone 'm' como portada y saca de la galería la portada anterior.
+func (s *recetaService) ReemplazarPortada(ctx context.Context, recetaID uint, m *media.Media, lector Lector) (*RecetaFoto, error) {
+	receta, err := s.obtenerEditable(ctx, recetaID, lector)
+	if err != nil {
+		return nil, err
+	}
+	fotos, err := s.galeria(ctx, receta)
+	if err != nil {
+		return nil, err
+	}
+	anterior := PortadaDe(fotos)
+	var foto *RecetaFoto
+	for i := range fotos {
+		if fotos[i].MediaID == m.ID { // Ya está en la galería: solo se marca
+			foto = &fotos[i]
+		}
+	}
+	if foto != nil {
+		foto.Portada = true
+		if err := s.recetaRepo.UpdateFoto(ctx, foto); err != nil {
+			return nil, fmt.Errorf("servicio recetas: error al marcar portada %d de %d: %w", foto.ID, recetaID, err)
+		}
+	} else {
+		if anterior == nil && len(fotos) >= MaxFotosPorReceta {
+			return nil, ErrRecetaDemasiadasFotos
+		}
+		foto = &RecetaFoto{RecetaID: recetaID, MediaID: m.ID, Media: m, Portada: true}
+		if anterior != nil { // Hereda los textos de la portada a la que reemplaza
+			foto.Leyenda, foto.TextoAlt = anterior.Leyenda, anterior.TextoAlt
+		}
+		if err := s.crearFoto(ctx, foto); err != nil {
+			return nil, err
+		}
+	}
+	if anterior != nil && anterior.ID != foto.ID {
+		return foto, s.quitarFoto(ctx, receta, *anterior)
+	}
+	return foto, s.sincronizarPortada(ctx, receta)
+}
+
+// QuitarPortada quita la portada de la galería (o la foto, si la receta no tiene galería).
+func (s *recetaService) QuitarPortada(ctx context.Context, recetaID uint, lector Lector) error {
+	receta, err := s.obtenerEditable(ctx, recetaID, lector)
+	if err != nil {
+		return err
+	}
+	portada := PortadaDe(receta.Fotos)
+	if portada == nil {
+		return s.cambiarFoto(ctx, recetaID, nil)
+	}
+	return s.quitarFoto(ctx, receta, *portada)
+}
+
+// galeria devuelve las fotos de la receta. Si aún no tiene galería pero sí una foto registrada
+// (subida antes de existir la galería), la incorpora como portada para no perderla al añadir otras.
+func (s *recetaService) galeria(ctx context.Context, receta *Receta) ([]RecetaFoto, error) {
+	if len(receta.Fotos) > 0 || receta.FotoMedia == nil {
+		return receta.Fotos, nil
+	}
+	foto := &RecetaFoto{RecetaID: receta.ID, MediaID: receta.FotoMedia.ID, Media: receta.FotoMedia, Portada: true}
+	if err := s.crearFoto(ctx, foto); err != nil {
+		return nil, err
+	}
+	receta.Fotos = []RecetaFoto{*foto}
+	return receta.Fotos, nil
+}
+
+// crearFoto guarda la foto en la galería y registra el uso de su archivo.
+func (s *recetaService) crearFoto(ctx context.Context, foto *RecetaFoto) error {
+	if err := s.recetaRepo.CreateFoto(ctx, foto); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrRecordNotFound):
+			return ErrRecetaNotFound
+		case errors.Is(err, repository.ErrDuplicateRecord):
+			return ErrRecetaFotoDuplicada
+		case errors.Is(err, repository.ErrForeignKeyViolation):
+			return media.ErrMediaNotFound
+		}
+		return fmt.Errorf("servicio recetas: error al añadir foto a %d: %w", foto.RecetaID, err)
+	}
+	if err := s.mediaSvc.Usar(ctx, foto.MediaID, EntidadMediaGaleria, foto.ID); err != nil {
+		// Sin el uso, el archivo podría borrarse con la foto aún en la galería: deshacer el alta.
+		if errDelete := s.recetaRepo.DeleteFoto(ctx, foto.RecetaID, foto.ID); errDelete != nil {
+			log.Printf("Servicio: Error deshaciendo la foto ID %d de la receta ID %d: %v\n", foto.ID, foto.RecetaID, errDelete)
+		}
+		return fmt.Errorf("servicio recetas: error al registrar foto de %d: %w", foto.RecetaID, err)
+	}
+	return nil
+}
+
+// quitarFoto borra la foto de la galería, recalcula la portada y libera el archivo.
+func (s *recetaService) quitarFoto(ctx context.Context, receta *Receta, foto RecetaFoto) error {
+	if err := s.recetaRepo.DeleteFoto(ctx, receta.ID, foto.ID); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrRecetaFotoNotFound
+		}
+		return fmt.Errorf("servicio recetas: error al quitar foto %d de %d: %w", foto.ID, receta.ID, err)
+	}
+	log.Printf("Servicio: Foto ID %d quitada de la galería de la receta ID %d\n", foto.ID, receta.ID)
+	err := s.sincronizarPortada(ctx, receta) // Antes de liberar: si era la portada, deja de usarse también como tal
+	s.liberarGaleria(ctx, []RecetaFoto{foto})
+	return err
+}
+
+// sincronizarPortada guarda en Foto/FotoID la portada actual de la galería. Si la galería
+// queda vacía, quita la foto, salvo las anteriores a la galería (sin registro en 'media').
+func (s *recetaService) sincronizarPortada(ctx context.Context, receta *Receta) error {
+	fotos, err := s.recetaRepo.FindFotos(ctx, receta.ID)
+	if err != nil {
+		return fmt.Errorf("servicio recetas: error al obtener fotos de %d: %w", receta.ID, err)
+	}
+	portada := PortadaDe(fotos)
+	switch {
+	case portada == nil && receta.FotoID != nil:
+		err = s.cambiarFoto(ctx, receta.ID, nil)
+	case portada != nil && (receta.FotoID == nil || *receta.FotoID != portada.MediaID):
+		err = s.cambiarFoto(ctx, receta.ID, portada.Media)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if portada == nil {
+		receta.Foto, receta.FotoID, receta.FotoMedia = "", nil, nil
+	} else {
+		receta.Foto, receta.FotoID, receta.FotoMedia = portada.Media.Archivo(), &portada.MediaID, portada.Media
+	}
+	return nil
+}
+
+// buscarFoto devuelve la foto 'fotoID' de la galería (ErrRecetaFotoNotFound si no está).
+func buscarFoto(fotos []RecetaFoto, fotoID uint) (*RecetaFoto, error) {
+	for i := range fotos {
+		if fotos[i].ID == fotoID {
+			return &fotos[i], nil
+		}
+	}
+	return nil, ErrRecetaFotoNotFound
+}
+
+// aplicarInputFoto copia a la foto los campos indicados, validando su longitud.
+func aplicarInputFoto(foto *RecetaFoto, input RecetaFotoInputDTO) error {
+	if input.Leyenda != nil {
+		foto.Leyenda = strings.TrimSpace(*input.Leyenda)
+	}
+	if input.TextoAlt != nil {
+		foto.TextoAlt = strings.TrimSpace(*input.TextoAlt)
+	}
+	if input.Portada != nil {
+		foto.Portada = *input.Portada
+	}
+	if utf8.RuneCountInString(foto.Leyenda) > MaxLongitudTextoFoto || utf8.RuneCountInString(foto.TextoAlt) > MaxLongitudTextoFoto {
+		return ErrRecetaFotoTextoInvalido
+	}
+	return nil
+}
+
+// --- Traducciones ---
+
+// Traducir aplica las traducciones a 'idioma' de las recetas y de sus categorías.
+func (s *recetaService) Traducir(ctx context.Context, idioma i18n.Idioma, recs []Receta) ([]Receta, error) {
+	if !i18n.EsTraducible(idioma) || len(recs) == 0 {
+		return recs, nil
+	}
+	ids := make([]uint, 0, len(recs))
+	var cats []categorias.Categoria
+	vistas := make(map[uint]bool)
+	for _, r := range recs {
+		ids = append(ids, r.ID)
+		if r.Categoria != nil && !vistas[r.Categoria.ID] {
+			vistas[r.Categoria.ID] = true
+			cats = append(cats, *r.Categoria)
+		}
+	}
+
+	traducciones, err := s.recetaRepo.FindTraduccionesEnIdioma(ctx, idioma, ids)
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al obtener traducciones '%s': %w", idioma, err)
+	}
+	porReceta := make(map[uint]RecetaTraduccion, len(traducciones))
+	for _, t := range traducciones {
+		porReceta[t.RecetaID] = t
+	}
+
+	porCategoria := make(map[uint]categorias.Categoria, len(cats))
+	if len(cats) > 0 {
+		catsTraducidas, err := s.categoriaSvc.Traducir(ctx, idioma, cats)
+		if err != nil {
+			return nil, fmt.Errorf("servicio recetas: error al traducir categorías: %w", err)
+		}
+		for _, c := range catsTraducidas {
+			porCategoria[c.ID] = c
+		}
+	}
+
+	traducidas := make([]Receta, len(recs)) // No modificar el slice recibido
+	for i, r := range recs {
+		if t, ok := porReceta[r.ID]; ok {
+			r = r.Traducida(t)
+		}
+		if r.Categoria != nil {
+			if c, ok := porCategoria[r.Categoria.ID]; ok {
+				r.Categoria = &c
+			}
+		}
+		traducidas[i] = r
+	}
+	return traducidas, nil
+}
+
+// BuscarPorSlug resuelve un slug en el idioma indicado, con respaldo al idioma por defecto.
+func (s *recetaService) BuscarPorSlug(ctx context.Context, idioma i18n.Idioma, slug string) (*Receta, error) {
+	if i18n.EsTraducible(idioma) {
+		rec, err := s.recetaRepo.GetBySlugTraducido(ctx, idioma, slug)
+		if err == nil {
+			return rec, nil
+		}
+		if !errors.Is(err, repository.ErrRecordNotFound) {
+			return nil, fmt.Errorf("servicio recetas: error al obtener por slug %s (%s): %w", slug, idioma, err)
+		}
+	}
+	return s.GetBySlug(ctx, slug)
+}
+
+// ListarTraducciones devuelve las traducciones de una receta existente.
+func (s *recetaService) ListarTraducciones(ctx context.Context, recetaID uint) ([]RecetaTraduccion, error) {
+	if _, err := s.GetByID(ctx, recetaID); err != nil {
+		return nil, err
+	}
+	traducciones, err := s.recetaRepo.FindTraducciones(ctx, recetaID)
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error al listar traducciones de %d: %w", recetaID, err)
+	}
+	return traducciones, nil
+}
+
+// GuardarTraduccion crea o reemplaza la traducción de una receta a un idioma.
+func (s *recetaService) GuardarTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma, input RecetaTraduccionInputDTO) (*RecetaTraduccion, error) {
+	if !i18n.EsTraducible(idioma) {
+		return nil, fmt.Errorf("%w: '%s'", i18n.ErrIdiomaNoTraducible, idioma)
+	}
+	nombreLimpio := strings.TrimSpace(input.Nombre)
+	if nombreLimpio == "" {
+		return nil, ErrRecetaNombreInvalido
+	}
+	if _, err := s.GetByID(ctx, recetaID); err != nil {
+		return nil, err
+	}
+
+	slugLibre, err := utils.SlugDisponible(ctx, slug.Make(nombreLimpio), func(ctx context.Context, candidato string) (bool, error) {
+		return s.recetaRepo.SlugTraducidoEnUso(ctx, idioma, candidato, recetaID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("servicio recetas: error buscando slug libre para la traducción '%s' de %d: %w", idioma, recetaID, err)
+	}
+
+	traduccion := &RecetaTraduccion{
+		RecetaID:    recetaID,
+		Idioma:      idioma,
+		Nombre:      nombreLimpio,
+		Slug:        slugLibre,
+		Descripcion: input.Descripcion,
+	}
+	if err := s.recetaRepo.GuardarTraduccion(ctx, traduccion); err != nil {
+		if errors.Is(err, repository.ErrForeignKeyViolation) { // La receta se purgó entre la verificación y el guardado
+			return nil, repository.ConCausa(ErrRecetaNotFound, err)
+		}
+		return nil, fmt.Errorf("servicio recetas: error al guardar traducción '%s' de %d: %w", idioma, recetaID, err)
+	}
+
+	log.Printf("Servicio: Traducción '%s' de la receta ID %d guardada (slug '%s')\n", idioma, recetaID, slugLibre)
+	return traduccion, nil
+}
+
+// EliminarTraduccion borra la traducción de una receta a un idioma.
+func (s *recetaService) EliminarTraduccion(ctx context.Context, recetaID uint, idioma i18n.Idioma) error {
+	if !i18n.EsTraducible(idioma) {
+		return fmt.Errorf("%w: '%s'", i18n.ErrIdiomaNoTraducible, idioma)
+	}
+	if err := s.recetaRepo.DeleteTraduccion(ctx, recetaID, idioma); err != nil {
+		if errors.Is(err, repository.ErrRecordNotFound) {
+			return ErrRecetaTraduccionNotFound
+		}
+		return fmt.Errorf("servicio recetas: error al eliminar traducción '%s' de %d: %w", idioma, recetaID, err)
+	}
+	log.Printf("Servicio: Traducción '%s' de la receta ID %d eliminada\n", idioma, recetaID)
+	return nil
+}
+
+// ListarTraduccionesFaltantes agrupa por receta los idiomas a los que le falta traducción.
+func (s *recetaService) ListarTraduccionesFaltantes(ctx context.Context, idioma *i18n.Idioma) ([]TraduccionesFaltantes, error) {
+	idiomas := i18n.Traducibles()
+	if idioma != nil {
+		if !i18n.EsTraducible(*idioma) {
+			return nil, fmt.Errorf("%w: '%s'", i18n.ErrIdiomaNoTraducible, *idioma)
+		}
+		idiomas = []i18n.Idioma{*idioma}
+	}
+
+	faltantes := []TraduccionesFaltantes{}
+	indice := make(map[uint]int) // recetaID -> posición en 'faltantes'
+	for _, id := range idiomas {
+		recs, err := s.recetaRepo.FindSinTraduccion(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("servicio recetas: error al buscar recetas sin traducción '%s': %w", id, err)
+		}
+		for _, r := range recs {
+			if i, ok := indice[r.ID]; ok {
+				faltantes[i].Idiomas = append(faltantes[i].Idiomas, id)
+				continue
+			}
+			indice[r.ID] = len(faltantes)
+			faltantes = append(faltantes, TraduccionesFaltantes{Receta: r, Idiomas: []i18n.Idioma{id}})
+		}
+	}
+	sort.SliceStable(faltantes, func(i, j int) bool { return faltantes[i].Receta.ID > faltantes[j].Receta.ID })
+	return faltantes, nil
+}
@@ -10,11 +10,28 @@ type RecetaInputDTO struct {
 	Nombre            string
 	CategoriaID       uint
 	TiempoPreparacion string
+	Porciones         int    // Opcional: 0 = por defecto (Create) o sin cambios (Update)
 	Descripcion       string
 	Foto              string // Nombre del archivo o URL (el servicio podría procesar esto)
+	UsuarioID         *uint  // Usuario autenticado que crea/edita (queda como autor de la revisión)
+	Editor            bool   // El usuario puede editar recetas ajenas
 	// Ingredientes      []uint // Ejemplo: Podríamos recibir IDs de ingredientes aquí a futuro
 }
 
+// RecetaTraduccionInputDTO es el contenido traducido de una receta (el idioma va aparte).
+type RecetaTraduccionInputDTO struct {
+	Nombre      string
+	Descripcion string // Descripción o pasos
+}
+
+// RecetaFotoInputDTO son los datos editables de una foto de la galería.
+// nil = sin cambios al editar (vacío/no al añadir).
+type RecetaFotoInputDTO struct {
+	Leyenda  *string
+	TextoAlt *string
+	Portada  *bool
+}
+
 // Podrías tener otros DTOs de servicio si fueran necesarios, por ejemplo, para filtros:
 // type RecetaFiltroDTO struct {
 //     NombreContiene *string
@@ -6,12 +6,16 @@
 package recetas_test // Usar paquete _test para forzar testing de API pública
 
 import (
-	"backend/categorias"    // Para Categoria y CategoriaService, ErrCategoriaNotFound
-	"backend/recetas"       // El paquete que estamos probando
-	"backend/recetas/mocks" // Nuestros mocks
+	"backend/categorias"        // Para Categoria y CategoriaService, ErrCategoriaNotFound
+	"backend/media"             // Archivos registrados de las fotos
+	"backend/recetas"           // El paquete que estamos probando
+	"backend/recetas/mocks"     // Nuestros mocks
+	"backend/shared/i18n"       // Idiomas de las traducciones
+	"backend/shared/repository" // Para repository.ErrRecordNotFound
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,6 +29,7 @@ type RecetaServiceTestSuite struct {
 	suite.Suite
 	mockRecetaRepo   *mocks.RecetaRepositoryMock
 	mockCategoriaSvc *mocks.CategoriaServiceMock
+	mockMediaSvc     *mocks.MediaServiceMock
 	service          recetas.RecetaService // Interfaz del servicio bajo test
 	fixedTime        time.Time
 }
@@ -33,7 +38,8 @@ type RecetaServiceTestSuite struct {
 func (s *RecetaServiceTestSuite) SetupTest() {
 	s.mockRecetaRepo = new(mocks.RecetaRepositoryMock)
 	s.mockCategoriaSvc = new(mocks.CategoriaServiceMock)
-	s.service = recetas.NewRecetaService(s.mockRecetaRepo, s.mockCategoriaSvc)
+	s.mockMediaSvc = new(mocks.MediaServiceMock)
+	s.service = recetas.NewRecetaService(s.mockRecetaRepo, s.mockCategoriaSvc, s.mockMediaSvc)
 	s.fixedTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) // Para consistencia en CreatedAt/UpdatedAt
 }
 
@@ -70,7 +76,8 @@ func (s *RecetaServiceTestSuite) TestCreate_Success() {
 			rec.CategoriaID == input.CategoriaID &&
 			rec.TiempoPreparacion == input.TiempoPreparacion &&
 			rec.Descripcion == input.Descripcion &&
-			rec.Foto == input.Foto
+			rec.Foto == input.Foto &&
+			rec.Estado == recetas.EstadoBorrador // Nace como borrador
 	})).Return(nil).Once()
 
 	// Act
@@ -185,6 +192,693 @@ func (s *RecetaServiceTestSuite) TestCreate_Fail_RecetaRepoError() {
 	s.mockRecetaRepo.AssertExpectations(s.T())
 }
 
-// TODO: Escribir tests para GetAll, GetByID, GetBySlug, Update, Delete, FindByCategoriaID
+// listenerEliminadaStub registra las recetas eliminadas que se le notifican.
+type listenerEliminadaStub struct {
+	notificadas []uint
+	err         error
+}
+
+func (l *listenerEliminadaStub) RecetaEliminada(ctx context.Context, recetaID uint) error {
+	l.notificadas = append(l.notificadas, recetaID)
+	return l.err
+}
+
+// listenerRestauradaStub además registra las recetas restauradas.
+type listenerRestauradaStub struct {
+	listenerEliminadaStub
+	restauradas []uint
+}
+
+func (l *listenerRestauradaStub) RecetaRestaurada(ctx context.Context, recetaID uint) error {
+	l.restauradas = append(l.restauradas, recetaID)
+	return nil
+}
+
+func (s *RecetaServiceTestSuite) TestDelete_NotificaListeners() {
+	ctx := context.Background()
+	conError := &listenerEliminadaStub{err: errors.New("fallo del listener")}
+	ok := &listenerEliminadaStub{}
+	service := recetas.NewRecetaService(s.mockRecetaRepo, s.mockCategoriaSvc, s.mockMediaSvc, conError, ok)
+
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7}, nil).Once()
+	s.mockRecetaRepo.On("Delete", ctx, uint(7)).Return(nil).Once()
+
+	err := service.Delete(ctx, 7, recetas.Lector{Editor: true})
+
+	s.NoError(err) // Un listener con error no hace fallar el Delete
+	s.Equal([]uint{7}, conError.notificadas)
+	s.Equal([]uint{7}, ok.notificadas)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestDelete_NotFound_NoNotifica() {
+	ctx := context.Background()
+	listener := &listenerEliminadaStub{}
+	service := recetas.NewRecetaService(s.mockRecetaRepo, s.mockCategoriaSvc, s.mockMediaSvc, listener)
+
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(nil, repository.ErrRecordNotFound).Once()
+
+	err := service.Delete(ctx, 7, recetas.Lector{Editor: true})
+
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.Empty(listener.notificadas)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestDelete_SoloAutorOEditor() {
+	ctx := context.Background()
+	autor := uint(3)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, AutorID: &autor, Estado: recetas.EstadoPublicada}, nil)
+
+	err := s.service.Delete(ctx, 7, recetas.LectorUsuario(4))
+	s.ErrorIs(err, recetas.ErrRecetaSoloAutor)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+
+	s.mockRecetaRepo.On("Delete", ctx, uint(7)).Return(nil).Once()
+	s.NoError(s.service.Delete(ctx, 7, recetas.LectorUsuario(autor)))
+}
+
+func (s *RecetaServiceTestSuite) TestObtenerVisible_BorradorSoloParaAutorYEditores() {
+	ctx := context.Background()
+	autor := uint(3)
+	borrador := &recetas.Receta{ID: 7, AutorID: &autor, Estado: recetas.EstadoBorrador}
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(borrador, nil)
+
+	_, err := s.service.ObtenerVisible(ctx, 7, recetas.LectorUsuario(4))
+	s.ErrorIs(err, recetas.ErrRecetaNotFound, "Otro usuario")
+	_, err = s.service.ObtenerVisible(ctx, 7, recetas.Lector{})
+	s.ErrorIs(err, recetas.ErrRecetaNotFound, "Anónimo")
+
+	receta, err := s.service.ObtenerVisible(ctx, 7, recetas.LectorUsuario(autor))
+	s.NoError(err)
+	s.Equal(borrador, receta)
+	_, err = s.service.ObtenerVisible(ctx, 7, recetas.Lector{Editor: true})
+	s.NoError(err)
+}
+
+// --- Historial de revisiones ---
+
+func (s *RecetaServiceTestSuite) TestUpdate_RegistraEditor_ConservaFoto() {
+	ctx := context.Background()
+	editor := uint(9)
+	existente := &recetas.Receta{ID: 7, Nombre: "Paella", CategoriaID: 1, Porciones: 4, Foto: "paella.jpg"}
+	input := recetas.RecetaInputDTO{Nombre: "Paella mixta", CategoriaID: 1, TiempoPreparacion: "1 hora", Descripcion: "...", UsuarioID: &editor, Editor: true}
+
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(1)).Return(&categorias.Categoria{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(existente, nil).Once()
+	s.mockRecetaRepo.On("Update", ctx, mock.MatchedBy(func(r *recetas.Receta) bool {
+		return r.EditorID != nil && *r.EditorID == editor && r.Foto == "paella.jpg" && r.Slug == "paella-mixta"
+	})).Return(nil).Once()
+
+	receta, err := s.service.Update(ctx, 7, input)
+
+	s.NoError(err)
+	s.Equal("Paella mixta", receta.Nombre)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestUpdate_OtroUsuario_SoloAutor() {
+	ctx := context.Background()
+	autor, otro := uint(3), uint(4)
+	existente := &recetas.Receta{ID: 7, Nombre: "Paella", CategoriaID: 1, AutorID: &autor, Estado: recetas.EstadoPublicada}
+	input := recetas.RecetaInputDTO{Nombre: "Paella mixta", CategoriaID: 1, UsuarioID: &otro}
+
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(1)).Return(&categorias.Categoria{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(existente, nil).Once()
+
+	_, err := s.service.Update(ctx, 7, input)
+
+	s.ErrorIs(err, recetas.ErrRecetaSoloAutor)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestCompararRevisiones_Success() {
+	ctx := context.Background()
+	rev2 := &recetas.RecetaRevision{RecetaID: 7, Numero: 2, Nombre: "Paella", CategoriaID: 1, Porciones: 4, TiempoPreparacion: "1 hora"}
+	rev3 := &recetas.RecetaRevision{RecetaID: 7, Numero: 3, Nombre: "Paella", CategoriaID: 1, Porciones: 6, TiempoPreparacion: "1 hora 30 mins"}
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Estado: recetas.EstadoPublicada}, nil).Once()
+	s.mockRecetaRepo.On("GetRevision", ctx, uint(7), 3).Return(rev3, nil).Once()
+	s.mockRecetaRepo.On("GetRevision", ctx, uint(7), 2).Return(rev2, nil).Once()
+
+	cambios, err := s.service.CompararRevisiones(ctx, 7, 2, 3, recetas.Lector{})
+
+	s.NoError(err)
+	s.Equal([]recetas.CambioCampo{
+		{Campo: "tiempo_preparacion", Antes: "1 hora", Despues: "1 hora 30 mins"},
+		{Campo: "porciones", Antes: "4", Despues: "6"},
+	}, cambios)
+}
+
+func (s *RecetaServiceTestSuite) TestCompararRevisiones_Fail_RevisionNotFound() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Estado: recetas.EstadoPublicada}, nil).Once()
+	s.mockRecetaRepo.On("GetRevision", ctx, uint(7), 9).Return(nil, repository.ErrRecordNotFound).Once()
+
+	_, err := s.service.CompararRevisiones(ctx, 7, 8, 9, recetas.Lector{})
+
+	s.ErrorIs(err, recetas.ErrRevisionNotFound)
+}
+
+func (s *RecetaServiceTestSuite) TestListarRevisiones_Fail_BorradorAjeno() {
+	ctx := context.Background()
+	autor := uint(3)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, AutorID: &autor, Estado: recetas.EstadoBorrador}, nil).Once()
+
+	_, err := s.service.ListarRevisiones(ctx, 7, recetas.LectorUsuario(4))
+
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "FindRevisiones", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestRestaurarRevision_Success() {
+	ctx := context.Background()
+	usuario := uint(3)
+	actual := &recetas.Receta{ID: 7, Nombre: "Paella rota", Slug: "paella-rota", CategoriaID: 2, Porciones: 4, AutorID: &usuario}
+	revision := &recetas.RecetaRevision{RecetaID: 7, Numero: 1, Nombre: "Paella", Slug: "paella", CategoriaID: 1, Porciones: 4, Descripcion: "Texto original"}
+	restaurada := &recetas.Receta{ID: 7, Nombre: "Paella", Slug: "paella", CategoriaID: 1, Porciones: 4, Descripcion: "Texto original"}
+
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(actual, nil).Once()
+	s.mockRecetaRepo.On("GetRevision", ctx, uint(7), 1).Return(revision, nil).Once()
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(1)).Return(&categorias.Categoria{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("Update", ctx, mock.MatchedBy(func(r *recetas.Receta) bool {
+		return r.Nombre == "Paella" && r.Descripcion == "Texto original" && r.CategoriaID == 1 && *r.EditorID == usuario
+	})).Return(nil).Once()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(restaurada, nil).Once()
+
+	receta, err := s.service.RestaurarRevision(ctx, 7, 1, recetas.LectorUsuario(usuario))
+
+	s.NoError(err)
+	s.Equal(restaurada, receta)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestRestaurarRevision_Fail_CategoriaEliminada() {
+	ctx := context.Background()
+	autor := uint(3)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, AutorID: &autor}, nil).Once()
+	s.mockRecetaRepo.On("GetRevision", ctx, uint(7), 1).Return(&recetas.RecetaRevision{Numero: 1, CategoriaID: 5}, nil).Once()
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(5)).Return(nil, categorias.ErrCategoriaNotFound).Once()
+
+	_, err := s.service.RestaurarRevision(ctx, 7, 1, recetas.LectorUsuario(autor))
+
+	s.ErrorIs(err, recetas.ErrRecetaSinCategoria)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestRestaurarRevision_Fail_NoEsElAutor() {
+	ctx := context.Background()
+	autor := uint(3)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, AutorID: &autor, Estado: recetas.EstadoPublicada}, nil).Once()
+
+	_, err := s.service.RestaurarRevision(ctx, 7, 1, recetas.LectorUsuario(4))
+
+	s.ErrorIs(err, recetas.ErrRecetaSoloAutor)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "GetRevision", mock.Anything, mock.Anything, mock.Anything)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestRestaurarRevision_EditorDeRecetaSinAutor() {
+	ctx := context.Background()
+	editor := uint(9)
+	actual := &recetas.Receta{ID: 7, Nombre: "Paella rota", CategoriaID: 1, Estado: recetas.EstadoBorrador} // Sin autor
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(actual, nil).Twice()
+	s.mockRecetaRepo.On("GetRevision", ctx, uint(7), 1).Return(&recetas.RecetaRevision{Numero: 1, Nombre: "Paella", CategoriaID: 1}, nil).Once()
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(1)).Return(&categorias.Categoria{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("Update", ctx, mock.MatchedBy(func(r *recetas.Receta) bool { return *r.EditorID == editor })).Return(nil).Once()
+
+	_, err := s.service.RestaurarRevision(ctx, 7, 1, recetas.Lector{UsuarioID: &editor, Editor: true})
+
+	s.NoError(err)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestDiferenciarRevisiones_DesdeVacia() {
+	cambios := recetas.DiferenciarRevisiones(recetas.RecetaRevision{}, recetas.RecetaRevision{Nombre: "Paella", CategoriaID: 1, Porciones: 4})
+
+	campos := make([]string, 0, len(cambios))
+	for _, c := range cambios {
+		campos = append(campos, c.Campo)
+	}
+	s.Equal([]string{"nombre", "categoria_id", "porciones"}, campos)
+}
+
+// --- Flujo de publicación ---
+
+func (s *RecetaServiceTestSuite) TestGetAll_SoloPublicadas() {
+	ctx := context.Background()
+	publicadas := []recetas.Receta{{ID: 1, Estado: recetas.EstadoPublicada}}
+	s.mockRecetaRepo.On("Find", ctx, recetas.FiltroRecetas{Estados: []recetas.EstadoReceta{recetas.EstadoPublicada}}).Return(publicadas, nil).Once()
+
+	recs, err := s.service.GetAll(ctx)
+
+	s.NoError(err)
+	s.Equal(publicadas, recs)
+}
+
+func (s *RecetaServiceTestSuite) TestCambiarEstado_AutorEnviaARevision() {
+	ctx := context.Background()
+	autor := uint(3)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, AutorID: &autor, Estado: recetas.EstadoBorrador}, nil).Once()
+	s.mockRecetaRepo.On("UpdateEstado", ctx, uint(7), recetas.EstadoEnRevision, (*time.Time)(nil)).Return(nil).Once()
+
+	receta, err := s.service.CambiarEstado(ctx, 7, recetas.CambioEstadoInput{Estado: recetas.EstadoEnRevision, UsuarioID: &autor})
+
+	s.NoError(err)
+	s.Equal(recetas.EstadoEnRevision, receta.Estado)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestCambiarEstado_Fail_AutorNoPuedePublicar() {
+	ctx := context.Background()
+	autor := uint(3)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, AutorID: &autor, Estado: recetas.EstadoEnRevision}, nil).Once()
+
+	_, err := s.service.CambiarEstado(ctx, 7, recetas.CambioEstadoInput{Estado: recetas.EstadoPublicada, UsuarioID: &autor})
+
+	s.ErrorIs(err, recetas.ErrCambioEstadoSoloEditor)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "UpdateEstado", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestCambiarEstado_Fail_OtroUsuario() {
+	ctx := context.Background()
+	autor, otro := uint(3), uint(4)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, AutorID: &autor, Estado: recetas.EstadoBorrador}, nil).Once()
+
+	_, err := s.service.CambiarEstado(ctx, 7, recetas.CambioEstadoInput{Estado: recetas.EstadoEnRevision, UsuarioID: &otro})
+
+	s.ErrorIs(err, recetas.ErrRecetaNoEsDelAutor)
+}
+
+func (s *RecetaServiceTestSuite) TestCambiarEstado_Fail_TransicionInvalida() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Estado: recetas.EstadoBorrador}, nil).Once()
+
+	_, err := s.service.CambiarEstado(ctx, 7, recetas.CambioEstadoInput{Estado: recetas.EstadoArchivada, ComoEditor: true})
+
+	s.ErrorIs(err, recetas.ErrTransicionEstadoInvalida)
+}
+
+func (s *RecetaServiceTestSuite) TestCambiarEstado_EditorProgramaPublicacion() {
+	ctx := context.Background()
+	manana := time.Now().Add(24 * time.Hour)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Estado: recetas.EstadoEnRevision}, nil).Once()
+	s.mockRecetaRepo.On("UpdateEstado", ctx, uint(7), recetas.EstadoEnRevision, &manana).Return(nil).Once()
+
+	receta, err := s.service.CambiarEstado(ctx, 7, recetas.CambioEstadoInput{Estado: recetas.EstadoPublicada, PublicarEn: &manana, ComoEditor: true})
+
+	s.NoError(err)
+	s.True(receta.Programada())
+	s.False(receta.EsPublica())
+}
+
+func (s *RecetaServiceTestSuite) TestCambiarEstado_EditorPublicaAhora() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Estado: recetas.EstadoEnRevision}, nil).Once()
+	s.mockRecetaRepo.On("UpdateEstado", ctx, uint(7), recetas.EstadoPublicada, mock.AnythingOfType("*time.Time")).Return(nil).Once()
+
+	receta, err := s.service.CambiarEstado(ctx, 7, recetas.CambioEstadoInput{Estado: recetas.EstadoPublicada, ComoEditor: true})
+
+	s.NoError(err)
+	s.True(receta.EsPublica())
+	s.NotNil(receta.PublicarEn)
+}
+
+func (s *RecetaServiceTestSuite) TestCambiarEstado_Fail_PublicarEnSinPublicar() {
+	manana := time.Now().Add(24 * time.Hour)
+
+	_, err := s.service.CambiarEstado(context.Background(), 7, recetas.CambioEstadoInput{Estado: recetas.EstadoArchivada, PublicarEn: &manana, ComoEditor: true})
+
+	s.ErrorIs(err, recetas.ErrPublicarEnInvalido)
+}
+
+func (s *RecetaServiceTestSuite) TestPublicarProgramadas() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("PublicarProgramadas", ctx, mock.AnythingOfType("time.Time")).Return(int64(2), nil).Once()
+
+	n, err := s.service.PublicarProgramadas(ctx)
+
+	s.NoError(err)
+	s.Equal(int64(2), n)
+}
+
+func (s *RecetaServiceTestSuite) TestVisiblePara() {
+	autor, otro := uint(3), uint(4)
+	borrador := recetas.Receta{AutorID: &autor, Estado: recetas.EstadoBorrador}
+	s.True(borrador.VisiblePara(recetas.LectorUsuario(autor)))
+	s.False(borrador.VisiblePara(recetas.LectorUsuario(otro)))
+	s.False(borrador.VisiblePara(recetas.Lector{}))
+	s.True(borrador.VisiblePara(recetas.Lector{UsuarioID: &otro, Editor: true}), "Los editores ven los borradores ajenos")
+	s.True(recetas.Receta{Estado: recetas.EstadoPublicada}.VisiblePara(recetas.Lector{}))
+}
+
+// --- Papelera ---
+
+func (s *RecetaServiceTestSuite) TestRestaurarDePapelera_SlugOcupado() {
+	ctx := context.Background()
+	eliminada := &recetas.Receta{ID: 7, Slug: "paella", CategoriaID: 1}
+	restaurada := &recetas.Receta{ID: 7, Slug: "paella-2", CategoriaID: 1}
+	s.mockRecetaRepo.On("GetEliminadaByID", ctx, uint(7)).Return(eliminada, nil).Once()
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(1)).Return(&categorias.Categoria{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("GetBySlug", ctx, "paella").Return(&recetas.Receta{ID: 9, Slug: "paella"}, nil).Once()
+	s.mockRecetaRepo.On("GetBySlug", ctx, "paella-2").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRecetaRepo.On("Restaurar", ctx, uint(7), "paella-2").Return(nil).Once()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(restaurada, nil).Once()
+
+	receta, err := s.service.RestaurarDePapelera(ctx, 7)
+
+	s.NoError(err)
+	s.Equal("paella-2", receta.Slug)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestRestaurarDePapelera_NotificaListeners() {
+	ctx := context.Background()
+	soloEliminada := &listenerEliminadaStub{}
+	restaurada := &listenerRestauradaStub{}
+	service := recetas.NewRecetaService(s.mockRecetaRepo, s.mockCategoriaSvc, s.mockMediaSvc, soloEliminada, restaurada)
+
+	s.mockRecetaRepo.On("GetEliminadaByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Slug: "paella", CategoriaID: 1}, nil).Once()
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(1)).Return(&categorias.Categoria{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("GetBySlug", ctx, "paella").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRecetaRepo.On("Restaurar", ctx, uint(7), "paella").Return(nil).Once()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Slug: "paella", CategoriaID: 1}, nil).Once()
+
+	_, err := service.RestaurarDePapelera(ctx, 7)
+
+	s.NoError(err)
+	s.Equal([]uint{7}, restaurada.restauradas)
+	s.Empty(soloEliminada.notificadas)
+}
+
+func (s *RecetaServiceTestSuite) TestRestaurarDePapelera_Fail_CategoriaEliminada() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetEliminadaByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, Slug: "paella", CategoriaID: 5}, nil).Once()
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(5)).Return(nil, categorias.ErrCategoriaNotFound).Once()
+
+	_, err := s.service.RestaurarDePapelera(ctx, 7)
+
+	s.ErrorIs(err, recetas.ErrRecetaSinCategoria)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "Restaurar", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestPurgarDePapelera_NoEstaEnPapelera() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetEliminadaByID", ctx, uint(7)).Return(nil, repository.ErrRecordNotFound).Once()
+
+	err := s.service.PurgarDePapelera(ctx, 7)
+
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "Purgar", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestPurgarDePapelera_LiberaLaFoto() {
+	ctx := context.Background()
+	fotoID := uint(3)
+	s.mockRecetaRepo.On("GetEliminadaByID", ctx, uint(7)).Return(&recetas.Receta{ID: 7, FotoID: &fotoID}, nil).Once()
+	s.mockRecetaRepo.On("FindFotos", ctx, uint(7)).Return([]recetas.RecetaFoto{{ID: 2, RecetaID: 7, MediaID: 3}}, nil).Once()
+	s.mockRecetaRepo.On("Purgar", ctx, uint(7)).Return(nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(3), recetas.EntidadMedia, uint(7)).Return(nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(3), recetas.EntidadMediaGaleria, uint(2)).Return(nil).Once()
+
+	err := s.service.PurgarDePapelera(ctx, 7)
+
+	s.NoError(err)
+	s.mockMediaSvc.AssertExpectations(s.T())
+}
+
+// TODO: Escribir tests para GetAll, GetByID, GetBySlug, Update, FindByCategoriaID
 // Siguiendo patrones similares: caso de éxito, caso de "no encontrado", caso de error del repositorio.
 // Para Update, también casos de validación de CategoriaID y receta no encontrada.
+
+func (s *RecetaServiceTestSuite) TestCreate_Fail_CategoriaBorradaTrasValidar() {
+	ctx := context.Background()
+	input := recetas.RecetaInputDTO{Nombre: "Flan", CategoriaID: 1}
+	fk := &repository.ConstraintError{Base: repository.ErrForeignKeyViolation, Campo: "categoria_id", Causa: errors.New("Error 1452")}
+	s.mockCategoriaSvc.On("GetByID", ctx, uint(1)).Return(&categorias.Categoria{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("Create", ctx, mock.AnythingOfType("*recetas.Receta")).Return(fmt.Errorf("repo gorm recetas: create: %w", fk)).Once()
+
+	receta, err := s.service.Create(ctx, input)
+
+	s.Nil(receta)
+	s.ErrorIs(err, recetas.ErrRecetaSinCategoria)
+	campo, _ := repository.CampoEnConflicto(err)
+	s.Equal("categoria_id", campo)
+}
+
+// --- Traducciones ---
+
+func (s *RecetaServiceTestSuite) TestTraducir_AplicaTraduccionDeRecetaYCategoria() {
+	ctx := context.Background()
+	postres := &categorias.Categoria{ID: 7, Nombre: "Postres", Slug: "postres"}
+	recs := []recetas.Receta{
+		{ID: 1, Nombre: "Flan", Slug: "flan", Descripcion: "Batir los huevos...", Categoria: postres},
+		{ID: 2, Nombre: "Natillas", Slug: "natillas", Categoria: postres},
+	}
+	s.mockRecetaRepo.On("FindTraduccionesEnIdioma", ctx, i18n.EN, []uint{1, 2}).Return([]recetas.RecetaTraduccion{
+		{RecetaID: 1, Idioma: i18n.EN, Nombre: "Custard", Slug: "custard", Descripcion: "Beat the eggs..."},
+	}, nil).Once()
+	s.mockCategoriaSvc.On("Traducir", ctx, i18n.EN, []categorias.Categoria{*postres}).
+		Return([]categorias.Categoria{{ID: 7, Nombre: "Desserts", Slug: "desserts", Idioma: i18n.EN}}, nil).Once()
+
+	traducidas, err := s.service.Traducir(ctx, i18n.EN, recs)
+
+	s.NoError(err)
+	s.Equal("Custard", traducidas[0].Nombre)
+	s.Equal("Beat the eggs...", traducidas[0].Descripcion)
+	s.Equal(i18n.EN, traducidas[0].Idioma)
+	s.Equal("Natillas", traducidas[1].Nombre) // Sin traducción: idioma por defecto
+	s.Empty(traducidas[1].Idioma)
+	s.Equal("Desserts", traducidas[1].Categoria.Nombre)
+	s.Equal("Postres", postres.Nombre) // La categoría original no se modifica
+	s.mockRecetaRepo.AssertExpectations(s.T())
+	s.mockCategoriaSvc.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestBuscarPorSlug_RecurreAlSlugPorDefecto() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetBySlugTraducido", ctx, i18n.EN, "flan").Return(nil, repository.ErrRecordNotFound).Once()
+	s.mockRecetaRepo.On("GetBySlug", ctx, "flan").Return(&recetas.Receta{ID: 1, Slug: "flan"}, nil).Once()
+
+	receta, err := s.service.BuscarPorSlug(ctx, i18n.EN, "flan")
+
+	s.NoError(err)
+	s.Equal(uint(1), receta.ID)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestGuardarTraduccion_Fail_RecetaNoExiste() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(9)).Return(nil, repository.ErrRecordNotFound).Once()
+
+	traduccion, err := s.service.GuardarTraduccion(ctx, 9, i18n.EN, recetas.RecetaTraduccionInputDTO{Nombre: "Custard"})
+
+	s.Nil(traduccion)
+	s.ErrorIs(err, recetas.ErrRecetaNotFound)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "GuardarTraduccion", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestListarTraduccionesFaltantes() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("FindSinTraduccion", ctx, i18n.EN).Return([]recetas.Receta{{ID: 3}, {ID: 1}}, nil).Once()
+
+	faltantes, err := s.service.ListarTraduccionesFaltantes(ctx, nil) // Todos los idiomas traducibles
+
+	s.NoError(err)
+	s.Len(faltantes, 2)
+	s.Equal(uint(3), faltantes[0].Receta.ID)
+	s.Equal([]i18n.Idioma{i18n.EN}, faltantes[0].Idiomas)
+
+	es := i18n.IdiomaPorDefecto
+	_, err = s.service.ListarTraduccionesFaltantes(ctx, &es)
+	s.ErrorIs(err, i18n.ErrIdiomaNoTraducible)
+}
+
+// --- Galería de fotos ---
+
+func (s *RecetaServiceTestSuite) TestAgregarFoto_PrimeraFoto_PasaASerLaPortada() {
+	ctx := context.Background()
+	m := &media.Media{ID: 5, Clave: "recetas/nueva.jpg"}
+	leyenda := "  Emplatado  "
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("CreateFoto", ctx, mock.MatchedBy(func(f *recetas.RecetaFoto) bool {
+		return f.RecetaID == 1 && f.MediaID == 5 && f.Leyenda == "Emplatado" && !f.Portada
+	})).Run(func(args mock.Arguments) {
+		f := args.Get(1).(*recetas.RecetaFoto)
+		f.ID, f.Orden = 8, 1
+	}).Return(nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMediaGaleria, uint(8)).Return(nil).Once()
+	s.mockRecetaRepo.On("FindFotos", ctx, uint(1)).Return([]recetas.RecetaFoto{{ID: 8, RecetaID: 1, MediaID: 5, Media: m, Orden: 1}}, nil).Once()
+	// La portada calculada (la primera) pasa a ser la foto de la receta
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	s.mockRecetaRepo.On("ActualizarFoto", ctx, uint(1), "nueva.jpg", mock.Anything).Return((*uint)(nil), nil).Once()
+
+	foto, err := s.service.AgregarFoto(ctx, 1, m, recetas.RecetaFotoInputDTO{Leyenda: &leyenda}, recetas.Lector{Editor: true})
+
+	s.Require().NoError(err)
+	s.Equal(uint(8), foto.ID)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+	s.mockMediaSvc.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestAgregarFoto_Fail_TextoLargo() {
+	ctx := context.Background()
+	larga := strings.Repeat("ñ", recetas.MaxLongitudTextoFoto+1)
+
+	_, err := s.service.AgregarFoto(ctx, 1, &media.Media{ID: 5}, recetas.RecetaFotoInputDTO{TextoAlt: &larga}, recetas.Lector{Editor: true})
+
+	s.ErrorIs(err, recetas.ErrRecetaFotoTextoInvalido)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "CreateFoto", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestAgregarFoto_Fail_GaleriaLlena() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1, Fotos: make([]recetas.RecetaFoto, recetas.MaxFotosPorReceta)}, nil).Once()
+
+	_, err := s.service.AgregarFoto(ctx, 1, &media.Media{ID: 5}, recetas.RecetaFotoInputDTO{}, recetas.Lector{Editor: true})
+
+	s.ErrorIs(err, recetas.ErrRecetaDemasiadasFotos)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "CreateFoto", mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestAgregarFoto_Fail_UsoNoRegistrado_DeshaceElAlta() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1}, nil).Once()
+	s.mockRecetaRepo.On("CreateFoto", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*recetas.RecetaFoto).ID = 8
+	}).Return(nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMediaGaleria, uint(8)).Return(errors.New("bd caída")).Once()
+	s.mockRecetaRepo.On("DeleteFoto", ctx, uint(1), uint(8)).Return(nil).Once()
+
+	_, err := s.service.AgregarFoto(ctx, 1, &media.Media{ID: 5}, recetas.RecetaFotoInputDTO{}, recetas.Lector{Editor: true})
+
+	s.Error(err)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestReemplazarPortada_SacaLaAnteriorDeLaGaleria() {
+	ctx := context.Background()
+	vieja := &media.Media{ID: 4, Clave: "recetas/vieja.jpg"}
+	nueva := &media.Media{ID: 5, Clave: "recetas/nueva.jpg"}
+	fotoAnterior := uint(4)
+	receta := &recetas.Receta{ID: 1, Foto: "vieja.jpg", FotoID: &fotoAnterior, FotoMedia: vieja,
+		Fotos: []recetas.RecetaFoto{{ID: 7, RecetaID: 1, MediaID: 4, Media: vieja, Orden: 1, Portada: true, TextoAlt: "Paella"}}}
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(receta, nil).Once()
+	s.mockRecetaRepo.On("CreateFoto", ctx, mock.MatchedBy(func(f *recetas.RecetaFoto) bool {
+		return f.MediaID == 5 && f.Portada && f.TextoAlt == "Paella" // Hereda los textos
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*recetas.RecetaFoto).ID = 8
+	}).Return(nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMediaGaleria, uint(8)).Return(nil).Once()
+	s.mockRecetaRepo.On("DeleteFoto", ctx, uint(1), uint(7)).Return(nil).Once()
+	s.mockRecetaRepo.On("FindFotos", ctx, uint(1)).Return([]recetas.RecetaFoto{{ID: 8, RecetaID: 1, MediaID: 5, Media: nueva, Orden: 2, Portada: true}}, nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	s.mockRecetaRepo.On("ActualizarFoto", ctx, uint(1), "nueva.jpg", mock.Anything).Return(&fotoAnterior, nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(4), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(4), recetas.EntidadMediaGaleria, uint(7)).Return(nil).Once()
+
+	foto, err := s.service.ReemplazarPortada(ctx, 1, nueva, recetas.Lector{Editor: true})
+
+	s.Require().NoError(err)
+	s.Equal(uint(8), foto.ID)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+	s.mockMediaSvc.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestReemplazarPortada_FotoSinGaleria_LaIncorpora() {
+	ctx := context.Background()
+	actual := &media.Media{ID: 4, Clave: "recetas/actual.jpg"}
+	nueva := &media.Media{ID: 5, Clave: "recetas/nueva.jpg"}
+	fotoID := uint(4)
+	// Receta con foto registrada, subida antes de existir la galería
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1, Foto: "actual.jpg", FotoID: &fotoID, FotoMedia: actual}, nil).Once()
+	s.mockRecetaRepo.On("CreateFoto", ctx, mock.MatchedBy(func(f *recetas.RecetaFoto) bool { return f.MediaID == 4 && f.Portada })).
+		Run(func(args mock.Arguments) { args.Get(1).(*recetas.RecetaFoto).ID = 7 }).Return(nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(4), recetas.EntidadMediaGaleria, uint(7)).Return(nil).Once()
+	s.mockRecetaRepo.On("CreateFoto", ctx, mock.MatchedBy(func(f *recetas.RecetaFoto) bool { return f.MediaID == 5 && f.Portada })).
+		Run(func(args mock.Arguments) { args.Get(1).(*recetas.RecetaFoto).ID = 8 }).Return(nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMediaGaleria, uint(8)).Return(nil).Once()
+	s.mockRecetaRepo.On("DeleteFoto", ctx, uint(1), uint(7)).Return(nil).Once()
+	s.mockRecetaRepo.On("FindFotos", ctx, uint(1)).Return([]recetas.RecetaFoto{{ID: 8, RecetaID: 1, MediaID: 5, Media: nueva, Portada: true}}, nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	s.mockRecetaRepo.On("ActualizarFoto", ctx, uint(1), "nueva.jpg", mock.Anything).Return(&fotoID, nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(4), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(4), recetas.EntidadMediaGaleria, uint(7)).Return(nil).Once()
+
+	_, err := s.service.ReemplazarPortada(ctx, 1, nueva, recetas.Lector{Editor: true})
+
+	s.NoError(err)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+	s.mockMediaSvc.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestQuitarPortada_SinGaleria_QuitaLaFoto() {
+	ctx := context.Background()
+	anterior := uint(4)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1, Foto: "vieja.jpg"}, nil).Once()
+	s.mockRecetaRepo.On("ActualizarFoto", ctx, uint(1), "", (*uint)(nil)).Return(&anterior, nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(4), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+
+	err := s.service.QuitarPortada(ctx, 1, recetas.Lector{Editor: true})
+
+	s.NoError(err)
+	s.mockMediaSvc.AssertNotCalled(s.T(), "Usar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.mockMediaSvc.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestQuitarFoto_LaSiguientePasaASerLaPortada() {
+	ctx := context.Background()
+	primera := &media.Media{ID: 4, Clave: "recetas/primera.jpg"}
+	segunda := &media.Media{ID: 5, Clave: "recetas/segunda.jpg"}
+	fotoID := uint(4)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1, FotoID: &fotoID, Fotos: []recetas.RecetaFoto{
+		{ID: 7, RecetaID: 1, MediaID: 4, Media: primera, Orden: 1},
+		{ID: 8, RecetaID: 1, MediaID: 5, Media: segunda, Orden: 2},
+	}}, nil).Once()
+	s.mockRecetaRepo.On("DeleteFoto", ctx, uint(1), uint(7)).Return(nil).Once()
+	s.mockRecetaRepo.On("FindFotos", ctx, uint(1)).Return([]recetas.RecetaFoto{{ID: 8, RecetaID: 1, MediaID: 5, Media: segunda, Orden: 2}}, nil).Once()
+	s.mockMediaSvc.On("Usar", ctx, uint(5), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	s.mockRecetaRepo.On("ActualizarFoto", ctx, uint(1), "segunda.jpg", mock.Anything).Return(&fotoID, nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(4), recetas.EntidadMedia, uint(1)).Return(nil).Once()
+	s.mockMediaSvc.On("Liberar", ctx, uint(4), recetas.EntidadMediaGaleria, uint(7)).Return(nil).Once()
+
+	err := s.service.QuitarFoto(ctx, 1, 7, recetas.Lector{Editor: true})
+
+	s.NoError(err)
+	s.mockRecetaRepo.AssertExpectations(s.T())
+	s.mockMediaSvc.AssertExpectations(s.T())
+}
+
+func (s *RecetaServiceTestSuite) TestQuitarFoto_Fail_DeOtraReceta() {
+	ctx := context.Background()
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1, Fotos: []recetas.RecetaFoto{{ID: 7, RecetaID: 1}}}, nil).Once()
+
+	err := s.service.QuitarFoto(ctx, 1, 99, recetas.Lector{Editor: true})
+
+	s.ErrorIs(err, recetas.ErrRecetaFotoNotFound)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "DeleteFoto", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestQuitarFoto_Fail_NoEsElAutor() {
+	ctx := context.Background()
+	autor := uint(3)
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(&recetas.Receta{ID: 1, AutorID: &autor, Estado: recetas.EstadoPublicada,
+		Fotos: []recetas.RecetaFoto{{ID: 7, RecetaID: 1}}}, nil)
+
+	err := s.service.QuitarFoto(ctx, 1, 7, recetas.LectorUsuario(4))
+	s.ErrorIs(err, recetas.ErrRecetaSoloAutor)
+	err = s.service.QuitarFoto(ctx, 1, 7, recetas.Lector{}) // Anónimo
+	s.ErrorIs(err, recetas.ErrRecetaSoloAutor)
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "DeleteFoto", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *RecetaServiceTestSuite) TestReordenarFotos_Fail_OrdenIncompletoORepetido() {
+	ctx := context.Background()
+	receta := &recetas.Receta{ID: 1, Fotos: []recetas.RecetaFoto{{ID: 7}, {ID: 8}}}
+	s.mockRecetaRepo.On("GetByID", ctx, uint(1)).Return(receta, nil)
+
+	for _, ids := range [][]uint{{7}, {7, 7}, {7, 9}} {
+		_, err := s.service.ReordenarFotos(ctx, 1, ids, recetas.Lector{Editor: true})
+		s.ErrorIs(err, recetas.ErrOrdenFotosInvalido, "ids %v", ids)
+	}
+	s.mockRecetaRepo.AssertNotCalled(s.T(), "ReordenarFotos", mock.Anything, mock.Anything, mock.Anything)
+}
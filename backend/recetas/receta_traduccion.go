@@ -0,0 +1,48 @@
+// backend/recetas/receta_traduccion.go
+
+// Este archivo define las traducciones del contenido de una receta.
+//
+// El contenido en el idioma por defecto (i18n.IdiomaPorDefecto) vive en la propia
+// receta; cada traducción guarda nombre, slug y descripción (que incluye los pasos)
+// en otro idioma soportado. El resto de campos (porciones, tiempo, foto...) no se traduce.
+// Las lecturas públicas devuelven la receta en el idioma de la petición si tiene
+// traducción y, si no, en el idioma por defecto (ver RecetaService.Traducir).
+// Los editores consultan qué recetas aún no están traducidas con ListarTraduccionesFaltantes.
+
+package recetas
+
+import (
+	"errors"
+	"time"
+
+	"backend/shared/i18n"
+)
+
+// RecetaTraduccion es el contenido de una receta en un idioma distinto del idioma por defecto.
+type RecetaTraduccion struct {
+	RecetaID    uint
+	Idioma      i18n.Idioma
+	Nombre      string
+	Slug        string // Único por idioma
+	Descripcion string // Descripción o pasos, como Receta.Descripcion
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Traducida devuelve una copia de la receta con el contenido de la traducción.
+func (r Receta) Traducida(t RecetaTraduccion) Receta {
+	r.Nombre = t.Nombre
+	r.Slug = t.Slug
+	r.Descripcion = t.Descripcion
+	r.Idioma = t.Idioma
+	return r
+}
+
+// TraduccionesFaltantes es una receta junto con los idiomas a los que aún no está traducida.
+type TraduccionesFaltantes struct {
+	Receta  Receta
+	Idiomas []i18n.Idioma // Ordenados
+}
+
+// ErrRecetaTraduccionNotFound se produce al eliminar una traducción que no existe.
+var ErrRecetaTraduccionNotFound = errors.New("la receta no tiene traducción a ese idioma")
@@ -0,0 +1,71 @@
+// backend/recetas/receta_traduccion_model_gorm.go
+
+// Este archivo define el modelo de persistencia de las traducciones de recetas.
+// Las traducciones se conservan mientras la receta está en la papelera y se
+// borran en cascada al purgarla.
+
+package recetas
+
+import (
+	"time"
+
+	"backend/shared/i18n"
+)
+
+// RecetaTraduccionModel representa la tabla 'receta_traducciones'.
+// Una fila por receta e idioma; el slug es único dentro de cada idioma.
+type RecetaTraduccionModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	RecetaID    uint   `gorm:"not null;uniqueIndex:uk_receta_traducciones_idioma,priority:1"`
+	Idioma      string `gorm:"type:varchar(5);not null;uniqueIndex:uk_receta_traducciones_idioma,priority:2;uniqueIndex:uk_receta_traducciones_slug,priority:1"`
+	Nombre      string `gorm:"type:varchar(150);not null"`
+	Slug        string `gorm:"type:varchar(180);not null;uniqueIndex:uk_receta_traducciones_slug,priority:2"`
+	Descripcion string `gorm:"type:text"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Receta RecetaModel `gorm:"foreignKey:RecetaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+func (RecetaTraduccionModel) TableName() string {
+	return "receta_traducciones"
+}
+
+// --- Funciones de Mapeo ---
+
+func (m *RecetaTraduccionModel) ToDomain() *RecetaTraduccion {
+	if m == nil {
+		return nil
+	}
+	return &RecetaTraduccion{
+		RecetaID:    m.RecetaID,
+		Idioma:      i18n.Idioma(m.Idioma),
+		Nombre:      m.Nombre,
+		Slug:        m.Slug,
+		Descripcion: m.Descripcion,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func FromRecetaTraduccionDomain(d *RecetaTraduccion) *RecetaTraduccionModel {
+	if d == nil {
+		return nil
+	}
+	return &RecetaTraduccionModel{
+		RecetaID:    d.RecetaID,
+		Idioma:      string(d.Idioma),
+		Nombre:      d.Nombre,
+		Slug:        d.Slug,
+		Descripcion: d.Descripcion,
+	}
+}
+
+// RecetaTraduccionModelsToDomains convierte un slice de modelos GORM a dominio.
+func RecetaTraduccionModelsToDomains(models []RecetaTraduccionModel) []RecetaTraduccion {
+	traducciones := make([]RecetaTraduccion, 0, len(models))
+	for _, model := range models {
+		traducciones = append(traducciones, *model.ToDomain())
+	}
+	return traducciones
+}
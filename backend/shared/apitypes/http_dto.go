@@ -16,10 +16,25 @@
 // @Success 200 {object} apitypes.ErrorResponse "Respuesta de error"
 package apitypes
 
-// ErrorResponse es la estructura estándar para respuestas de error JSON de la API.
+// ErrorResponse es el formato de error anterior a ProblemDetails. Solo se envía a los
+// clientes que piden explícitamente "application/json" (periodo de deprecación).
 type ErrorResponse struct {
 	Error   string      `json:"error" example:"Mensaje descriptivo del error"`           // Mensaje principal del error
+	Code    string      `json:"code,omitempty" example:"receta_no_encontrada"`            // Código estable del error (ver shared/apperrors)
 	Details map[string]string `json:"details,omitempty" example:"field_name:problema de validación"` // Ejemplo más simple para map
 }
 
+// ProblemDetails es la respuesta de error estándar de la API (RFC 9457, "application/problem+json").
+// Code, RequestID y Details son miembros de extensión.
+type ProblemDetails struct {
+	Type      string            `json:"type" example:"/problemas/receta_no_encontrada"`              // Identifica el tipo de problema
+	Title     string            `json:"title" example:"Not Found"`                                     // Resumen del tipo de problema (no cambia entre ocurrencias)
+	Status    int               `json:"status" example:"404"`                                          // Código de estado HTTP
+	Detail    string            `json:"detail,omitempty" example:"receta no encontrada"`               // Explicación de esta ocurrencia
+	Instance  string            `json:"instance,omitempty" example:"/api/v1/recetas/42"`               // Ruta de la petición que falló
+	Code      string            `json:"code,omitempty" example:"receta_no_encontrada"`                 // Código estable del error (ver shared/apperrors)
+	RequestID string            `json:"request_id,omitempty" example:"4f9c2a7e1b3d4c5e8f9a0b1c2d3e4f50"` // Igual que la cabecera X-Request-ID
+	Details   map[string]string `json:"details,omitempty"`                                             // Errores por campo (validación, restricciones)
+}
+
 // (Podrías tener aquí otros DTOs comunes de API, como para paginación)
\ No newline at end of file
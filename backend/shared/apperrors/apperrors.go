@@ -0,0 +1,135 @@
+// backend/shared/apperrors/apperrors.go
+
+// Este paquete define AppError, el error tipado que ErrorHandler convierte en respuesta
+// HTTP, y un registro donde cada paquete de características declara cómo se traducen
+// sus errores de dominio (estado HTTP y código estable). Así el middleware no necesita
+// importar ninguna característica.
+//
+// Ejemplo de uso (en el paquete de la característica):
+//
+//	func init() {
+//		apperrors.Registrar(ErrRecetaNotFound, http.StatusNotFound, "receta_no_encontrada")
+//	}
+
+package apperrors
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// AppError es un error con toda la información necesaria para responder al cliente.
+type AppError struct {
+	Codigo   string            // Código estable para el cliente (ej: "receta_no_encontrada")
+	Estado   int               // Código de estado HTTP
+	Mensaje  string            // Mensaje legible
+	Detalles map[string]string // Opcional: detalles por campo
+	Causa    error             // Error original; no se expone al cliente
+}
+
+// Nuevo crea un AppError sin causa. Útil para errores que no vienen de un centinela registrado.
+func Nuevo(estado int, codigo, mensaje string) *AppError {
+	return &AppError{Codigo: codigo, Estado: estado, Mensaje: mensaje}
+}
+
+func (e *AppError) Error() string { return e.Mensaje }
+
+func (e *AppError) Unwrap() error { return e.Causa }
+
+// regla describe cómo se traduce un error centinela registrado.
+type regla struct {
+	estado        int
+	codigo        string
+	mensajePropio bool // Usar el mensaje del centinela en lugar del de la cadena completa
+}
+
+var (
+	mu     sync.RWMutex
+	reglas = map[error]regla{}
+)
+
+// Registrar declara que 'err' (comparado por identidad a lo largo de la cadena de errores)
+// se responde con 'estado' y 'codigo'. El mensaje es el del error completo, de modo que
+// el contexto añadido con fmt.Errorf("%w: ...") llega al cliente.
+// Registrar dos veces el mismo error es un error de programación y provoca un panic.
+func Registrar(err error, estado int, codigo string) {
+	registrar(err, regla{estado: estado, codigo: codigo})
+}
+
+// RegistrarSinDetalle es como Registrar, pero responde solo con el mensaje de 'err',
+// ocultando lo que se haya envuelto a su alrededor (ej: detalles de una librería).
+func RegistrarSinDetalle(err error, estado int, codigo string) {
+	registrar(err, regla{estado: estado, codigo: codigo, mensajePropio: true})
+}
+
+func registrar(err error, r regla) {
+	if err == nil || !reflect.TypeOf(err).Comparable() {
+		panic(fmt.Sprintf("apperrors: no se puede registrar el error %v", err))
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if _, existe := reglas[err]; existe {
+		panic(fmt.Sprintf("apperrors: el error %q ya está registrado", err))
+	}
+	reglas[err] = r
+}
+
+// Resolver busca en la cadena de 'err' un *AppError o un error registrado y devuelve
+// el AppError correspondiente. El recorrido es en profundidad y en orden, igual que
+// errors.Is, así que gana el error más externo: fmt.Errorf("%w: %w", ErrDominio, errRepo)
+// se resuelve como ErrDominio. Devuelve false si no hay nada registrado.
+func Resolver(err error) (*AppError, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var resuelto *AppError
+	recorrer(err, func(nodo error) bool {
+		if appErr, ok := nodo.(*AppError); ok {
+			copia := *appErr
+			resuelto = &copia
+			return true
+		}
+		if !reflect.TypeOf(nodo).Comparable() {
+			return false
+		}
+		r, ok := reglas[nodo]
+		if !ok {
+			return false
+		}
+		mensaje := err.Error()
+		if r.mensajePropio {
+			mensaje = nodo.Error()
+		}
+		resuelto = &AppError{Codigo: r.codigo, Estado: r.estado, Mensaje: mensaje, Causa: err}
+		return true
+	})
+	if resuelto == nil {
+		return nil, false
+	}
+	return resuelto, true
+}
+
+// recorrer visita la cadena de errores en el mismo orden que errors.Is y se detiene
+// cuando 'visitar' devuelve true.
+func recorrer(err error, visitar func(error) bool) bool {
+	for err != nil {
+		if visitar(err) {
+			return true
+		}
+		switch x := err.(type) {
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		case interface{ Unwrap() []error }:
+			for _, e := range x.Unwrap() {
+				if recorrer(e, visitar) {
+					return true
+				}
+			}
+			return false
+		default:
+			return false
+		}
+	}
+	return false
+}
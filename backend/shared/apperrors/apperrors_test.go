@@ -0,0 +1,80 @@
+// backend/shared/apperrors/apperrors_test.go
+package apperrors_test // Usar paquete _test para probar como cliente externo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"backend/shared/apperrors" // El paquete que estamos probando
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errNoEncontrado = errors.New("cosa no encontrada")
+	errInterno      = errors.New("fallo de la librería")
+	errCampo        = errors.New("campo en conflicto")
+)
+
+func init() {
+	apperrors.Registrar(errNoEncontrado, http.StatusNotFound, "cosa_no_encontrada")
+	apperrors.RegistrarSinDetalle(errInterno, http.StatusUnauthorized, "token_invalido")
+	apperrors.Registrar(errCampo, http.StatusConflict, "conflicto")
+}
+
+func TestResolver_ErrorRegistradoEnvuelto(t *testing.T) {
+	err := fmt.Errorf("%w: la cosa 7 no existe", errNoEncontrado)
+
+	appErr, ok := apperrors.Resolver(fmt.Errorf("servicio: %w", err))
+
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, appErr.Estado)
+	assert.Equal(t, "cosa_no_encontrada", appErr.Codigo)
+	assert.Equal(t, "servicio: cosa no encontrada: la cosa 7 no existe", appErr.Mensaje)
+	assert.ErrorIs(t, appErr, errNoEncontrado, "La causa debe seguir en la cadena")
+}
+
+func TestResolver_SinDetalleOcultaElResto(t *testing.T) {
+	appErr, ok := apperrors.Resolver(fmt.Errorf("%w: %w", errInterno, errors.New("token is expired")))
+
+	require.True(t, ok)
+	assert.Equal(t, "fallo de la librería", appErr.Mensaje)
+}
+
+func TestResolver_GanaElErrorMasExterno(t *testing.T) {
+	// Un error de dominio que envuelve otro también registrado (ej: ConCausa).
+	appErr, ok := apperrors.Resolver(fmt.Errorf("%w: %w", errCampo, errNoEncontrado))
+
+	require.True(t, ok)
+	assert.Equal(t, "conflicto", appErr.Codigo)
+}
+
+func TestResolver_AppErrorDirecto(t *testing.T) {
+	original := apperrors.Nuevo(http.StatusTeapot, "tetera", "soy una tetera")
+
+	appErr, ok := apperrors.Resolver(fmt.Errorf("handler: %w", original))
+
+	require.True(t, ok)
+	assert.Equal(t, http.StatusTeapot, appErr.Estado)
+	assert.Equal(t, "soy una tetera", appErr.Mensaje)
+}
+
+func TestResolver_NoRegistrado(t *testing.T) {
+	_, ok := apperrors.Resolver(errors.New("desconocido"))
+	assert.False(t, ok)
+
+	// Los errores no comparables (ej: slices) no deben provocar un panic.
+	_, ok = apperrors.Resolver(errorSlice{"a", "b"})
+	assert.False(t, ok)
+}
+
+func TestRegistrar_DuplicadoHacePanic(t *testing.T) {
+	assert.Panics(t, func() { apperrors.Registrar(errNoEncontrado, http.StatusNotFound, "otra") })
+}
+
+type errorSlice []string
+
+func (e errorSlice) Error() string { return fmt.Sprint([]string(e)) }
@@ -20,6 +20,13 @@ type JWTConfig struct {
 	Issuer             string `mapstructure:"issuer"`
 }
 
+// --- AuthConfig contiene la configuración de permisos. ---
+type AuthConfig struct {
+	// IDs de los usuarios admin (rutas /admin; también editores del contenido).
+	// Por variable de entorno: APP_AUTH_ADMINS="1,2".
+	Admins []uint `mapstructure:"admins"`
+}
+
 // --- SMTPConfig contiene la configuración para el cliente SMTP. ---
 type SMTPConfig struct {
 	Host     string `mapstructure:"host"`
@@ -28,6 +35,73 @@ type SMTPConfig struct {
 	Password string `mapstructure:"password"` // ¡Este es un secreto!
 	From     string `mapstructure:"from"`     // Email "De" por defecto para los correos
 	AdminTo  string `mapstructure:"admin_to"` // Email del admin a quien se envían los contactos
+	// Carpeta con plantillas de correo que sustituyen a las embebidas (vacío = solo las embebidas).
+	PlantillasDir string `mapstructure:"plantillas_dir"`
+	IdiomaAdmin   string `mapstructure:"idioma_admin"` // Idioma de los correos al admin ("es", "en")
+	// Seguridad de la conexión: "auto" (TLS implícito en el 465, si no STARTTLS si se ofrece),
+	// "starttls" (obligatorio), "tls" (implícito) o "ninguna" (solo servidores locales).
+	Seguridad       string `mapstructure:"seguridad"`
+	Auth            string `mapstructure:"auth"`             // "plain", "login", "cram-md5" o "ninguna"
+	TimeoutSegundos int    `mapstructure:"timeout_segundos"` // Máximo por envío (0 = sin límite propio)
+}
+
+// --- JobsConfig contiene la configuración de las tareas en segundo plano. ---
+type JobsConfig struct {
+	// Cada cuánto se publican las recetas programadas (0 o negativo = desactivado).
+	PublicacionIntervaloSegundos int `mapstructure:"publicacion_intervalo_segundos"`
+	// Días que un registro eliminado permanece en la papelera antes de purgarse (0 = no purgar nunca).
+	PapeleraRetencionDias int `mapstructure:"papelera_retencion_dias"`
+	// Cada cuánto se revisa la papelera.
+	PapeleraIntervaloMinutos int `mapstructure:"papelera_intervalo_minutos"`
+	// Cada cuánto se buscan archivos subidos que nadie usa (0 = desactivado; ver cmd/huerfanos).
+	HuerfanosIntervaloHoras int `mapstructure:"huerfanos_intervalo_horas"`
+	// Antigüedad mínima de un archivo sin uso para borrarlo (protege las subidas en curso).
+	HuerfanosGraciaHoras int `mapstructure:"huerfanos_gracia_horas"`
+	// true: solo informa en el log, no borra.
+	HuerfanosSimular bool `mapstructure:"huerfanos_simular"`
+	// Cada cuánto se envían los correos de la bandeja de salida (0 = desactivado: no se envía ninguno).
+	CorreosIntervaloSegundos int `mapstructure:"correos_intervalo_segundos"`
+	// Intentos de envío de un correo antes de darlo por fallido (se reintenta a mano desde /admin/correos).
+	CorreosMaxIntentos int `mapstructure:"correos_max_intentos"`
+	// Espera tras el primer fallo; se duplica en cada intento hasta correos_espera_max_minutos.
+	CorreosEsperaBaseSegundos int `mapstructure:"correos_espera_base_segundos"`
+	CorreosEsperaMaxMinutos   int `mapstructure:"correos_espera_max_minutos"`
+}
+
+// --- UploadsConfig contiene los límites de las imágenes subidas (0 = valor por defecto). ---
+type UploadsConfig struct {
+	MaxBytes   int64 `mapstructure:"max_bytes"`   // Tamaño máximo del archivo
+	MaxAncho   int   `mapstructure:"max_ancho"`   // Ancho máximo en píxeles
+	MaxAlto    int   `mapstructure:"max_alto"`    // Alto máximo en píxeles
+	MaxPixeles int   `mapstructure:"max_pixeles"` // Ancho x alto máximo (protege de imágenes enormes muy comprimidas)
+}
+
+// --- StorageConfig indica dónde se guardan los archivos subidos. ---
+type StorageConfig struct {
+	Driver string             `mapstructure:"driver"` // "local" (por defecto) o "s3"
+	Local  LocalStorageConfig `mapstructure:"local"`
+	S3     S3StorageConfig    `mapstructure:"s3"`
+
+	ClaveFirma           string `mapstructure:"clave_firma"`            // HMAC de las URLs firmadas del driver local. ¡Secreto!
+	FirmaMinutos         int    `mapstructure:"firma_minutos"`          // Validez de las URLs de archivos no públicos
+	CachePublicoSegundos int    `mapstructure:"cache_publico_segundos"` // max-age de los archivos públicos servidos por la API
+}
+
+// LocalStorageConfig es una carpeta del disco servida por la propia API.
+type LocalStorageConfig struct {
+	Dir     string `mapstructure:"dir"`      // Carpeta raíz
+	URLBase string `mapstructure:"url_base"` // Ruta bajo la que se sirve
+}
+
+// S3StorageConfig es un bucket compatible con S3 (AWS, MinIO, R2...).
+type S3StorageConfig struct {
+	Endpoint   string `mapstructure:"endpoint"`    // ej: https://s3.eu-west-1.amazonaws.com o http://localhost:9000
+	Region     string `mapstructure:"region"`
+	Bucket     string `mapstructure:"bucket"`
+	AccessKey  string `mapstructure:"access_key"`
+	SecretKey  string `mapstructure:"secret_key"`  // ¡Este es un secreto!
+	PathStyle  bool   `mapstructure:"path_style"`  // true: endpoint/bucket/clave (MinIO); false: bucket.endpoint/clave
+	URLPublica string `mapstructure:"url_publica"` // Prefijo de las URLs públicas (CDN); vacío = URL del bucket
 }
 
 // --- Structs Config, ServerConfig, DatabaseConfig (sin cambios) ---
@@ -37,6 +111,11 @@ type Config struct {
 	Server    ServerConfig   `mapstructure:"server"`
 	Database  DatabaseConfig `mapstructure:"database"`
 	SMTP      SMTPConfig     `mapstructure:"smtp"`
+	JWT       JWTConfig      `mapstructure:"jwt"`
+	Auth      AuthConfig     `mapstructure:"auth"`
+	Jobs      JobsConfig     `mapstructure:"jobs"`
+	Uploads   UploadsConfig  `mapstructure:"uploads"`
+	Storage   StorageConfig  `mapstructure:"storage"`
 }
 type ServerConfig struct {
 	Port int `mapstructure:"port"`
@@ -108,6 +187,32 @@ func LoadConfig(configPath string) (config Config, err error) {
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", 3306)
 	viper.SetDefault("database.params", "parseTime=true")
+	viper.SetDefault("jwt.token_expires_in_minutes", 60)
+	viper.SetDefault("smtp.idioma_admin", "es")
+	viper.SetDefault("smtp.seguridad", "auto")
+	viper.SetDefault("smtp.auth", "plain")
+	viper.SetDefault("smtp.timeout_segundos", 30)
+	viper.SetDefault("jobs.publicacion_intervalo_segundos", 60)
+	viper.SetDefault("jobs.papelera_retencion_dias", 30)
+	viper.SetDefault("jobs.papelera_intervalo_minutos", 60)
+	viper.SetDefault("jobs.huerfanos_intervalo_horas", 24)
+	viper.SetDefault("jobs.huerfanos_gracia_horas", 48)
+	viper.SetDefault("jobs.huerfanos_simular", false)
+	viper.SetDefault("jobs.correos_intervalo_segundos", 15)
+	viper.SetDefault("jobs.correos_max_intentos", 8) // Con las esperas por defecto, unas 10 horas
+	viper.SetDefault("jobs.correos_espera_base_segundos", 60)
+	viper.SetDefault("jobs.correos_espera_max_minutos", 240)
+	viper.SetDefault("uploads.max_bytes", 2<<20) // 2MB
+	viper.SetDefault("uploads.max_ancho", 6000)
+	viper.SetDefault("uploads.max_alto", 6000)
+	viper.SetDefault("uploads.max_pixeles", 36000000)
+	viper.SetDefault("storage.driver", "local")
+	viper.SetDefault("storage.local.dir", "uploads")
+	viper.SetDefault("storage.local.url_base", "/uploads")
+	viper.SetDefault("storage.s3.region", "us-east-1")
+	viper.SetDefault("storage.s3.path_style", true)
+	viper.SetDefault("storage.firma_minutos", 60)
+	viper.SetDefault("storage.cache_publico_segundos", 31536000) // Un año: los nombres derivan del contenido
     // Es mejor definir User/Name en los archivos de config o ENV que confiar en defaults
 	// viper.SetDefault("database.user", "root")
 	// viper.SetDefault("database.name", "recetas_dev")
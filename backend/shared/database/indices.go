@@ -0,0 +1,51 @@
+// backend/shared/database/indices.go
+
+// Este archivo contiene utilidades de migración para índices únicos compatibles
+// con soft delete.
+//
+// Un índice UNIQUE(slug) también cuenta las filas en la papelera: eliminar "Postres"
+// y volver a crearla viola el índice aunque GORM oculte la fila eliminada.
+// La solución es una columna generada 'activo' = IF(deleted_at IS NULL, 1, NULL),
+// declarada en el modelo como solo lectura (tag "->"), y el índice UNIQUE(slug, activo):
+// MySQL no compara los NULL entre sí, así que la unicidad solo se exige entre las
+// filas no eliminadas.
+
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// AsegurarIndiceUnico deja 'indice' en 'tabla' como UNIQUE sobre exactamente 'columnas'.
+// Si existe con otra definición (ej: el UNIQUE(slug) anterior a la papelera), lo
+// reemplaza en una sola sentencia ALTER TABLE para no quedar nunca sin índice.
+// Es idempotente: ejecútela después de AutoMigrate, que no modifica índices ya existentes.
+func AsegurarIndiceUnico(db *gorm.DB, tabla, indice string, columnas ...string) error {
+	var actuales []string
+	err := db.Raw(
+		"SELECT column_name FROM information_schema.statistics "+
+			"WHERE table_schema = DATABASE() AND table_name = ? AND index_name = ? ORDER BY seq_in_index",
+		tabla, indice,
+	).Scan(&actuales).Error
+	if err != nil {
+		return fmt.Errorf("migración índices: error leyendo %s.%s: %w", tabla, indice, err)
+	}
+
+	deseadas := strings.Join(columnas, ",")
+	if strings.EqualFold(strings.Join(actuales, ","), deseadas) {
+		return nil // Ya está como queremos
+	}
+
+	crear := fmt.Sprintf("ADD UNIQUE INDEX `%s` (%s)", indice, "`"+strings.Join(columnas, "`, `")+"`")
+	sentencia := fmt.Sprintf("ALTER TABLE `%s` %s", tabla, crear)
+	if len(actuales) > 0 {
+		sentencia = fmt.Sprintf("ALTER TABLE `%s` DROP INDEX `%s`, %s", tabla, indice, crear)
+	}
+	if err := db.Exec(sentencia).Error; err != nil {
+		return fmt.Errorf("migración índices: error recreando %s.%s (%s): %w", tabla, indice, deseadas, err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// backend/shared/i18n/i18n.go
+
+// Este paquete contiene el catálogo de mensajes de la API y la negociación de idioma.
+//
+// Cada idioma es un archivo JSON plano en catalogos/ (clave -> mensaje), embebido en el
+// binario. Para añadir un idioma basta con añadir su archivo: las claves que falten se
+// resuelven con el idioma por defecto. Los mensajes admiten verbos de fmt (%s, %d...).
+//
+// Convención de claves:
+//   - errores.<codigo>: mensaje de un error registrado en shared/apperrors.
+//   - validacion.<tag>: mensajes de validación del binding de Gin.
+//   - formato.fecha:    layout de time.Format para las fechas.
+
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Idioma es un código de idioma ISO 639-1 (ej: "es", "en").
+type Idioma string
+
+// Idiomas con catálogo propio.
+const (
+	ES Idioma = "es"
+	EN Idioma = "en"
+)
+
+// IdiomaPorDefecto es el idioma de los mensajes originales del código y el de respaldo.
+const IdiomaPorDefecto = ES
+
+//go:embed catalogos/*.json
+var archivosCatalogo embed.FS
+
+// catalogos se carga al iniciar; después solo se lee, por lo que no necesita mutex.
+var catalogos = cargarCatalogos()
+
+func cargarCatalogos() map[Idioma]map[string]string {
+	archivos, err := archivosCatalogo.ReadDir("catalogos")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: no se pudieron leer los catálogos: %v", err))
+	}
+	resultado := make(map[Idioma]map[string]string, len(archivos))
+	for _, archivo := range archivos {
+		contenido, err := archivosCatalogo.ReadFile(path.Join("catalogos", archivo.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: no se pudo leer %s: %v", archivo.Name(), err))
+		}
+		var mensajes map[string]string
+		if err := json.Unmarshal(contenido, &mensajes); err != nil {
+			panic(fmt.Sprintf("i18n: catálogo %s inválido: %v", archivo.Name(), err))
+		}
+		resultado[Idioma(strings.TrimSuffix(archivo.Name(), ".json"))] = mensajes
+	}
+	if _, ok := resultado[IdiomaPorDefecto]; !ok {
+		panic("i18n: falta el catálogo del idioma por defecto")
+	}
+	return resultado
+}
+
+// Soportados devuelve los idiomas con catálogo, ordenados.
+func Soportados() []Idioma {
+	idiomas := make([]Idioma, 0, len(catalogos))
+	for idioma := range catalogos {
+		idiomas = append(idiomas, idioma)
+	}
+	sort.Slice(idiomas, func(i, j int) bool { return idiomas[i] < idiomas[j] })
+	return idiomas
+}
+
+// Soporta indica si hay catálogo para el idioma.
+func Soporta(idioma Idioma) bool {
+	_, ok := catalogos[idioma]
+	return ok
+}
+
+// ErrIdiomaNoTraducible se produce al guardar contenido traducido en un idioma sin catálogo
+// o en el idioma por defecto (ese contenido vive en la propia entidad, no en una traducción).
+var ErrIdiomaNoTraducible = errors.New("el idioma no está soportado o es el idioma por defecto")
+
+// Traducibles devuelve los idiomas soportados distintos del idioma por defecto, ordenados:
+// los idiomas en los que el contenido (recetas, categorías...) puede tener traducción.
+func Traducibles() []Idioma {
+	idiomas := make([]Idioma, 0, len(catalogos)-1)
+	for _, idioma := range Soportados() {
+		if idioma != IdiomaPorDefecto {
+			idiomas = append(idiomas, idioma)
+		}
+	}
+	return idiomas
+}
+
+// EsTraducible indica si el contenido puede tener traducción a 'idioma' (ver Traducibles).
+func EsTraducible(idioma Idioma) bool {
+	return idioma != IdiomaPorDefecto && Soporta(idioma)
+}
+
+// Buscar devuelve el mensaje de 'clave' en 'idioma', sin recurrir al idioma por defecto.
+func Buscar(idioma Idioma, clave string) (string, bool) {
+	mensaje, ok := catalogos[idioma][clave]
+	return mensaje, ok
+}
+
+// T traduce 'clave' a 'idioma' y aplica 'args' con fmt.Sprintf. Si el idioma no tiene
+// la clave se usa el idioma por defecto, y si tampoco existe se devuelve la clave.
+func T(idioma Idioma, clave string, args ...interface{}) string {
+	mensaje, ok := Buscar(idioma, clave)
+	if !ok {
+		if mensaje, ok = Buscar(IdiomaPorDefecto, clave); !ok {
+			return clave
+		}
+	}
+	if len(args) == 0 {
+		return mensaje
+	}
+	return fmt.Sprintf(mensaje, args...)
+}
+
+// Negociar elige el idioma de la respuesta. 'lang' (el parámetro ?lang=) tiene prioridad
+// si es un idioma soportado; si no, se usa la preferencia de mayor peso de Accept-Language
+// que esté soportada (comparando solo el idioma primario: "en-GB" -> "en").
+func Negociar(acceptLanguage, lang string) Idioma {
+	if idioma := normalizar(lang); Soporta(idioma) {
+		return idioma
+	}
+
+	mejor, mejorPeso := IdiomaPorDefecto, 0.0
+	for _, rango := range strings.Split(acceptLanguage, ",") {
+		partes := strings.Split(rango, ";")
+		idioma := normalizar(partes[0])
+		if !Soporta(idioma) {
+			continue
+		}
+		peso := 1.0
+		for _, p := range partes[1:] {
+			if nombre, valor, ok := strings.Cut(strings.TrimSpace(p), "="); ok && strings.EqualFold(nombre, "q") {
+				if q, err := strconv.ParseFloat(valor, 64); err == nil {
+					peso = q
+				}
+			}
+		}
+		if peso > mejorPeso { // Ante empate gana el primero, como indica el orden del cliente
+			mejor, mejorPeso = idioma, peso
+		}
+	}
+	return mejor
+}
+
+// normalizar reduce una etiqueta de idioma ("EN-us") a su idioma primario ("en").
+func normalizar(etiqueta string) Idioma {
+	etiqueta = strings.ToLower(strings.TrimSpace(etiqueta))
+	primario, _, _ := strings.Cut(strings.ReplaceAll(etiqueta, "_", "-"), "-")
+	return Idioma(primario)
+}
+
+type claveContexto struct{}
+
+// ConIdioma devuelve un contexto que lleva el idioma negociado de la petición.
+func ConIdioma(ctx context.Context, idioma Idioma) context.Context {
+	return context.WithValue(ctx, claveContexto{}, idioma)
+}
+
+// IdiomaDe devuelve el idioma guardado con ConIdioma, o el idioma por defecto.
+func IdiomaDe(ctx context.Context) Idioma {
+	if idioma, ok := ctx.Value(claveContexto{}).(Idioma); ok {
+		return idioma
+	}
+	return IdiomaPorDefecto
+}
@@ -0,0 +1,65 @@
+// backend/shared/i18n/i18n_test.go
+package i18n_test // Usar paquete _test para probar como cliente externo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"backend/shared/i18n" // El paquete que estamos probando
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegociar(t *testing.T) {
+	casos := []struct {
+		accept, lang string
+		esperado     i18n.Idioma
+	}{
+		{"", "", i18n.ES},
+		{"en-US,en;q=0.9,es;q=0.8", "", i18n.EN},
+		{"fr-FR, es;q=0.5, en;q=0.7", "", i18n.EN}, // Francés no soportado: gana el mayor peso soportado
+		{"de", "", i18n.IdiomaPorDefecto},
+		{"es", "EN", i18n.EN},       // ?lang= tiene prioridad
+		{"en", "fr", i18n.EN},       // ?lang= no soportado: se ignora
+		{"en;q=0, es", "", i18n.ES}, // q=0 es un rechazo explícito
+		{"*", "", i18n.IdiomaPorDefecto},
+	}
+	for _, c := range casos {
+		assert.Equal(t, c.esperado, i18n.Negociar(c.accept, c.lang), "accept=%q lang=%q", c.accept, c.lang)
+	}
+}
+
+func TestT_FormateaYRecurreAlIdiomaPorDefecto(t *testing.T) {
+	assert.Equal(t, "The 'Nombre' field is required.", i18n.T(i18n.EN, "validacion.required", "Nombre"))
+	assert.Equal(t, "receta no encontrada", i18n.T(i18n.Idioma("pt"), "errores.receta_no_encontrada"))
+	assert.Equal(t, "clave.inexistente", i18n.T(i18n.EN, "clave.inexistente"))
+}
+
+func TestCatalogos_MismasClavesQueElIdiomaPorDefecto(t *testing.T) {
+	for _, idioma := range i18n.Soportados() {
+		for _, clave := range []string{"formato.fecha", "errores.error_interno", "validacion.invalido"} {
+			_, ok := i18n.Buscar(idioma, clave)
+			assert.True(t, ok, "falta %q en el catálogo %q", clave, idioma)
+		}
+	}
+	// Los verbos de formato deben coincidir para que T no produzca "%!s(MISSING)".
+	for _, clave := range []string{"validacion.min", "restriccion.duplicado"} {
+		es, _ := i18n.Buscar(i18n.ES, clave)
+		en, _ := i18n.Buscar(i18n.EN, clave)
+		assert.Equal(t, strings.Count(es, "%"), strings.Count(en, "%"), clave)
+	}
+}
+
+func TestIdiomaDe(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, i18n.IdiomaPorDefecto, i18n.IdiomaDe(ctx))
+	assert.Equal(t, i18n.EN, i18n.IdiomaDe(i18n.ConIdioma(ctx, i18n.EN)))
+}
+
+func TestTraducibles_ExcluyeElIdiomaPorDefecto(t *testing.T) {
+	assert.Equal(t, []i18n.Idioma{i18n.EN}, i18n.Traducibles())
+	assert.True(t, i18n.EsTraducible(i18n.EN))
+	assert.False(t, i18n.EsTraducible(i18n.IdiomaPorDefecto))
+	assert.False(t, i18n.EsTraducible(i18n.Idioma("pt")))
+}
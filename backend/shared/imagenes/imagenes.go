@@ -0,0 +1,202 @@
+// backend/shared/imagenes/imagenes.go
+
+// Este paquete procesa las imágenes subidas antes de publicarlas:
+//
+//   - Endereza la imagen según su orientación EXIF y la vuelve a codificar, con lo que
+//     se descartan todos los metadatos (EXIF, GPS, perfiles de la cámara...).
+//   - Genera una variante por tamaño (ver TamanosPorDefecto), sin ampliar nunca la imagen.
+//   - Cada variante se guarda en el formato original (JPEG o PNG) y en WebP. El codificador
+//     WebP es nativo y solo produce WebP sin pérdida, así que no siempre ocupa menos que el JPEG.
+//
+// Los archivos se guardan en el almacenamiento (ver shared/storage) junto al original y se
+// nombran a partir de él: foto.jpg -> foto.jpg (la variante "full" reemplaza al original),
+// foto_card.jpg, foto_thumb.jpg y sus equivalentes .webp.
+//
+// Ejemplo de uso:
+//
+//	variantes, err := imagenes.Procesar(ctx, almacen, "recetas/foto.jpg", datos, imagenes.TamanosPorDefecto)
+
+package imagenes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"path"
+	"strings"
+
+	"backend/shared/storage"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+)
+
+// VarianteFull es el tamaño que reemplaza al archivo original.
+const VarianteFull = "full"
+
+// calidadJPEG es la calidad de las variantes JPEG (1-100).
+const calidadJPEG = 85
+
+// Tamano describe una variante a generar.
+type Tamano struct {
+	Nombre   string // "thumb", "card", "full"...
+	AnchoMax int    // Ancho máximo en píxeles
+}
+
+// TamanosPorDefecto son las variantes de las fotos de recetas, de menor a mayor.
+var TamanosPorDefecto = []Tamano{
+	{Nombre: "thumb", AnchoMax: 320},
+	{Nombre: "card", AnchoMax: 800},
+	{Nombre: VarianteFull, AnchoMax: 1600},
+}
+
+// Variante es una copia generada de una imagen. Se guarda como JSON junto a la entidad
+// que la usa, por lo que los nombres de campo JSON no deben cambiar.
+type Variante struct {
+	Nombre      string `json:"nombre"`
+	Ancho       int    `json:"ancho"`
+	Alto        int    `json:"alto"`
+	Archivo     string `json:"archivo"`      // Nombre del archivo en el formato original
+	ArchivoWebP string `json:"archivo_webp"` // Nombre del archivo WebP
+}
+
+// ErrImagenIlegible se produce si el archivo no se puede decodificar como JPEG o PNG.
+var ErrImagenIlegible = errors.New("no se pudo decodificar la imagen")
+
+// Procesar genera las variantes de la imagen 'datos', guardada en 'almacen' bajo 'clave', y las
+// guarda junto a ella (misma carpeta). Las devuelve en el orden de 'tamanos'. Si falla, borra lo
+// que haya escrito (salvo la clave original, que la variante "full" reemplaza).
+func Procesar(ctx context.Context, almacen storage.Storage, clave string, datos []byte, tamanos []Tamano) ([]Variante, error) {
+	img, formato, err := image.Decode(bytes.NewReader(datos))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrImagenIlegible, err)
+	}
+	if formato == "jpeg" {
+		img = Enderezar(img, OrientacionEXIF(datos))
+	}
+
+	dir := path.Dir(clave)
+	ext := path.Ext(clave)
+	base := strings.TrimSuffix(path.Base(clave), ext)
+
+	variantes := make([]Variante, 0, len(tamanos))
+	var escritos []string
+	limpiar := func() {
+		for _, archivo := range escritos {
+			if k := path.Join(dir, archivo); k != clave {
+				_ = almacen.Delete(ctx, k)
+			}
+		}
+	}
+	for _, t := range tamanos {
+		nombre := nombreVariante(base, t)
+		redimensionada := Redimensionar(img, t.AnchoMax)
+		v := Variante{
+			Nombre:      t.Nombre,
+			Ancho:       redimensionada.Bounds().Dx(),
+			Alto:        redimensionada.Bounds().Dy(),
+			Archivo:     nombre + ext,
+			ArchivoWebP: nombre + ".webp",
+		}
+		if err := guardar(ctx, almacen, path.Join(dir, v.Archivo), redimensionada, formato); err != nil {
+			limpiar()
+			return nil, err
+		}
+		escritos = append(escritos, v.Archivo)
+		if err := guardar(ctx, almacen, path.Join(dir, v.ArchivoWebP), redimensionada, "webp"); err != nil {
+			limpiar()
+			return nil, err
+		}
+		escritos = append(escritos, v.ArchivoWebP)
+		variantes = append(variantes, v)
+	}
+	return variantes, nil
+}
+
+// nombreVariante es el nombre (sin extensión) del archivo de la variante 't' de 'base'.
+func nombreVariante(base string, t Tamano) string {
+	if t.Nombre == VarianteFull {
+		return base
+	}
+	return base + "_" + t.Nombre
+}
+
+// Original devuelve el nombre (sin extensión) de la imagen a la que pertenece el archivo: el
+// mismo para la principal y para todas sus variantes, ej: "abc_thumb.webp" -> "abc".
+func Original(archivo string, tamanos []Tamano) string {
+	base := strings.TrimSuffix(archivo, path.Ext(archivo))
+	if base == "" { // Archivos ocultos como ".subida-123" (un temporal no es una extensión)
+		return archivo
+	}
+	for _, t := range tamanos {
+		if t.Nombre != VarianteFull && strings.HasSuffix(base, "_"+t.Nombre) {
+			return strings.TrimSuffix(base, "_"+t.Nombre)
+		}
+	}
+	return base
+}
+
+// Archivos devuelve los nombres de todos los archivos de una imagen: el principal y los
+// de sus variantes, sin repetir. Sirve para borrarlos juntos.
+func Archivos(principal string, variantes []Variante) []string {
+	vistos := make(map[string]bool)
+	var archivos []string
+	agregar := func(nombre string) {
+		if nombre != "" && !vistos[nombre] {
+			vistos[nombre] = true
+			archivos = append(archivos, nombre)
+		}
+	}
+	agregar(principal)
+	for _, v := range variantes {
+		agregar(v.Archivo)
+		agregar(v.ArchivoWebP)
+	}
+	return archivos
+}
+
+// Redimensionar reduce la imagen para que su ancho no pase de 'anchoMax', conservando la
+// proporción. Si ya es más estrecha, la devuelve sin cambios.
+func Redimensionar(img image.Image, anchoMax int) image.Image {
+	b := img.Bounds()
+	if anchoMax <= 0 || b.Dx() <= anchoMax {
+		return img
+	}
+	alto := b.Dy() * anchoMax / b.Dx()
+	if alto < 1 {
+		alto = 1
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, anchoMax, alto))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// tiposMIME son los Content-Type de los formatos que se generan.
+var tiposMIME = map[string]string{"jpeg": "image/jpeg", "png": "image/png", "webp": "image/webp"}
+
+// guardar codifica la imagen en memoria y la guarda en 'clave'.
+func guardar(ctx context.Context, almacen storage.Storage, clave string, img image.Image, formato string) error {
+	var buf bytes.Buffer
+	var err error
+	switch formato {
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: calidadJPEG})
+	case "png":
+		err = png.Encode(&buf, img)
+	case "webp":
+		err = nativewebp.Encode(&buf, img, nil)
+	default:
+		err = fmt.Errorf("formato %q no soportado", formato)
+	}
+	if err != nil {
+		return fmt.Errorf("imagenes: codificar %s: %w", clave, err)
+	}
+	if err := almacen.Put(ctx, clave, &buf, int64(buf.Len()), tiposMIME[formato]); err != nil {
+		return fmt.Errorf("imagenes: guardar %s: %w", clave, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// backend/shared/imagenes/imagenes_test.go
+package imagenes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"backend/shared/imagenes"
+	"backend/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jpegConOrientacion codifica una imagen w x h e inserta un segmento APP1 con la
+// orientación EXIF dada (en orden "MM") y un par de bytes que simulan datos GPS.
+func jpegConOrientacion(t *testing.T, w, h, orientacion int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255}) // Esquina superior izquierda roja
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08") // Cabecera TIFF, IFD en el offset 8
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3) // SHORT
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, uint16(orientacion))
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0, 'G', 'P', 'S')
+	segmento := append([]byte("Exif\x00\x00"), tiff...)
+
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segmento)+2))
+	app1 = append(app1, segmento...)
+
+	datos := buf.Bytes()
+	return append(append(append([]byte{}, datos[:2]...), app1...), datos[2:]...)
+}
+
+func TestOrientacionEXIF(t *testing.T) {
+	assert.Equal(t, 6, imagenes.OrientacionEXIF(jpegConOrientacion(t, 4, 2, 6)))
+	assert.Equal(t, 1, imagenes.OrientacionEXIF([]byte("no es un jpeg")))
+}
+
+func TestProcesar_GeneraVariantesSinAmpliarNiMetadatos(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	almacen := storage.NewLocal(dir, "/uploads")
+	// 2000x1000 con orientación 6: una vez enderezada mide 1000x2000
+	datos := jpegConOrientacion(t, 2000, 1000, 6)
+	require.NoError(t, almacen.Put(ctx, "recetas/foto.jpg", bytes.NewReader(datos), int64(len(datos)), "image/jpeg"))
+
+	tamanos := []imagenes.Tamano{
+		{Nombre: "thumb", AnchoMax: 320},
+		{Nombre: imagenes.VarianteFull, AnchoMax: 1600},
+	}
+	variantes, err := imagenes.Procesar(ctx, almacen, "recetas/foto.jpg", datos, tamanos)
+	require.NoError(t, err)
+	require.Len(t, variantes, 2)
+
+	assert.Equal(t, imagenes.Variante{Nombre: "thumb", Ancho: 320, Alto: 640, Archivo: "foto_thumb.jpg", ArchivoWebP: "foto_thumb.webp"}, variantes[0])
+	// La variante full no se amplía y reemplaza al original
+	assert.Equal(t, imagenes.Variante{Nombre: "full", Ancho: 1000, Alto: 2000, Archivo: "foto.jpg", ArchivoWebP: "foto.webp"}, variantes[1])
+
+	for _, archivo := range imagenes.Archivos("foto.jpg", variantes) {
+		guardado, err := os.ReadFile(filepath.Join(dir, "recetas", archivo))
+		require.NoError(t, err, archivo)
+		assert.False(t, bytes.Contains(guardado, []byte("Exif")), "%s conserva EXIF", archivo)
+		assert.False(t, bytes.Contains(guardado, []byte("GPS")), "%s conserva GPS", archivo)
+	}
+}
+
+func TestProcesar_RechazaArchivosQueNoSonImagenes(t *testing.T) {
+	almacen := storage.NewLocal(t.TempDir(), "/uploads")
+	_, err := imagenes.Procesar(context.Background(), almacen, "recetas/foto.jpg", []byte("texto"), imagenes.TamanosPorDefecto)
+	assert.ErrorIs(t, err, imagenes.ErrImagenIlegible)
+}
+
+func TestOriginal_AgrupaLasVariantes(t *testing.T) {
+	for archivo, original := range map[string]string{
+		"abc.jpg":             "abc",
+		"abc.webp":            "abc",
+		"abc_thumb.webp":      "abc",
+		"abc_card.jpg":        "abc",
+		"20250411_021531.jpg": "20250411_021531", // Nombres antiguos con "_": no es una variante
+		".subida-123456":      ".subida-123456",
+	} {
+		assert.Equal(t, original, imagenes.Original(archivo, imagenes.TamanosPorDefecto), archivo)
+	}
+}
+
+func TestEnderezar_Rota90Horario(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+
+	rotada := imagenes.Enderezar(img, 6)
+	assert.Equal(t, image.Rect(0, 0, 2, 3), rotada.Bounds())
+	// La esquina superior izquierda pasa a la superior derecha
+	assert.Equal(t, color.NRGBA{R: 255, A: 255}, rotada.At(1, 0))
+}
+
+func TestCalcularMarcador_ColorLiso(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	for y := 0; y < 30; y++ {
+		for x := 0; x < 40; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	m := imagenes.CalcularMarcador(img)
+	// 4x3 componentes ("L"), el color medio (rojo puro, "TI:j") y 11 componentes AC de 2 caracteres
+	require.Len(t, m.BlurHash, 6+11*2)
+	assert.Equal(t, "L", m.BlurHash[:1])
+	assert.Equal(t, "TI:j", m.BlurHash[2:6])
+	assert.Equal(t, "#ff0000", m.Color)
+}
+
+func TestColorDominante_IgnoraLaTransparencia(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for y := 0; y < 10; y++ {
+		for x := 0; x < 10; x++ {
+			switch {
+			case y < 6: // Mayoría transparente
+			case x < 7:
+				img.Set(x, y, color.NRGBA{B: 200, A: 255})
+			default:
+				img.Set(x, y, color.NRGBA{G: 200, A: 255})
+			}
+		}
+	}
+	assert.Equal(t, "#0000c8", imagenes.ColorDominante(img))
+}
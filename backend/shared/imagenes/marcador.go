@@ -0,0 +1,206 @@
+// backend/shared/imagenes/marcador.go
+
+// Este archivo calcula lo que el cliente muestra mientras carga una imagen: su BlurHash
+// (https://blurha.sh, una versión muy borrosa codificada en ~30 caracteres) y su color
+// dominante. Ambos se calculan sobre la variante más pequeña, que basta para un resultado
+// tan borroso y evita decodificar la imagen completa otra vez.
+
+package imagenes
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"path"
+	"strings"
+
+	"backend/shared/storage"
+)
+
+// Marcador es el sustituto de una imagen mientras carga.
+type Marcador struct {
+	BlurHash string // ej: "LEHV6nWB2yk8pyo0adR*.7kCMdnj"
+	Color    string // Color dominante, ej: "#a0522d"
+}
+
+// Resolución a la que se reduce la imagen antes de calcular el marcador.
+const (
+	anchoBlurHash = 32
+	anchoColor    = 64
+)
+
+// CalcularMarcador calcula el marcador de una imagen.
+func CalcularMarcador(img image.Image) Marcador {
+	return Marcador{
+		BlurHash: BlurHash(Redimensionar(img, anchoBlurHash)),
+		Color:    ColorDominante(Redimensionar(img, anchoColor)),
+	}
+}
+
+// MarcadorDe calcula el marcador de una imagen guardada a partir de su variante más pequeña
+// (el archivo principal si no tiene variantes).
+func MarcadorDe(ctx context.Context, almacen storage.Storage, clave string, variantes []Variante) (Marcador, error) {
+	archivo := path.Base(clave)
+	menor := 0
+	for _, v := range variantes {
+		if menor == 0 || v.Ancho < menor {
+			archivo, menor = v.Archivo, v.Ancho
+		}
+	}
+	r, err := almacen.Get(ctx, path.Join(path.Dir(clave), archivo))
+	if err != nil {
+		return Marcador{}, fmt.Errorf("imagenes: marcador de %s: %w", clave, err)
+	}
+	defer r.Close()
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return Marcador{}, fmt.Errorf("%w: %s: %v", ErrImagenIlegible, archivo, err)
+	}
+	return CalcularMarcador(img), nil
+}
+
+// --- BlurHash ---
+
+// caracteresBase83 es el alfabeto de BlurHash.
+const caracteresBase83 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// BlurHash codifica la imagen con 4x3 componentes (3x4 si es vertical), lo habitual para fotos.
+// Conviene reducirla antes: el coste crece con el número de píxeles.
+func BlurHash(img image.Image) string {
+	cx, cy := 4, 3
+	if img.Bounds().Dy() > img.Bounds().Dx() {
+		cx, cy = 3, 4
+	}
+	b := img.Bounds()
+	ancho, alto := b.Dx(), b.Dy()
+	if ancho == 0 || alto == 0 {
+		return ""
+	}
+
+	// Componentes de la transformada del coseno, sobre los colores en espacio lineal.
+	lineal := make([][3]float64, ancho*alto)
+	for y := 0; y < alto; y++ {
+		for x := 0; x < ancho; x++ {
+			r, g, bl, _ := img.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			lineal[y*ancho+x] = [3]float64{aLineal(r >> 8), aLineal(g >> 8), aLineal(bl >> 8)}
+		}
+	}
+	factores := make([][3]float64, 0, cx*cy)
+	for j := 0; j < cy; j++ {
+		for i := 0; i < cx; i++ {
+			normalizacion := 2.0
+			if i == 0 && j == 0 {
+				normalizacion = 1
+			}
+			var f [3]float64
+			for y := 0; y < alto; y++ {
+				for x := 0; x < ancho; x++ {
+					base := math.Cos(math.Pi*float64(i*x)/float64(ancho)) * math.Cos(math.Pi*float64(j*y)/float64(alto))
+					p := lineal[y*ancho+x]
+					f[0] += base * p[0]
+					f[1] += base * p[1]
+					f[2] += base * p[2]
+				}
+			}
+			escala := normalizacion / float64(ancho*alto)
+			factores = append(factores, [3]float64{f[0] * escala, f[1] * escala, f[2] * escala})
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(base83((cx-1)+(cy-1)*9, 1))
+	dc, ac := factores[0], factores[1:]
+	maximo := 1.0
+	if len(ac) > 0 {
+		var real float64
+		for _, f := range ac {
+			real = math.Max(real, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		cuantizado := int(math.Max(0, math.Min(82, math.Floor(real*166-0.5))))
+		maximo = float64(cuantizado+1) / 166
+		sb.WriteString(base83(cuantizado, 1))
+	} else {
+		sb.WriteString(base83(0, 1))
+	}
+	sb.WriteString(base83(aSRGB(dc[0])<<16|aSRGB(dc[1])<<8|aSRGB(dc[2]), 4))
+	for _, f := range ac {
+		q := func(v float64) int {
+			return int(math.Max(0, math.Min(18, math.Floor(potenciaConSigno(v/maximo, 0.5)*9+9.5))))
+		}
+		sb.WriteString(base83(q(f[0])*19*19+q(f[1])*19+q(f[2]), 2))
+	}
+	return sb.String()
+}
+
+// aLineal convierte un canal sRGB (0-255) a intensidad lineal (0-1).
+func aLineal(v uint32) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// aSRGB convierte una intensidad lineal (0-1) a canal sRGB (0-255).
+func aSRGB(v float64) int {
+	c := math.Max(0, math.Min(1, v))
+	if c <= 0.0031308 {
+		return int(c*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(c, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func potenciaConSigno(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
+
+// base83 codifica 'valor' con 'longitud' dígitos del alfabeto de BlurHash.
+func base83(valor, longitud int) string {
+	digitos := make([]byte, longitud)
+	for i := longitud - 1; i >= 0; i-- {
+		digitos[i] = caracteresBase83[valor%83]
+		valor /= 83
+	}
+	return string(digitos)
+}
+
+// --- Color dominante ---
+
+// ColorDominante devuelve, en "#rrggbb", el color más frecuente de la imagen: agrupa los píxeles
+// en 4096 tonos (4 bits por canal), elige el grupo más numeroso y promedia sus colores.
+// Los píxeles transparentes no cuentan. Conviene reducirla antes.
+func ColorDominante(img image.Image) string {
+	type grupo struct {
+		n       int
+		r, g, b uint64
+	}
+	grupos := make(map[uint32]*grupo)
+	var mejor *grupo
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := img.At(x, y).RGBA()
+			if a < 0x8000 {
+				continue
+			}
+			r, g, bl = r>>8, g>>8, bl>>8
+			clave := (r>>4)<<8 | (g>>4)<<4 | bl>>4
+			gr := grupos[clave]
+			if gr == nil {
+				gr = &grupo{}
+				grupos[clave] = gr
+			}
+			gr.n++
+			gr.r, gr.g, gr.b = gr.r+uint64(r), gr.g+uint64(g), gr.b+uint64(bl)
+			if mejor == nil || gr.n > mejor.n {
+				mejor = gr
+			}
+		}
+	}
+	if mejor == nil {
+		return ""
+	}
+	n := uint64(mejor.n)
+	return fmt.Sprintf("#%02x%02x%02x", mejor.r/n, mejor.g/n, mejor.b/n)
+}
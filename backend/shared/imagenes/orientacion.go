@@ -0,0 +1,118 @@
+// backend/shared/imagenes/orientacion.go
+
+// Lectura de la orientación EXIF de un JPEG y aplicación a los píxeles.
+// Al volver a codificar la imagen se pierde el EXIF, así que la rotación que
+// indicaba la cámara debe aplicarse antes o la foto se vería girada.
+
+package imagenes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// tagOrientacion es la etiqueta EXIF Orientation (valores 1 a 8).
+const tagOrientacion = 0x0112
+
+// OrientacionEXIF devuelve la orientación EXIF de un JPEG, o 1 (normal) si no tiene
+// o no se puede leer.
+func OrientacionEXIF(jpegDatos []byte) int {
+	if len(jpegDatos) < 4 || jpegDatos[0] != 0xFF || jpegDatos[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(jpegDatos); {
+		if jpegDatos[i] != 0xFF {
+			return 1
+		}
+		marcador := jpegDatos[i+1]
+		if marcador == 0xDA || marcador == 0xD9 { // Empiezan los datos de imagen: no hay más cabeceras
+			return 1
+		}
+		tamano := int(binary.BigEndian.Uint16(jpegDatos[i+2:]))
+		if tamano < 2 || i+2+tamano > len(jpegDatos) {
+			return 1
+		}
+		segmento := jpegDatos[i+4 : i+2+tamano]
+		if marcador == 0xE1 && bytes.HasPrefix(segmento, []byte("Exif\x00\x00")) {
+			return orientacionTIFF(segmento[6:])
+		}
+		i += 2 + tamano
+	}
+	return 1
+}
+
+// orientacionTIFF busca la etiqueta de orientación en el primer IFD de una cabecera TIFF.
+func orientacionTIFF(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var orden binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		orden = binary.LittleEndian
+	case "MM":
+		orden = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(orden.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entradas := int(orden.Uint16(tiff[ifd:]))
+	for k := 0; k < entradas; k++ {
+		e := ifd + 2 + 12*k
+		if e+12 > len(tiff) {
+			return 1
+		}
+		if orden.Uint16(tiff[e:]) == tagOrientacion {
+			if o := int(orden.Uint16(tiff[e+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// Enderezar aplica la orientación EXIF a la imagen (1 = sin cambios).
+func Enderezar(img image.Image, orientacion int) image.Image {
+	if orientacion < 2 || orientacion > 8 {
+		return img
+	}
+	b := img.Bounds()
+	src := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if orientacion >= 5 { // Las orientaciones 5 a 8 intercambian ancho y alto
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientacion {
+			case 2: // Espejo horizontal
+				sx, sy = w-1-x, y
+			case 3: // Girada 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // Espejo vertical
+				sx, sy = x, h-1-y
+			case 5: // Traspuesta
+				sx, sy = y, x
+			case 6: // Girar 90° en sentido horario
+				sx, sy = y, h-1-x
+			case 7: // Traspuesta inversa
+				sx, sy = w-1-y, h-1-x
+			case 8: // Girar 90° en sentido antihorario
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
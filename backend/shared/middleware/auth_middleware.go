@@ -0,0 +1,136 @@
+// backend/shared/middleware/auth_middleware.go
+
+// Middlewares de autenticación basados en JWT.
+//
+// - OptionalAuth: si la petición trae un "Authorization: Bearer <token>" válido,
+//   deja el ID y el email del usuario en el contexto de Gin. Si no trae token,
+//   la petición continúa como anónima.
+// - RequireAuth: exige que OptionalAuth (u otro middleware) haya identificado
+//   al usuario; si no, corta la petición con ErrNoAutenticado (401).
+// - Admins: marca como admin al usuario identificado si su ID está en auth.admins.
+//   Los admins son también los editores del contenido (ven y publican borradores ajenos).
+// - RequireAdmin: protege las rutas /admin; 401 si es anónimo, 403 si no es admin.
+//
+// Los paquetes de características leen el usuario con c.Get(ContextKeyUserID)
+// (sin importar este paquete, para no crear ciclos con ErrorHandler).
+//
+// Ejemplo de uso:
+//
+// router.Use(middleware.OptionalAuth(tokenVerifier), middleware.Admins(cfg.Auth.Admins))
+// meGroup := apiV1.Group("/me", middleware.RequireAuth())
+// adminGroup := apiV1.Group("/admin", middleware.RequireAdmin())
+
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"backend/shared/apperrors"
+	"backend/shared/security"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Claves bajo las que se guarda el usuario autenticado en el contexto de Gin.
+const (
+	ContextKeyUserID    = "userID"
+	ContextKeyUserEmail = "userEmail"
+	ContextKeyEsAdmin   = "esAdmin" // true si el usuario es admin (ver Admins)
+)
+
+// Errores de autenticación que ErrorHandler traduce a 401.
+var (
+	ErrNoAutenticado = errors.New("se requiere autenticación")
+	ErrTokenInvalido = errors.New("token de autenticación inválido o expirado")
+	ErrSinPermiso    = errors.New("no tiene permiso para esta operación")
+)
+
+func init() {
+	apperrors.Registrar(ErrNoAutenticado, http.StatusUnauthorized, "no_autenticado")
+	// No exponer el detalle de la librería JWT, solo el error genérico.
+	apperrors.RegistrarSinDetalle(ErrTokenInvalido, http.StatusUnauthorized, "token_invalido")
+	apperrors.Registrar(ErrSinPermiso, http.StatusForbidden, "sin_permiso")
+}
+
+// OptionalAuth identifica al usuario si la petición trae un token Bearer.
+// Un token presente pero inválido se rechaza (401) en lugar de tratarse como anónimo,
+// para que el cliente sepa que debe renovarlo.
+func OptionalAuth(verifier security.TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found || strings.TrimSpace(tokenString) == "" {
+			_ = c.Error(fmt.Errorf("%w: cabecera Authorization mal formada", ErrTokenInvalido))
+			c.Abort()
+			return
+		}
+
+		claims, err := verifier.VerifyToken(strings.TrimSpace(tokenString))
+		if err != nil {
+			_ = c.Error(fmt.Errorf("%w: %w", ErrTokenInvalido, err))
+			c.Abort()
+			return
+		}
+
+		c.Set(ContextKeyUserID, claims.UserID)
+		c.Set(ContextKeyUserEmail, claims.Email)
+		c.Next()
+	}
+}
+
+// RequireAuth corta la petición si no hay un usuario autenticado en el contexto.
+// Debe registrarse DESPUÉS de OptionalAuth.
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get(ContextKeyUserID); !exists {
+			_ = c.Error(ErrNoAutenticado)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// Admins marca como admin (ContextKeyEsAdmin) al usuario identificado si su ID está en 'ids'.
+// Debe registrarse DESPUÉS de OptionalAuth.
+func Admins(ids []uint) gin.HandlerFunc {
+	admins := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		admins[id] = true
+	}
+	return func(c *gin.Context) {
+		if v, exists := c.Get(ContextKeyUserID); exists {
+			if id, ok := v.(uint); ok && admins[id] {
+				c.Set(ContextKeyEsAdmin, true)
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin corta la petición si el usuario no es admin: ErrNoAutenticado (401) si es
+// anónimo, ErrSinPermiso (403) si está identificado pero no es admin.
+// Debe registrarse DESPUÉS de OptionalAuth y Admins.
+func RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, exists := c.Get(ContextKeyUserID); !exists {
+			_ = c.Error(ErrNoAutenticado)
+			c.Abort()
+			return
+		}
+		if !c.GetBool(ContextKeyEsAdmin) {
+			_ = c.Error(ErrSinPermiso)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
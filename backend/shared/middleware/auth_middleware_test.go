@@ -0,0 +1,39 @@
+// backend/shared/middleware/auth_middleware_test.go
+package middleware_test // Usar paquete _test para probar como cliente externo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/shared/middleware" // El paquete que estamos probando
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// pedirAdmin sirve una ruta protegida con RequireAdmin como el usuario 'usuarioID' (0 = anónimo).
+func pedirAdmin(usuarioID uint, admins []uint) int {
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	router.Use(func(c *gin.Context) { // En lugar de OptionalAuth
+		if usuarioID != 0 {
+			c.Set(middleware.ContextKeyUserID, usuarioID)
+		}
+	})
+	router.Use(middleware.Admins(admins))
+	router.GET("/admin/cosas", middleware.RequireAdmin(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/cosas", nil))
+	return w.Code
+}
+
+func TestRequireAdmin(t *testing.T) {
+	admins := []uint{1, 7}
+
+	assert.Equal(t, http.StatusUnauthorized, pedirAdmin(0, admins), "Anónimo")
+	assert.Equal(t, http.StatusForbidden, pedirAdmin(2, admins), "Identificado pero no admin")
+	assert.Equal(t, http.StatusOK, pedirAdmin(7, admins))
+	assert.Equal(t, http.StatusForbidden, pedirAdmin(1, nil), "Sin admins configurados nadie entra")
+}
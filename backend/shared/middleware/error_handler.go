@@ -8,7 +8,8 @@
 // El middleware:
 // - Captura errores de forma centralizada
 // - Proporciona respuestas JSON con formato estándar
-// - Maneja diferentes tipos de errores (4xx, 5xx)
+// - Maneja diferentes tipos de errores (4xx, 5xx) según lo registrado en shared/apperrors
+//   por cada paquete de características
 // - Incluye detalles útiles para el cliente
 // - Puede ser personalizado para adaptarse a diferentes entornos
 //
@@ -22,28 +23,35 @@
 package middleware
 
 import (
-	"errors"  // Para errors.Is y errors.As
-	"fmt"     // Para formatear mensajes de error
-	"log"     // Para logging. A futuro, reemplazar con un logger estructurado.
+	"errors"   // Para errors.Is y errors.As
+	"log"      // Para logging. A futuro, reemplazar con un logger estructurado.
 	"net/http" // Para los códigos de estado HTTP
-	//"strings"  // Para operaciones con strings, si fueran necesarias
-
-	// --- Paquetes de Características ---
-	// Importamos los paquetes de características para acceder a sus errores de dominio definidos.
-	"backend/categorias"
-	"backend/recetas"
+	"strconv"  // Para el parámetro q de la cabecera Accept
+	"strings"  // Para interpretar la cabecera Accept
 
 	// --- Paquetes Compartidos ---
-	"backend/shared/apitypes" // Para nuestro DTO estándar de respuesta de error
+	// Los paquetes de características registran sus errores en apperrors (ver *_api_errors.go),
+	// así que este middleware no importa ninguno de ellos.
+	"backend/shared/apitypes"   // Para nuestro DTO estándar de respuesta de error
+	"backend/shared/apperrors"  // Para traducir errores de dominio a respuestas HTTP
+	"backend/shared/i18n"       // Para los mensajes en el idioma de la petición
+	"backend/shared/repository" // Para las violaciones de restricciones traducidas por los repositorios
 
 	// --- Paquetes de Terceros ---
-	"github.com/gin-gonic/gin"                // El framework web
+	"github.com/gin-gonic/gin"               // El framework web
 	"github.com/go-playground/validator/v10" // Para manejar errores de validación del binding de Gin
 )
 
+func init() {
+	// Violaciones de restricciones de la BD que ningún servicio tradujo a un error de dominio.
+	// Se responde con el mensaje genérico: el del driver no debe llegar al cliente.
+	apperrors.RegistrarSinDetalle(repository.ErrDuplicateRecord, http.StatusConflict, "registro_duplicado")
+	apperrors.RegistrarSinDetalle(repository.ErrForeignKeyViolation, http.StatusUnprocessableEntity, "referencia_invalida")
+}
+
 // formatValidationErrors es una función helper para convertir errores de validación de `validator/v10`
-// en un mapa más legible para la respuesta de la API.
-func formatValidationErrors(verrs validator.ValidationErrors) map[string]string {
+// en un mapa más legible para la respuesta de la API, con los mensajes en el idioma de la petición.
+func formatValidationErrors(verrs validator.ValidationErrors, idioma i18n.Idioma) map[string]string {
 	errs := make(map[string]string)
 	for _, f := range verrs {
 		// f.StructNamespace() // Proporciona el path completo al campo, ej: "User.Email"
@@ -52,22 +60,16 @@ func formatValidationErrors(verrs validator.ValidationErrors) map[string]string
 		// f.Param()           // Proporciona el parámetro de la regla, ej: "6" para "min=6"
 		// f.Value()           // Proporciona el valor que falló la validación
 
-		// Construir un mensaje de error útil basado en el tag de validación
+		// Construir un mensaje de error útil basado en el tag de validación (ver catálogos de i18n)
 		var msg string
 		switch f.Tag() {
-		case "required":
-			msg = fmt.Sprintf("El campo '%s' es requerido.", f.Field())
-		case "email":
-			msg = fmt.Sprintf("El campo '%s' debe ser una dirección de email válida.", f.Field())
-		case "min":
-			msg = fmt.Sprintf("El campo '%s' debe tener al menos %s caracteres.", f.Field(), f.Param())
-		case "max":
-			msg = fmt.Sprintf("El campo '%s' no debe exceder los %s caracteres.", f.Field(), f.Param())
-		case "gt":
-			msg = fmt.Sprintf("El campo '%s' debe ser mayor que %s.", f.Field(), f.Param())
-		// Puedes añadir más 'case' para otros tags de validación comunes que uses.
+		case "required", "email":
+			msg = i18n.T(idioma, "validacion."+f.Tag(), f.Field())
+		case "min", "max", "gt":
+			msg = i18n.T(idioma, "validacion."+f.Tag(), f.Field(), f.Param())
+		// Para otros tags, añadir la clave "validacion.<tag>" a los catálogos y un 'case' aquí.
 		default:
-			msg = fmt.Sprintf("El campo '%s' es inválido (regla de validación: '%s').", f.Field(), f.Tag())
+			msg = i18n.T(idioma, "validacion.invalido", f.Field(), f.Tag())
 		}
 		errs[f.Field()] = msg // Usar el nombre del campo (sin el nombre del struct) como clave
 	}
@@ -101,72 +103,142 @@ func ErrorHandler() gin.HandlerFunc {
 		// Siempre es buena práctica loguear el error en el servidor para depuración,
 		// especialmente los errores 5xx o cualquier error inesperado.
 		// TODO: Reemplazar 'log.Printf' con un logger estructurado (slog, zap, zerolog)
-		//       que incluya más contexto (RequestID, UserID si hay auth, etc.).
-		log.Printf("[ErrorHandler] Error detectado: [%T] %v\n", err, err)
+		//       que incluya más contexto (UserID si hay auth, etc.).
+		requestID := c.GetString(ContextKeyRequestID)
+		idioma := i18n.IdiomaDe(c.Request.Context())
+		log.Printf("[ErrorHandler] [%s] Error detectado: [%T] %v\n", requestID, err, err)
 		// Para errores envueltos, %+v puede dar un stack trace si el error lo soporta:
 		// log.Printf("[ErrorHandler] Detalle completo del error: %+v\n", err)
 
 
 		// --- Mapeo del Error a Respuesta HTTP ---
-		var statusCode int
-		var responseBody apitypes.ErrorResponse // Usar nuestro DTO estándar para errores
-
-		switch {
-		// --- Errores de Dominio de Categorias ---
-		case errors.Is(err, categorias.ErrCategoriaNotFound):
-			statusCode = http.StatusNotFound // 404
-			responseBody = apitypes.ErrorResponse{Error: err.Error()}
-		case errors.Is(err, categorias.ErrCategoriaNombreYaExiste):
-			statusCode = http.StatusConflict // 409
-			responseBody = apitypes.ErrorResponse{Error: err.Error()}
-
-		// --- Errores de Dominio de Recetas ---
-		case errors.Is(err, recetas.ErrRecetaNotFound):
-			statusCode = http.StatusNotFound // 404
-			responseBody = apitypes.ErrorResponse{Error: err.Error()}
-		case errors.Is(err, recetas.ErrRecetaNombreInvalido):
-			statusCode = http.StatusBadRequest // 400
-			responseBody = apitypes.ErrorResponse{Error: err.Error()}
-		case errors.Is(err, recetas.ErrRecetaSinCategoria):
-			// Este error es devuelto por el servicio de recetas si, por ejemplo,
-			// se intenta crear una receta con un CategoriaID que no existe.
-			// Un 400 (Bad Request) o 422 (Unprocessable Entity) es apropiado.
-			statusCode = http.StatusBadRequest
-			responseBody = apitypes.ErrorResponse{Error: err.Error()}
-		// Puedes añadir más 'case errors.Is(err, recetas.OtroErrorDeReceta)' aquí.
-
-		// --- Errores de Validación del Binding de Gin ---
-		case errors.As(err, &validator.ValidationErrors{}):
-			statusCode = http.StatusBadRequest // 400
-			validationErrors := err.(validator.ValidationErrors)
+		var appErr *apperrors.AppError
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			// --- Errores de Validación del Binding de Gin ---
 			// Formatear los errores de validación para incluirlos en 'details'.
-			responseBody = apitypes.ErrorResponse{
-				Error:   "Uno o más campos fallaron la validación.",
-				Details: formatValidationErrors(validationErrors),
+			appErr = &apperrors.AppError{
+				Estado:   http.StatusBadRequest, // 400
+				Codigo:   "validacion_fallida",
+				Mensaje:  "Uno o más campos fallaron la validación.",
+				Detalles: formatValidationErrors(validationErrors, idioma),
 			}
-		
-		// --- Otros Errores Comunes que podrías querer manejar explícitamente ---
-		// Ejemplo: si un servicio devuelve un error genérico de "acción no autorizada"
-		// var ErrAccionNoAutorizada = errors.New("acción no autorizada") // Definido en algún paquete
-		// case errors.Is(err, ErrAccionNoAutorizada):
-		//  statusCode = http.StatusForbidden // 403
-		//  responseBody = apitypes.ErrorResponse{Error: err.Error()}
-
-		// --- Caso por Defecto: Error Interno del Servidor ---
-		default:
-			statusCode = http.StatusInternalServerError // 500
+		} else if resuelto, ok := apperrors.Resolver(err); ok {
+			// --- Errores registrados por las características (o AppError directos) ---
+			appErr = resuelto
+		} else {
+			// --- Caso por Defecto: Error Interno del Servidor ---
 			// En producción, NUNCA exponer err.Error() directamente para errores 500
 			// si podría contener información sensible de la infraestructura.
-			responseBody = apitypes.ErrorResponse{Error: "Ocurrió un error interno inesperado en el servidor."}
+			appErr = &apperrors.AppError{
+				Estado:  http.StatusInternalServerError, // 500
+				Codigo:  "error_interno",
+				Mensaje: "Ocurrió un error interno inesperado en el servidor.",
+			}
 			// Loguear el error completo con stack trace es VITAL aquí para el equipo de desarrollo.
 			log.Printf("--- DETALLE ERROR INTERNO NO MANEJADO (DEFAULT CASE) --- \nError: %v\nStack (si disponible): %+v\n---------------------------\n", err, err)
 		}
 
-		// Enviar la respuesta JSON al cliente y detener cualquier procesamiento adicional.
+		// --- Localización ---
+		// Los mensajes del código están en el idioma por defecto y pueden llevar contexto
+		// (ej: "...: la categoría ID 5 no existe"); para otros idiomas se usa el catálogo.
+		if idioma != i18n.IdiomaPorDefecto {
+			if mensaje, ok := i18n.Buscar(idioma, "errores."+appErr.Codigo); ok {
+				appErr.Mensaje = mensaje
+			}
+		}
+		// Si el error viene de una restricción de la BD, indicar qué campo la violó.
+		if appErr.Detalles == nil {
+			appErr.Detalles = detallesDeRestriccion(err, idioma)
+		}
+
+		// Enviar la respuesta al cliente y detener cualquier procesamiento adicional.
 		// Solo enviar respuesta si no se ha enviado ya (c.Writer.Written() es false).
 		// Esto previene errores de "http: superfluous response.WriteHeader call".
 		if !c.Writer.Written() {
-			c.AbortWithStatusJSON(statusCode, responseBody)
+			responderError(c, appErr, requestID)
+		}
+	}
+}
+
+// ContentTypeProblem es el tipo de contenido de las respuestas de error (RFC 9457).
+const ContentTypeProblem = "application/problem+json"
+
+// prefijoTipoProblema forma el miembro "type" a partir del código estable del error.
+// Es una referencia relativa: identifica el tipo, no tiene por qué poder descargarse.
+const prefijoTipoProblema = "/problemas/"
+
+// responderError escribe el error como problem+json o, para los clientes que aún piden
+// el formato anterior, como apitypes.ErrorResponse.
+func responderError(c *gin.Context, appErr *apperrors.AppError, requestID string) {
+	if prefiereFormatoLegacy(c.GetHeader("Accept")) {
+		c.AbortWithStatusJSON(appErr.Estado, apitypes.ErrorResponse{
+			Error:   appErr.Mensaje,
+			Code:    appErr.Codigo,
+			Details: appErr.Detalles,
+		})
+		return
+	}
+
+	tipo := "about:blank"
+	if appErr.Codigo != "" {
+		tipo = prefijoTipoProblema + appErr.Codigo
+	}
+	c.Header("Content-Type", ContentTypeProblem) // render.JSON respeta un Content-Type ya fijado
+	c.AbortWithStatusJSON(appErr.Estado, apitypes.ProblemDetails{
+		Type:      tipo,
+		Title:     http.StatusText(appErr.Estado),
+		Status:    appErr.Estado,
+		Detail:    appErr.Mensaje,
+		Instance:  c.Request.URL.Path,
+		Code:      appErr.Codigo,
+		RequestID: requestID,
+		Details:   appErr.Detalles,
+	})
+}
+
+// prefiereFormatoLegacy indica si el cliente pide "application/json" sin aceptar
+// "application/problem+json". Los clientes sin Accept, con "*/*" o que aceptan
+// problem+json reciben el formato nuevo.
+// TODO: eliminar cuando termine el periodo de deprecación del formato anterior.
+func prefiereFormatoLegacy(accept string) bool {
+	pideJSON := false
+	for _, rango := range strings.Split(accept, ",") {
+		partes := strings.Split(rango, ";")
+		if rechazado(partes[1:]) {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(partes[0])) {
+		case ContentTypeProblem:
+			return false
+		case "application/json":
+			pideJSON = true
+		}
+	}
+	return pideJSON
+}
+
+// rechazado indica si los parámetros de un rango de Accept incluyen q=0.
+func rechazado(params []string) bool {
+	for _, p := range params {
+		nombre, valor, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if ok && strings.EqualFold(nombre, "q") {
+			q, err := strconv.ParseFloat(valor, 64)
+			return err == nil && q == 0
 		}
 	}
-}
\ No newline at end of file
+	return false
+}
+
+// detallesDeRestriccion devuelve {campo: mensaje} si 'err' contiene una violación de
+// restricción con el campo identificado, o nil en caso contrario.
+func detallesDeRestriccion(err error, idioma i18n.Idioma) map[string]string {
+	campo, ok := repository.CampoEnConflicto(err)
+	if !ok {
+		return nil
+	}
+	if errors.Is(err, repository.ErrForeignKeyViolation) {
+		return map[string]string{campo: i18n.T(idioma, "restriccion.referencia", campo)}
+	}
+	return map[string]string{campo: i18n.T(idioma, "restriccion.duplicado", campo)}
+}
@@ -0,0 +1,116 @@
+// backend/shared/middleware/error_handler_test.go
+package middleware_test // Usar paquete _test para probar como cliente externo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/shared/apitypes"
+	"backend/shared/apperrors"
+	"backend/shared/middleware" // El paquete que estamos probando
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errCosaNoEncontrada = errors.New("cosa no encontrada")
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	apperrors.Registrar(errCosaNoEncontrada, http.StatusNotFound, "cosa_no_encontrada")
+}
+
+// ejecutar sirve una petición a un handler que falla con 'err' y devuelve la respuesta.
+func ejecutar(t *testing.T, accept string, err error) *httptest.ResponseRecorder {
+	t.Helper()
+	router := gin.New()
+	router.Use(middleware.RequestID(), middleware.ErrorHandler())
+	router.GET("/cosas/:id", func(c *gin.Context) { _ = c.Error(err) })
+
+	req := httptest.NewRequest(http.MethodGet, "/cosas/7", nil)
+	req.Header.Set(middleware.HeaderRequestID, "req-123")
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestErrorHandler_ProblemJSONPorDefecto(t *testing.T) {
+	for _, accept := range []string{"", "*/*", "application/problem+json", "application/json, application/problem+json"} {
+		w := ejecutar(t, accept, fmt.Errorf("%w: id 7", errCosaNoEncontrada))
+
+		require.Equal(t, http.StatusNotFound, w.Code, accept)
+		assert.Equal(t, middleware.ContentTypeProblem, w.Header().Get("Content-Type"), accept)
+		var problema apitypes.ProblemDetails
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problema))
+		assert.Equal(t, apitypes.ProblemDetails{
+			Type:      "/problemas/cosa_no_encontrada",
+			Title:     "Not Found",
+			Status:    http.StatusNotFound,
+			Detail:    "cosa no encontrada: id 7",
+			Instance:  "/cosas/7",
+			Code:      "cosa_no_encontrada",
+			RequestID: "req-123",
+		}, problema, accept)
+	}
+}
+
+func TestErrorHandler_FormatoLegacyConAcceptJSON(t *testing.T) {
+	for _, accept := range []string{"application/json", "application/json, application/problem+json;q=0"} {
+		w := ejecutar(t, accept, errCosaNoEncontrada)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+		var legacy map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &legacy))
+		assert.Equal(t, "cosa no encontrada", legacy["error"], accept)
+		assert.NotContains(t, legacy, "status", accept)
+	}
+}
+
+func TestErrorHandler_ValidacionConservaDetallesPorCampo(t *testing.T) {
+	var entrada struct {
+		Nombre string `validate:"required"`
+	}
+	errValidacion := validator.New().Struct(entrada)
+
+	w := ejecutar(t, "", errValidacion)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	var problema apitypes.ProblemDetails
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problema))
+	assert.Equal(t, "validacion_fallida", problema.Code)
+	assert.Equal(t, map[string]string{"Nombre": "El campo 'Nombre' es requerido."}, problema.Details)
+}
+
+func TestErrorHandler_ErrorNoRegistradoEs500SinDetalle(t *testing.T) {
+	w := ejecutar(t, "", errors.New("dial tcp 10.0.0.3:3306: connection refused"))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "10.0.0.3")
+	assert.Equal(t, "req-123", w.Header().Get(middleware.HeaderRequestID))
+}
+
+func TestErrorHandler_MensajesEnIngles(t *testing.T) {
+	router := gin.New()
+	router.Use(middleware.Localizacion(), middleware.ErrorHandler())
+	router.GET("/x", func(c *gin.Context) { _ = c.Error(errors.New("fallo interno")) })
+
+	req := httptest.NewRequest(http.MethodGet, "/x?lang=en", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var problema apitypes.ProblemDetails
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problema))
+	assert.Equal(t, "error_interno", problema.Code)
+	assert.Equal(t, "An unexpected internal server error occurred.", problema.Detail)
+	assert.Equal(t, "en", w.Header().Get("Content-Language"))
+}
@@ -0,0 +1,38 @@
+// backend/shared/middleware/locale.go
+
+// Middleware que negocia el idioma de la respuesta.
+//
+// El idioma sale del parámetro "?lang=" o, si no viene o no está soportado, de la
+// cabecera Accept-Language (ver i18n.Negociar). Se guarda en el contexto de la petición
+// (i18n.IdiomaDe(c.Request.Context())) para que ErrorHandler y los servicios lo usen.
+//
+// Ejemplo de uso (antes de ErrorHandler):
+//
+// router.Use(middleware.Localizacion())
+
+package middleware
+
+import (
+	"net/http"
+
+	"backend/shared/apperrors"
+	"backend/shared/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	// Lo devuelven los servicios que guardan traducciones (recetas, categorías).
+	apperrors.Registrar(i18n.ErrIdiomaNoTraducible, http.StatusBadRequest, "idioma_no_traducible")
+}
+
+// Localizacion negocia el idioma de la petición (ver comentario del archivo).
+func Localizacion() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idioma := i18n.Negociar(c.GetHeader("Accept-Language"), c.Query("lang"))
+		c.Request = c.Request.WithContext(i18n.ConIdioma(c.Request.Context(), idioma))
+		c.Header("Content-Language", string(idioma))
+		c.Header("Vary", "Accept-Language") // Las cachés deben distinguir por idioma
+		c.Next()
+	}
+}
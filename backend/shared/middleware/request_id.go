@@ -0,0 +1,63 @@
+// backend/shared/middleware/request_id.go
+
+// Middleware que asigna un identificador a cada petición.
+//
+// Si el cliente (o un proxy) envía "X-Request-ID" con un valor razonable, se reutiliza;
+// si no, se genera uno aleatorio. El ID se devuelve en la cabecera de la respuesta y
+// queda en el contexto de Gin para los logs y las respuestas de error.
+//
+// Ejemplo de uso (antes de ErrorHandler):
+//
+// router.Use(middleware.RequestID())
+
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// HeaderRequestID es la cabecera por la que viaja el identificador de la petición.
+	HeaderRequestID = "X-Request-ID"
+	// ContextKeyRequestID es la clave bajo la que se guarda en el contexto de Gin.
+	ContextKeyRequestID = "requestID"
+
+	maxLongitudRequestID = 128
+)
+
+// RequestID asigna un identificador a la petición (ver comentario del archivo).
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if !requestIDValido(id) {
+			id = nuevoRequestID()
+		}
+		c.Set(ContextKeyRequestID, id)
+		c.Header(HeaderRequestID, id)
+		c.Next()
+	}
+}
+
+// requestIDValido acepta solo caracteres seguros para logs y cabeceras.
+func requestIDValido(id string) bool {
+	if id == "" || len(id) > maxLongitudRequestID {
+		return false
+	}
+	for _, r := range id {
+		esSeguro := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			r == '-' || r == '_' || r == '.' || r == ':'
+		if !esSeguro {
+			return false
+		}
+	}
+	return true
+}
+
+func nuevoRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b) // crypto/rand.Read no falla en las plataformas soportadas
+	return hex.EncodeToString(b)
+}
@@ -12,6 +12,27 @@ type EmailData struct {
 	Subject string   // Asunto del email
 	Body    string   // Cuerpo del email (puede ser texto plano o HTML)
 	IsHTML  bool     // Indica si el cuerpo es HTML
+	// TextBody es la alternativa en texto plano de un cuerpo HTML (ver Plantillas): se envían
+	// las dos partes y el cliente de correo muestra la que prefiera.
+	TextBody string
+
+	// Las direcciones admiten nombre: "Eva López <eva@ejemplo.com>".
+	Cc          []string     // Copia, visible para todos
+	Bcc         []string     // Copia oculta: solo se usa en el sobre SMTP, no va en las cabeceras
+	ReplyTo     string       // Dirección a la que se responde (ej: quien escribió un contacto)
+	Attachments []Attachment // Adjuntos
+
+	// MessageID identifica el mensaje (la parte antes de "@" de la cabecera Message-ID; el
+	// dominio es el del remitente). Vacío = uno aleatorio. Quien reintenta un mismo correo
+	// debe pasar siempre el mismo, para que el destinatario reconozca los duplicados.
+	MessageID string
+}
+
+// Attachment es un archivo adjunto a un email.
+type Attachment struct {
+	Filename    string // Nombre con el que lo ve el destinatario (puede tener tildes)
+	ContentType string // Tipo MIME; vacío = deducido de la extensión de Filename
+	Content     []byte
 }
 
 // EmailNotifier define el contrato para cualquier servicio que envíe emails.
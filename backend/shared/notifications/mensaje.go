@@ -0,0 +1,265 @@
+// backend/shared/notifications/mensaje.go
+
+// Composición de los emails en formato MIME (RFC 5322 / RFC 2045-2047):
+//   - Las cabeceras con texto no ASCII (asunto, nombres) van codificadas (RFC 2047).
+//   - Las direcciones se validan: un salto de línea en un dato no puede inyectar cabeceras.
+//   - Los cuerpos van en quoted-printable y los adjuntos en base64, en líneas cortas.
+//
+// Estructura del mensaje según lo que lleve:
+//
+//	multipart/mixed            (solo si hay adjuntos)
+//	├── multipart/alternative  (solo si es HTML con alternativa en texto)
+//	│   ├── text/plain
+//	│   └── text/html
+//	└── adjuntos...
+
+package notifications
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// mensaje es un email listo para enviar: el sobre SMTP y el contenido.
+type mensaje struct {
+	remitente     string   // MAIL FROM (solo la dirección)
+	destinatarios []string // RCPT TO: To, Cc y Bcc
+	datos         []byte   // Cabeceras y cuerpo
+}
+
+// parte es una parte MIME: sus cabeceras de contenido y cómo escribir su cuerpo.
+type parte struct {
+	cabecera textproto.MIMEHeader
+	escribir func(w io.Writer) error
+}
+
+// componerMensaje genera el email de 'data' con remitente 'from' y fecha 'ahora'.
+func componerMensaje(data EmailData, from *mail.Address, ahora time.Time) (*mensaje, error) {
+	to, err := direcciones("To", data.To)
+	if err != nil {
+		return nil, err
+	}
+	if len(to) == 0 {
+		return nil, fmt.Errorf("email no puede ser enviado: no hay destinatarios (To)")
+	}
+	cc, err := direcciones("Cc", data.Cc)
+	if err != nil {
+		return nil, err
+	}
+	bcc, err := direcciones("Bcc", data.Bcc)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &mensaje{remitente: from.Address}
+	for _, lista := range [][]*mail.Address{to, cc, bcc} {
+		for _, d := range lista {
+			m.destinatarios = append(m.destinatarios, d.Address)
+		}
+	}
+
+	var buf bytes.Buffer
+	cabecera(&buf, "From", from.String())
+	if data.ReplyTo != "" {
+		replyTo, err := direcciones("Reply-To", []string{data.ReplyTo})
+		if err != nil {
+			return nil, err
+		}
+		cabecera(&buf, "Reply-To", replyTo[0].String())
+	}
+	cabecera(&buf, "To", listaDirecciones(to))
+	if len(cc) > 0 {
+		cabecera(&buf, "Cc", listaDirecciones(cc))
+	}
+	// Bcc no va en las cabeceras: los demás destinatarios no deben verlo
+	cabecera(&buf, "Subject", mime.QEncoding.Encode("UTF-8", unaLinea(data.Subject)))
+	cabecera(&buf, "Date", ahora.Format(time.RFC1123Z))
+	cabecera(&buf, "Message-ID", messageID(data.MessageID, from.Address))
+	cabecera(&buf, "MIME-Version", "1.0")
+
+	contenido := parteCuerpo(data)
+	if len(data.Attachments) > 0 {
+		contenido = parteMixta(contenido, data.Attachments)
+	}
+	escribirCabeceras(&buf, contenido.cabecera)
+	buf.WriteString("\r\n")
+	if err := contenido.escribir(&buf); err != nil {
+		return nil, err
+	}
+	m.datos = buf.Bytes()
+	return m, nil
+}
+
+// direcciones valida una lista de direcciones ("eva@ejemplo.com" o "Eva <eva@ejemplo.com>").
+func direcciones(campo string, lista []string) ([]*mail.Address, error) {
+	validas := make([]*mail.Address, 0, len(lista))
+	for _, d := range lista {
+		direccion, err := mail.ParseAddress(d)
+		if err != nil {
+			return nil, fmt.Errorf("dirección inválida en %s %q: %w", campo, d, err)
+		}
+		validas = append(validas, direccion)
+	}
+	return validas, nil
+}
+
+// listaDirecciones formatea direcciones para una cabecera (codifica los nombres no ASCII).
+func listaDirecciones(lista []*mail.Address) string {
+	textos := make([]string, len(lista))
+	for i, d := range lista {
+		textos[i] = d.String()
+	}
+	return strings.Join(textos, ", ")
+}
+
+// unaLinea quita los saltos de línea de un texto que va en una cabecera.
+func unaLinea(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// messageID forma el identificador con el dominio del remitente; si no se indica 'id', lo
+// genera único.
+func messageID(id, remitente string) string {
+	dominio := "localhost"
+	if i := strings.LastIndex(remitente, "@"); i >= 0 {
+		dominio = remitente[i+1:]
+	}
+	if id != "" {
+		return fmt.Sprintf("<%s@%s>", id, dominio)
+	}
+	aleatorio := make([]byte, 16)
+	_, _ = rand.Read(aleatorio)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(aleatorio), dominio)
+}
+
+func cabecera(w io.Writer, nombre, valor string) {
+	fmt.Fprintf(w, "%s: %s\r\n", nombre, valor)
+}
+
+// escribirCabeceras escribe las cabeceras en orden fijo (un mapa no tiene orden).
+func escribirCabeceras(w io.Writer, h textproto.MIMEHeader) {
+	nombres := make([]string, 0, len(h))
+	for nombre := range h {
+		nombres = append(nombres, nombre)
+	}
+	sort.Strings(nombres)
+	for _, nombre := range nombres {
+		for _, valor := range h[nombre] {
+			cabecera(w, nombre, valor)
+		}
+	}
+}
+
+// parteCuerpo es el cuerpo del email: una parte, o un HTML con su alternativa en texto como
+// multipart/alternative: primero el texto y después el HTML, porque el cliente muestra la
+// última parte que sepa mostrar (RFC 2046).
+func parteCuerpo(data EmailData) parte {
+	if !data.IsHTML || data.TextBody == "" {
+		tipo := "text/plain"
+		if data.IsHTML {
+			tipo = "text/html"
+		}
+		return parteTexto(tipo, data.Body)
+	}
+	return parteMultipart("alternative", []parte{
+		parteTexto("text/plain", data.TextBody),
+		parteTexto("text/html", data.Body),
+	})
+}
+
+// parteMixta añade los adjuntos al cuerpo.
+func parteMixta(cuerpo parte, adjuntos []Attachment) parte {
+	partes := []parte{cuerpo}
+	for _, a := range adjuntos {
+		partes = append(partes, parteAdjunto(a))
+	}
+	return parteMultipart("mixed", partes)
+}
+
+// parteTexto va en quoted-printable: las líneas largas de un HTML no superan el límite de SMTP.
+func parteTexto(tipo, texto string) parte {
+	return parte{
+		cabecera: textproto.MIMEHeader{
+			"Content-Type":              {tipo + `; charset="UTF-8"`},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		escribir: func(w io.Writer) error {
+			qp := quotedprintable.NewWriter(w)
+			if _, err := io.WriteString(qp, texto); err != nil {
+				return err
+			}
+			return qp.Close()
+		},
+	}
+}
+
+// parteAdjunto va en base64. El nombre se codifica según RFC 2231 si no es ASCII.
+func parteAdjunto(a Attachment) parte {
+	tipo := a.ContentType
+	if tipo == "" {
+		tipo = mime.TypeByExtension(filepath.Ext(a.Filename)) // Puede traer parámetros (charset)
+	}
+	tipo, parametros, err := mime.ParseMediaType(tipo)
+	if err != nil { // Vacío o inválido
+		tipo, parametros = "application/octet-stream", map[string]string{}
+	}
+	parametros["name"] = a.Filename
+	return parte{
+		cabecera: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(tipo, parametros)},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		escribir: func(w io.Writer) error {
+			const porLinea = 76 // Límite de RFC 2045
+			codificado := base64.StdEncoding.EncodeToString(a.Content)
+			for len(codificado) > 0 {
+				n := min(porLinea, len(codificado))
+				if _, err := io.WriteString(w, codificado[:n]+"\r\n"); err != nil {
+					return err
+				}
+				codificado = codificado[n:]
+			}
+			return nil
+		},
+	}
+}
+
+// parteMultipart agrupa varias partes en una multipart/<subtipo>.
+func parteMultipart(subtipo string, partes []parte) parte {
+	limite := multipart.NewWriter(io.Discard).Boundary() // Aleatorio: se decide antes de escribir
+	return parte{
+		cabecera: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtipo, map[string]string{"boundary": limite})},
+		},
+		escribir: func(w io.Writer) error {
+			mw := multipart.NewWriter(w)
+			if err := mw.SetBoundary(limite); err != nil {
+				return err
+			}
+			for _, p := range partes {
+				pw, err := mw.CreatePart(p.cabecera)
+				if err != nil {
+					return err
+				}
+				if err := p.escribir(pw); err != nil {
+					return err
+				}
+			}
+			return mw.Close()
+		},
+	}
+}
@@ -0,0 +1,163 @@
+// backend/shared/notifications/plantillas.go
+
+// Plantillas de los correos. Cada correo tiene dos archivos por idioma en plantillas/<idioma>/:
+//   - <nombre>.html: la parte HTML, con html/template (escapa los datos: un mensaje de
+//     contacto no puede inyectar HTML en el correo del admin).
+//   - <nombre>.txt:  la alternativa en texto plano, con text/template. Debe definir también
+//     el asunto: {{define "asunto"}}...{{end}}.
+//
+// Las plantillas van embebidas en el binario. Con smtp.plantillas_dir se pueden sustituir
+// sin recompilar: un archivo con la misma ruta en esa carpeta (ej: es/contacto_nuevo.html)
+// tiene prioridad sobre el embebido. Se leen una vez, al usarlas por primera vez.
+// Si no hay plantilla en el idioma pedido se usa la del idioma por defecto (ver i18n).
+
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+
+	"backend/shared/i18n" // Idioma de la plantilla
+)
+
+//go:embed plantillas
+var plantillasEmbebidas embed.FS
+
+// ErrPlantillaNoEncontrada se produce si no hay plantilla con ese nombre (ni en el idioma por defecto).
+var ErrPlantillaNoEncontrada = errors.New("plantilla de correo no encontrada")
+
+// Renderizado es un correo generado con una plantilla.
+type Renderizado struct {
+	Asunto string
+	HTML   string
+	Texto  string
+}
+
+// EmailData arma el correo a enviar: HTML con su alternativa en texto plano.
+func (r *Renderizado) EmailData(to []string, from string) EmailData {
+	return EmailData{To: to, From: from, Subject: r.Asunto, Body: r.HTML, TextBody: r.Texto, IsHTML: true}
+}
+
+// Plantillas genera correos a partir de plantillas con nombre.
+type Plantillas interface {
+	// Renderizar aplica 'datos' a la plantilla 'nombre' en 'idioma'.
+	Renderizar(nombre string, idioma i18n.Idioma, datos interface{}) (*Renderizado, error)
+}
+
+type plantillasFS struct {
+	fuentes []fs.FS  // Por orden de prioridad: la carpeta de configuración y las embebidas
+	cache   sync.Map // "<idioma>/<nombre>" -> *plantilla
+}
+
+// plantilla es el par HTML/texto de un correo en un idioma.
+type plantilla struct {
+	html  *htmltemplate.Template
+	texto *texttemplate.Template
+}
+
+// NewPlantillas crea las plantillas embebidas; si 'dir' no está vacío, sus archivos tienen prioridad.
+func NewPlantillas(dir string) Plantillas {
+	embebidas, err := fs.Sub(plantillasEmbebidas, "plantillas")
+	if err != nil {
+		panic(fmt.Sprintf("notifications: plantillas embebidas: %v", err))
+	}
+	p := &plantillasFS{fuentes: []fs.FS{embebidas}}
+	if dir != "" {
+		p.fuentes = append([]fs.FS{os.DirFS(dir)}, p.fuentes...)
+	}
+	return p
+}
+
+func (p *plantillasFS) Renderizar(nombre string, idioma i18n.Idioma, datos interface{}) (*Renderizado, error) {
+	t, err := p.buscar(nombre, idioma)
+	if err != nil {
+		return nil, err
+	}
+	var asunto, html, texto bytes.Buffer
+	if err := t.texto.ExecuteTemplate(&asunto, "asunto", datos); err != nil {
+		return nil, fmt.Errorf("notifications: asunto de %s: %w", nombre, err)
+	}
+	if err := t.texto.Execute(&texto, datos); err != nil {
+		return nil, fmt.Errorf("notifications: texto de %s: %w", nombre, err)
+	}
+	if err := t.html.Execute(&html, datos); err != nil {
+		return nil, fmt.Errorf("notifications: HTML de %s: %w", nombre, err)
+	}
+	return &Renderizado{
+		Asunto: strings.Join(strings.Fields(asunto.String()), " "), // Una sola línea
+		HTML:   html.String(),
+		Texto:  strings.TrimSpace(texto.String()) + "\n",
+	}, nil
+}
+
+// buscar devuelve la plantilla en 'idioma' o, si no existe, en el idioma por defecto.
+func (p *plantillasFS) buscar(nombre string, idioma i18n.Idioma) (*plantilla, error) {
+	if strings.ContainsAny(nombre, `/\.`) {
+		return nil, fmt.Errorf("%w: nombre inválido %q", ErrPlantillaNoEncontrada, nombre)
+	}
+	for _, candidato := range []i18n.Idioma{idioma, i18n.IdiomaPorDefecto} {
+		clave := path.Join(string(candidato), nombre)
+		if t, ok := p.cache.Load(clave); ok {
+			return t.(*plantilla), nil
+		}
+		t, err := p.cargar(clave)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.cache.Store(clave, t)
+		return t, nil
+	}
+	return nil, fmt.Errorf("%w: %s (%s)", ErrPlantillaNoEncontrada, nombre, idioma)
+}
+
+// cargar lee y compila "<idioma>/<nombre>.html" y ".txt"; fs.ErrNotExist si no hay ninguno.
+func (p *plantillasFS) cargar(clave string) (*plantilla, error) {
+	fuenteHTML, errHTML := p.leer(clave + ".html")
+	fuenteTexto, errTexto := p.leer(clave + ".txt")
+	if errors.Is(errHTML, fs.ErrNotExist) && errors.Is(errTexto, fs.ErrNotExist) {
+		return nil, fs.ErrNotExist
+	}
+	if err := errors.Join(errHTML, errTexto); err != nil {
+		// %v y no %w: una plantilla a medias es un error, no "no existe" (no se salta al idioma por defecto)
+		return nil, fmt.Errorf("notifications: plantilla %s incompleta (hacen falta .html y .txt): %v", clave, err)
+	}
+
+	html, err := htmltemplate.New(clave + ".html").Option("missingkey=error").Parse(fuenteHTML)
+	if err != nil {
+		return nil, fmt.Errorf("notifications: plantilla %s.html: %w", clave, err)
+	}
+	texto, err := texttemplate.New(clave + ".txt").Option("missingkey=error").Parse(fuenteTexto)
+	if err != nil {
+		return nil, fmt.Errorf("notifications: plantilla %s.txt: %w", clave, err)
+	}
+	if texto.Lookup("asunto") == nil {
+		return nil, fmt.Errorf("notifications: plantilla %s.txt: falta {{define \"asunto\"}}", clave)
+	}
+	return &plantilla{html: html, texto: texto}, nil
+}
+
+// leer devuelve el archivo de la primera fuente que lo tenga.
+func (p *plantillasFS) leer(archivo string) (string, error) {
+	for _, fuente := range p.fuentes {
+		contenido, err := fs.ReadFile(fuente, archivo)
+		if err == nil {
+			return string(contenido), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", fs.ErrNotExist
+}
@@ -0,0 +1,95 @@
+// backend/shared/notifications/plantillas_test.go
+package notifications_test // Usar paquete _test para probar como cliente externo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"backend/shared/i18n"          // Idiomas de las plantillas
+	"backend/shared/notifications" // El paquete que estamos probando
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contacto tiene los campos que usa la plantilla contacto_nuevo (como contactos.ContactoForm).
+type contacto struct {
+	ID                                                 uint
+	NombreRemitente, EmailRemitente, TelefonoRemitente string
+	Asunto, Mensaje, IPOrigen, UserAgent               string
+	FechaContacto                                      time.Time
+}
+
+func nuevoContacto() contacto {
+	return contacto{
+		ID: 7, NombreRemitente: "Eva <b>", EmailRemitente: "eva@test.com", Asunto: "Hola\nqué tal",
+		Mensaje: "<script>alert(1)</script>", FechaContacto: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRenderizar_PorIdioma(t *testing.T) {
+	p := notifications.NewPlantillas("")
+
+	es, err := p.Renderizar("contacto_nuevo", i18n.ES, nuevoContacto())
+	require.NoError(t, err)
+	assert.Equal(t, "Nuevo Mensaje de Contacto: Hola qué tal", es.Asunto, "El asunto queda en una sola línea")
+	assert.Contains(t, es.Texto, "<script>alert(1)</script>", "El texto plano no se escapa")
+	assert.Contains(t, es.HTML, "&lt;script&gt;", "El HTML escapa los datos")
+	assert.NotContains(t, es.HTML, "<script>")
+	assert.Contains(t, es.HTML, "Eva &lt;b&gt;")
+
+	en, err := p.Renderizar("contacto_nuevo", i18n.EN, nuevoContacto())
+	require.NoError(t, err)
+	assert.NotEqual(t, es.Asunto, en.Asunto)
+
+	// Idioma sin plantillas: se usa el idioma por defecto
+	otro, err := p.Renderizar("contacto_nuevo", i18n.Idioma("fr"), nuevoContacto())
+	require.NoError(t, err)
+	assert.Equal(t, es.Asunto, otro.Asunto)
+
+	datos := otro.EmailData([]string{"admin@test.com"}, "noreply@test.com")
+	assert.True(t, datos.IsHTML)
+	assert.Equal(t, otro.HTML, datos.Body)
+	assert.Equal(t, otro.Texto, datos.TextBody)
+}
+
+func TestRenderizar_NoEncontrada(t *testing.T) {
+	p := notifications.NewPlantillas("")
+
+	_, err := p.Renderizar("no_existe", i18n.ES, nil)
+	assert.ErrorIs(t, err, notifications.ErrPlantillaNoEncontrada)
+
+	_, err = p.Renderizar("../es/contacto_nuevo", i18n.ES, nil)
+	assert.ErrorIs(t, err, notifications.ErrPlantillaNoEncontrada)
+}
+
+func TestRenderizar_CarpetaSustituyeALasEmbebidas(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "es"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "es", "contacto_nuevo.html"), []byte("<p>Propia: {{.NombreRemitente}}</p>"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "es", "contacto_nuevo.txt"), []byte(`{{define "asunto"}}Propio{{end}}Propia: {{.NombreRemitente}}`), 0o644))
+	p := notifications.NewPlantillas(dir)
+
+	r, err := p.Renderizar("contacto_nuevo", i18n.ES, nuevoContacto())
+	require.NoError(t, err)
+	assert.Equal(t, "Propio", r.Asunto)
+	assert.Equal(t, "<p>Propia: Eva &lt;b&gt;</p>", r.HTML)
+
+	// Las que no están en la carpeta siguen siendo las embebidas
+	en, err := p.Renderizar("contacto_nuevo", i18n.EN, nuevoContacto())
+	require.NoError(t, err)
+	assert.NotEqual(t, "Propio", en.Asunto)
+}
+
+func TestRenderizar_PlantillaIncompleta(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "es"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "es", "aviso.html"), []byte("<p>Sin texto</p>"), 0o644))
+	p := notifications.NewPlantillas(dir)
+
+	_, err := p.Renderizar("aviso", i18n.ES, nil)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, notifications.ErrPlantillaNoEncontrada)
+}
@@ -4,15 +4,38 @@ package notifications
 import (
 	"backend/shared/config" // Para obtener las credenciales y configuración SMTP
 	"context"
+	"crypto/tls"
 	"errors" // Para crear nuevos errores
 	"fmt"
+	"net"
+	"net/mail"
 	"net/smtp" // Paquete estándar de Go para SMTP
-	"strings"  // Para strings.Join y strings.Builder
+	"strings"
+	"time"
+)
+
+// Seguridad de la conexión con el servidor SMTP (smtp.seguridad).
+const (
+	SeguridadAuto     = "auto"     // TLS implícito en el puerto 465; en otro, STARTTLS si el servidor lo ofrece
+	SeguridadSTARTTLS = "starttls" // Exigir STARTTLS (falla si el servidor no lo ofrece)
+	SeguridadTLS      = "tls"      // TLS implícito desde la conexión (normalmente puerto 465)
+	SeguridadNinguna  = "ninguna"  // Sin cifrar: solo para servidores locales de pruebas
+)
+
+// Mecanismos de autenticación (smtp.auth). Ninguno envía la contraseña sin TLS salvo a localhost.
+const (
+	AuthPlain   = "plain"
+	AuthLogin   = "login" // Para servidores que no aceptan PLAIN (ej: algunos Exchange)
+	AuthCRAMMD5 = "cram-md5"
+	AuthNinguna = "ninguna" // Relay sin credenciales
 )
 
 // smtpNotifier implementa la interfaz EmailNotifier usando el protocolo SMTP.
 type smtpNotifier struct {
-	cfg config.SMTPConfig // Configuración SMTP inyectada (host, puerto, usuario, pass, from)
+	cfg       config.SMTPConfig // Configuración SMTP inyectada (host, puerto, usuario, pass, from)
+	from      *mail.Address     // Remitente por defecto (cfg.From ya validado)
+	seguridad string
+	timeout   time.Duration // Máximo por envío; 0 = solo el del ctx
 }
 
 // NewSMTPNotifier es la factory function para crear una instancia de smtpNotifier.
@@ -22,68 +45,204 @@ func NewSMTPNotifier(cfg config.SMTPConfig) (EmailNotifier, error) {
 	if cfg.Host == "" || cfg.Port == 0 {
 		return nil, errors.New("configuración SMTP inválida: host o puerto no definidos")
 	}
+	cfg.Auth = strings.ToLower(cfg.Auth)
+	switch cfg.Auth {
+	case "":
+		cfg.Auth = AuthPlain
+	case AuthPlain, AuthLogin, AuthCRAMMD5, AuthNinguna:
+	default:
+		return nil, fmt.Errorf("configuración SMTP inválida: auth %q (plain, login, cram-md5 o ninguna)", cfg.Auth)
+	}
 	// Para Mailtrap y muchos otros, username y password son necesarios para la autenticación.
-	if cfg.Username == "" || cfg.Password == "" {
+	if cfg.Auth != AuthNinguna && (cfg.Username == "" || cfg.Password == "") {
 		return nil, errors.New("configuración SMTP inválida: username o password no definidos")
 	}
 	if cfg.From == "" { // El remitente por defecto
 		return nil, errors.New("configuración SMTP inválida: email 'From' no definido")
 	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("configuración SMTP inválida: email 'From': %w", err)
+	}
 
-	return &smtpNotifier{cfg: cfg}, nil
+	seguridad := strings.ToLower(cfg.Seguridad)
+	switch seguridad {
+	case "", SeguridadAuto:
+		seguridad = SeguridadAuto
+		if cfg.Port == 465 {
+			seguridad = SeguridadTLS
+		}
+	case SeguridadSTARTTLS, SeguridadTLS, SeguridadNinguna:
+	default:
+		return nil, fmt.Errorf("configuración SMTP inválida: seguridad %q (auto, starttls, tls o ninguna)", cfg.Seguridad)
+	}
+
+	return &smtpNotifier{
+		cfg:       cfg,
+		from:      from,
+		seguridad: seguridad,
+		timeout:   time.Duration(cfg.TimeoutSegundos) * time.Second,
+	}, nil
 }
 
 // SendEmail envía un email usando las credenciales y servidor SMTP configurados.
+// Respeta el plazo y la cancelación de ctx (además de smtp.timeout_segundos): si vence a
+// mitad del envío, se corta la conexión y se devuelve el error de ctx.
 func (n *smtpNotifier) SendEmail(ctx context.Context, data EmailData) error {
-	// Construir la dirección del servidor SMTP
-	addr := fmt.Sprintf("%s:%d", n.cfg.Host, n.cfg.Port)
+	// From (usar el 'From' de EmailData si se provee, sino el de config)
+	from := n.from
+	if data.From != "" {
+		var err error
+		if from, err = mail.ParseAddress(data.From); err != nil {
+			return fmt.Errorf("smtpNotifier: email 'From' inválido: %w", err)
+		}
+	}
+	msg, err := componerMensaje(data, from, time.Now())
+	if err != nil {
+		return fmt.Errorf("smtpNotifier: error al componer el email: %w", err)
+	}
 
-	// Autenticación SMTP
-	// smtp.PlainAuth se usa comúnmente. El primer argumento es la identidad (usualmente vacío).
-	auth := smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	if n.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.timeout)
+		defer cancel()
+	}
+	conn, err := n.conectar(ctx)
+	if err != nil {
+		return fmt.Errorf("smtpNotifier: error al conectar con %s: %w", n.direccion(), err)
+	}
+	defer conn.Close()
+	// net/smtp no usa ctx: el plazo se aplica a la conexión, y cancelar ctx la corta
+	if limite, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(limite)
+	}
+	detener := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer detener()
+
+	if err := n.enviar(conn, msg); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("smtpNotifier: envío interrumpido: %w", ctx.Err())
+		}
+		return fmt.Errorf("smtpNotifier: error al enviar email: %w", err)
+	}
+	return nil
+}
 
-	// Construir el mensaje del email
-	var msgBuilder strings.Builder
+func (n *smtpNotifier) direccion() string {
+	return net.JoinHostPort(n.cfg.Host, fmt.Sprint(n.cfg.Port))
+}
 
-	// From (usar el 'From' de EmailData si se provee, sino el de config)
-	from := data.From
-	if from == "" {
-		from = n.cfg.From // Usar el remitente por defecto de la configuración
+// conectar abre la conexión, ya cifrada si la seguridad es TLS implícito.
+func (n *smtpNotifier) conectar(ctx context.Context) (net.Conn, error) {
+	if n.seguridad == SeguridadTLS {
+		dialer := &tls.Dialer{Config: n.configTLS()}
+		return dialer.DialContext(ctx, "tcp", n.direccion())
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", n.direccion())
+}
+
+func (n *smtpNotifier) configTLS() *tls.Config {
+	return &tls.Config{ServerName: n.cfg.Host, MinVersion: tls.VersionTLS12}
+}
+
+// enviar mantiene la conversación SMTP: STARTTLS, AUTH, MAIL, RCPT y DATA.
+func (n *smtpNotifier) enviar(conn net.Conn, msg *mensaje) error {
+	c, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		return err
 	}
-	msgBuilder.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	defer c.Close()
 
-	// To
-	if len(data.To) == 0 {
-		return errors.New("email no puede ser enviado: no hay destinatarios (To)")
+	if n.seguridad == SeguridadSTARTTLS || n.seguridad == SeguridadAuto {
+		ofrecido, _ := c.Extension("STARTTLS")
+		if ofrecido {
+			if err := c.StartTLS(n.configTLS()); err != nil {
+				return fmt.Errorf("STARTTLS: %w", err)
+			}
+		} else if n.seguridad == SeguridadSTARTTLS {
+			return errors.New("el servidor no ofrece STARTTLS")
+		}
+	}
+	if auth := n.autenticacion(); auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("el servidor no admite autenticación (AUTH)")
+		}
+		if err := c.Auth(auth); err != nil {
+			return fmt.Errorf("autenticación: %w", err)
+		}
 	}
-	msgBuilder.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(data.To, ",")))
 
-	// Subject
-	msgBuilder.WriteString(fmt.Sprintf("Subject: %s\r\n", data.Subject))
+	if err := c.Mail(msg.remitente); err != nil {
+		return err
+	}
+	for _, destinatario := range msg.destinatarios {
+		if err := c.Rcpt(destinatario); err != nil {
+			return fmt.Errorf("destinatario %s: %w", destinatario, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.datos); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
 
-	// Headers de Contenido
-	if data.IsHTML {
-		msgBuilder.WriteString("MIME-version: 1.0;\r\n")
-		msgBuilder.WriteString("Content-Type: text/html; charset=\"UTF-8\";\r\n")
-	} else {
-		msgBuilder.WriteString("Content-Type: text/plain; charset=\"UTF-8\";\r\n")
+// autenticacion devuelve el mecanismo configurado (nil = sin autenticación).
+func (n *smtpNotifier) autenticacion() smtp.Auth {
+	switch n.cfg.Auth {
+	case AuthLogin:
+		return &loginAuth{usuario: n.cfg.Username, password: n.cfg.Password, host: n.cfg.Host}
+	case AuthCRAMMD5:
+		return smtp.CRAMMD5Auth(n.cfg.Username, n.cfg.Password)
+	case AuthNinguna:
+		return nil
+	default:
+		// El primer argumento es la identidad (usualmente vacío).
+		return smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
 	}
-	msgBuilder.WriteString("Content-Transfer-Encoding: 8bit\r\n") // Común para UTF-8
+}
 
-	// Línea en blanco requerida entre headers y cuerpo
-	msgBuilder.WriteString("\r\n")
+// loginAuth implementa AUTH LOGIN, que net/smtp no trae. Como PlainAuth, no envía la
+// contraseña sin TLS salvo a localhost.
+type loginAuth struct {
+	usuario, password, host string
+}
 
-	// Cuerpo del mensaje
-	msgBuilder.WriteString(data.Body)
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	if !server.TLS && !esLocal(server.Name) {
+		return "", nil, errors.New("conexión sin cifrar")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("nombre de host incorrecto")
+	}
+	return "LOGIN", nil, nil
+}
 
-	// Enviar el email
-	// El contexto (ctx) podría usarse aquí para implementar timeouts si smtp.SendMail lo soportara directamente
-	// o si se envolviera en una goroutine con select y ctx.Done(). Por ahora, es para consistencia de interfaz.
-	err := smtp.SendMail(addr, auth, from, data.To, []byte(msgBuilder.String()))
-	if err != nil {
-		return fmt.Errorf("smtpNotifier: error al enviar email: %w", err)
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(string(fromServer))) {
+	case "username:":
+		return []byte(a.usuario), nil
+	case "password:":
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("AUTH LOGIN: respuesta inesperada %q", fromServer)
 	}
+}
 
-	// log.Printf("Email enviado exitosamente a: %s (Subject: %s)", strings.Join(data.To, ", "), data.Subject)
-	return nil
-}
\ No newline at end of file
+func esLocal(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,242 @@
+// backend/shared/notifications/smtp_notifier_test.go
+package notifications_test // Usar paquete _test para probar como cliente externo
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/shared/config"
+	"backend/shared/notifications" // El paquete que estamos probando
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// servidorSMTP es un servidor SMTP mínimo en localhost que guarda lo que recibe.
+type servidorSMTP struct {
+	ln            net.Listener
+	mudo          bool // No saluda nunca (para probar los plazos)
+	usuario       string
+	remitente     string
+	destinatarios []string
+	datos         chan string
+}
+
+func nuevoServidorSMTP(t *testing.T, mudo bool) *servidorSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &servidorSMTP{ln: ln, mudo: mudo, datos: make(chan string, 1)}
+	t.Cleanup(func() { ln.Close() })
+	go s.atender()
+	return s
+}
+
+func (s *servidorSMTP) config() config.SMTPConfig {
+	return config.SMTPConfig{Host: "127.0.0.1", Port: s.ln.Addr().(*net.TCPAddr).Port, Username: "usuario", Password: "secreto", From: "Recetas <noreply@recetas.test>"}
+}
+
+func (s *servidorSMTP) atender() {
+	conn, err := s.ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	if s.mudo {
+		io.Copy(io.Discard, conn) // Hasta que el cliente corte
+		return
+	}
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 127.0.0.1 ESMTP")
+	for {
+		linea, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		comando := strings.ToUpper(strings.SplitN(linea, " ", 2)[0])
+		switch {
+		case comando == "EHLO":
+			tp.PrintfLine("250-127.0.0.1")
+			tp.PrintfLine("250 AUTH PLAIN LOGIN")
+		case strings.HasPrefix(strings.ToUpper(linea), "AUTH PLAIN "):
+			credenciales, _ := base64.StdEncoding.DecodeString(linea[len("AUTH PLAIN "):])
+			s.usuario = strings.Split(string(credenciales), "\x00")[1]
+			tp.PrintfLine("235 OK")
+		case strings.HasPrefix(strings.ToUpper(linea), "AUTH LOGIN"):
+			tp.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte("Username:")))
+			usuario, _ := tp.ReadLine()
+			tp.PrintfLine("334 %s", base64.StdEncoding.EncodeToString([]byte("Password:")))
+			tp.ReadLine()
+			decodificado, _ := base64.StdEncoding.DecodeString(usuario)
+			s.usuario = string(decodificado)
+			tp.PrintfLine("235 OK")
+		case comando == "MAIL":
+			s.remitente = linea
+			tp.PrintfLine("250 OK")
+		case comando == "RCPT":
+			s.destinatarios = append(s.destinatarios, strings.Trim(strings.SplitN(linea, ":", 2)[1], "<> "))
+			tp.PrintfLine("250 OK")
+		case comando == "DATA":
+			tp.PrintfLine("354 Adelante")
+			datos, _ := io.ReadAll(tp.DotReader())
+			s.datos <- string(datos)
+			tp.PrintfLine("250 OK")
+		case comando == "QUIT":
+			tp.PrintfLine("221 Adiós")
+			return
+		default:
+			tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func TestSendEmail_MensajeMIME(t *testing.T) {
+	srv := nuevoServidorSMTP(t, false)
+	notifier, err := notifications.NewSMTPNotifier(srv.config())
+	require.NoError(t, err)
+
+	adjunto := []byte("%PDF-1.4 receta")
+	err = notifier.SendEmail(context.Background(), notifications.EmailData{
+		To:          []string{"Admin <admin@recetas.test>"},
+		Cc:          []string{"José Núñez <jose@recetas.test>"},
+		Bcc:         []string{"oculto@recetas.test"},
+		ReplyTo:     "eva@ejemplo.com",
+		Subject:     "Nuevo Mensaje: Canción",
+		Body:        "<p>Hola</p>",
+		TextBody:    "Hola",
+		IsHTML:      true,
+		Attachments: []notifications.Attachment{{Filename: "receta ñ.pdf", Content: adjunto}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "usuario", srv.usuario, "AUTH PLAIN por defecto")
+	assert.Contains(t, srv.remitente, "<noreply@recetas.test>")
+	assert.Equal(t, []string{"admin@recetas.test", "jose@recetas.test", "oculto@recetas.test"}, srv.destinatarios, "Bcc solo en el sobre")
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-srv.datos))
+	require.NoError(t, err)
+	var decoder mime.WordDecoder
+	asunto, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Nuevo Mensaje: Canción", asunto)
+	assert.NotEqual(t, asunto, msg.Header.Get("Subject"), "El asunto va codificado (RFC 2047)")
+	cc, err := msg.Header.AddressList("Cc")
+	require.NoError(t, err)
+	assert.Equal(t, "José Núñez", cc[0].Name)
+	assert.Empty(t, msg.Header.Get("Bcc"))
+	assert.Equal(t, "<eva@ejemplo.com>", msg.Header.Get("Reply-To"))
+	assert.Equal(t, "1.0", msg.Header.Get("MIME-Version"))
+	assert.Regexp(t, `^<.+@recetas\.test>$`, msg.Header.Get("Message-ID"))
+	fecha, err := msg.Header.Date()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), fecha, time.Minute)
+
+	// multipart/mixed: el cuerpo (multipart/alternative) y el adjunto
+	tipo, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", tipo)
+	partes := multipart.NewReader(msg.Body, params["boundary"])
+
+	cuerpo, err := partes.NextPart()
+	require.NoError(t, err)
+	tipo, params, err = mime.ParseMediaType(cuerpo.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/alternative", tipo)
+	alternativas := multipart.NewReader(cuerpo, params["boundary"])
+	for _, esperado := range []struct{ tipo, texto string }{{"text/plain", "Hola"}, {"text/html", "<p>Hola</p>"}} {
+		p, err := alternativas.NextPart() // Decodifica el quoted-printable
+		require.NoError(t, err)
+		assert.Contains(t, p.Header.Get("Content-Type"), esperado.tipo)
+		texto, _ := io.ReadAll(p)
+		assert.Equal(t, esperado.texto, string(texto))
+	}
+
+	p, err := partes.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "receta ñ.pdf", p.FileName())
+	assert.Contains(t, p.Header.Get("Content-Type"), "application/pdf")
+	contenido, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, p))
+	require.NoError(t, err)
+	assert.Equal(t, adjunto, contenido)
+}
+
+func TestSendEmail_MessageIDFijo(t *testing.T) {
+	data := notifications.EmailData{To: []string{"admin@recetas.test"}, Subject: "Hola", Body: "Hola", MessageID: "correo-7.1700000000"}
+
+	for i := 0; i < 2; i++ { // Un reintento del mismo correo
+		srv := nuevoServidorSMTP(t, false) // Atiende una sola conexión
+		notifier, err := notifications.NewSMTPNotifier(srv.config())
+		require.NoError(t, err)
+		require.NoError(t, notifier.SendEmail(context.Background(), data))
+		msg, err := mail.ReadMessage(strings.NewReader(<-srv.datos))
+		require.NoError(t, err)
+		assert.Equal(t, "<correo-7.1700000000@recetas.test>", msg.Header.Get("Message-ID"))
+	}
+}
+
+func TestSendEmail_AuthLogin(t *testing.T) {
+	srv := nuevoServidorSMTP(t, false)
+	cfg := srv.config()
+	cfg.Auth = notifications.AuthLogin
+	notifier, err := notifications.NewSMTPNotifier(cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.SendEmail(context.Background(), notifications.EmailData{To: []string{"admin@recetas.test"}, Subject: "Hola", Body: "Hola"}))
+	assert.Equal(t, "usuario", srv.usuario)
+	assert.Contains(t, <-srv.datos, "Content-Type: text/plain")
+}
+
+func TestSendEmail_RespetaElPlazoDelContexto(t *testing.T) {
+	srv := nuevoServidorSMTP(t, true)
+	notifier, err := notifications.NewSMTPNotifier(srv.config())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	inicio := time.Now()
+	err = notifier.SendEmail(ctx, notifications.EmailData{To: []string{"admin@recetas.test"}, Subject: "Hola", Body: "Hola"})
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "error: %v", err)
+	assert.Less(t, time.Since(inicio), 5*time.Second)
+}
+
+func TestSendEmail_DireccionInvalida(t *testing.T) {
+	srv := nuevoServidorSMTP(t, false)
+	notifier, err := notifications.NewSMTPNotifier(srv.config())
+	require.NoError(t, err)
+
+	// Un salto de línea no puede colarse como cabecera
+	err = notifier.SendEmail(context.Background(), notifications.EmailData{To: []string{"admin@recetas.test\r\nBcc: otro@x.test"}, Body: "Hola"})
+	assert.Error(t, err)
+}
+
+func TestNewSMTPNotifier_ConfiguracionInvalida(t *testing.T) {
+	base := config.SMTPConfig{Host: "smtp.test", Port: 587, Username: "u", Password: "p", From: "noreply@recetas.test"}
+
+	for nombre, cambiar := range map[string]func(*config.SMTPConfig){
+		"seguridad":    func(c *config.SMTPConfig) { c.Seguridad = "ssl3" },
+		"auth":         func(c *config.SMTPConfig) { c.Auth = "ntlm" },
+		"from":         func(c *config.SMTPConfig) { c.From = "no es un email" },
+		"credenciales": func(c *config.SMTPConfig) { c.Password = "" },
+	} {
+		cfg := base
+		cambiar(&cfg)
+		_, err := notifications.NewSMTPNotifier(cfg)
+		assert.Error(t, err, nombre)
+	}
+
+	// Sin autenticación no hacen falta credenciales
+	cfg := base
+	cfg.Auth, cfg.Username, cfg.Password = notifications.AuthNinguna, "", ""
+	_, err := notifications.NewSMTPNotifier(cfg)
+	assert.NoError(t, err)
+}
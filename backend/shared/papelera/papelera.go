@@ -0,0 +1,79 @@
+// backend/shared/papelera/papelera.go
+
+// Este paquete define la tarea que vacía la papelera: borra definitivamente los
+// registros eliminados (soft delete) hace más tiempo que el periodo de retención.
+// Cada característica con papelera (recetas, categorías, contactos) implementa
+// Purgador en su servicio; así este paquete no depende de ninguna de ellas.
+
+package papelera
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Purgador borra definitivamente los registros eliminados antes de 'antesDe'.
+type Purgador interface {
+	PurgarVencidas(ctx context.Context, antesDe time.Time) (int64, error)
+}
+
+// purgadorRegistrado asocia un purgador con el nombre que se usa en los logs.
+type purgadorRegistrado struct {
+	nombre   string
+	purgador Purgador
+}
+
+// Limpiador ejecuta todos los purgadores registrados cada 'intervalo'.
+type Limpiador struct {
+	retencion  time.Duration
+	intervalo  time.Duration
+	purgadores []purgadorRegistrado // En orden de registro
+	ahora      func() time.Time
+}
+
+// NewLimpiador crea el limpiador. retencion e intervalo deben ser positivos.
+func NewLimpiador(retencion, intervalo time.Duration) *Limpiador {
+	return &Limpiador{retencion: retencion, intervalo: intervalo, ahora: time.Now}
+}
+
+// Registrar añade un purgador. Se ejecutan en orden de registro: registre antes
+// a los dependientes (ej: recetas antes que categorías, que no se purgan si aún tienen recetas).
+func (l *Limpiador) Registrar(nombre string, p Purgador) *Limpiador {
+	l.purgadores = append(l.purgadores, purgadorRegistrado{nombre: nombre, purgador: p})
+	return l
+}
+
+// Iniciar vacía la papelera al arrancar y luego en cada intervalo, hasta que
+// se cancele ctx. Bloquea: ejecútelo en una goroutine.
+func (l *Limpiador) Iniciar(ctx context.Context) {
+	log.Printf("🗑️  Limpieza de papelera iniciada (retención %s, cada %s).\n", l.retencion, l.intervalo)
+	ticker := time.NewTicker(l.intervalo)
+	defer ticker.Stop()
+
+	for {
+		l.Ejecutar(ctx)
+		select {
+		case <-ctx.Done():
+			log.Println("🗑️  Limpieza de papelera detenida.")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Ejecutar hace una pasada por todos los purgadores. Un fallo en uno no impide
+// los demás; se loguea y se reintenta en la siguiente pasada.
+func (l *Limpiador) Ejecutar(ctx context.Context) {
+	antesDe := l.ahora().Add(-l.retencion)
+	for _, r := range l.purgadores {
+		n, err := r.purgador.PurgarVencidas(ctx, antesDe)
+		if err != nil {
+			log.Printf("Papelera: error purgando %s: %v\n", r.nombre, err)
+			continue
+		}
+		if n > 0 {
+			log.Printf("Papelera: %d registro(s) de %s purgado(s) definitivamente\n", n, r.nombre)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// backend/shared/repository/mysql_errors.go
+
+// Traducción de errores de restricciones de MySQL a los errores comunes de repositorio.
+// Todas las implementaciones GORM deben pasar sus errores de escritura por TraducirError
+// para que los servicios y el ErrorHandler puedan reaccionar sin conocer el driver.
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Códigos de error de MySQL que se traducen.
+const (
+	mysqlErrDuplicado        uint16 = 1062 // ER_DUP_ENTRY
+	mysqlErrFilaPadre        uint16 = 1451 // ER_ROW_IS_REFERENCED_2: borrar/actualizar un padre referenciado
+	mysqlErrFilaHijaSinPadre uint16 = 1452 // ER_NO_REFERENCED_ROW_2: insertar/actualizar un hijo sin padre
+)
+
+var (
+	// "Duplicate entry 'valor' for key 'tabla.indice'" (MySQL >= 8.0.19) o "... for key 'indice'".
+	reDuplicado = regexp.MustCompile(`Duplicate entry '(.*)' for key '([^']+)'`)
+	// "... (`db`.`tabla`, CONSTRAINT `fk` FOREIGN KEY (`columna`) REFERENCES `padre` (`id`) ...)".
+	reLlaveForanea = regexp.MustCompile("`([^`]+)`, CONSTRAINT `([^`]+)` FOREIGN KEY \\(`([^`]+)`")
+)
+
+// ConstraintError describe una violación de restricción de la base de datos.
+// Envuelve a ErrDuplicateRecord o ErrForeignKeyViolation (Base) y al error original del driver,
+// de modo que errors.Is sigue funcionando contra ambos.
+type ConstraintError struct {
+	Base   error  // ErrDuplicateRecord o ErrForeignKeyViolation
+	Codigo uint16 // Código de error de MySQL
+	Tabla  string // Tabla afectada, si el mensaje la incluye
+	Indice string // Índice único o nombre de la llave foránea
+	Campo  string // Campo que provocó la violación (ej: "slug", "categoria_id")
+	Valor  string // Valor duplicado (solo 1062); no se expone al cliente
+	Causa  error  // Error original del driver
+}
+
+func (e *ConstraintError) Error() string {
+	if e.Campo == "" {
+		return fmt.Sprintf("%v: %v", e.Base, e.Causa)
+	}
+	return fmt.Sprintf("%v (campo '%s'): %v", e.Base, e.Campo, e.Causa)
+}
+
+func (e *ConstraintError) Unwrap() []error {
+	return []error{e.Base, e.Causa}
+}
+
+// TraducirError convierte los errores 1062, 1451 y 1452 de MySQL en un *ConstraintError.
+// Cualquier otro error (incluido nil) se devuelve sin cambios.
+func TraducirError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if err == nil || !errors.As(err, &mysqlErr) {
+		return err
+	}
+
+	switch mysqlErr.Number {
+	case mysqlErrDuplicado:
+		cerr := &ConstraintError{Base: ErrDuplicateRecord, Codigo: mysqlErr.Number, Causa: err}
+		if m := reDuplicado.FindStringSubmatch(mysqlErr.Message); m != nil {
+			cerr.Valor = m[1]
+			cerr.Indice = m[2]
+			if tabla, indice, ok := strings.Cut(m[2], "."); ok {
+				cerr.Tabla, cerr.Indice = tabla, indice
+			}
+			cerr.Campo = campoDeIndice(cerr.Tabla, cerr.Indice)
+		}
+		return cerr
+	case mysqlErrFilaPadre, mysqlErrFilaHijaSinPadre:
+		cerr := &ConstraintError{Base: ErrForeignKeyViolation, Codigo: mysqlErr.Number, Causa: err}
+		if m := reLlaveForanea.FindStringSubmatch(mysqlErr.Message); m != nil {
+			cerr.Tabla, cerr.Indice, cerr.Campo = m[1], m[2], m[3]
+		}
+		return cerr
+	}
+	return err
+}
+
+// CampoEnConflicto devuelve el campo que provocó una violación de restricción, si se conoce.
+func CampoEnConflicto(err error) (string, bool) {
+	var cerr *ConstraintError
+	if errors.As(err, &cerr) && cerr.Campo != "" {
+		return cerr.Campo, true
+	}
+	return "", false
+}
+
+// campoDeIndice deduce el campo a partir del nombre del índice, siguiendo la
+// convención uk_<tabla>_<campo> (ej: uk_recetas_slug -> slug).
+func campoDeIndice(tabla, indice string) string {
+	if indice == "PRIMARY" {
+		return "id"
+	}
+	nombre, esUnico := strings.CutPrefix(indice, "uk_")
+	if tabla != "" {
+		if campo, ok := strings.CutPrefix(nombre, tabla+"_"); ok {
+			return campo
+		}
+	}
+	// Sin tabla en el mensaje (MySQL < 8.0.19) o con un prefijo abreviado: quitar el primer segmento.
+	if _, campo, ok := strings.Cut(nombre, "_"); ok && esUnico {
+		return campo
+	}
+	return nombre
+}
+
+// ConCausa devuelve un error que se presenta con el mensaje de 'dominio' pero conserva
+// 'causa' en la cadena. Los servicios lo usan al traducir una violación de restricción a
+// su error de dominio: errors.Is reconoce ambos y CampoEnConflicto sigue disponible.
+func ConCausa(dominio, causa error) error {
+	return &errorConCausa{dominio: dominio, causa: causa}
+}
+
+type errorConCausa struct {
+	dominio error
+	causa   error
+}
+
+func (e *errorConCausa) Error() string   { return e.dominio.Error() }
+func (e *errorConCausa) Unwrap() []error { return []error{e.dominio, e.causa} }
@@ -0,0 +1,77 @@
+// backend/shared/repository/mysql_errors_test.go
+package repository_test // Usar paquete _test para probar como cliente externo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend/shared/repository" // El paquete que estamos probando
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraducirError_Duplicado(t *testing.T) {
+	casos := []struct {
+		mensaje string
+		campo   string
+	}{
+		{"Duplicate entry 'postres-1' for key 'recetas.uk_recetas_slug'", "slug"}, // MySQL >= 8.0.19
+		{"Duplicate entry 'Postres-1' for key 'uk_categorias_nombre'", "nombre"},  // MySQL 5.7
+		{"Duplicate entry '3-1' for key 'receta_revisiones.uk_receta_revisiones_numero'", "numero"},
+		{"Duplicate entry '9' for key 'PRIMARY'", "id"},
+	}
+	for _, c := range casos {
+		driverErr := &mysql.MySQLError{Number: 1062, Message: c.mensaje}
+		err := repository.TraducirError(fmt.Errorf("gorm: %w", driverErr))
+
+		assert.True(t, errors.Is(err, repository.ErrDuplicateRecord), c.mensaje)
+		assert.True(t, errors.Is(err, driverErr), "El error original debe seguir en la cadena")
+		campo, ok := repository.CampoEnConflicto(err)
+		assert.True(t, ok, c.mensaje)
+		assert.Equal(t, c.campo, campo, c.mensaje)
+	}
+}
+
+func TestTraducirError_LlaveForanea(t *testing.T) {
+	hijo := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails " +
+		"(`recetario`.`recetas`, CONSTRAINT `fk_recetas_categoria` FOREIGN KEY (`categoria_id`) REFERENCES `categorias` (`id`) ON DELETE SET NULL)"}
+	padre := &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails " +
+		"(`recetario`.`receta_ingredientes`, CONSTRAINT `fk_receta_ingredientes_ingrediente` FOREIGN KEY (`ingrediente_id`) REFERENCES `ingredientes` (`id`))"}
+
+	for driverErr, campoEsperado := range map[*mysql.MySQLError]string{hijo: "categoria_id", padre: "ingrediente_id"} {
+		err := repository.TraducirError(driverErr)
+
+		var cerr *repository.ConstraintError
+		require.True(t, errors.As(err, &cerr))
+		assert.ErrorIs(t, err, repository.ErrForeignKeyViolation)
+		assert.Equal(t, driverErr.Number, cerr.Codigo)
+		assert.Equal(t, campoEsperado, cerr.Campo)
+	}
+}
+
+func TestTraducirError_OtrosErroresSinCambios(t *testing.T) {
+	assert.Nil(t, repository.TraducirError(nil))
+
+	generico := errors.New("conexión rechazada")
+	assert.Same(t, generico, repository.TraducirError(generico))
+
+	timeout := &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+	assert.Same(t, timeout, repository.TraducirError(timeout))
+}
+
+func TestConCausa_ConservaMensajeDeDominioYCampo(t *testing.T) {
+	dominio := errors.New("la categoría ya existe")
+	causa := repository.TraducirError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'x' for key 'categorias.uk_categorias_slug'"})
+
+	err := repository.ConCausa(dominio, causa)
+
+	assert.EqualError(t, err, "la categoría ya existe")
+	assert.ErrorIs(t, err, dominio)
+	assert.ErrorIs(t, err, repository.ErrDuplicateRecord)
+	campo, ok := repository.CampoEnConflicto(err)
+	assert.True(t, ok)
+	assert.Equal(t, "slug", campo)
+}
@@ -47,11 +47,12 @@ func NewJWTManager(cfg config.JWTConfig) (TokenGenerator, TokenVerifier, error)
 		return nil, nil, fmt.Errorf("jwtManager: la duración de expiración del token debe ser positiva")
 	}
 
-	return &jwtManager{
+	jm := &jwtManager{
 		secretKey:    []byte(cfg.SecretKey),
 		tokenExpires: time.Minute * time.Duration(cfg.TokenExpiresInMinutes),
 		issuer:       cfg.Issuer,
-	}, nil
+	}
+	return jm, jm, nil // El mismo manager cumple ambas interfaces
 }
 
 // GenerateToken crea un nuevo token JWT firmado.
@@ -112,7 +113,4 @@ func (jm *jwtManager) VerifyToken(tokenString string) (*Claims, error) {
 
 	// El struct 'claims' ahora está populado con los datos del token.
 	return claims, nil
-}
-
-// Necesitaremos añadir JWTConfig al struct Config en shared/config/config.go
-// y a los archivos YAML.
\ No newline at end of file
+}
\ No newline at end of file
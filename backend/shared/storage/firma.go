@@ -0,0 +1,66 @@
+// backend/shared/storage/firma.go
+
+// URLs firmadas del driver "local": dan acceso a un archivo no público hasta una fecha.
+// La firma es un HMAC-SHA256 de la clave y la fecha de caducidad con la clave de
+// configuración storage.clave_firma, y viaja en la consulta: ?expira=<unix>&firma=<base64url>.
+// Quien sirve los archivos (ver media.MediaHandler) la comprueba con ComprobarFirma.
+
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Parámetros de la consulta de una URL firmada.
+const (
+	ParamExpira = "expira"
+	ParamFirma  = "firma"
+)
+
+// Firmante firma y comprueba URLs con una clave secreta.
+type Firmante struct {
+	clave []byte
+}
+
+// NewFirmante crea un firmante con la clave secreta de la configuración.
+func NewFirmante(clave string) *Firmante {
+	return &Firmante{clave: []byte(clave)}
+}
+
+// Firmar devuelve la consulta que da acceso a 'clave' hasta 'vence'.
+func (f *Firmante) Firmar(clave string, vence time.Time) url.Values {
+	expira := strconv.FormatInt(vence.Unix(), 10)
+	q := url.Values{}
+	q.Set(ParamExpira, expira)
+	q.Set(ParamFirma, f.firma(clave, expira))
+	return q
+}
+
+// Comprobar valida la firma de 'clave' y devuelve hasta cuándo es válida.
+func (f *Firmante) Comprobar(clave, expira, firma string, ahora time.Time) (time.Time, error) {
+	segundos, err := strconv.ParseInt(expira, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: caducidad %q", ErrFirmaInvalida, expira)
+	}
+	// Comparación en tiempo constante: no dar pistas de cuántos bytes coinciden.
+	if !hmac.Equal([]byte(firma), []byte(f.firma(clave, expira))) {
+		return time.Time{}, ErrFirmaInvalida
+	}
+	vence := time.Unix(segundos, 0)
+	if !ahora.Before(vence) {
+		return time.Time{}, fmt.Errorf("%w: %s", ErrFirmaVencida, vence.UTC().Format(time.RFC3339))
+	}
+	return vence, nil
+}
+
+func (f *Firmante) firma(clave, expira string) string {
+	mac := hmac.New(sha256.New, f.clave)
+	mac.Write([]byte(clave + "\n" + expira))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
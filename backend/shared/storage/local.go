@@ -0,0 +1,185 @@
+// backend/shared/storage/local.go
+
+// Driver "local": guarda los archivos en una carpeta del disco. La API los sirve
+// bajo URLBase (ver media.MediaHandler), por lo que solo sirve para una instancia o
+// para carpetas compartidas (NFS...). Con Firma, genera URLs firmadas de los archivos
+// no públicos (ver firma.go).
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Local guarda los archivos bajo Dir y los publica bajo URLBase.
+type Local struct {
+	Dir     string    // Carpeta raíz, ej: "uploads"
+	URLBase string    // Prefijo de las URLs públicas, ej: "/uploads"
+	Firma   *Firmante // nil = sin URLs firmadas
+}
+
+// NewLocal crea un almacenamiento en disco.
+func NewLocal(dir, urlBase string) *Local {
+	return &Local{Dir: dir, URLBase: strings.TrimSuffix(urlBase, "/")}
+}
+
+// ruta convierte una clave en la ruta del archivo, comprobando que no salga de Dir.
+func (l *Local) ruta(clave string) (string, error) {
+	if err := ValidarClave(clave); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.Dir, filepath.FromSlash(clave)), nil
+}
+
+// Put escribe en un archivo temporal y lo renombra, para que nunca se sirva a medias.
+func (l *Local) Put(ctx context.Context, clave string, contenido io.Reader, tamano int64, contentType string) error {
+	ruta, err := l.ruta(clave)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(ruta), 0o750); err != nil {
+		return fmt.Errorf("storage local: crear carpeta de %s: %w", clave, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(ruta), ".subida-*")
+	if err != nil {
+		return fmt.Errorf("storage local: guardar %s: %w", clave, err)
+	}
+	_, err = io.Copy(tmp, contenido)
+	if errCerrar := tmp.Close(); err == nil {
+		err = errCerrar
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), ruta)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("storage local: guardar %s: %w", clave, err)
+	}
+	return nil
+}
+
+// Get abre el archivo.
+func (l *Local) Get(ctx context.Context, clave string) (io.ReadCloser, error) {
+	ruta, err := l.ruta(clave)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(ruta)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNoExiste, clave)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("storage local: abrir %s: %w", clave, err)
+	}
+	return f, nil
+}
+
+// Existe comprueba si el archivo está en disco.
+func (l *Local) Existe(ctx context.Context, clave string) (bool, error) {
+	ruta, err := l.ruta(clave)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(ruta); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("storage local: consultar %s: %w", clave, err)
+	}
+	return true, nil
+}
+
+// Delete borra el archivo si existe.
+func (l *Local) Delete(ctx context.Context, clave string) error {
+	ruta, err := l.ruta(clave)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(ruta); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("storage local: borrar %s: %w", clave, err)
+	}
+	return nil
+}
+
+// URL es la ruta bajo la que la API sirve la carpeta.
+func (l *Local) URL(clave string) string {
+	return l.URLBase + path.Join("/", clave)
+}
+
+// SignedURL firma la URL con caducidad. La caducidad se redondea a múltiplos de expira/2, para
+// que las peticiones seguidas reciban la misma URL y el navegador la cachee; siempre queda al
+// menos la mitad de 'expira'. Sin Firma devuelve ErrFirmaNoSoportada.
+func (l *Local) SignedURL(ctx context.Context, clave string, expira time.Duration) (string, error) {
+	if l.Firma == nil {
+		return "", ErrFirmaNoSoportada
+	}
+	if err := ValidarClave(clave); err != nil {
+		return "", err
+	}
+	if expira < time.Second {
+		return "", fmt.Errorf("storage local: caducidad de firma demasiado corta: %s", expira)
+	}
+	vence := time.Now().Add(expira).Truncate(expira / 2)
+	return l.URL(clave) + "?" + l.Firma.Firmar(clave, vence).Encode(), nil
+}
+
+// ComprobarFirma valida los parámetros de una URL firmada y devuelve hasta cuándo es válida.
+func (l *Local) ComprobarFirma(clave, expira, firma string) (time.Time, error) {
+	if l.Firma == nil {
+		return time.Time{}, ErrFirmaNoSoportada
+	}
+	return l.Firma.Comprobar(clave, expira, firma, time.Now())
+}
+
+// Listar recorre la carpeta. Incluye los temporales de subidas interrumpidas (".subida-*"):
+// quien limpia los archivos huérfanos decide, por su antigüedad, si siguen en curso.
+func (l *Local) Listar(ctx context.Context, prefijo string) ([]Objeto, error) {
+	var objetos []Objeto
+	err := filepath.WalkDir(l.Dir, func(ruta string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if ruta == l.Dir && errors.Is(err, os.ErrNotExist) {
+				return filepath.SkipAll // Aún no se ha subido nada
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(l.Dir, ruta)
+		if err != nil {
+			return err
+		}
+		clave := filepath.ToSlash(rel)
+		if !strings.HasPrefix(clave, prefijo) {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil // Borrado mientras se recorría
+		}
+		if err != nil {
+			return err
+		}
+		objetos = append(objetos, Objeto{Clave: clave, Tamano: info.Size(), Modificado: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("storage local: listar %q: %w", prefijo, err)
+	}
+	return objetos, nil
+}
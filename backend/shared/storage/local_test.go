@@ -0,0 +1,101 @@
+// backend/shared/storage/local_test.go
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/shared/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal_GuardaLeeYBorra(t *testing.T) {
+	s := storage.NewLocal(t.TempDir(), "/uploads/")
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "recetas/foto.jpg", strings.NewReader("jpeg"), 4, "image/jpeg"))
+	existe, err := s.Existe(ctx, "recetas/foto.jpg")
+	require.NoError(t, err)
+	assert.True(t, existe)
+
+	r, err := s.Get(ctx, "recetas/foto.jpg")
+	require.NoError(t, err)
+	datos, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "jpeg", string(datos))
+	assert.Equal(t, "/uploads/recetas/foto.jpg", s.URL("recetas/foto.jpg"))
+
+	require.NoError(t, s.Delete(ctx, "recetas/foto.jpg"))
+	require.NoError(t, s.Delete(ctx, "recetas/foto.jpg"))
+	_, err = s.Get(ctx, "recetas/foto.jpg")
+	assert.ErrorIs(t, err, storage.ErrNoExiste)
+
+	_, err = s.SignedURL(ctx, "recetas/foto.jpg", time.Minute)
+	assert.ErrorIs(t, err, storage.ErrFirmaNoSoportada)
+}
+
+func TestLocal_SignedURL(t *testing.T) {
+	s := storage.NewLocal(t.TempDir(), "/uploads")
+	s.Firma = storage.NewFirmante("secreto")
+
+	firmada, err := s.SignedURL(context.Background(), "recetas/foto.jpg", time.Hour)
+	require.NoError(t, err)
+	u, err := url.Parse(firmada)
+	require.NoError(t, err)
+	assert.Equal(t, "/uploads/recetas/foto.jpg", u.Path)
+	q := u.Query()
+
+	vence, err := s.ComprobarFirma("recetas/foto.jpg", q.Get(storage.ParamExpira), q.Get(storage.ParamFirma))
+	require.NoError(t, err)
+	assert.True(t, vence.After(time.Now().Add(30*time.Minute-time.Second)), "queda al menos la mitad de la caducidad")
+	otra, _ := s.SignedURL(context.Background(), "recetas/foto.jpg", time.Hour)
+	assert.Equal(t, firmada, otra, "la misma URL durante media caducidad (cacheable)")
+
+	_, err = s.ComprobarFirma("recetas/otra.jpg", q.Get(storage.ParamExpira), q.Get(storage.ParamFirma))
+	assert.ErrorIs(t, err, storage.ErrFirmaInvalida, "la firma es de otro archivo")
+	_, err = storage.NewLocal("", "/uploads").SignedURL(context.Background(), "recetas/foto.jpg", time.Hour)
+	assert.ErrorIs(t, err, storage.ErrFirmaNoSoportada)
+
+	// Caducada: firmada para un instante ya pasado
+	f := storage.NewFirmante("secreto")
+	pasada := f.Firmar("recetas/foto.jpg", time.Now().Add(-time.Minute))
+	_, err = f.Comprobar("recetas/foto.jpg", pasada.Get(storage.ParamExpira), pasada.Get(storage.ParamFirma), time.Now())
+	assert.ErrorIs(t, err, storage.ErrFirmaVencida)
+	_, err = storage.NewFirmante("otra").Comprobar("recetas/foto.jpg", q.Get(storage.ParamExpira), q.Get(storage.ParamFirma), time.Now())
+	assert.ErrorIs(t, err, storage.ErrFirmaInvalida, "otra clave")
+}
+
+func TestLocal_Listar(t *testing.T) {
+	ctx := context.Background()
+	s := storage.NewLocal(t.TempDir(), "/uploads")
+	objetos, err := s.Listar(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, objetos)
+
+	require.NoError(t, s.Put(ctx, "recetas/a.jpg", strings.NewReader("jpeg"), 4, "image/jpeg"))
+	require.NoError(t, s.Put(ctx, "otros/b.png", strings.NewReader("png"), 3, "image/png"))
+
+	objetos, err = s.Listar(ctx, "recetas/")
+	require.NoError(t, err)
+	require.Len(t, objetos, 1)
+	assert.Equal(t, "recetas/a.jpg", objetos[0].Clave)
+	assert.Equal(t, int64(4), objetos[0].Tamano)
+	assert.WithinDuration(t, time.Now(), objetos[0].Modificado, time.Minute)
+
+	objetos, err = storage.NewLocal(t.TempDir()+"/no-existe", "/uploads").Listar(ctx, "")
+	require.NoError(t, err, "una carpeta sin crear es un almacenamiento vacío")
+	assert.Empty(t, objetos)
+}
+
+func TestValidarClave(t *testing.T) {
+	for _, clave := range []string{"", "/etc/passwd", "../fuera.jpg", "recetas/../../x", "recetas//x", `recetas\x`} {
+		assert.ErrorIs(t, storage.ValidarClave(clave), storage.ErrClaveInvalida, clave)
+	}
+	assert.NoError(t, storage.ValidarClave("recetas/foto.jpg"))
+}
@@ -0,0 +1,395 @@
+// backend/shared/storage/s3.go
+
+// Driver "s3": guarda los archivos en un bucket compatible con S3 (AWS, MinIO, R2...).
+// Las peticiones se firman con AWS Signature Version 4 directamente sobre net/http:
+// solo se usan PUT, GET, HEAD y DELETE de objetos, el listado (ListObjectsV2) y las
+// URLs prefirmadas de GET.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/shared/config"
+)
+
+const (
+	algoritmoV4      = "AWS4-HMAC-SHA256"
+	formatoFechaV4   = "20060102T150405Z"
+	payloadSinFirmar = "UNSIGNED-PAYLOAD"
+	expiraMaxima     = 7 * 24 * time.Hour // Límite de S3 para URLs prefirmadas
+)
+
+// S3 guarda los archivos como objetos de un bucket.
+type S3 struct {
+	endpoint   *url.URL
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+	pathStyle  bool   // true: endpoint/bucket/clave (MinIO); false: bucket.endpoint/clave (AWS)
+	urlPublica string // Prefijo de URL() (CDN o bucket público); vacío = URL del objeto
+	client     *http.Client
+	ahora      func() time.Time // Reloj de las firmas (sustituible en tests)
+}
+
+// NewS3 crea el driver a partir de la configuración.
+func NewS3(cfg config.S3StorageConfig) (*S3, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("storage s3: endpoint, bucket, access_key y secret_key son obligatorios")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("storage s3: endpoint %q no válido (ej: https://s3.eu-west-1.amazonaws.com)", cfg.Endpoint)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &S3{
+		endpoint:   endpoint,
+		region:     region,
+		bucket:     cfg.Bucket,
+		accessKey:  cfg.AccessKey,
+		secretKey:  cfg.SecretKey,
+		pathStyle:  cfg.PathStyle,
+		urlPublica: strings.TrimSuffix(cfg.URLPublica, "/"),
+		client:     &http.Client{Timeout: 60 * time.Second},
+		ahora:      time.Now,
+	}, nil
+}
+
+// urlObjeto es la dirección del objeto en el endpoint, con la ruta ya codificada para la firma.
+func (s *S3) urlObjeto(clave string) *url.URL {
+	u := *s.endpoint
+	ruta := strings.TrimSuffix(u.Path, "/")
+	if s.pathStyle {
+		ruta += "/" + s.bucket
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = ruta + "/" + clave
+	u.RawPath = codificarRuta(u.Path)
+	u.RawQuery = ""
+	return &u
+}
+
+// urlBucket es la dirección del bucket (para listarlo).
+func (s *S3) urlBucket() *url.URL {
+	u := *s.endpoint
+	ruta := strings.TrimSuffix(u.Path, "/")
+	if s.pathStyle {
+		ruta += "/" + s.bucket
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		ruta += "/"
+	}
+	u.Path = ruta
+	u.RawPath = codificarRuta(u.Path)
+	u.RawQuery = ""
+	return &u
+}
+
+// Put sube el objeto. El contenido se lee entero para firmar su hash (las imágenes son pequeñas).
+func (s *S3) Put(ctx context.Context, clave string, contenido io.Reader, tamano int64, contentType string) error {
+	if err := ValidarClave(clave); err != nil {
+		return err
+	}
+	datos, err := io.ReadAll(contenido)
+	if err != nil {
+		return fmt.Errorf("storage s3: leer %s: %w", clave, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.urlObjeto(clave).String(), bytes.NewReader(datos))
+	if err != nil {
+		return fmt.Errorf("storage s3: put %s: %w", clave, err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.hacer(req, datos)
+	if err != nil {
+		return fmt.Errorf("storage s3: put %s: %w", clave, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("storage s3: put %s: %w", clave, errorRespuesta(resp))
+	}
+	return nil
+}
+
+// Get descarga el objeto.
+func (s *S3) Get(ctx context.Context, clave string) (io.ReadCloser, error) {
+	resp, err := s.sinCuerpo(ctx, http.MethodGet, clave)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %s", ErrNoExiste, clave)
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("storage s3: get %s: %w", clave, errorRespuesta(resp))
+	}
+}
+
+// Existe consulta el objeto con HEAD.
+func (s *S3) Existe(ctx context.Context, clave string) (bool, error) {
+	resp, err := s.sinCuerpo(ctx, http.MethodHead, clave)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("storage s3: head %s: %w", clave, errorRespuesta(resp))
+	}
+}
+
+// Delete borra el objeto (S3 no distingue si existía).
+func (s *S3) Delete(ctx context.Context, clave string) error {
+	resp, err := s.sinCuerpo(ctx, http.MethodDelete, clave)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("storage s3: delete %s: %w", clave, errorRespuesta(resp))
+	}
+	return nil
+}
+
+// URL usa la URL pública configurada (CDN, bucket público) o, si no hay, la del objeto.
+func (s *S3) URL(clave string) string {
+	if s.urlPublica != "" {
+		return s.urlPublica + codificarRuta("/"+clave)
+	}
+	return s.urlObjeto(clave).String()
+}
+
+// SignedURL es una URL prefirmada de GET (máximo 7 días, límite de S3).
+func (s *S3) SignedURL(ctx context.Context, clave string, expira time.Duration) (string, error) {
+	if err := ValidarClave(clave); err != nil {
+		return "", err
+	}
+	if expira < time.Second || expira > expiraMaxima {
+		return "", fmt.Errorf("storage s3: la caducidad debe estar entre 1s y %s", expiraMaxima)
+	}
+	u := s.urlObjeto(clave)
+	fecha := s.ahora().UTC()
+	q := url.Values{}
+	q.Set("X-Amz-Algorithm", algoritmoV4)
+	q.Set("X-Amz-Credential", s.accessKey+"/"+s.ambito(fecha))
+	q.Set("X-Amz-Date", fecha.Format(formatoFechaV4))
+	q.Set("X-Amz-Expires", strconv.Itoa(int(expira.Seconds())))
+	q.Set("X-Amz-SignedHeaders", "host")
+	u.RawQuery = consultaCanonica(q)
+
+	canonica := strings.Join([]string{
+		http.MethodGet, u.EscapedPath(), u.RawQuery, "host:" + u.Host + "\n", "host", payloadSinFirmar,
+	}, "\n")
+	u.RawQuery += "&X-Amz-Signature=" + s.firma(fecha, canonica)
+	return u.String(), nil
+}
+
+// listado es la parte que se usa de la respuesta de ListObjectsV2.
+type listado struct {
+	Contenidos []struct {
+		Clave      string    `xml:"Key"`
+		Tamano     int64     `xml:"Size"`
+		Modificado time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	Truncado  bool   `xml:"IsTruncated"`
+	Siguiente string `xml:"NextContinuationToken"`
+}
+
+// Listar pide el listado por páginas (S3 devuelve como mucho 1000 objetos por petición).
+func (s *S3) Listar(ctx context.Context, prefijo string) ([]Objeto, error) {
+	var objetos []Objeto
+	continuacion := ""
+	for {
+		u := s.urlBucket()
+		q := url.Values{"list-type": {"2"}}
+		if prefijo != "" {
+			q.Set("prefix", prefijo)
+		}
+		if continuacion != "" {
+			q.Set("continuation-token", continuacion)
+		}
+		u.RawQuery = consultaCanonica(q)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("storage s3: listar %q: %w", prefijo, err)
+		}
+		resp, err := s.hacer(req, nil)
+		if err != nil {
+			return nil, fmt.Errorf("storage s3: listar %q: %w", prefijo, err)
+		}
+		var pagina listado
+		if resp.StatusCode != http.StatusOK {
+			err = errorRespuesta(resp)
+		} else {
+			err = xml.NewDecoder(resp.Body).Decode(&pagina)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("storage s3: listar %q: %w", prefijo, err)
+		}
+		for _, c := range pagina.Contenidos {
+			objetos = append(objetos, Objeto{Clave: c.Clave, Tamano: c.Tamano, Modificado: c.Modificado})
+		}
+		if !pagina.Truncado || pagina.Siguiente == "" {
+			return objetos, nil
+		}
+		continuacion = pagina.Siguiente
+	}
+}
+
+// sinCuerpo hace una petición firmada sin contenido (GET, HEAD, DELETE).
+func (s *S3) sinCuerpo(ctx context.Context, metodo, clave string) (*http.Response, error) {
+	if err := ValidarClave(clave); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, metodo, s.urlObjeto(clave).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("storage s3: %s %s: %w", strings.ToLower(metodo), clave, err)
+	}
+	resp, err := s.hacer(req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("storage s3: %s %s: %w", strings.ToLower(metodo), clave, err)
+	}
+	return resp, nil
+}
+
+// hacer firma la petición (cabecera Authorization) y la envía.
+func (s *S3) hacer(req *http.Request, cuerpo []byte) (*http.Response, error) {
+	s.firmar(req, cuerpo, s.ahora().UTC())
+	return s.client.Do(req)
+}
+
+// cabecerasNoFirmadas son las que un proxy o el propio cliente HTTP pueden cambiar.
+var cabecerasNoFirmadas = map[string]bool{
+	"authorization": true, "user-agent": true, "accept-encoding": true, "connection": true, "expect": true,
+}
+
+// firmar añade x-amz-date, x-amz-content-sha256 y Authorization (Signature V4 con el hash del cuerpo).
+func (s *S3) firmar(req *http.Request, cuerpo []byte, fecha time.Time) {
+	hashCuerpo := sha256.Sum256(cuerpo)
+	req.Header.Set("X-Amz-Date", fecha.Format(formatoFechaV4))
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(hashCuerpo[:]))
+
+	valores := map[string]string{"host": req.URL.Host}
+	for nombre, v := range req.Header {
+		if n := strings.ToLower(nombre); !cabecerasNoFirmadas[n] {
+			valores[n] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	nombres := make([]string, 0, len(valores))
+	for n := range valores {
+		nombres = append(nombres, n)
+	}
+	sort.Strings(nombres)
+	var cabeceras strings.Builder
+	for _, n := range nombres {
+		cabeceras.WriteString(n + ":" + valores[n] + "\n")
+	}
+	firmadas := strings.Join(nombres, ";")
+
+	canonica := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), consultaCanonica(req.URL.Query()), cabeceras.String(), firmadas,
+		hex.EncodeToString(hashCuerpo[:]),
+	}, "\n")
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algoritmoV4, s.accessKey, s.ambito(fecha), firmadas, s.firma(fecha, canonica)))
+}
+
+// ambito es el "credential scope" de la firma: fecha/región/s3/aws4_request.
+func (s *S3) ambito(fecha time.Time) string {
+	return fecha.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// firma calcula la firma V4 de una petición canónica.
+func (s *S3) firma(fecha time.Time, canonica string) string {
+	hashCanonica := sha256.Sum256([]byte(canonica))
+	aFirmar := strings.Join([]string{
+		algoritmoV4, fecha.Format(formatoFechaV4), s.ambito(fecha), hex.EncodeToString(hashCanonica[:]),
+	}, "\n")
+	clave := hmacSHA256([]byte("AWS4"+s.secretKey), fecha.Format("20060102"))
+	clave = hmacSHA256(clave, s.region)
+	clave = hmacSHA256(clave, "s3")
+	clave = hmacSHA256(clave, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(clave, aFirmar))
+}
+
+func hmacSHA256(clave []byte, datos string) []byte {
+	h := hmac.New(sha256.New, clave)
+	h.Write([]byte(datos))
+	return h.Sum(nil)
+}
+
+// codificarRuta aplica la codificación de URI de AWS a cada segmento, conservando "/".
+func codificarRuta(ruta string) string {
+	partes := strings.Split(ruta, "/")
+	for i, p := range partes {
+		partes[i] = codificarURI(p)
+	}
+	return strings.Join(partes, "/")
+}
+
+// codificarURI deja sin codificar solo A-Z, a-z, 0-9, '-', '_', '.' y '~' (regla de SigV4).
+func codificarURI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || strings.IndexByte("-_.~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// consultaCanonica ordena los parámetros por nombre y los codifica como pide SigV4.
+func consultaCanonica(q url.Values) string {
+	nombres := make([]string, 0, len(q))
+	for n := range q {
+		nombres = append(nombres, n)
+	}
+	sort.Strings(nombres)
+	var partes []string
+	for _, n := range nombres {
+		valores := append([]string(nil), q[n]...)
+		sort.Strings(valores)
+		for _, v := range valores {
+			partes = append(partes, codificarURI(n)+"="+codificarURI(v))
+		}
+	}
+	return strings.Join(partes, "&")
+}
+
+// errorRespuesta resume una respuesta de error de S3 (el cuerpo XML trae el código, ej: AccessDenied).
+func errorRespuesta(resp *http.Response) error {
+	cuerpo, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("respuesta %d: %s", resp.StatusCode, strings.TrimSpace(string(cuerpo)))
+}
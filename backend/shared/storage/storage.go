@@ -0,0 +1,143 @@
+// backend/shared/storage/storage.go
+
+// Este paquete abstrae dónde se guardan los archivos subidos (fotos de recetas...), para
+// que varias instancias de la API puedan compartirlos. Hay dos drivers:
+//
+//   - "local": una carpeta del disco, servida por la propia API (ver Local).
+//   - "s3": un bucket compatible con S3 (AWS, MinIO, R2...), ver S3.
+//
+// Los archivos se identifican por una clave relativa con "/" como separador,
+// ej: "recetas/3f2a...9c.jpg". Nunca empieza por "/" ni contiene "..".
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/shared/config"
+)
+
+// Storage guarda y sirve archivos.
+type Storage interface {
+	// Put guarda el contenido bajo 'clave', reemplazándolo si ya existía.
+	Put(ctx context.Context, clave string, contenido io.Reader, tamano int64, contentType string) error
+	// Get abre el archivo; devuelve ErrNoExiste si no está. Quien llama debe cerrarlo.
+	Get(ctx context.Context, clave string) (io.ReadCloser, error)
+	// Existe indica si hay un archivo con esa clave.
+	Existe(ctx context.Context, clave string) (bool, error)
+	// Delete borra el archivo. Que no exista no es un error.
+	Delete(ctx context.Context, clave string) error
+	// URL es la dirección pública del archivo (absoluta o relativa al servidor de la API).
+	URL(clave string) string
+	// SignedURL es una dirección temporal que da acceso al archivo durante 'expira'.
+	SignedURL(ctx context.Context, clave string, expira time.Duration) (string, error)
+	// Listar devuelve los archivos cuya clave empieza por 'prefijo' ("" = todos), en cualquier orden.
+	Listar(ctx context.Context, prefijo string) ([]Objeto, error)
+}
+
+// Objeto describe un archivo guardado.
+type Objeto struct {
+	Clave      string
+	Tamano     int64
+	Modificado time.Time
+}
+
+var (
+	// ErrNoExiste se produce al leer un archivo que no está guardado.
+	ErrNoExiste = errors.New("storage: el archivo no existe")
+	// ErrClaveInvalida se produce si la clave está vacía, es absoluta o sale de la raíz.
+	ErrClaveInvalida = errors.New("storage: clave no válida")
+	// ErrFirmaNoSoportada se produce si el driver no puede generar URLs firmadas.
+	ErrFirmaNoSoportada = errors.New("storage: el driver no genera URLs firmadas")
+	// ErrFirmaInvalida se produce si la firma de una URL no corresponde a su archivo.
+	ErrFirmaInvalida = errors.New("la firma del enlace no es válida")
+	// ErrFirmaVencida se produce si la URL firmada ya caducó.
+	ErrFirmaVencida = errors.New("el enlace ha caducado")
+)
+
+// Drivers disponibles (config: storage.driver).
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// ValidarClave comprueba que la clave sea relativa y no salga de la raíz del almacenamiento.
+func ValidarClave(clave string) error {
+	if clave == "" || strings.HasPrefix(clave, "/") || strings.Contains(clave, "\\") {
+		return fmt.Errorf("%w: %q", ErrClaveInvalida, clave)
+	}
+	for _, parte := range strings.Split(clave, "/") {
+		if parte == "" || parte == "." || parte == ".." {
+			return fmt.Errorf("%w: %q", ErrClaveInvalida, clave)
+		}
+	}
+	return nil
+}
+
+// Nuevo crea el almacenamiento indicado en la configuración.
+func Nuevo(cfg config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		local := NewLocal(cfg.Local.Dir, cfg.Local.URLBase)
+		if cfg.ClaveFirma != "" {
+			local.Firma = NewFirmante(cfg.ClaveFirma)
+		}
+		return local, nil
+	case DriverS3:
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("storage: driver %q desconocido (usa %q o %q)", cfg.Driver, DriverLocal, DriverS3)
+	}
+}
+
+// --- Almacenamiento predeterminado ---
+
+// Los DTO de varios paquetes (recetas anidadas en favoritos, planificador...) construyen URLs
+// de fotos sin pasar por un handler, así que el almacenamiento activo se registra aquí al arrancar.
+var (
+	mu              sync.RWMutex
+	predeterminado  Storage       = NewLocal("uploads", "/uploads")
+	caducidadFirmas time.Duration = time.Hour
+)
+
+// Predeterminado devuelve el almacenamiento registrado con EstablecerPredeterminado
+// (al arrancar, la carpeta local "uploads" servida en /uploads).
+func Predeterminado() Storage {
+	mu.RLock()
+	defer mu.RUnlock()
+	return predeterminado
+}
+
+// EstablecerPredeterminado registra el almacenamiento activo. Se llama una vez al arrancar.
+func EstablecerPredeterminado(s Storage) {
+	mu.Lock()
+	defer mu.Unlock()
+	predeterminado = s
+}
+
+// EstablecerCaducidadFirmas fija la validez de las URLs de URLPrivada. Se llama una vez al arrancar.
+func EstablecerCaducidadFirmas(d time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	caducidadFirmas = d
+}
+
+// URLPrivada es la URL firmada de un archivo no público en el almacenamiento predeterminado.
+// Si el driver no firma, devuelve la URL normal, que solo funcionará para quien tenga permiso.
+func URLPrivada(clave string) string {
+	mu.RLock()
+	s, expira := predeterminado, caducidadFirmas
+	mu.RUnlock()
+	// Firmar no hace E/S en ningún driver, así que no hace falta el contexto de la petición.
+	u, err := s.SignedURL(context.Background(), clave, expira)
+	if err != nil {
+		return s.URL(clave)
+	}
+	return u
+}
@@ -9,6 +9,9 @@ package utils
 import (
 	"fmt"
 	"log"
+
+	"backend/shared/config"
+	"backend/shared/database"
 )
 
 // CheckDatabaseConnection intenta cargar la configuración y establecer una conexión
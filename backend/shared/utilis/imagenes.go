@@ -1,50 +1,225 @@
 // backend/internal/utils/imagenes.go
 
-// Este archivo contiene la lógica de manejo de imágenes.
-// Utiliza GORM para la definición de la tabla y el mapeo de campos.
-// No contiene tags de Gorm. Representa el negocio, no la base de datos.
+// Este archivo contiene la lógica de manejo de imágenes subidas.
+// El tipo se detecta por el contenido (no por la extensión ni por el Content-Type del
+// cliente) y el archivo se nombra con el hash de su contenido, de modo que dos subidas
+// iguales comparten archivo y dos distintas nunca se sobrescriben.
 
 package utils
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"image"
+	_ "image/jpeg" // Registrar el decodificador JPEG
+	_ "image/png"  // Registrar el decodificador PNG
+	"io"
 	"mime/multipart"
-	"path/filepath"
-	"strings"
-	"time"
-	"github.com/gin-gonic/gin"
+	"net/http"
+	"path"
+	"backend/shared/apperrors"
+	"backend/shared/storage"
 )
 
-// GuardarImagen guarda un archivo de imagen en una subcarpeta dentro de /uploads/
-// Valida tamaño y formato. Devuelve ruta relativa y error.
-func GuardarImagen(c *gin.Context, file *multipart.FileHeader, carpeta string) (string, error) {
-	//Validar  mimetype
-	contentType := file.Header.Get("Content-Type")
-	if contentType == "" || !strings.HasPrefix(contentType, "image/") {
-    	return "", fmt.Errorf("el archivo no es una imagen")
+// ErrImagenInvalida se produce si el archivo subido no es una imagen aceptada.
+var ErrImagenInvalida = errors.New("imagen no válida")
+
+func init() {
+	apperrors.Registrar(ErrImagenInvalida, http.StatusBadRequest, "imagen_invalida")
+}
+
+// LimitesImagen acota lo que se acepta en una subida. Un campo a cero usa el valor
+// de LimitesImagenPorDefecto.
+type LimitesImagen struct {
+	MaxBytes   int64 // Tamaño máximo del archivo
+	MaxAncho   int   // Ancho máximo en píxeles
+	MaxAlto    int   // Alto máximo en píxeles
+	MaxPixeles int   // Ancho x alto máximo: frena las "bombas de descompresión" (archivo pequeño, imagen enorme)
+}
+
+// LimitesImagenPorDefecto son los límites si la configuración no indica otros.
+var LimitesImagenPorDefecto = LimitesImagen{
+	MaxBytes:   2 << 20, // 2MB
+	MaxAncho:   6000,
+	MaxAlto:    6000,
+	MaxPixeles: 36_000_000,
+}
+
+// conDefectos completa los límites no configurados.
+func (l LimitesImagen) conDefectos() LimitesImagen {
+	if l.MaxBytes <= 0 {
+		l.MaxBytes = LimitesImagenPorDefecto.MaxBytes
+	}
+	if l.MaxAncho <= 0 {
+		l.MaxAncho = LimitesImagenPorDefecto.MaxAncho
+	}
+	if l.MaxAlto <= 0 {
+		l.MaxAlto = LimitesImagenPorDefecto.MaxAlto
+	}
+	if l.MaxPixeles <= 0 {
+		l.MaxPixeles = LimitesImagenPorDefecto.MaxPixeles
+	}
+	return l
+}
+
+// formatosImagen son los tipos aceptados (detectados por contenido) y su extensión.
+var formatosImagen = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// marcasPolyglot son fragmentos que no aparecen en una foto legítima y delatan un archivo
+// que además es HTML, PHP... (se buscan sin distinguir mayúsculas). Son lo bastante largos
+// para que no aparezcan por azar en los datos comprimidos de la imagen.
+var marcasPolyglot = [][]byte{
+	[]byte("<script"), []byte("<html"), []byte("<body"), []byte("<!doctype"), []byte("<?php"), []byte("<iframe"), []byte("javascript:"),
+}
+
+// finPNG es el chunk IEND con el que termina todo PNG.
+var finPNG = []byte{0, 0, 0, 0, 'I', 'E', 'N', 'D', 0xAE, 0x42, 0x60, 0x82}
+
+// InfoImagen describe una imagen validada.
+type InfoImagen struct {
+	Tipo  string // MIME detectado ("image/jpeg" o "image/png")
+	Ext   string // Extensión según el tipo (".jpg" o ".png")
+	Ancho int
+	Alto  int
+	Bytes int64
+	Hash  string // SHA-256 del contenido, en hexadecimal
+}
+
+// ImagenGuardada es el resultado de GuardarImagen.
+type ImagenGuardada struct {
+	InfoImagen
+	Clave     string // Clave en el almacenamiento: <carpeta>/<hash>.<ext>
+	Existente bool   // true si ya había un archivo con el mismo contenido (se reescribió igual)
+	Datos     []byte // Contenido validado (para procesarlo sin volver a leerlo)
+}
+
+// ValidarImagen comprueba que 'datos' sea una imagen JPEG o PNG dentro de los límites:
+// detecta el tipo por sus bytes iniciales, mira las dimensiones antes de decodificarla,
+// la decodifica entera y rechaza los archivos con contenido ajeno a la imagen.
+func ValidarImagen(datos []byte, limites LimitesImagen) (InfoImagen, error) {
+	limites = limites.conDefectos()
+	if len(datos) == 0 || int64(len(datos)) > limites.MaxBytes {
+		return InfoImagen{}, fmt.Errorf("%w: la imagen debe pesar entre 1 byte y %d bytes", ErrImagenInvalida, limites.MaxBytes)
+	}
+
+	tipo := http.DetectContentType(datos) // Usa los "magic bytes", no el nombre del archivo
+	ext, ok := formatosImagen[tipo]
+	if !ok {
+		return InfoImagen{}, fmt.Errorf("%w: formato %s no permitido (solo JPEG y PNG)", ErrImagenInvalida, tipo)
 	}
 
-	// Validaciones básicas
-	if file.Size == 0 || file.Size > 2<<20 {
-		return "", fmt.Errorf("la imagen debe pesar entre 1 byte y 2MB")
+	// Las dimensiones se leen de la cabecera, sin reservar memoria para los píxeles.
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(datos))
+	if err != nil {
+		return InfoImagen{}, fmt.Errorf("%w: cabecera ilegible: %v", ErrImagenInvalida, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > limites.MaxAncho || cfg.Height > limites.MaxAlto ||
+		cfg.Width*cfg.Height > limites.MaxPixeles {
+		return InfoImagen{}, fmt.Errorf("%w: %dx%d píxeles supera el máximo (%dx%d, %d píxeles)",
+			ErrImagenInvalida, cfg.Width, cfg.Height, limites.MaxAncho, limites.MaxAlto, limites.MaxPixeles)
 	}
 
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	if ext != ".jpg" && ext != ".png" {
-		return "", fmt.Errorf("formato de imagen no permitido (solo .jpg, .png)")
+	if _, _, err := image.Decode(bytes.NewReader(datos)); err != nil {
+		return InfoImagen{}, fmt.Errorf("%w: no se pudo decodificar: %v", ErrImagenInvalida, err)
+	}
+	if err := comprobarSinContenidoAjeno(datos, tipo); err != nil {
+		return InfoImagen{}, err
 	}
 
-	// Generar nombre único y ruta de guardado
-	filename := fmt.Sprintf("%s%s", time.Now().Format("20060102_150405"), ext)
-	rutaRelativa := filepath.Join("uploads", carpeta, filename)
+	suma := sha256.Sum256(datos)
+	return InfoImagen{
+		Tipo:  tipo,
+		Ext:   ext,
+		Ancho: cfg.Width,
+		Alto:  cfg.Height,
+		Bytes: int64(len(datos)),
+		Hash:  hex.EncodeToString(suma[:]),
+	}, nil
+}
 
-	// Guardar archivo
-	if err := c.SaveUploadedFile(file, rutaRelativa); err != nil {
-		return "", fmt.Errorf("no se pudo guardar la imagen: %w", err)
+// comprobarSinContenidoAjeno rechaza los polyglots: datos añadidos tras el final de la
+// imagen (ZIP, HTML...) o marcado que un navegador podría interpretar.
+func comprobarSinContenidoAjeno(datos []byte, tipo string) error {
+	switch tipo {
+	case "image/jpeg":
+		if !bytes.HasSuffix(bytes.TrimRight(datos, "\x00"), []byte{0xFF, 0xD9}) {
+			return fmt.Errorf("%w: hay datos después del final de la imagen JPEG", ErrImagenInvalida)
+		}
+	case "image/png":
+		if !bytes.HasSuffix(datos, finPNG) {
+			return fmt.Errorf("%w: hay datos después del final de la imagen PNG", ErrImagenInvalida)
+		}
 	}
+	minusculas := bytes.ToLower(datos)
+	for _, marca := range marcasPolyglot {
+		if bytes.Contains(minusculas, marca) {
+			return fmt.Errorf("%w: el archivo contiene %q", ErrImagenInvalida, marca)
+		}
+	}
+	return nil
+}
 
-	return rutaRelativa, nil
+// NombreImagen es el nombre de archivo de una imagen validada: los primeros 128 bits del
+// hash de su contenido y la extensión de su tipo real.
+func NombreImagen(info InfoImagen) string {
+	return info.Hash[:32] + info.Ext
+}
+
+// GuardarImagen valida el archivo subido (ver ValidarImagen) y lo guarda en 'almacen' bajo
+// <carpeta>/ con un nombre derivado de su contenido. Si ya existía un archivo con ese contenido
+// devuelve Existente = true, pero lo escribe igualmente: el recolector de huérfanos puede estar
+// borrándolo en ese momento, y reescribir el mismo contenido en la misma clave no cambia nada.
+// Los errores de validación envuelven ErrImagenInvalida.
+func GuardarImagen(ctx context.Context, almacen storage.Storage, file *multipart.FileHeader, carpeta string, limites LimitesImagen) (ImagenGuardada, error) {
+	limites = limites.conDefectos()
+	if file.Size == 0 || file.Size > limites.MaxBytes { // Descarte rápido; se vuelve a comprobar al leer
+		return ImagenGuardada{}, fmt.Errorf("%w: la imagen debe pesar entre 1 byte y %d bytes", ErrImagenInvalida, limites.MaxBytes)
+	}
+	f, err := file.Open()
+	if err != nil {
+		return ImagenGuardada{}, fmt.Errorf("no se pudo leer la imagen subida: %w", err)
+	}
+	defer f.Close()
+	datos, err := io.ReadAll(io.LimitReader(f, limites.MaxBytes+1))
+	if err != nil {
+		return ImagenGuardada{}, fmt.Errorf("no se pudo leer la imagen subida: %w", err)
+	}
+	info, err := ValidarImagen(datos, limites)
+	if err != nil {
+		return ImagenGuardada{}, err
+	}
+
+	guardada := ImagenGuardada{InfoImagen: info, Clave: path.Join(carpeta, NombreImagen(info)), Datos: datos}
+	existe, err := almacen.Existe(ctx, guardada.Clave)
+	if err != nil {
+		return ImagenGuardada{}, fmt.Errorf("no se pudo comprobar la imagen: %w", err)
+	}
+	guardada.Existente = existe // Mismo contenido ya subido: el llamador puede reutilizar sus variantes
+	if err := almacen.Put(ctx, guardada.Clave, bytes.NewReader(datos), info.Bytes, info.Tipo); err != nil {
+		return ImagenGuardada{}, fmt.Errorf("no se pudo guardar la imagen: %w", err)
+	}
+	return guardada, nil
+}
+
+// EliminarImagen borra el archivo 'nombre' de <carpeta>/ en 'almacen'. Solo se usa el nombre
+// base, de modo que 'nombre' no puede salir de la carpeta. Que el archivo no exista no es un error.
+func EliminarImagen(ctx context.Context, almacen storage.Storage, carpeta, nombre string) error {
+	base := path.Base(nombre)
+	if nombre == "" || base == "." || base == "/" || base == ".." {
+		return nil
+	}
+	if err := almacen.Delete(ctx, path.Join(carpeta, base)); err != nil {
+		return fmt.Errorf("no se pudo eliminar la imagen %s: %w", base, err)
+	}
+	return nil
 }
 
 // Call
-// rutaRelativa, err := utils.GuardarImagen(c, file, "usuarios")
+// guardada, err := utils.GuardarImagen(ctx, almacen, file, "usuarios", utils.LimitesImagenPorDefecto)
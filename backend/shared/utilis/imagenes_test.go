@@ -0,0 +1,60 @@
+// backend/shared/utilis/imagenes_test.go
+package utils_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	utils "backend/shared/utilis"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pngDe(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h))))
+	return buf.Bytes()
+}
+
+func TestValidarImagen_DetectaElTipoPorContenido(t *testing.T) {
+	info, err := utils.ValidarImagen(pngDe(t, 20, 10), utils.LimitesImagen{})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", info.Tipo)
+	assert.Equal(t, ".png", info.Ext)
+	assert.Equal(t, 20, info.Ancho)
+	assert.Equal(t, 10, info.Alto)
+
+	// El mismo contenido produce el mismo nombre
+	otra, err := utils.ValidarImagen(pngDe(t, 20, 10), utils.LimitesImagen{})
+	require.NoError(t, err)
+	assert.Equal(t, utils.NombreImagen(info), utils.NombreImagen(otra))
+	assert.Len(t, utils.NombreImagen(info), 32+len(".png"))
+}
+
+func TestValidarImagen_Rechazos(t *testing.T) {
+	valida := pngDe(t, 20, 20)
+	casos := map[string]struct {
+		datos   []byte
+		limites utils.LimitesImagen
+	}{
+		"vacía":                   {nil, utils.LimitesImagen{}},
+		"no es imagen":            {[]byte("GIF89a no soportado"), utils.LimitesImagen{}},
+		"html con extensión .png": {[]byte("<html><script>alert(1)</script></html>"), utils.LimitesImagen{}},
+		"demasiado pesada":        {valida, utils.LimitesImagen{MaxBytes: 10}},
+		"demasiado ancha":         {pngDe(t, 30, 1), utils.LimitesImagen{MaxAncho: 25}},
+		"demasiados píxeles":      {valida, utils.LimitesImagen{MaxPixeles: 399}},
+		"truncada":                {valida[:len(valida)-20], utils.LimitesImagen{}},
+		"datos tras el final":     {append(append([]byte{}, valida...), []byte("PK\x03\x04zip")...), utils.LimitesImagen{}},
+		"marcado incrustado": {
+			append(append(append([]byte{}, valida[:33]...), []byte("\x00\x00\x00\x10tEXt<?php echo 1;")...), valida[33:]...),
+			utils.LimitesImagen{},
+		},
+	}
+	for nombre, c := range casos {
+		_, err := utils.ValidarImagen(c.datos, c.limites)
+		assert.ErrorIs(t, err, utils.ErrImagenInvalida, nombre)
+	}
+}
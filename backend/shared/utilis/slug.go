@@ -0,0 +1,34 @@
+// backend/shared/utilis/slug.go
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// maxIntentosSlug limita la búsqueda de un sufijo libre (base, base-2, ..., base-N).
+const maxIntentosSlug = 100
+
+// ErrSlugNoDisponible se devuelve si no hay un sufijo libre dentro de maxIntentosSlug.
+var ErrSlugNoDisponible = errors.New("no se encontró un slug libre")
+
+// SlugDisponible devuelve 'base' si está libre o, si no, el primer 'base-N' (N >= 2) libre.
+// 'ocupado' indica si un slug candidato ya lo usa otro registro activo.
+func SlugDisponible(ctx context.Context, base string, ocupado func(ctx context.Context, candidato string) (bool, error)) (string, error) {
+	for i := 1; i <= maxIntentosSlug; i++ {
+		candidato := base
+		if i > 1 {
+			candidato = fmt.Sprintf("%s-%d", base, i)
+		}
+		enUso, err := ocupado(ctx, candidato)
+		if err != nil {
+			return "", err
+		}
+		if !enUso {
+			return candidato, nil
+		}
+	}
+	return "", fmt.Errorf("%w para '%s'", ErrSlugNoDisponible, base)
+}
@@ -1,11 +1,13 @@
 package utils
 
 import (
-    "fmt"
-    "time"
+	"time"
+
+	"backend/shared/i18n"
 )
 
-// Formatea una fecha en el formato DD/MM/YYYY
-func FormatearFecha(fecha time.Time) string {
-    return fmt.Sprintf("%02d/%02d/%d", fecha.Day(), fecha.Month(), fecha.Year())
+// Formatea una fecha según el idioma (ej: "es" -> DD/MM/YYYY, "en" -> "Jan 2, 2006").
+// El formato sale de la clave "formato.fecha" del catálogo de i18n.
+func FormatearFecha(fecha time.Time, idioma i18n.Idioma) string {
+	return fecha.Format(i18n.T(idioma, "formato.fecha"))
 }